@@ -24,6 +24,7 @@ require (
 	github.com/arran4/golang-ical v0.1.0
 	github.com/asaskevich/govalidator v0.0.0-20200907205600-7a23bdc65eef
 	github.com/bbrks/go-blurhash v1.1.1
+	github.com/blevesearch/snowballstem v0.9.0
 	github.com/c2h5oh/datasize v0.0.0-20220606134207-859f65c6625b
 	github.com/coreos/go-oidc/v3 v3.7.0
 	github.com/cweill/gotests v1.6.0
@@ -941,11 +941,11 @@ type ` + name + ` struct {
 }
 
 // ToMail returns the mail notification for ` + name + `
-func (n *` + name + `) ToMail() *notifications.Mail {
+func (n *` + name + `) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("").
-		Greeting("Hi ").
-		Line("").
+		Subject(i18n.T(lang, "notifications.` + notficationName + `.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", "")).
+		Line(i18n.T(lang, "notifications.` + notficationName + `.message")).
 		Action("", "")
 }
 
@@ -954,6 +954,11 @@ func (n *` + name + `) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the ` + name + ` notification as web push message
+func (n *` + name + `) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *` + name + `) Name() string {
 	return "` + notficationName + `"
@@ -965,7 +970,7 @@ func (n *` + name + `) Name() string {
 		return err
 	}
 
-	printSuccess("The new notification has been created successfully! Head over to %s and adjust its content.", filename)
+	printSuccess("The new notification has been created successfully! Head over to %s and adjust its content. Add the translation keys to pkg/i18n/lang/en.json.", filename)
 
 	return nil
 }
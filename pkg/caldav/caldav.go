@@ -58,6 +58,25 @@ type Todo struct {
 	Updated time.Time // last-mod
 }
 
+// Event holds a single VEVENT
+type Event struct {
+	// Required
+	Timestamp time.Time
+	UID       string
+	Start     time.Time
+
+	// Optional
+	Summary     string
+	Description string
+	Color       string
+	Categories  []string
+	End         time.Time // The event ends at its start if this is not after Start
+	Alarms      []Alarm
+
+	Created time.Time
+	Updated time.Time // last-mod
+}
+
 // Alarm holds infos about an alarm from a caldav event
 type Alarm struct {
 	Time        time.Time
@@ -101,7 +120,12 @@ func formatDuration(duration time.Duration) string {
 
 // ParseTodos returns a caldav vcalendar string with todos
 func ParseTodos(config *Config, todos []*Todo) (caldavtodos string) {
-	caldavtodos = `BEGIN:VCALENDAR
+	return ParseCalendar(config, todos, nil)
+}
+
+// ParseCalendar returns a vcalendar string with todos and events
+func ParseCalendar(config *Config, todos []*Todo, events []*Event) (calendar string) {
+	calendar = `BEGIN:VCALENDAR
 VERSION:2.0
 METHOD:PUBLISH
 X-PUBLISHED-TTL:PT4H
@@ -109,88 +133,139 @@ X-WR-CALNAME:` + config.Name + `
 PRODID:-//` + config.ProdID + `//EN` + getCaldavColor(config.Color)
 
 	for _, t := range todos {
-		if t.UID == "" {
-			t.UID = makeCalDavTimeFromTimeStamp(t.Timestamp) + utils.Sha256(t.Summary)
-		}
+		calendar += parseTodo(t)
+	}
 
-		caldavtodos += `
+	for _, e := range events {
+		calendar += parseEvent(e)
+	}
+
+	calendar += `
+END:VCALENDAR` // Need a line break
+
+	return
+}
+
+func parseTodo(t *Todo) (caldavtodo string) {
+	if t.UID == "" {
+		t.UID = makeCalDavTimeFromTimeStamp(t.Timestamp) + utils.Sha256(t.Summary)
+	}
+
+	caldavtodo += `
 BEGIN:VTODO
 UID:` + t.UID + `
 DTSTAMP:` + makeCalDavTimeFromTimeStamp(t.Timestamp) + `
 SUMMARY:` + t.Summary + getCaldavColor(t.Color)
 
-		if t.Start.Unix() > 0 {
-			caldavtodos += `
+	if t.Start.Unix() > 0 {
+		caldavtodo += `
 DTSTART:` + makeCalDavTimeFromTimeStamp(t.Start)
-			if t.Duration != 0 && t.DueDate.Unix() == 0 {
-				caldavtodos += `
+		if t.Duration != 0 && t.DueDate.Unix() == 0 {
+			caldavtodo += `
 DURATION:PT` + formatDuration(t.Duration)
-			}
 		}
-		if t.End.Unix() > 0 {
-			caldavtodos += `
+	}
+	if t.End.Unix() > 0 {
+		caldavtodo += `
 DTEND:` + makeCalDavTimeFromTimeStamp(t.End)
-		}
-		if t.Description != "" {
-			re := regexp.MustCompile(`\r?\n`)
-			formattedDescription := re.ReplaceAllString(t.Description, "\\n")
-			caldavtodos += `
+	}
+	if t.Description != "" {
+		re := regexp.MustCompile(`\r?\n`)
+		formattedDescription := re.ReplaceAllString(t.Description, "\\n")
+		caldavtodo += `
 DESCRIPTION:` + formattedDescription
-		}
-		if t.Completed.Unix() > 0 {
-			caldavtodos += `
+	}
+	if t.Completed.Unix() > 0 {
+		caldavtodo += `
 COMPLETED:` + makeCalDavTimeFromTimeStamp(t.Completed) + `
 STATUS:COMPLETED`
-		}
-		if t.Organizer != nil {
-			caldavtodos += `
+	}
+	if t.Organizer != nil {
+		caldavtodo += `
 ORGANIZER;CN=:` + t.Organizer.Username
-		}
+	}
 
-		if t.RelatedToUID != "" {
-			caldavtodos += `
+	if t.RelatedToUID != "" {
+		caldavtodo += `
 RELATED-TO:` + t.RelatedToUID
-		}
+	}
 
-		if t.DueDate.Unix() > 0 {
-			caldavtodos += `
+	if t.DueDate.Unix() > 0 {
+		caldavtodo += `
 DUE:` + makeCalDavTimeFromTimeStamp(t.DueDate)
-		}
+	}
 
-		if t.Created.Unix() > 0 {
-			caldavtodos += `
+	if t.Created.Unix() > 0 {
+		caldavtodo += `
 CREATED:` + makeCalDavTimeFromTimeStamp(t.Created)
-		}
+	}
 
-		if t.Priority != 0 {
-			caldavtodos += `
+	if t.Priority != 0 {
+		caldavtodo += `
 PRIORITY:` + strconv.Itoa(mapPriorityToCaldav(t.Priority))
-		}
+	}
 
-		if t.RepeatAfter > 0 || t.RepeatMode == models.TaskRepeatModeMonth {
-			if t.RepeatMode == models.TaskRepeatModeMonth {
-				caldavtodos += `
+	if t.RepeatAfter > 0 || t.RepeatMode == models.TaskRepeatModeMonth {
+		if t.RepeatMode == models.TaskRepeatModeMonth {
+			caldavtodo += `
 RRULE:FREQ=MONTHLY;BYMONTHDAY=` + t.DueDate.Format("02") // Day of the month
-			} else {
-				caldavtodos += `
+		} else {
+			caldavtodo += `
 RRULE:FREQ=SECONDLY;INTERVAL=` + strconv.FormatInt(t.RepeatAfter, 10)
-			}
 		}
+	}
 
-		if len(t.Categories) > 0 {
-			caldavtodos += `
+	if len(t.Categories) > 0 {
+		caldavtodo += `
 CATEGORIES:` + strings.Join(t.Categories, ",")
-		}
+	}
 
-		caldavtodos += `
+	caldavtodo += `
 LAST-MODIFIED:` + makeCalDavTimeFromTimeStamp(t.Updated)
-		caldavtodos += ParseAlarms(t.Alarms, t.Summary)
-		caldavtodos += `
+	caldavtodo += ParseAlarms(t.Alarms, t.Summary)
+	caldavtodo += `
 END:VTODO`
+	return
+}
+
+func parseEvent(e *Event) (caldavevent string) {
+	if e.UID == "" {
+		e.UID = makeCalDavTimeFromTimeStamp(e.Timestamp) + utils.Sha256(e.Summary)
 	}
 
-	caldavtodos += `
-END:VCALENDAR` // Need a line break
+	caldavevent += `
+BEGIN:VEVENT
+UID:` + e.UID + `
+DTSTAMP:` + makeCalDavTimeFromTimeStamp(e.Timestamp) + `
+SUMMARY:` + e.Summary + getCaldavColor(e.Color) + `
+DTSTART:` + makeCalDavTimeFromTimeStamp(e.Start)
+
+	if e.End.After(e.Start) {
+		caldavevent += `
+DTEND:` + makeCalDavTimeFromTimeStamp(e.End)
+	}
+	if e.Description != "" {
+		re := regexp.MustCompile(`\r?\n`)
+		formattedDescription := re.ReplaceAllString(e.Description, "\\n")
+		caldavevent += `
+DESCRIPTION:` + formattedDescription
+	}
+
+	if e.Created.Unix() > 0 {
+		caldavevent += `
+CREATED:` + makeCalDavTimeFromTimeStamp(e.Created)
+	}
+
+	if len(e.Categories) > 0 {
+		caldavevent += `
+CATEGORIES:` + strings.Join(e.Categories, ",")
+	}
+
+	caldavevent += `
+LAST-MODIFIED:` + makeCalDavTimeFromTimeStamp(e.Updated)
+	caldavevent += ParseAlarms(e.Alarms, e.Summary)
+	caldavevent += `
+END:VEVENT`
 
 	return
 }
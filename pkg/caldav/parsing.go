@@ -36,48 +36,122 @@ func GetCaldavTodosForTasks(project *models.ProjectWithTasksAndBuckets, projectT
 	// Make caldav todos from Vikunja todos
 	var caldavtodos []*Todo
 	for _, t := range projectTasks {
+		caldavtodos = append(caldavtodos, getCaldavTodoForTask(&t.Task))
+	}
 
-		duration := t.EndDate.Sub(t.StartDate)
-		var categories []string
-		for _, label := range t.Labels {
-			categories = append(categories, label.Title)
-		}
-		var alarms []Alarm
-		for _, reminder := range t.Reminders {
-			alarms = append(alarms, Alarm{
-				Time:       reminder.Reminder,
-				Duration:   time.Duration(reminder.RelativePeriod) * time.Second,
-				RelativeTo: reminder.RelativeTo,
-			})
+	caldavConfig := &Config{
+		Name:   project.Title,
+		ProdID: "Vikunja Todo App",
+	}
+
+	return ParseTodos(caldavConfig, caldavtodos)
+}
+
+func getCaldavTodoForTask(t *models.Task) *Todo {
+	duration := t.EndDate.Sub(t.StartDate)
+	var categories []string
+	for _, label := range t.Labels {
+		categories = append(categories, label.Title)
+	}
+	var alarms []Alarm
+	for _, reminder := range t.Reminders {
+		alarms = append(alarms, Alarm{
+			Time:       reminder.Reminder,
+			Duration:   time.Duration(reminder.RelativePeriod) * time.Second,
+			RelativeTo: reminder.RelativeTo,
+		})
+	}
+
+	return &Todo{
+		Timestamp:   t.Updated,
+		UID:         t.UID,
+		Summary:     t.Title,
+		Description: t.Description,
+		Completed:   t.DoneAt,
+		// Organizer:     &t.CreatedBy, // Disabled until we figure out how this works
+		Categories:  categories,
+		Priority:    t.Priority,
+		Start:       t.StartDate,
+		End:         t.EndDate,
+		Created:     t.Created,
+		Updated:     t.Updated,
+		DueDate:     t.DueDate,
+		Duration:    duration,
+		RepeatAfter: t.RepeatAfter,
+		RepeatMode:  t.RepeatMode,
+		Alarms:      alarms,
+	}
+}
+
+// getCaldavEventForTask returns an event for a task based on the given date of it, or nil if the task does not have
+// that date.
+func getCaldavEventForTask(t *models.Task, eventDate models.CalendarFeedEventDate) *Event {
+	var start, end time.Time
+	switch eventDate {
+	case models.CalendarFeedEventDateDueDate:
+		start = t.DueDate
+	case models.CalendarFeedEventDateStartEndDate:
+		start = t.StartDate
+		end = t.EndDate
+		if start.IsZero() {
+			start = end
 		}
+	}
+	if start.IsZero() {
+		return nil
+	}
 
-		caldavtodos = append(caldavtodos, &Todo{
-			Timestamp:   t.Updated,
-			UID:         t.UID,
-			Summary:     t.Title,
-			Description: t.Description,
-			Completed:   t.DoneAt,
-			// Organizer:     &t.CreatedBy, // Disabled until we figure out how this works
-			Categories:  categories,
-			Priority:    t.Priority,
-			Start:       t.StartDate,
-			End:         t.EndDate,
-			Created:     t.Created,
-			Updated:     t.Updated,
-			DueDate:     t.DueDate,
-			Duration:    duration,
-			RepeatAfter: t.RepeatAfter,
-			RepeatMode:  t.RepeatMode,
-			Alarms:      alarms,
+	var categories []string
+	for _, label := range t.Labels {
+		categories = append(categories, label.Title)
+	}
+	// Reminders relative to a date of the task can't be mapped to the dates of the event, but all of them
+	// have their actual time set.
+	var alarms []Alarm
+	for _, reminder := range t.Reminders {
+		alarms = append(alarms, Alarm{
+			Time: reminder.Reminder,
 		})
 	}
 
+	return &Event{
+		Timestamp: t.Updated,
+		// The todo and event of a task can be in the same calendar, their uids must differ.
+		UID:         t.UID + "-event",
+		Summary:     t.Title,
+		Description: t.Description,
+		Categories:  categories,
+		Start:       start,
+		End:         end,
+		Created:     t.Created,
+		Updated:     t.Updated,
+		Alarms:      alarms,
+	}
+}
+
+// GetCalendarFeedForTasks returns a vcalendar with the tasks as todos and events, depending on the settings of the feed.
+// The lines of it are separated with CRLF as required by RFC 5545, calendar apps subscribing to feeds are often strict
+// about that.
+func GetCalendarFeedForTasks(feed *models.CalendarFeed, name string, tasks []*models.Task) string {
+	var todos []*Todo
+	var events []*Event
+	for _, t := range tasks {
+		if feed.IncludeTodos {
+			todos = append(todos, getCaldavTodoForTask(t))
+		}
+		if feed.EventDate != models.CalendarFeedEventDateNone {
+			if e := getCaldavEventForTask(t, feed.EventDate); e != nil {
+				events = append(events, e)
+			}
+		}
+	}
+
 	caldavConfig := &Config{
-		Name:   project.Title,
+		Name:   name,
 		ProdID: "Vikunja Todo App",
 	}
 
-	return ParseTodos(caldavConfig, caldavtodos)
+	return strings.ReplaceAll(ParseCalendar(caldavConfig, todos, events), "\n", "\r\n")
 }
 
 func ParseTaskFromVTODO(content string) (vTask *models.Task, err error) {
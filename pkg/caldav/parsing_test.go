@@ -17,6 +17,7 @@
 package caldav
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -404,3 +405,147 @@ END:VCALENDAR`,
 		})
 	}
 }
+
+func TestGetCalendarFeedForTasks(t *testing.T) {
+	tasks := []*models.Task{
+		{
+			Title:       "Task 1",
+			UID:         "randomuid",
+			Description: "Description",
+			Created:     time.Unix(1543626721, 0).In(config.GetTimeZone()),
+			DueDate:     time.Unix(1543626722, 0).In(config.GetTimeZone()),
+			StartDate:   time.Unix(1543626723, 0).In(config.GetTimeZone()),
+			EndDate:     time.Unix(1543626724, 0).In(config.GetTimeZone()),
+			Updated:     time.Unix(1543626725, 0).In(config.GetTimeZone()),
+			Labels: []*models.Label{
+				{
+					ID:    1,
+					Title: "label1",
+				},
+			},
+			Reminders: []*models.TaskReminder{
+				{
+					Reminder:       time.Unix(1543626731, 0).In(config.GetTimeZone()),
+					RelativePeriod: -3600,
+					RelativeTo:     models.ReminderRelationDueDate,
+				},
+			},
+		},
+		{
+			Title:   "Task 2",
+			UID:     "randomuid2",
+			Created: time.Unix(1543626721, 0).In(config.GetTimeZone()),
+			Updated: time.Unix(1543626725, 0).In(config.GetTimeZone()),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		feed       *models.CalendarFeed
+		wantCaldav string
+	}{
+		{
+			name: "Events at the due date",
+			feed: &models.CalendarFeed{
+				EventDate: models.CalendarFeedEventDateDueDate,
+			},
+			wantCaldav: `BEGIN:VCALENDAR
+VERSION:2.0
+METHOD:PUBLISH
+X-PUBLISHED-TTL:PT4H
+X-WR-CALNAME:Feed title
+PRODID:-//Vikunja Todo App//EN
+BEGIN:VEVENT
+UID:randomuid-event
+DTSTAMP:20181201T011205Z
+SUMMARY:Task 1
+DTSTART:20181201T011202Z
+DESCRIPTION:Description
+CREATED:20181201T011201Z
+CATEGORIES:label1
+LAST-MODIFIED:20181201T011205Z
+BEGIN:VALARM
+TRIGGER;VALUE=DATE-TIME:20181201T011211Z
+ACTION:DISPLAY
+DESCRIPTION:Task 1
+END:VALARM
+END:VEVENT
+END:VCALENDAR`,
+		},
+		{
+			name: "Events from start to end date",
+			feed: &models.CalendarFeed{
+				EventDate: models.CalendarFeedEventDateStartEndDate,
+			},
+			wantCaldav: `BEGIN:VCALENDAR
+VERSION:2.0
+METHOD:PUBLISH
+X-PUBLISHED-TTL:PT4H
+X-WR-CALNAME:Feed title
+PRODID:-//Vikunja Todo App//EN
+BEGIN:VEVENT
+UID:randomuid-event
+DTSTAMP:20181201T011205Z
+SUMMARY:Task 1
+DTSTART:20181201T011203Z
+DTEND:20181201T011204Z
+DESCRIPTION:Description
+CREATED:20181201T011201Z
+CATEGORIES:label1
+LAST-MODIFIED:20181201T011205Z
+BEGIN:VALARM
+TRIGGER;VALUE=DATE-TIME:20181201T011211Z
+ACTION:DISPLAY
+DESCRIPTION:Task 1
+END:VALARM
+END:VEVENT
+END:VCALENDAR`,
+		},
+		{
+			name: "Todos only",
+			feed: &models.CalendarFeed{
+				IncludeTodos: true,
+			},
+			wantCaldav: `BEGIN:VCALENDAR
+VERSION:2.0
+METHOD:PUBLISH
+X-PUBLISHED-TTL:PT4H
+X-WR-CALNAME:Feed title
+PRODID:-//Vikunja Todo App//EN
+BEGIN:VTODO
+UID:randomuid
+DTSTAMP:20181201T011205Z
+SUMMARY:Task 1
+DTSTART:20181201T011203Z
+DTEND:20181201T011204Z
+DESCRIPTION:Description
+DUE:20181201T011202Z
+CREATED:20181201T011201Z
+CATEGORIES:label1
+LAST-MODIFIED:20181201T011205Z
+BEGIN:VALARM
+TRIGGER;RELATED=END:-PT1H0M0S
+ACTION:DISPLAY
+DESCRIPTION:Task 1
+END:VALARM
+END:VTODO
+BEGIN:VTODO
+UID:randomuid2
+DTSTAMP:20181201T011205Z
+SUMMARY:Task 2
+CREATED:20181201T011201Z
+LAST-MODIFIED:20181201T011205Z
+END:VTODO
+END:VCALENDAR`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetCalendarFeedForTasks(tt.feed, "Feed title", tasks)
+			want := strings.ReplaceAll(tt.wantCaldav, "\n", "\r\n")
+			if diff, equal := messagediff.PrettyDiff(got, want); !equal {
+				t.Errorf("GetCalendarFeedForTasks() got = %v, want %v, diff = %s", got, want, diff)
+			}
+		})
+	}
+}
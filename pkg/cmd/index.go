@@ -30,27 +30,56 @@ func init() {
 
 var indexCmd = &cobra.Command{
 	Use:   "index",
-	Short: "Reindex all of Vikunja's data into Typesense. This will remove any existing index.",
+	Short: "Reindex all of Vikunja's data into Typesense and the embedded search index, whichever is enabled. This will remove any existing index.",
 	PreRun: func(cmd *cobra.Command, args []string) {
 		initialize.FullInitWithoutAsync()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		if !config.TypesenseEnabled.GetBool() {
-			log.Error("Typesense not enabled")
+		typesenseEnabled := config.TypesenseEnabled.GetBool()
+		searchIndexEnabled := config.SearchIndexEnabled.GetBool()
+		if !typesenseEnabled && !searchIndexEnabled {
+			log.Error("Neither Typesense nor the search index are enabled")
 			return
 		}
 
 		log.Infof("Indexing… This may take a while.")
 
-		err := models.CreateTypesenseCollections()
-		if err != nil {
-			log.Criticalf("Could not create Typesense collections: %s", err.Error())
-			return
+		if typesenseEnabled {
+			err := models.CreateTypesenseCollections()
+			if err != nil {
+				log.Criticalf("Could not create Typesense collections: %s", err.Error())
+				return
+			}
+			err = models.ReindexAllTasks()
+			if err != nil {
+				log.Criticalf("Could not reindex all tasks into Typesense: %s", err.Error())
+				return
+			}
+			err = models.ReindexAllEntities()
+			if err != nil {
+				log.Criticalf("Could not reindex all other entities into Typesense: %s", err.Error())
+				return
+			}
 		}
-		err = models.ReindexAllTasks()
-		if err != nil {
-			log.Criticalf("Could not reindex all tasks into Typesense: %s", err.Error())
-			return
+
+		if searchIndexEnabled && models.IsSearchIndexInUse() {
+			err := models.RequestSearchIndexRebuild()
+			if err != nil {
+				log.Criticalf("Could not request a rebuild of the search index: %s", err.Error())
+				return
+			}
+			log.Infof("The search index is opened by a running Vikunja server, it will rebuild the index within the next minute.")
+		} else if searchIndexEnabled {
+			err := models.RebuildSearchIndex()
+			if err != nil {
+				log.Criticalf("Could not rebuild the search index: %s", err.Error())
+				return
+			}
+			err = models.CloseSearchIndex()
+			if err != nil {
+				log.Criticalf("Could not save the search index: %s", err.Error())
+				return
+			}
 		}
 
 		log.Infof("Done!")
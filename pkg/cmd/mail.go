@@ -0,0 +1,236 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"code.vikunja.io/api/pkg/i18n"
+	"code.vikunja.io/api/pkg/initialize"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/mail"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/notifications"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mailListFlagStatus      []string
+	mailPurgeFlagStatus     []string
+	mailFlagOlderThan       time.Duration
+	mailFlagPreviewOutput   string
+	mailFlagPreviewLanguage string
+)
+
+func init() {
+	mailListCmd.Flags().StringSliceVarP(&mailListFlagStatus, "status", "s", nil, "Only show mails with this status. Can be queued, sending, sent or failed and may be given multiple times.")
+
+	mailPurgeCmd.Flags().StringSliceVarP(&mailPurgeFlagStatus, "status", "s", []string{string(mail.QueueStatusSent), string(mail.QueueStatusFailed)}, "Remove mails with this status. Can be queued, sending, sent or failed and may be given multiple times.")
+	mailPurgeCmd.Flags().DurationVarP(&mailFlagOlderThan, "older-than", "o", 0, "Only remove mails which were last updated longer ago than this, for example 72h.")
+
+	mailPreviewCmd.Flags().StringVarP(&mailFlagPreviewOutput, "output", "o", "mail-previews", "The directory where the previews will be saved.")
+	mailPreviewCmd.Flags().StringVarP(&mailFlagPreviewLanguage, "language", "l", i18n.DefaultLanguage, "The language the previews will be rendered in.")
+
+	mailCmd.AddCommand(mailListCmd, mailRetryCmd, mailPurgeCmd, mailPreviewCmd)
+	rootCmd.AddCommand(mailCmd)
+}
+
+func getMailStatusesFromFlag(flag []string) []mail.QueueStatus {
+	statuses := make([]mail.QueueStatus, 0, len(flag))
+	for _, s := range flag {
+		status := mail.QueueStatus(s)
+		if !status.IsValid() {
+			log.Fatalf("Invalid mail status %s", s)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func formatMailQueueTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+var mailCmd = &cobra.Command{
+	Use:   "mail",
+	Short: "Manage the queue of outgoing mails.",
+}
+
+var mailListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Shows a list of all mails in the queue.",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initialize.FullInitWithoutAsync()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		mails, err := mail.ListQueuedMails(getMailStatusesFromFlag(mailListFlagStatus)...)
+		if err != nil {
+			log.Fatalf("Error getting mails: %s", err)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"ID",
+			"To",
+			"Subject",
+			"Status",
+			"Attempts",
+			"Next attempt",
+			"Sent",
+			"Last error",
+			"Created",
+		})
+
+		for _, m := range mails {
+			nextAttempt := ""
+			if m.Status == mail.QueueStatusQueued {
+				nextAttempt = formatMailQueueTime(m.NextAttempt)
+			}
+
+			table.Append([]string{
+				strconv.FormatInt(m.ID, 10),
+				m.To,
+				m.Subject,
+				string(m.Status),
+				strconv.Itoa(m.Attempts),
+				nextAttempt,
+				formatMailQueueTime(m.SentAt),
+				m.LastError,
+				formatMailQueueTime(m.Created),
+			})
+		}
+
+		table.Render()
+	},
+}
+
+var mailRetryCmd = &cobra.Command{
+	Use:   "retry [mail id...]",
+	Short: "Put mails back into the queue to send them again. Retries all failed mails if no id is provided.",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initialize.FullInitWithoutAsync()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ids := make([]int64, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				log.Fatalf("Invalid mail id %s", arg)
+			}
+			ids = append(ids, id)
+		}
+
+		count, err := mail.RetryQueuedMails(ids...)
+		if err != nil {
+			log.Fatalf("Error retrying mails: %s", err)
+		}
+
+		fmt.Printf("Put %d mails back into the queue.\n", count)
+	},
+}
+
+var mailPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Remove mails from the queue. Removes all sent and failed mails by default.",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initialize.FullInitWithoutAsync()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		statuses := getMailStatusesFromFlag(mailPurgeFlagStatus)
+		if len(statuses) == 0 {
+			log.Fatalf("Please provide at least one status of mails to remove.")
+		}
+
+		var olderThan time.Time
+		if mailFlagOlderThan > 0 {
+			olderThan = time.Now().Add(-mailFlagOlderThan)
+		}
+
+		count, err := mail.PurgeQueuedMails(olderThan, statuses...)
+		if err != nil {
+			log.Fatalf("Error removing mails: %s", err)
+		}
+
+		fmt.Printf("Removed %d mails from the queue.\n", count)
+	},
+}
+
+var mailPreviewCmd = &cobra.Command{
+	Use:   "preview [template name]",
+	Short: "Render previews of all notification mails with example data using the configured templates. Renders only the given template if a name is provided.",
+	Args:  cobra.MaximumNArgs(1),
+	PreRun: func(cmd *cobra.Command, args []string) {
+		initialize.LightInit()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := os.MkdirAll(mailFlagPreviewOutput, 0o750)
+		if err != nil {
+			log.Fatalf("Could not create the output directory: %s", err)
+		}
+
+		logoName, logo, err := notifications.GetMailLogo()
+		if err != nil {
+			log.Fatalf("Could not read the mail logo: %s", err)
+		}
+		err = os.WriteFile(filepath.Join(mailFlagPreviewOutput, logoName), logo, 0o640)
+		if err != nil {
+			log.Fatalf("Could not save the mail logo: %s", err)
+		}
+
+		var rendered int
+		for _, n := range models.GetMailPreviewNotifications() {
+			name, opts, err := notifications.RenderNotificationPreview(n, mailFlagPreviewLanguage)
+			if err != nil {
+				log.Fatalf("Could not render the mail for %T: %s", n, err)
+			}
+			if opts == nil {
+				continue
+			}
+
+			if len(args) > 0 && args[0] != name {
+				continue
+			}
+
+			base := filepath.Join(mailFlagPreviewOutput, name)
+			err = os.WriteFile(base+".html", []byte(opts.HTMLMessage), 0o640)
+			if err != nil {
+				log.Fatalf("Could not save the preview: %s", err)
+			}
+			err = os.WriteFile(base+".txt", []byte("Subject: "+opts.Subject+"\n"+opts.Message), 0o640)
+			if err != nil {
+				log.Fatalf("Could not save the preview: %s", err)
+			}
+
+			fmt.Printf("%s: %s\n", name, opts.Subject)
+			rendered++
+		}
+
+		if rendered == 0 {
+			log.Fatalf("There is no mail with the template %s", args[0])
+		}
+
+		fmt.Printf("Saved %d previews to %s\n", rendered, mailFlagPreviewOutput)
+	},
+}
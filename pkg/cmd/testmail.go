@@ -35,9 +35,6 @@ var testmailCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		initialize.LightInit()
-
-		// Start the mail daemon
-		mail.StartMailDaemon()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Info("Sending testmail...")
@@ -27,6 +27,7 @@ import (
 	"github.com/asaskevich/govalidator"
 
 	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
 	"code.vikunja.io/api/pkg/initialize"
 	"code.vikunja.io/api/pkg/log"
 	"code.vikunja.io/api/pkg/models"
@@ -371,6 +372,10 @@ var userDeleteCmd = &cobra.Command{
 			log.Fatalf("Error saving everything: %s", err)
 		}
 
+		if err := events.DispatchPending(s); err != nil {
+			log.Fatalf("Could not dispatch events: %s", err)
+		}
+
 		if userFlagDeleteNow {
 			fmt.Println("User deleted successfully.")
 		} else {
@@ -27,6 +27,8 @@ import (
 	"code.vikunja.io/api/pkg/cron"
 	"code.vikunja.io/api/pkg/initialize"
 	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/mail"
+	"code.vikunja.io/api/pkg/models"
 	"code.vikunja.io/api/pkg/routes"
 	"code.vikunja.io/api/pkg/utils"
 	"code.vikunja.io/api/pkg/version"
@@ -69,6 +71,9 @@ var webCmd = &cobra.Command{
 	Short: "Starts the rest api web server",
 	PreRun: func(cmd *cobra.Command, args []string) {
 		initialize.FullInit()
+
+		// Only the web server sends mails, all other commands put them into the queue.
+		mail.StartMailDaemon()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 
@@ -103,5 +108,8 @@ var webCmd = &cobra.Command{
 			e.Logger.Fatal(err)
 		}
 		cron.Stop()
+		if err := models.CloseSearchIndex(); err != nil {
+			log.Errorf("Could not save the search index: %s", err)
+		}
 	},
 }
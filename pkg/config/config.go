@@ -64,6 +64,7 @@ const (
 	ServiceEnableEmailReminders  Key = `service.enableemailreminders`
 	ServiceEnableUserDeletion    Key = `service.enableuserdeletion`
 	ServiceMaxAvatarSize         Key = `service.maxavatarsize`
+	ServiceSyncRetentionDays     Key = `service.syncretentiondays`
 
 	AuthLocalEnabled      Key = `auth.local.enabled`
 	AuthOpenIDEnabled     Key = `auth.openid.enabled`
@@ -73,36 +74,50 @@ const (
 	LegalImprintURL Key = `legal.imprinturl`
 	LegalPrivacyURL Key = `legal.privacyurl`
 
-	DatabaseType                  Key = `database.type`
-	DatabaseHost                  Key = `database.host`
-	DatabaseUser                  Key = `database.user`
-	DatabasePassword              Key = `database.password`
-	DatabaseDatabase              Key = `database.database`
-	DatabasePath                  Key = `database.path`
-	DatabaseMaxOpenConnections    Key = `database.maxopenconnections`
-	DatabaseMaxIdleConnections    Key = `database.maxidleconnections`
-	DatabaseMaxConnectionLifetime Key = `database.maxconnectionlifetime`
-	DatabaseSslMode               Key = `database.sslmode`
-	DatabaseSslCert               Key = `database.sslcert`
-	DatabaseSslKey                Key = `database.sslkey`
-	DatabaseSslRootCert           Key = `database.sslrootcert`
-	DatabaseTLS                   Key = `database.tls`
+	DatabaseType                   Key = `database.type`
+	DatabaseHost                   Key = `database.host`
+	DatabaseUser                   Key = `database.user`
+	DatabasePassword               Key = `database.password`
+	DatabaseDatabase               Key = `database.database`
+	DatabasePath                   Key = `database.path`
+	DatabaseMaxOpenConnections     Key = `database.maxopenconnections`
+	DatabaseMaxIdleConnections     Key = `database.maxidleconnections`
+	DatabaseMaxConnectionLifetime  Key = `database.maxconnectionlifetime`
+	DatabaseSslMode                Key = `database.sslmode`
+	DatabaseSslCert                Key = `database.sslcert`
+	DatabaseSslKey                 Key = `database.sslkey`
+	DatabaseSslRootCert            Key = `database.sslrootcert`
+	DatabaseTLS                    Key = `database.tls`
+	DatabaseFullTextSearch         Key = `database.fulltextsearch`
+	DatabaseFullTextSearchLanguage Key = `database.fulltextsearchlanguage`
 
 	TypesenseEnabled Key = `typesense.enabled`
 	TypesenseURL     Key = `typesense.url`
 	TypesenseAPIKey  Key = `typesense.apikey`
 
-	MailerEnabled       Key = `mailer.enabled`
-	MailerHost          Key = `mailer.host`
-	MailerPort          Key = `mailer.port`
-	MailerUsername      Key = `mailer.username`
-	MailerPassword      Key = `mailer.password`
-	MailerAuthType      Key = `mailer.authtype`
-	MailerSkipTLSVerify Key = `mailer.skiptlsverify`
-	MailerFromEmail     Key = `mailer.fromemail`
-	MailerQueuelength   Key = `mailer.queuelength`
-	MailerQueueTimeout  Key = `mailer.queuetimeout`
-	MailerForceSSL      Key = `mailer.forcessl`
+	SearchIndexEnabled  Key = `searchindex.enabled`
+	SearchIndexPath     Key = `searchindex.path`
+	SearchIndexLanguage Key = `searchindex.language`
+
+	MailerEnabled          Key = `mailer.enabled`
+	MailerHost             Key = `mailer.host`
+	MailerPort             Key = `mailer.port`
+	MailerUsername         Key = `mailer.username`
+	MailerPassword         Key = `mailer.password`
+	MailerAuthType         Key = `mailer.authtype`
+	MailerSkipTLSVerify    Key = `mailer.skiptlsverify`
+	MailerFromEmail        Key = `mailer.fromemail`
+	MailerQueuelength      Key = `mailer.queuelength`
+	MailerQueueTimeout     Key = `mailer.queuetimeout`
+	MailerQueueMaxAttempts Key = `mailer.queuemaxattempts`
+	MailerQueueRetryDelay  Key = `mailer.queueretrydelay`
+	MailerQueueRateLimit   Key = `mailer.queueratelimit`
+	MailerTemplatesDir     Key = `mailer.templatesdir`
+	MailerBrandName        Key = `mailer.brandname`
+	MailerBrandColor       Key = `mailer.brandcolor`
+	MailerBackgroundColor  Key = `mailer.backgroundcolor`
+	MailerLogo             Key = `mailer.logo`
+	MailerForceSSL         Key = `mailer.forcessl`
 
 	RedisEnabled  Key = `redis.enabled`
 	RedisHost     Key = `redis.host`
@@ -131,17 +146,19 @@ const (
 	FilesBasePath Key = `files.basepath`
 	FilesMaxSize  Key = `files.maxsize`
 
-	MigrationTodoistEnable             Key = `migration.todoist.enable`
-	MigrationTodoistClientID           Key = `migration.todoist.clientid`
-	MigrationTodoistClientSecret       Key = `migration.todoist.clientsecret`
-	MigrationTodoistRedirectURL        Key = `migration.todoist.redirecturl`
-	MigrationTrelloEnable              Key = `migration.trello.enable`
-	MigrationTrelloKey                 Key = `migration.trello.key`
-	MigrationTrelloRedirectURL         Key = `migration.trello.redirecturl`
-	MigrationMicrosoftTodoEnable       Key = `migration.microsofttodo.enable`
-	MigrationMicrosoftTodoClientID     Key = `migration.microsofttodo.clientid`
-	MigrationMicrosoftTodoClientSecret Key = `migration.microsofttodo.clientsecret`
-	MigrationMicrosoftTodoRedirectURL  Key = `migration.microsofttodo.redirecturl`
+	MigrationTodoistEnable               Key = `migration.todoist.enable`
+	MigrationTodoistClientID             Key = `migration.todoist.clientid`
+	MigrationTodoistClientSecret         Key = `migration.todoist.clientsecret`
+	MigrationTodoistRedirectURL          Key = `migration.todoist.redirecturl`
+	MigrationTrelloEnable                Key = `migration.trello.enable`
+	MigrationTrelloKey                   Key = `migration.trello.key`
+	MigrationTrelloRedirectURL           Key = `migration.trello.redirecturl`
+	MigrationMicrosoftTodoEnable         Key = `migration.microsofttodo.enable`
+	MigrationMicrosoftTodoClientID       Key = `migration.microsofttodo.clientid`
+	MigrationMicrosoftTodoClientSecret   Key = `migration.microsofttodo.clientsecret`
+	MigrationMicrosoftTodoRedirectURL    Key = `migration.microsofttodo.redirecturl`
+	MigrationVikunjaInstanceEnable       Key = `migration.vikunjainstance.enable`
+	MigrationVikunjaInstanceAllowedHosts Key = `migration.vikunjainstance.allowedhosts`
 
 	CorsEnable  Key = `cors.enable`
 	CorsOrigins Key = `cors.origins`
@@ -177,6 +194,13 @@ const (
 	WebhooksTimeoutSeconds Key = `webhooks.timeoutseconds`
 	WebhooksProxyURL       Key = `webhooks.proxyurl`
 	WebhooksProxyPassword  Key = `webhooks.proxypassword`
+
+	WebPushEnabled         Key = `webpush.enabled`
+	WebPushVAPIDPublicKey  Key = `webpush.vapidpublickey`
+	WebPushVAPIDPrivateKey Key = `webpush.vapidprivatekey`
+	WebPushSubject         Key = `webpush.subject`
+
+	ChatAllowedHosts Key = `chat.allowedhosts`
 )
 
 // GetString returns a string config value
@@ -306,6 +330,7 @@ func InitDefaultConfig() {
 	ServiceEnableEmailReminders.setDefault(true)
 	ServiceEnableUserDeletion.setDefault(true)
 	ServiceMaxAvatarSize.setDefault(1024)
+	ServiceSyncRetentionDays.setDefault(90)
 	ServiceDemoMode.setDefault(false)
 
 	// Auth
@@ -327,9 +352,15 @@ func InitDefaultConfig() {
 	DatabaseSslKey.setDefault("")
 	DatabaseSslRootCert.setDefault("")
 	DatabaseTLS.setDefault("false")
+	DatabaseFullTextSearch.setDefault(false)
+	DatabaseFullTextSearchLanguage.setDefault("english")
 
 	// Typesense
 	TypesenseEnabled.setDefault(false)
+	// Search index
+	SearchIndexEnabled.setDefault(false)
+	SearchIndexPath.setDefault(ServiceRootpath.GetString() + "/search-index")
+	SearchIndexLanguage.setDefault("english")
 
 	// Mailer
 	MailerEnabled.setDefault(false)
@@ -341,6 +372,14 @@ func InitDefaultConfig() {
 	MailerFromEmail.setDefault("mail@vikunja")
 	MailerQueuelength.setDefault(100)
 	MailerQueueTimeout.setDefault(30)
+	MailerQueueMaxAttempts.setDefault(5)
+	MailerQueueRetryDelay.setDefault(60)
+	MailerQueueRateLimit.setDefault(0)
+	MailerTemplatesDir.setDefault("")
+	MailerBrandName.setDefault("Vikunja")
+	MailerBrandColor.setDefault("#1973ff")
+	MailerBackgroundColor.setDefault("#f3f4f6")
+	MailerLogo.setDefault("")
 	MailerForceSSL.setDefault(false)
 	MailerAuthType.setDefault("plain")
 	// Redis
@@ -378,6 +417,8 @@ func InitDefaultConfig() {
 	MigrationTodoistEnable.setDefault(false)
 	MigrationTrelloEnable.setDefault(false)
 	MigrationMicrosoftTodoEnable.setDefault(false)
+	MigrationVikunjaInstanceEnable.setDefault(false)
+	MigrationVikunjaInstanceAllowedHosts.setDefault([]string{})
 	// Avatar
 	AvatarGravaterExpiration.setDefault(3600)
 	// Project Backgrounds
@@ -395,6 +436,10 @@ func InitDefaultConfig() {
 	// Webhook
 	WebhooksEnabled.setDefault(true)
 	WebhooksTimeoutSeconds.setDefault(30)
+	// Web Push
+	WebPushEnabled.setDefault(false)
+	// Chat
+	ChatAllowedHosts.setDefault([]string{})
 }
 
 // InitConfig initializes the config, sets defaults etc.
@@ -19,6 +19,7 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"sync"
 	"time"
 
 	"code.vikunja.io/api/pkg/config"
@@ -29,10 +30,19 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"xorm.io/xorm"
 )
 
 var pubsub *gochannel.GoChannel
 
+// running is closed once events are processed, events dispatched before that are lost.
+var running = make(chan struct{})
+
+var (
+	pendingEvents     = make(map[*xorm.Session][]Event)
+	pendingEventsLock sync.Mutex
+)
+
 // Event represents the event interface used by all events
 type Event interface {
 	Name() string
@@ -93,9 +103,19 @@ func InitEvents() (err error) {
 		}
 	}
 
+	go func() {
+		<-router.Running()
+		close(running)
+	}()
+
 	return router.Run(context.Background())
 }
 
+// Running returns a channel which is closed once events are processed.
+func Running() <-chan struct{} {
+	return running
+}
+
 // Dispatch dispatches an event
 func Dispatch(event Event) error {
 	if isUnderTest {
@@ -111,3 +131,41 @@ func Dispatch(event Event) error {
 	msg := message.NewMessage(watermill.NewUUID(), content)
 	return pubsub.Publish(event.Name(), msg)
 }
+
+// DispatchOnCommit dispatches an event once the transaction of the session was committed, so that listeners
+// don't act on changes which are rolled back later. If the session is not in a transaction, the event is
+// dispatched right away.
+// Whoever started the transaction needs to call DispatchPending after committing it or CleanupPending after
+// rolling it back.
+func DispatchOnCommit(s *xorm.Session, event Event) error {
+	if !s.IsInTx() {
+		return Dispatch(event)
+	}
+
+	pendingEventsLock.Lock()
+	defer pendingEventsLock.Unlock()
+	pendingEvents[s] = append(pendingEvents[s], event)
+	return nil
+}
+
+// DispatchPending dispatches all events which were waiting for the transaction of the session to be committed.
+func DispatchPending(s *xorm.Session) error {
+	pendingEventsLock.Lock()
+	pending := pendingEvents[s]
+	delete(pendingEvents, s)
+	pendingEventsLock.Unlock()
+
+	for _, event := range pending {
+		if err := Dispatch(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CleanupPending drops all events which were waiting for the transaction of the session to be committed.
+func CleanupPending(s *xorm.Session) {
+	pendingEventsLock.Lock()
+	defer pendingEventsLock.Unlock()
+	delete(pendingEvents, s)
+}
@@ -52,6 +52,16 @@ func AssertDispatched(t *testing.T, event Event) {
 	assert.True(t, found, "Failed to assert "+event.Name()+" has been dispatched.")
 }
 
+// AssertNotDispatched asserts an event has not been dispatched.
+func AssertNotDispatched(t *testing.T, event Event) {
+	for _, testEvent := range dispatchedTestEvents {
+		if event.Name() == testEvent.Name() {
+			assert.Fail(t, "Failed to assert "+event.Name()+" has not been dispatched.")
+			return
+		}
+	}
+}
+
 // TestListener takes an event and a listener and calls the listener's Handle method.
 func TestListener(t *testing.T, event Event, listener Listener) {
 	content, err := json.Marshal(event)
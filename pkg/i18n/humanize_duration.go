@@ -14,18 +14,18 @@
 // You should have received a copy of the GNU Affero General Public Licensee
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-package utils
+package i18n
 
 import (
-	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// HumanizeDuration formats a time.Duration in a human-friendly format.
+// HumanizeDuration formats a time.Duration in a human-friendly format in the given language.
 // Based on https://gist.github.com/harshavardhana/327e0577c4fed9211f65
-func HumanizeDuration(duration time.Duration) string {
+func HumanizeDuration(lang string, duration time.Duration) string {
 	years := int64(duration.Hours() / 24 / 365)
 	days := int64(duration.Hours()/24) - years*365
 	weeks := days / 7
@@ -35,8 +35,8 @@ func HumanizeDuration(duration time.Duration) string {
 	minutes := int64(math.Mod(duration.Minutes(), 60))
 
 	chunks := []struct {
-		singularName string
-		amount       int64
+		unit   string
+		amount int64
 	}{
 		{"year", years},
 		{"week", weeks},
@@ -52,14 +52,14 @@ func HumanizeDuration(duration time.Duration) string {
 		case 0:
 			continue
 		case 1:
-			parts = append(parts, fmt.Sprintf("one %s", chunk.singularName))
+			parts = append(parts, T(lang, "time."+chunk.unit+".one"))
 		default:
-			parts = append(parts, fmt.Sprintf("%d %ss", chunk.amount, chunk.singularName))
+			parts = append(parts, T(lang, "time."+chunk.unit+".other", strconv.FormatInt(chunk.amount, 10)))
 		}
 	}
 
 	if len(parts) > 1 {
-		return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+		return strings.Join(parts[:len(parts)-1], ", ") + " " + T(lang, "time.and") + " " + parts[len(parts)-1]
 	}
 
 	return strings.Join(parts, ", ")
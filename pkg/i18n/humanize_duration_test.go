@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Affero General Public Licensee
 // along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
-package utils
+package i18n
 
 import (
 	"testing"
@@ -26,39 +26,45 @@ import (
 func TestHumanizeDuration(t *testing.T) {
 	t.Run("one part", func(t *testing.T) {
 		d := 1 * time.Hour
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "one hour", dur)
 	})
 	t.Run("amount > 1", func(t *testing.T) {
 		d := 2 * time.Hour
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "2 hours", dur)
 	})
 	t.Run("2 parts", func(t *testing.T) {
 		d := 2*time.Hour + 48*time.Hour
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "2 days and 2 hours", dur)
 	})
 	t.Run("multiple parts", func(t *testing.T) {
 		d := 2*time.Hour + 24*15*time.Hour
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "2 weeks, one day and 2 hours", dur)
 	})
 	t.Run("years", func(t *testing.T) {
 		day := 24 * time.Hour
 		d := 2*time.Hour + 365*day + 14*day
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "one year, 2 weeks and 2 hours", dur)
 	})
 	t.Run("ignore seconds", func(t *testing.T) {
 		d := 2*time.Hour + 48*time.Hour + 23*time.Second
-		dur := HumanizeDuration(d)
+		dur := HumanizeDuration(DefaultLanguage, d)
 
 		assert.Equal(t, "2 days and 2 hours", dur)
 	})
+	t.Run("translated", func(t *testing.T) {
+		d := 2*time.Hour + 24*15*time.Hour
+		dur := HumanizeDuration("de-DE", d)
+
+		assert.Equal(t, "2 Wochen, ein Tag und 2 Stunden", dur)
+	})
 }
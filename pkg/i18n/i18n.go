@@ -0,0 +1,151 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.vikunja.io/api/pkg/log"
+)
+
+// DefaultLanguage is used for everything which is not translated into the language of a user.
+const DefaultLanguage = "en"
+
+//go:embed lang/*.json
+var langFiles embed.FS
+
+var (
+	translations     map[string]map[string]string
+	translationsOnce sync.Once
+)
+
+// flatten turns the nested json structure of a language file into a map with dot-separated keys.
+func flatten(prefix string, in map[string]interface{}, out map[string]string) {
+	for key, value := range in {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case string:
+			out[key] = v
+		case map[string]interface{}:
+			flatten(key, v, out)
+		}
+	}
+}
+
+func loadTranslations() {
+	translations = make(map[string]map[string]string)
+
+	files, err := langFiles.ReadDir("lang")
+	if err != nil {
+		log.Fatalf("Could not read translations: %s", err)
+	}
+
+	for _, file := range files {
+		content, err := langFiles.ReadFile(path.Join("lang", file.Name()))
+		if err != nil {
+			log.Fatalf("Could not read translation file %s: %s", file.Name(), err)
+		}
+
+		raw := make(map[string]interface{})
+		err = json.Unmarshal(content, &raw)
+		if err != nil {
+			log.Fatalf("Could not parse translation file %s: %s", file.Name(), err)
+		}
+
+		lang := strings.ToLower(strings.TrimSuffix(file.Name(), ".json"))
+		translations[lang] = make(map[string]string)
+		flatten("", raw, translations[lang])
+	}
+}
+
+// candidates returns all languages which should be tried for a language code, in order.
+// Language codes of users look like "de-DE", so "de-de" is tried first, then "de" and then the default language.
+func candidates(lang string) []string {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	langs := []string{}
+	if lang != "" {
+		langs = append(langs, lang)
+		if base, _, has := strings.Cut(lang, "-"); has {
+			langs = append(langs, base)
+		}
+	}
+	return append(langs, DefaultLanguage)
+}
+
+// AvailableLanguages returns all languages there is a translation for.
+func AvailableLanguages() []string {
+	translationsOnce.Do(loadTranslations)
+
+	langs := make([]string, 0, len(translations))
+	for lang := range translations {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// T returns the translation of a key in a language. Falls back to English if the key is not translated
+// into that language and to the key itself if it does not exist at all.
+// Params are inserted using fmt placeholders like %[1]s.
+func T(lang, key string, params ...string) string {
+	translationsOnce.Do(loadTranslations)
+
+	for _, l := range candidates(lang) {
+		translation, has := translations[l][key]
+		if !has {
+			continue
+		}
+
+		if len(params) == 0 {
+			return translation
+		}
+
+		args := make([]interface{}, 0, len(params))
+		for _, p := range params {
+			args = append(args, p)
+		}
+		return fmt.Sprintf(translation, args...)
+	}
+
+	log.Errorf("Translation key %s does not exist", key)
+	return key
+}
+
+// FormatDateTime formats a time in the date format of a language and the given time zone.
+// If the time zone is empty or invalid, the time is formatted in the time zone it already has.
+func FormatDateTime(lang string, t time.Time, timezone string) string {
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			log.Debugf("Could not load time zone %s: %s", timezone, err)
+		} else {
+			t = t.In(loc)
+		}
+	}
+
+	return t.Format(T(lang, "date.datetime_format"))
+}
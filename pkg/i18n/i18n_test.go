@@ -0,0 +1,68 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	t.Run("default language", func(t *testing.T) {
+		assert.Equal(t, "Hi Frederick,", T(DefaultLanguage, "notifications.common.greeting", "Frederick"))
+	})
+	t.Run("language with region", func(t *testing.T) {
+		assert.Equal(t, "Hallo Frederick,", T("de-DE", "notifications.common.greeting", "Frederick"))
+	})
+	t.Run("unknown language", func(t *testing.T) {
+		assert.Equal(t, "Hi Frederick,", T("xx-XX", "notifications.common.greeting", "Frederick"))
+	})
+	t.Run("empty language", func(t *testing.T) {
+		assert.Equal(t, "Have a nice day!", T("", "notifications.common.have_nice_day"))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		assert.Equal(t, "notifications.does.not.exist", T("de-DE", "notifications.does.not.exist"))
+	})
+	t.Run("multiple params", func(t *testing.T) {
+		assert.Equal(t, `Reminder for "Task" (Project)`, T("en", "notifications.task.reminder.subject", "Task", "Project"))
+	})
+}
+
+func TestTranslationsComplete(t *testing.T) {
+	translationsOnce.Do(loadTranslations)
+
+	for _, lang := range AvailableLanguages() {
+		for key := range translations[DefaultLanguage] {
+			_, has := translations[lang][key]
+			assert.True(t, has, "Key %s is missing in language %s", key, lang)
+		}
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	date := time.Date(2023, 3, 7, 14, 30, 0, 0, time.UTC)
+
+	t.Run("in time zone", func(t *testing.T) {
+		assert.Equal(t, "03/07/2023 3:30 PM CET", FormatDateTime(DefaultLanguage, date, "Europe/Berlin"))
+		assert.Equal(t, "07.03.2023 15:30 CET", FormatDateTime("de-DE", date, "Europe/Berlin"))
+	})
+	t.Run("invalid time zone", func(t *testing.T) {
+		assert.Equal(t, "03/07/2023 2:30 PM UTC", FormatDateTime(DefaultLanguage, date, "Nowhere/Special"))
+	})
+}
@@ -29,6 +29,8 @@ import (
 	"code.vikunja.io/api/pkg/models"
 	"code.vikunja.io/api/pkg/modules/auth/openid"
 	"code.vikunja.io/api/pkg/modules/keyvalue"
+	migrationModule "code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/modules/webpush"
 	"code.vikunja.io/api/pkg/red"
 	"code.vikunja.io/api/pkg/user"
 )
@@ -76,8 +78,17 @@ func FullInitWithoutAsync() {
 	// Init Typesense
 	models.InitTypesense()
 
-	// Start the mail daemon
-	mail.StartMailDaemon()
+	// Init the embedded search index
+	err := models.InitSearchIndex()
+	if err != nil {
+		log.Errorf("Could not open the search index, searching through the database instead: %s", err)
+	}
+
+	// Init Postgres full text search
+	models.InitFullTextSearch()
+
+	// Make sure there are keys to send web push notifications
+	webpush.InitVAPIDKeys()
 }
 
 // FullInit initializes all kinds of things in the right order
@@ -91,15 +102,29 @@ func FullInit() {
 	models.RegisterOverdueReminderCron()
 	user.RegisterTokenCleanupCron()
 	user.RegisterDeletionNotificationCron()
+	user.RegisterNotificationDigestCron()
+	mail.RegisterQueueCleanupCron()
 	models.RegisterUserDeletionCron()
 	models.RegisterOldExportCleanupCron()
+	models.RegisterTombstoneCleanupCron()
 	openid.CleanupSavedOpenIDProviders()
 	models.RegisterPeriodicTypesenseResyncCron()
+	models.RegisterSearchIndexRebuildCron()
+
+	// Resume migration jobs once events are processed, they are started through events
+	go func() {
+		<-events.Running()
+		err := migrationModule.ResumeJobs()
+		if err != nil {
+			log.Errorf("Could not resume migration jobs: %s", err)
+		}
+	}()
 
 	// Start processing events
 	go func() {
 		models.RegisterListeners()
 		user.RegisterListeners()
+		migrationModule.RegisterListeners()
 		err := events.InitEvents()
 		if err != nil {
 			log.Fatal(err.Error())
@@ -0,0 +1,110 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	serve := func(e *echo.Echo, path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	request := func(t *testing.T, path string, header http.Header) *httptest.ResponseRecorder {
+		e, err := setupTestEnv()
+		require.NoError(t, err)
+		return serve(e, path, header)
+	}
+
+	t.Run("project feed", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y.ics", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "private, max-age=900", rec.Header().Get("Cache-Control"))
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		assert.Contains(t, rec.Body.String(), "BEGIN:VCALENDAR\r\n")
+		assert.Contains(t, rec.Body.String(), "X-WR-CALNAME:Test1\r\n")
+		assert.Contains(t, rec.Body.String(), "BEGIN:VTODO\r\n")
+		assert.Contains(t, rec.Body.String(), "BEGIN:VEVENT\r\n")
+		// Done tasks are not part of the feed
+		assert.NotContains(t, rec.Body.String(), "SUMMARY:task #2 done\r\n")
+	})
+	t.Run("without extension", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("not modified", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y.ics", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		etag := rec.Header().Get("ETag")
+
+		rec = request(t, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y.ics", http.Header{"If-None-Match": []string{etag}})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+
+		rec = request(t, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y.ics", http.Header{"If-None-Match": []string{`"outdated"`}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("saved filter feed", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/u1f1Kd3WmPq8ZsXv5RtYbN0cLhGj7eFa2uIo9T4w.ics", nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "X-WR-CALNAME:testfilter1\r\n")
+		assert.Contains(t, rec.Body.String(), "BEGIN:VEVENT\r\n")
+		assert.NotContains(t, rec.Body.String(), "BEGIN:VTODO")
+	})
+	t.Run("owner lost access", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/u1p2Hs6QeLw1RbNx8ZkTcVy3MgJp5DaU0fOi7E2r.ics", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":17001`)
+	})
+	t.Run("nonexisting", func(t *testing.T) {
+		rec := request(t, "/api/v1/feeds/doesnotexist.ics", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+	t.Run("revoked", func(t *testing.T) {
+		th := webHandlerTest{
+			user: &testuser1,
+			strFunc: func() handler.CObject {
+				return &models.CalendarFeed{}
+			},
+			t: t,
+		}
+		// Setting up the test env loads the fixtures again, therefore the feed is requested without doing that.
+		e, err := setupTestEnv()
+		require.NoError(t, err)
+		_, err = th.testDeleteWithUser(nil, map[string]string{"feed": "1"})
+		require.NoError(t, err)
+
+		rec := serve(e, "/api/v1/feeds/u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y.ics", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -65,6 +65,7 @@ func TestBucket(t *testing.T) {
 			rec, err := testHandler.testUpdateWithUser(nil, map[string]string{"bucket": "1"}, `{"title":"TestLoremIpsum"}`)
 			assert.NoError(t, err)
 			assert.Contains(t, rec.Body.String(), `"title":"TestLoremIpsum"`)
+			assert.Equal(t, `"1-1"`, rec.Result().Header.Get("ETag"))
 		})
 		t.Run("Nonexisting Bucket", func(t *testing.T) {
 			_, err := testHandler.testUpdateWithUser(nil, map[string]string{"bucket": "9999"}, `{"title":"TestLoremIpsum"}`)
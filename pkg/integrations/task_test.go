@@ -58,6 +58,7 @@ func TestTask(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Contains(t, rec.Body.String(), `"title":"Lorem Ipsum"`)
 				assert.NotContains(t, rec.Body.String(), `"title":"task #1"`)
+				assert.Equal(t, `"1-1"`, rec.Result().Header.Get("ETag"))
 			})
 			t.Run("Description", func(t *testing.T) {
 				rec, err := testHandler.testUpdateWithUser(nil, map[string]string{"projecttask": "1"}, `{"description":"Dolor sit amet"}`)
@@ -0,0 +1,170 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package integrations
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/models"
+	vikunjainstance "code.vikunja.io/api/pkg/modules/migration/vikunja-instance"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVikunjaInstanceMigration(t *testing.T) {
+	// The other instance is a second server in this process which uses the same database. Everything of user 1 is
+	// migrated to user 2.
+	e, err := setupTestEnv()
+	require.NoError(t, err)
+	server := httptest.NewServer(e)
+	defer server.Close()
+	config.MigrationVikunjaInstanceAllowedHosts.Set([]string{"127.0.0.1"})
+	defer config.MigrationVikunjaInstanceAllowedHosts.Set([]string{})
+	files.InitTestFileFixtures(t)
+
+	s := db.NewSession()
+	token := &models.APIToken{
+		Title: "migration",
+		Permissions: models.APIPermissions{
+			"projects":                     []string{"read_all"},
+			"projects_tasks":               []string{"read_all"},
+			"projects_buckets":             []string{"read_all"},
+			"projects_background_download": []string{"read_one"},
+			"tasks_comments":               []string{"read_all"},
+			"tasks_attachments_download":   []string{"read_one"},
+			"filters":                      []string{"read_one"},
+		},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	err = token.Create(s, &testuser1)
+	require.NoError(t, err)
+	// Filters with ids of projects which are migrated get the new ids, filters with projects which are not are skipped
+	err = (&models.SavedFilter{
+		Title:   "Filter for Test1",
+		Filters: &models.TaskCollection{FilterBy: []string{"project_id"}, FilterValue: []string{"1"}, FilterComparator: []string{"equals"}},
+	}).Create(s, &testuser1)
+	require.NoError(t, err)
+	err = (&models.SavedFilter{
+		Title:   "Filter for a project of another user",
+		Filters: &models.TaskCollection{FilterBy: []string{"project_id"}, FilterValue: []string{"2"}, FilterComparator: []string{"equals"}},
+	}).Create(s, &testuser1)
+	require.NoError(t, err)
+	s.Close()
+
+	u, err := user.GetUserByID(db.NewSession(), 2)
+	require.NoError(t, err)
+
+	t.Run("invalid token", func(t *testing.T) {
+		m := &vikunjainstance.Migration{URL: server.URL, Token: "tk_invalid"}
+		err := m.Migrate(u)
+		require.Error(t, err)
+		assert.True(t, models.IsErrInvalidData(err))
+	})
+	t.Run("missing url", func(t *testing.T) {
+		m := &vikunjainstance.Migration{Token: token.Token}
+		err := m.Migrate(u)
+		assert.True(t, models.IsErrInvalidData(err))
+	})
+	t.Run("normal", func(t *testing.T) {
+		m := &vikunjainstance.Migration{URL: server.URL + "/", Token: token.Token}
+		err := m.Migrate(u)
+		require.NoError(t, err)
+
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"title":    "Test1",
+			"owner_id": u.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":         "task #1",
+			"description":   "Lorem Ipsum",
+			"created_by_id": u.ID,
+		}, false)
+		db.AssertExists(t, "labels", map[string]interface{}{
+			"title":         "Label #4 - visible via other task",
+			"created_by_id": u.ID,
+		}, false)
+		db.AssertExists(t, "task_comments", map[string]interface{}{
+			"comment":   "Lorem Ipsum Dolor Sit Amet",
+			"author_id": u.ID,
+		}, false)
+		db.AssertExists(t, "task_attachments", map[string]interface{}{
+			"created_by_id": u.ID,
+		}, false)
+		db.AssertExists(t, "task_relations", map[string]interface{}{
+			"relation_kind": models.RelationKindSubtask,
+			"created_by_id": u.ID,
+		}, false)
+		db.AssertExists(t, "saved_filters", map[string]interface{}{
+			"title":    "testfilter1",
+			"owner_id": u.ID,
+		}, false)
+
+		s := db.NewSession()
+		defer s.Close()
+
+		// The buckets of the project are kept
+		project := &models.Project{}
+		_, err = s.Where("title = ? AND owner_id = ?", "Test1", u.ID).Get(project)
+		require.NoError(t, err)
+		buckets, err := s.Where("project_id = ?", project.ID).Count(&models.Bucket{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), buckets)
+
+		// The done bucket is the copy of the done bucket on the other instance
+		doneBucket := &models.Bucket{}
+		_, err = s.Where("id = ?", project.DoneBucketID).Get(doneBucket)
+		require.NoError(t, err)
+		assert.Equal(t, project.ID, doneBucket.ProjectID)
+		assert.Equal(t, "testbucket3", doneBucket.Title)
+
+		filter := &models.SavedFilter{}
+		_, err = s.Where("title = ? AND owner_id = ?", "Filter for Test1", u.ID).Get(filter)
+		require.NoError(t, err)
+		assert.Equal(t, []string{strconv.FormatInt(project.ID, 10)}, filter.Filters.FilterValue)
+		db.AssertMissing(t, "saved_filters", map[string]interface{}{
+			"title":    "Filter for a project of another user",
+			"owner_id": u.ID,
+		})
+
+		// Child projects stay below their parent, archived projects stay archived
+		parent := &models.Project{}
+		_, err = s.Where("title = ? AND owner_id = ?", "Test22 archived individually", u.ID).Get(parent)
+		require.NoError(t, err)
+		assert.True(t, parent.IsArchived)
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"title":             "Test21 archived through parent list",
+			"owner_id":          u.ID,
+			"parent_project_id": parent.ID,
+		}, false)
+
+		// Reminders are copied with the task
+		task := &models.Task{}
+		_, err = s.Where("title = ? AND created_by_id = ?", "task #27 with reminders and start_date", u.ID).Get(task)
+		require.NoError(t, err)
+		reminders, err := s.Where("task_id = ?", task.ID).Count(&models.TaskReminder{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), reminders)
+	})
+}
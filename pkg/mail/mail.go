@@ -19,16 +19,26 @@ package mail
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"time"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/cron"
 	"code.vikunja.io/api/pkg/log"
 
 	"github.com/wneessen/go-mail"
 )
 
-// Queue is the mail queue
-var Queue chan *mail.Msg
+// queueWakeup is used to notify the mail daemon about newly queued mails so it
+// does not have to wait for the next poll.
+var queueWakeup = make(chan struct{}, 1)
+
+func wakeUpDaemon() {
+	select {
+	case queueWakeup <- struct{}{}:
+	default:
+	}
+}
 
 func getClient() (*mail.Client, error) {
 
@@ -82,10 +92,8 @@ func getClient() (*mail.Client, error) {
 	)
 }
 
-// StartMailDaemon starts the mail daemon
+// StartMailDaemon starts the mail daemon which sends all mails from the queue.
 func StartMailDaemon() {
-	Queue = make(chan *mail.Msg, config.MailerQueuelength.GetInt())
-
 	if !config.MailerEnabled.GetBool() {
 		return
 	}
@@ -100,41 +108,89 @@ func StartMailDaemon() {
 		log.Errorf("Could not create mail client: %v", err)
 		return
 	}
+
 	go func() {
-		var err error
 		open := false
-		for {
-			select {
-			case m, ok := <-Queue:
-				if !ok {
-					return
-				}
-				if !open {
-					err = c.DialWithContext(context.Background())
-					if err != nil {
-						log.Errorf("Error during connect to smtp server: %s", err)
-						break
-					}
-					open = true
+		lastSent := time.Now()
+		var lastStaleReset time.Time
+
+		send := func(m *mail.Msg) error {
+			if !open {
+				err := c.DialWithContext(context.Background())
+				if err != nil {
+					return fmt.Errorf("error during connect to smtp server: %w", err)
 				}
-				err = c.Send(m)
+				open = true
+			}
+
+			err := c.Send(m)
+			if err != nil {
+				// Start with a fresh connection for the next mail in case this one is broken
+				_ = c.Close()
+				open = false
+				return err
+			}
+
+			lastSent = time.Now()
+			return nil
+		}
+
+		for {
+			// Mails can be left behind as sending by a process which was stopped while sending them
+			if time.Since(lastStaleReset) > queueStaleSendingAfter {
+				err := resetStaleMails()
 				if err != nil {
-					log.Errorf("Error when sending mail: %s", err)
-					break
+					log.Errorf("Could not reset stale mails in the queue: %s", err)
 				}
-				// Close the connection to the SMTP server if no email was sent in
-				// the last 30 seconds.
-			case <-time.After(config.MailerQueueTimeout.GetDuration() * time.Second):
-				if open {
-					open = false
-					err = c.Close()
-					if err != nil {
-						log.Errorf("Error closing the mail server connection: %s\n", err)
-						break
-					}
+				lastStaleReset = time.Now()
+			}
+
+			processed, err := processQueue(send)
+			if err != nil {
+				log.Errorf("Error processing the mail queue: %s", err)
+			}
+
+			// There might be more mails waiting if the whole batch was used
+			if err == nil && processed >= config.MailerQueuelength.GetInt() {
+				continue
+			}
+
+			// Close the connection to the SMTP server if no email was sent in
+			// the last 30 seconds.
+			if open && time.Since(lastSent) > config.MailerQueueTimeout.GetDuration()*time.Second {
+				open = false
+				err = c.Close()
+				if err != nil {
+					log.Errorf("Error closing the mail server connection: %s\n", err)
+				} else {
 					log.Info("Closed connection to mail server")
 				}
 			}
+
+			select {
+			case <-queueWakeup:
+			case <-time.After(queuePollInterval):
+			}
 		}
 	}()
 }
+
+// RegisterQueueCleanupCron removes mails from the queue which were sent successfully
+// a while ago.
+func RegisterQueueCleanupCron() {
+	const logPrefix = "[Mail Queue Cleanup Cron] "
+
+	err := cron.Schedule("0 * * * *", func() {
+		deleted, err := PurgeQueuedMails(time.Now().Add(-queueKeepSentMails), QueueStatusSent)
+		if err != nil {
+			log.Errorf(logPrefix+"Error removing old sent mails: %s", err)
+			return
+		}
+		if deleted > 0 {
+			log.Debugf(logPrefix+"Deleted %d old sent mails", deleted)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Could not register mail queue cleanup cron: %s", err)
+	}
+}
@@ -0,0 +1,385 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package mail
+
+import (
+	"bytes"
+	"io"
+	netmail "net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+
+	"github.com/wneessen/go-mail"
+	"xorm.io/builder"
+)
+
+// QueueStatus is the delivery status of a mail in the outgoing mail queue.
+type QueueStatus string
+
+// All possible statuses of a queued mail
+const (
+	// QueueStatusQueued mails are waiting to be sent, either for the first time or for a retry.
+	QueueStatusQueued QueueStatus = "queued"
+	// QueueStatusSending mails are currently handed to the mail server by a daemon.
+	QueueStatusSending QueueStatus = "sending"
+	// QueueStatusSent mails were accepted by the mail server.
+	QueueStatusSent QueueStatus = "sent"
+	// QueueStatusFailed mails could not be sent after the maximum number of attempts.
+	QueueStatusFailed QueueStatus = "failed"
+)
+
+// IsValid checks if the status is one of the known queue statuses.
+func (s QueueStatus) IsValid() bool {
+	switch s {
+	case QueueStatusQueued, QueueStatusSending, QueueStatusSent, QueueStatusFailed:
+		return true
+	}
+	return false
+}
+
+const (
+	// A mail which is in "sending" longer than this is considered abandoned, for example
+	// because the process sending it was killed, and will be picked up again.
+	queueStaleSendingAfter = 10 * time.Minute
+	// The maximum time between two retries of a mail.
+	queueMaxRetryDelay = 24 * time.Hour
+	// How often the daemon looks for due mails if it is not woken up by a new mail.
+	queuePollInterval = 10 * time.Second
+	// Sent mails are kept this long so they can be inspected through the cli.
+	queueKeepSentMails = 7 * 24 * time.Hour
+)
+
+// QueuedMail is a mail in the persistent outgoing mail queue.
+type QueuedMail struct {
+	ID int64 `xorm:"bigint autoincr not null unique pk"`
+
+	From        string            `xorm:"text null"`
+	To          string            `xorm:"text not null"`
+	Domain      string            `xorm:"varchar(250) not null index"`
+	Subject     string            `xorm:"text null"`
+	Message     string            `xorm:"longtext null"`
+	HTMLMessage string            `xorm:"longtext null"`
+	ContentType ContentType       `xorm:"int not null default 0"`
+	Boundary    string            `xorm:"varchar(250) null"`
+	Headers     []*header         `xorm:"json null"`
+	Embeds      map[string][]byte `xorm:"json null"`
+
+	Status      QueueStatus `xorm:"varchar(20) not null index"`
+	Attempts    int         `xorm:"int not null default 0"`
+	LastError   string      `xorm:"text null"`
+	NextAttempt time.Time   `xorm:"DATETIME not null index"`
+	SentAt      time.Time   `xorm:"DATETIME null"`
+
+	Created time.Time `xorm:"created not null"`
+	Updated time.Time `xorm:"updated not null"`
+}
+
+// TableName holds the table name for the mail queue
+func (*QueuedMail) TableName() string {
+	return "mail_queue"
+}
+
+func (q *QueuedMail) toOpts() *Opts {
+	opts := &Opts{
+		From:        q.From,
+		To:          q.To,
+		Subject:     q.Subject,
+		Message:     q.Message,
+		HTMLMessage: q.HTMLMessage,
+		ContentType: q.ContentType,
+		Boundary:    q.Boundary,
+		Headers:     q.Headers,
+	}
+
+	if len(q.Embeds) > 0 {
+		opts.Embeds = make(map[string]io.Reader, len(q.Embeds))
+		for name, content := range q.Embeds {
+			opts.Embeds[name] = bytes.NewReader(content)
+		}
+	}
+
+	return opts
+}
+
+// recipientDomain returns the lowercased domain part of a recipient address.
+func recipientDomain(to string) string {
+	address := to
+	if parsed, err := netmail.ParseAddress(to); err == nil {
+		address = parsed.Address
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at == -1 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(address[at+1:]))
+}
+
+// enqueue stores a mail in the queue. Everything the mail embeds is read into
+// memory so the mail can be sent later by any process.
+func enqueue(opts *Opts) (queued *QueuedMail, err error) {
+	queued = &QueuedMail{
+		From:        opts.From,
+		To:          opts.To,
+		Domain:      recipientDomain(opts.To),
+		Subject:     opts.Subject,
+		Message:     opts.Message,
+		HTMLMessage: opts.HTMLMessage,
+		ContentType: opts.ContentType,
+		Boundary:    opts.Boundary,
+		Headers:     opts.Headers,
+		Embeds:      make(map[string][]byte, len(opts.Embeds)+len(opts.EmbedFS)),
+		Status:      QueueStatusQueued,
+		NextAttempt: time.Now(),
+	}
+
+	for name, content := range opts.Embeds {
+		queued.Embeds[name], err = io.ReadAll(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for name, fs := range opts.EmbedFS {
+		queued.Embeds[name], err = fs.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	_, err = s.Insert(queued)
+	return
+}
+
+// domainRateLimiter limits how many mails are sent to a single recipient domain per minute.
+type domainRateLimiter struct {
+	sync.Mutex
+	windows map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+var rateLimiter = &domainRateLimiter{windows: make(map[string]*rateWindow)}
+
+// allow checks if another mail may be sent to the domain right now. If not, it
+// returns the time when the next mail may be sent.
+func (l *domainRateLimiter) allow(domain string, now time.Time) (bool, time.Time) {
+	limit := config.MailerQueueRateLimit.GetInt()
+	if limit <= 0 || domain == "" {
+		return true, now
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	w, has := l.windows[domain]
+	if !has || now.Sub(w.start) >= time.Minute {
+		w = &rateWindow{start: now}
+		l.windows[domain] = w
+	}
+
+	if w.count >= limit {
+		return false, w.start.Add(time.Minute)
+	}
+
+	w.count++
+	return true, now
+}
+
+// retryDelay returns how long to wait before the next attempt after a mail failed
+// the given number of times. The delay doubles with every attempt.
+func retryDelay(attempts int) time.Duration {
+	delay := config.MailerQueueRetryDelay.GetDuration() * time.Second
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= queueMaxRetryDelay {
+			return queueMaxRetryDelay
+		}
+	}
+	return delay
+}
+
+type sendFunc func(m *mail.Msg) error
+
+// processQueue sends all mails which are due. It returns how many mails it took
+// from the queue, regardless of whether sending them succeeded.
+func processQueue(send sendFunc) (processed int, err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	now := time.Now()
+	due := []*QueuedMail{}
+	err = s.
+		Where("status = ? AND next_attempt <= ?", QueueStatusQueued, now).
+		OrderBy("next_attempt asc, id asc").
+		Limit(config.MailerQueuelength.GetInt()).
+		Find(&due)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range due {
+		allowed, next := rateLimiter.allow(m.Domain, time.Now())
+		if !allowed {
+			_, err = s.
+				Where("id = ? AND status = ?", m.ID, QueueStatusQueued).
+				Cols("next_attempt").
+				Update(&QueuedMail{NextAttempt: next})
+			if err != nil {
+				return processed, err
+			}
+			continue
+		}
+
+		// Claim the mail so that no other daemon sends it as well
+		claimed, err := s.
+			Where("id = ? AND status = ?", m.ID, QueueStatusQueued).
+			Cols("status").
+			Update(&QueuedMail{Status: QueueStatusSending})
+		if err != nil {
+			return processed, err
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		processed++
+		m.Attempts++
+
+		sendErr := send(getMessage(m.toOpts()))
+		if sendErr == nil {
+			m.Status = QueueStatusSent
+			m.SentAt = time.Now()
+			m.LastError = ""
+		} else {
+			m.LastError = sendErr.Error()
+			if m.Attempts >= config.MailerQueueMaxAttempts.GetInt() {
+				m.Status = QueueStatusFailed
+				log.Errorf("Giving up sending mail %d to %s after %d attempts: %s", m.ID, m.To, m.Attempts, sendErr)
+			} else {
+				m.Status = QueueStatusQueued
+				m.NextAttempt = time.Now().Add(retryDelay(m.Attempts))
+				log.Warningf("Error when sending mail %d to %s, will retry at %s: %s", m.ID, m.To, m.NextAttempt.Format(time.RFC3339), sendErr)
+			}
+		}
+
+		_, err = s.
+			Where("id = ?", m.ID).
+			Cols("status", "attempts", "last_error", "next_attempt", "sent_at").
+			Update(m)
+		if err != nil {
+			return processed, err
+		}
+	}
+
+	return processed, nil
+}
+
+// resetStaleMails puts mails back into the queue which were claimed for sending but
+// never finished, for example because the process sending them was stopped.
+func resetStaleMails() error {
+	s := db.NewSession()
+	defer s.Close()
+
+	_, err := s.
+		Where("status = ? AND updated < ?", QueueStatusSending, time.Now().Add(-queueStaleSendingAfter)).
+		Cols("status").
+		Update(&QueuedMail{Status: QueueStatusQueued})
+	return err
+}
+
+// ListQueuedMails returns all mails in the queue with one of the given statuses,
+// or all of them if no status is given.
+func ListQueuedMails(statuses ...QueueStatus) (mails []*QueuedMail, err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	cond := builder.NewCond()
+	if len(statuses) > 0 {
+		cond = builder.In("status", statusesToInterfaces(statuses)...)
+	}
+
+	mails = []*QueuedMail{}
+	err = s.
+		Where(cond).
+		OrderBy("id asc").
+		Find(&mails)
+	return
+}
+
+// RetryQueuedMails puts the mails with the given ids back into the queue so that
+// they are sent as soon as possible, resetting their attempts. If no ids are given,
+// all failed mails are retried. Mails which were already sent are not touched.
+func RetryQueuedMails(ids ...int64) (count int64, err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	var cond builder.Cond = builder.Eq{"status": QueueStatusFailed}
+	if len(ids) > 0 {
+		cond = builder.And(
+			builder.In("id", ids),
+			builder.In("status", QueueStatusFailed, QueueStatusQueued),
+		)
+	}
+
+	count, err = s.
+		Where(cond).
+		Cols("status", "attempts", "last_error", "next_attempt").
+		Update(&QueuedMail{
+			Status:      QueueStatusQueued,
+			NextAttempt: time.Now(),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	wakeUpDaemon()
+	return count, nil
+}
+
+// PurgeQueuedMails removes all mails with one of the given statuses from the queue.
+// If olderThan is not zero, only mails which were last updated before that are removed.
+func PurgeQueuedMails(olderThan time.Time, statuses ...QueueStatus) (count int64, err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	cond := builder.NewCond().And(builder.In("status", statusesToInterfaces(statuses)...))
+	if !olderThan.IsZero() {
+		cond = cond.And(builder.Lt{"updated": olderThan})
+	}
+
+	return s.Where(cond).Delete(&QueuedMail{})
+}
+
+func statusesToInterfaces(statuses []QueueStatus) []interface{} {
+	values := make([]interface{}, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, status)
+	}
+	return values
+}
@@ -0,0 +1,192 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package mail
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wneessen/go-mail"
+)
+
+func resetQueue(t *testing.T) {
+	s := db.NewSession()
+	defer s.Close()
+	_, err := s.Where("1 = 1").Delete(&QueuedMail{})
+	require.NoError(t, err)
+
+	rateLimiter = &domainRateLimiter{windows: make(map[string]*rateWindow)}
+}
+
+func getQueuedMail(t *testing.T, id int64) *QueuedMail {
+	s := db.NewSession()
+	defer s.Close()
+	m := &QueuedMail{}
+	has, err := s.ID(id).Get(m)
+	require.NoError(t, err)
+	require.True(t, has)
+	return m
+}
+
+func queueTestMail(t *testing.T, to string) *QueuedMail {
+	queued, err := enqueue(&Opts{
+		To:          to,
+		Subject:     "Lorem Ipsum",
+		Message:     "Dolor sit amet",
+		HTMLMessage: "<p>Dolor sit amet</p>",
+		ContentType: ContentTypeMultipart,
+		Embeds: map[string]io.Reader{
+			"logo.png": strings.NewReader("logo"),
+		},
+	})
+	require.NoError(t, err)
+	return queued
+}
+
+func TestQueue(t *testing.T) {
+	t.Run("send", func(t *testing.T) {
+		resetQueue(t)
+		queued := queueTestMail(t, "User 1 <user1@example.com>")
+		assert.Equal(t, "example.com", queued.Domain)
+		assert.Equal(t, []byte("logo"), queued.Embeds["logo.png"])
+
+		var sent []*mail.Msg
+		processed, err := processQueue(func(m *mail.Msg) error {
+			sent = append(sent, m)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		require.Len(t, sent, 1)
+		assert.Equal(t, []string{"Lorem Ipsum"}, sent[0].GetGenHeader(mail.HeaderSubject))
+
+		m := getQueuedMail(t, queued.ID)
+		assert.Equal(t, QueueStatusSent, m.Status)
+		assert.Equal(t, 1, m.Attempts)
+		assert.False(t, m.SentAt.IsZero())
+
+		// Sent mails are not sent again
+		processed, err = processQueue(func(m *mail.Msg) error {
+			t.Fatal("mail sent twice")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+	})
+	t.Run("retry and dead letter", func(t *testing.T) {
+		resetQueue(t)
+		config.MailerQueueMaxAttempts.Set(2)
+		defer config.MailerQueueMaxAttempts.Set(5)
+
+		queued := queueTestMail(t, "user1@example.com")
+		failing := func(m *mail.Msg) error {
+			return errors.New("connection refused")
+		}
+
+		_, err := processQueue(failing)
+		require.NoError(t, err)
+
+		m := getQueuedMail(t, queued.ID)
+		assert.Equal(t, QueueStatusQueued, m.Status)
+		assert.Equal(t, 1, m.Attempts)
+		assert.Equal(t, "connection refused", m.LastError)
+		assert.True(t, m.NextAttempt.After(time.Now()))
+
+		// Not due yet
+		processed, err := processQueue(failing)
+		require.NoError(t, err)
+		assert.Equal(t, 0, processed)
+
+		s := db.NewSession()
+		_, err = s.ID(queued.ID).Cols("next_attempt").Update(&QueuedMail{NextAttempt: time.Now().Add(-time.Second)})
+		s.Close()
+		require.NoError(t, err)
+
+		processed, err = processQueue(failing)
+		require.NoError(t, err)
+		assert.Equal(t, 1, processed)
+
+		m = getQueuedMail(t, queued.ID)
+		assert.Equal(t, QueueStatusFailed, m.Status)
+		assert.Equal(t, 2, m.Attempts)
+
+		count, err := RetryQueuedMails()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		m = getQueuedMail(t, queued.ID)
+		assert.Equal(t, QueueStatusQueued, m.Status)
+		assert.Equal(t, 0, m.Attempts)
+		assert.Empty(t, m.LastError)
+	})
+	t.Run("rate limit per domain", func(t *testing.T) {
+		resetQueue(t)
+		config.MailerQueueRateLimit.Set(1)
+		defer config.MailerQueueRateLimit.Set(0)
+
+		first := queueTestMail(t, "user1@example.com")
+		second := queueTestMail(t, "user2@EXAMPLE.com")
+		other := queueTestMail(t, "user3@example.org")
+
+		processed, err := processQueue(func(m *mail.Msg) error {
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, processed)
+
+		assert.Equal(t, QueueStatusSent, getQueuedMail(t, first.ID).Status)
+		assert.Equal(t, QueueStatusSent, getQueuedMail(t, other.ID).Status)
+
+		m := getQueuedMail(t, second.ID)
+		assert.Equal(t, QueueStatusQueued, m.Status)
+		assert.Equal(t, 0, m.Attempts)
+		assert.True(t, m.NextAttempt.After(time.Now()))
+	})
+	t.Run("purge", func(t *testing.T) {
+		resetQueue(t)
+		queueTestMail(t, "user1@example.com")
+		_, err := processQueue(func(m *mail.Msg) error {
+			return nil
+		})
+		require.NoError(t, err)
+		queued := queueTestMail(t, "user2@example.com")
+
+		count, err := PurgeQueuedMails(time.Time{}, QueueStatusSent, QueueStatusFailed)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		mails, err := ListQueuedMails()
+		require.NoError(t, err)
+		require.Len(t, mails, 1)
+		assert.Equal(t, queued.ID, mails[0].ID)
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 2*time.Minute, retryDelay(2))
+	assert.Equal(t, 4*time.Minute, retryDelay(3))
+	assert.Equal(t, queueMaxRetryDelay, retryDelay(100))
+}
@@ -119,6 +119,17 @@ func SendMail(opts *Opts) {
 		return
 	}
 
-	m := getMessage(opts)
-	Queue <- m
+	if !config.MailerEnabled.GetBool() {
+		log.Debugf("Mailer is disabled, not sending mail to %s", opts.To)
+		return
+	}
+
+	queued, err := enqueue(opts)
+	if err != nil {
+		log.Errorf("Could not put mail to %s in the queue: %s", opts.To, err)
+		return
+	}
+
+	log.Debugf("Queued mail %d to %s", queued.ID, opts.To)
+	wakeUpDaemon()
 }
@@ -0,0 +1,68 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"time"
+
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+type users20261018194500 struct {
+	NotificationDigestInterval string    `xorm:"varchar(10) null"`
+	NotificationDigestLastSent time.Time `xorm:"datetime null"`
+}
+
+func (users20261018194500) TableName() string {
+	return "users"
+}
+
+type notificationDigestQueue20261018194500 struct {
+	ID                     int64     `xorm:"bigint autoincr not null unique pk"`
+	NotifiableID           int64     `xorm:"bigint not null index"`
+	Name                   string    `xorm:"varchar(250) not null"`
+	DatabaseNotificationID int64     `xorm:"bigint null"`
+	ProjectID              int64     `xorm:"bigint not null default 0"`
+	ProjectTitle           string    `xorm:"text null"`
+	TaskID                 int64     `xorm:"bigint not null default 0"`
+	TaskTitle              string    `xorm:"text null"`
+	TaskURL                string    `xorm:"text null"`
+	Subject                string    `xorm:"text not null"`
+	Lines                  []string  `xorm:"json null"`
+	Created                time.Time `xorm:"created not null"`
+}
+
+func (notificationDigestQueue20261018194500) TableName() string {
+	return "notification_digest_queue"
+}
+
+func init() {
+	migrations = append(migrations, &xormigrate.Migration{
+		ID:          "20261018194500",
+		Description: "Add notification digests",
+		Migrate: func(tx *xorm.Engine) error {
+			return tx.Sync2(
+				users20261018194500{},
+				notificationDigestQueue20261018194500{},
+			)
+		},
+		Rollback: func(tx *xorm.Engine) error {
+			return nil
+		},
+	})
+}
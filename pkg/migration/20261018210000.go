@@ -0,0 +1,62 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"time"
+
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+type mailQueue20261018210000 struct {
+	ID          int64             `xorm:"bigint autoincr not null unique pk"`
+	From        string            `xorm:"text null"`
+	To          string            `xorm:"text not null"`
+	Domain      string            `xorm:"varchar(250) not null index"`
+	Subject     string            `xorm:"text null"`
+	Message     string            `xorm:"longtext null"`
+	HTMLMessage string            `xorm:"longtext null"`
+	ContentType int               `xorm:"int not null default 0"`
+	Boundary    string            `xorm:"varchar(250) null"`
+	Headers     []interface{}     `xorm:"json null"`
+	Embeds      map[string][]byte `xorm:"json null"`
+	Status      string            `xorm:"varchar(20) not null index"`
+	Attempts    int               `xorm:"int not null default 0"`
+	LastError   string            `xorm:"text null"`
+	NextAttempt time.Time         `xorm:"DATETIME not null index"`
+	SentAt      time.Time         `xorm:"DATETIME null"`
+	Created     time.Time         `xorm:"created not null"`
+	Updated     time.Time         `xorm:"updated not null"`
+}
+
+func (mailQueue20261018210000) TableName() string {
+	return "mail_queue"
+}
+
+func init() {
+	migrations = append(migrations, &xormigrate.Migration{
+		ID:          "20261018210000",
+		Description: "Add persistent mail queue table",
+		Migrate: func(tx *xorm.Engine) error {
+			return tx.Sync2(mailQueue20261018210000{})
+		},
+		Rollback: func(tx *xorm.Engine) error {
+			return nil
+		},
+	})
+}
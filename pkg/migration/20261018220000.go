@@ -0,0 +1,72 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+type tasks20261018220000 struct {
+	Version int64 `xorm:"bigint not null default 0"`
+}
+
+func (tasks20261018220000) TableName() string {
+	return "tasks"
+}
+
+type projects20261018220000 struct {
+	Version int64 `xorm:"bigint not null default 0"`
+}
+
+func (projects20261018220000) TableName() string {
+	return "projects"
+}
+
+type buckets20261018220000 struct {
+	Version int64 `xorm:"bigint not null default 0"`
+}
+
+func (buckets20261018220000) TableName() string {
+	return "buckets"
+}
+
+type taskComments20261018220000 struct {
+	Version int64 `xorm:"bigint not null default 0"`
+}
+
+func (taskComments20261018220000) TableName() string {
+	return "task_comments"
+}
+
+func init() {
+	migrations = append(migrations, &xormigrate.Migration{
+		ID:          "20261018220000",
+		Description: "Add version columns for optimistic concurrency control",
+		Migrate: func(tx *xorm.Engine) error {
+			return tx.Sync2(
+				tasks20261018220000{},
+				projects20261018220000{},
+				buckets20261018220000{},
+				taskComments20261018220000{},
+			)
+		},
+		Rollback: func(tx *xorm.Engine) error {
+			return nil
+		},
+	})
+}
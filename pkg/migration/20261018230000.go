@@ -0,0 +1,58 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"time"
+
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+type migrationJobs20261018230000 struct {
+	ID                int64     `xorm:"bigint autoincr not null unique pk"`
+	UserID            int64     `xorm:"bigint not null index"`
+	MigratorName      string    `xorm:"varchar(255) not null"`
+	Status            string    `xorm:"varchar(20) not null"`
+	FileID            int64     `xorm:"bigint null"`
+	Payload           string    `xorm:"longtext null"`
+	ProjectsProcessed int64     `xorm:"bigint not null default 0"`
+	TasksProcessed    int64     `xorm:"bigint not null default 0"`
+	FilesProcessed    int64     `xorm:"bigint not null default 0"`
+	Warnings          []string  `xorm:"json null"`
+	Error             string    `xorm:"text null"`
+	Created           time.Time `xorm:"created not null"`
+	Updated           time.Time `xorm:"updated not null"`
+	Finished          time.Time `xorm:"null"`
+}
+
+func (migrationJobs20261018230000) TableName() string {
+	return "migration_jobs"
+}
+
+func init() {
+	migrations = append(migrations, &xormigrate.Migration{
+		ID:          "20261018230000",
+		Description: "Add migration jobs to run migrations in the background",
+		Migrate: func(tx *xorm.Engine) error {
+			return tx.Sync2(migrationJobs20261018230000{})
+		},
+		Rollback: func(tx *xorm.Engine) error {
+			return nil
+		},
+	})
+}
@@ -0,0 +1,54 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"time"
+
+	"src.techknowlogick.com/xormigrate"
+	"xorm.io/xorm"
+)
+
+type calendarFeeds20261018233000 struct {
+	ID           int64     `xorm:"bigint autoincr not null unique pk"`
+	Token        string    `xorm:"varchar(40) not null unique"`
+	ProjectID    int64     `xorm:"bigint not null default 0 index"`
+	FilterID     int64     `xorm:"bigint not null default 0 index"`
+	IncludeTodos bool      `xorm:"bool not null default false"`
+	EventDate    string    `xorm:"varchar(20) not null default ''"`
+	IncludeDone  bool      `xorm:"bool not null default false"`
+	OwnerID      int64     `xorm:"bigint not null index"`
+	Created      time.Time `xorm:"created not null"`
+	Updated      time.Time `xorm:"updated not null"`
+}
+
+func (calendarFeeds20261018233000) TableName() string {
+	return "calendar_feeds"
+}
+
+func init() {
+	migrations = append(migrations, &xormigrate.Migration{
+		ID:          "20261018233000",
+		Description: "Add calendar feeds to subscribe to projects and saved filters as iCalendar file",
+		Migrate: func(tx *xorm.Engine) error {
+			return tx.Sync2(calendarFeeds20261018233000{})
+		},
+		Rollback: func(tx *xorm.Engine) error {
+			return nil
+		},
+	})
+}
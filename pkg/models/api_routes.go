@@ -25,8 +25,17 @@ import (
 
 var apiTokenRoutes = map[string]*APITokenRoute{}
 
+// The group and permission needed for every route usable with api tokens, with the method and path of the route as key.
+var apiTokenRoutePermissions = map[string]*apiTokenRoutePermission{}
+
 func init() {
 	apiTokenRoutes = make(map[string]*APITokenRoute)
+	apiTokenRoutePermissions = make(map[string]*apiTokenRoutePermission)
+}
+
+type apiTokenRoutePermission struct {
+	group      string
+	permission string
 }
 
 type APITokenRoute struct {
@@ -64,14 +73,28 @@ func getRouteGroupName(path string) string {
 	}
 }
 
+// Routes which could not be used with api tokens before. They have their own group so that existing tokens don't get
+// access to them, users need to allow them explicitly. The key is the method and path of the route.
+var apiTokenOptInRoutes = map[string]*apiTokenRoutePermission{
+	// /tasks/all already uses the read_all permission of the tasks group
+	"GET /api/v1/projects/:project/tasks":             {group: "projects_tasks", permission: "read_all"},
+	"GET /api/v1/tasks/:task/attachments/:attachment": {group: "tasks_attachments_download", permission: "read_one"},
+	"GET /api/v1/projects/:project/background":        {group: "projects_background_download", permission: "read_one"},
+}
+
 // CollectRoutesForAPITokenUsage gets called for every added APITokenRoute and builds a list of all routes we can use for the api tokens.
 func CollectRoutesForAPITokenUsage(route echo.Route) {
 
-	if !strings.Contains(route.Name, "(*WebHandler)") {
+	key := route.Method + " " + route.Path
+	optIn, isOptIn := apiTokenOptInRoutes[key]
+	if !strings.Contains(route.Name, "(*WebHandler)") && !isOptIn {
 		return
 	}
 
 	routeGroupName := getRouteGroupName(route.Path)
+	if isOptIn {
+		routeGroupName = optIn.group
+	}
 
 	if routeGroupName == "subscriptions" ||
 		routeGroupName == "notifications" ||
@@ -85,35 +108,52 @@ func CollectRoutesForAPITokenUsage(route echo.Route) {
 		apiTokenRoutes[routeGroupName] = &APITokenRoute{}
 	}
 
+	detail := &RouteDetail{
+		Path:   route.Path,
+		Method: route.Method,
+	}
+	var permission string
 	if strings.Contains(route.Name, "CreateWeb") {
-		apiTokenRoutes[routeGroupName].Create = &RouteDetail{
-			Path:   route.Path,
-			Method: route.Method,
-		}
+		permission = "create"
 	}
 	if strings.Contains(route.Name, "ReadOneWeb") {
-		apiTokenRoutes[routeGroupName].ReadOne = &RouteDetail{
-			Path:   route.Path,
-			Method: route.Method,
-		}
+		permission = "read_one"
 	}
 	if strings.Contains(route.Name, "ReadAllWeb") {
-		apiTokenRoutes[routeGroupName].ReadAll = &RouteDetail{
-			Path:   route.Path,
-			Method: route.Method,
-		}
+		permission = "read_all"
 	}
 	if strings.Contains(route.Name, "UpdateWeb") {
-		apiTokenRoutes[routeGroupName].Update = &RouteDetail{
-			Path:   route.Path,
-			Method: route.Method,
-		}
+		permission = "update"
 	}
 	if strings.Contains(route.Name, "DeleteWeb") {
-		apiTokenRoutes[routeGroupName].Delete = &RouteDetail{
-			Path:   route.Path,
-			Method: route.Method,
-		}
+		permission = "delete"
+	}
+	if isOptIn {
+		permission = optIn.permission
+	}
+
+	// Every permission of a group allows exactly one route
+	var previous *RouteDetail
+	switch permission {
+	case "create":
+		previous, apiTokenRoutes[routeGroupName].Create = apiTokenRoutes[routeGroupName].Create, detail
+	case "read_one":
+		previous, apiTokenRoutes[routeGroupName].ReadOne = apiTokenRoutes[routeGroupName].ReadOne, detail
+	case "read_all":
+		previous, apiTokenRoutes[routeGroupName].ReadAll = apiTokenRoutes[routeGroupName].ReadAll, detail
+	case "update":
+		previous, apiTokenRoutes[routeGroupName].Update = apiTokenRoutes[routeGroupName].Update, detail
+	case "delete":
+		previous, apiTokenRoutes[routeGroupName].Delete = apiTokenRoutes[routeGroupName].Delete, detail
+	default:
+		return
+	}
+	if previous != nil {
+		delete(apiTokenRoutePermissions, previous.Method+" "+previous.Path)
+	}
+	apiTokenRoutePermissions[key] = &apiTokenRoutePermission{
+		group:      routeGroupName,
+		permission: permission,
 	}
 }
 
@@ -138,37 +178,18 @@ func CanDoAPIRoute(c echo.Context, token *APIToken) (can bool) {
 		path = c.Request().URL.Path
 	}
 
-	routeGroupName := getRouteGroupName(path)
-
-	group, hasGroup := token.Permissions[routeGroupName]
-	if !hasGroup {
-		return false
-	}
-
-	var route string
-	routes, has := apiTokenRoutes[routeGroupName]
+	route, has := apiTokenRoutePermissions[c.Request().Method+" "+path]
 	if !has {
 		return false
 	}
 
-	if routes.Create != nil && routes.Create.Path == path && routes.Create.Method == c.Request().Method {
-		route = "create"
-	}
-	if routes.ReadOne != nil && routes.ReadOne.Path == path && routes.ReadOne.Method == c.Request().Method {
-		route = "read_one"
-	}
-	if routes.ReadAll != nil && routes.ReadAll.Path == path && routes.ReadAll.Method == c.Request().Method {
-		route = "read_all"
-	}
-	if routes.Update != nil && routes.Update.Path == path && routes.Update.Method == c.Request().Method {
-		route = "update"
-	}
-	if routes.Delete != nil && routes.Delete.Path == path && routes.Delete.Method == c.Request().Method {
-		route = "delete"
+	group, hasGroup := token.Permissions[route.group]
+	if !hasGroup {
+		return false
 	}
 
 	for _, p := range group {
-		if p == route {
+		if p == route.permission {
 			return true
 		}
 	}
@@ -0,0 +1,108 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanDoAPIRoute(t *testing.T) {
+	oldRoutes, oldPermissions := apiTokenRoutes, apiTokenRoutePermissions
+	apiTokenRoutes = make(map[string]*APITokenRoute)
+	apiTokenRoutePermissions = make(map[string]*apiTokenRoutePermission)
+	defer func() {
+		apiTokenRoutes, apiTokenRoutePermissions = oldRoutes, oldPermissions
+	}()
+
+	for _, route := range []echo.Route{
+		{Method: http.MethodGet, Path: "/api/v1/tasks/all", Name: "code.vikunja.io/web/handler.(*WebHandler).ReadAllWeb-fm"},
+		{Method: http.MethodGet, Path: "/api/v1/projects/:project/tasks", Name: "code.vikunja.io/web/handler.(*WebHandler).ReadAllWeb-fm"},
+		{Method: http.MethodPut, Path: "/api/v1/projects/:project/tasks", Name: "code.vikunja.io/web/handler.(*WebHandler).CreateWeb-fm"},
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:projecttask", Name: "code.vikunja.io/web/handler.(*WebHandler).ReadOneWeb-fm"},
+		{Method: http.MethodPost, Path: "/api/v1/tasks/:projecttask", Name: "code.vikunja.io/web/handler.(*WebHandler).UpdateWeb-fm"},
+		{Method: http.MethodDelete, Path: "/api/v1/tasks/:projecttask", Name: "code.vikunja.io/web/handler.(*WebHandler).DeleteWeb-fm"},
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:task/attachments", Name: "code.vikunja.io/web/handler.(*WebHandler).ReadAllWeb-fm"},
+		{Method: http.MethodDelete, Path: "/api/v1/tasks/:task/attachments/:attachment", Name: "code.vikunja.io/web/handler.(*WebHandler).DeleteWeb-fm"},
+		{Method: http.MethodGet, Path: "/api/v1/tasks/:task/attachments/:attachment", Name: "code.vikunja.io/api/pkg/routes/api/v1.GetTaskAttachment"},
+		{Method: http.MethodPut, Path: "/api/v1/tasks/:task/attachments", Name: "code.vikunja.io/api/pkg/routes/api/v1.UploadTaskAttachment"},
+		{Method: http.MethodGet, Path: "/api/v1/projects/:project/background", Name: "code.vikunja.io/api/pkg/modules/background/handler.GetProjectBackground"},
+		{Method: http.MethodDelete, Path: "/api/v1/projects/:project/background", Name: "code.vikunja.io/api/pkg/modules/background/handler.RemoveProjectBackground"},
+	} {
+		CollectRoutesForAPITokenUsage(route)
+	}
+
+	canDo := func(method, path string, permissions APIPermissions) bool {
+		c := echo.New().NewContext(httptest.NewRequest(method, path, nil), httptest.NewRecorder())
+		c.SetPath(path)
+		return CanDoAPIRoute(c, &APIToken{Permissions: permissions})
+	}
+
+	t.Run("permission mapping", func(t *testing.T) {
+		assert.Equal(t, &apiTokenRoutePermission{group: "tasks", permission: "read_all"}, apiTokenRoutePermissions["GET /api/v1/tasks/all"])
+		assert.Equal(t, &apiTokenRoutePermission{group: "tasks", permission: "create"}, apiTokenRoutePermissions["PUT /api/v1/projects/:project/tasks"])
+		assert.Equal(t, &apiTokenRoutePermission{group: "tasks", permission: "read_one"}, apiTokenRoutePermissions["GET /api/v1/tasks/:projecttask"])
+		assert.Equal(t, &apiTokenRoutePermission{group: "tasks", permission: "update"}, apiTokenRoutePermissions["POST /api/v1/tasks/:projecttask"])
+		assert.Equal(t, &apiTokenRoutePermission{group: "tasks", permission: "delete"}, apiTokenRoutePermissions["DELETE /api/v1/tasks/:projecttask"])
+		assert.Equal(t, "/api/v1/tasks/all", apiTokenRoutes["tasks"].ReadAll.Path)
+	})
+	t.Run("same path with another method", func(t *testing.T) {
+		permissions := APIPermissions{"tasks": []string{"read_one"}}
+		assert.True(t, canDo(http.MethodGet, "/api/v1/tasks/:projecttask", permissions))
+		assert.False(t, canDo(http.MethodPost, "/api/v1/tasks/:projecttask", permissions))
+		assert.False(t, canDo(http.MethodDelete, "/api/v1/tasks/:projecttask", permissions))
+	})
+	t.Run("opt-in routes", func(t *testing.T) {
+		assert.Equal(t, "/api/v1/projects/:project/tasks", apiTokenRoutes["projects_tasks"].ReadAll.Path)
+		assert.Equal(t, "/api/v1/tasks/:task/attachments/:attachment", apiTokenRoutes["tasks_attachments_download"].ReadOne.Path)
+		assert.Equal(t, "/api/v1/projects/:project/background", apiTokenRoutes["projects_background_download"].ReadOne.Path)
+
+		assert.True(t, canDo(http.MethodGet, "/api/v1/projects/:project/tasks", APIPermissions{"projects_tasks": []string{"read_all"}}))
+		assert.True(t, canDo(http.MethodGet, "/api/v1/tasks/:task/attachments/:attachment", APIPermissions{"tasks_attachments_download": []string{"read_one"}}))
+		assert.True(t, canDo(http.MethodGet, "/api/v1/projects/:project/background", APIPermissions{"projects_background_download": []string{"read_one"}}))
+		assert.False(t, canDo(http.MethodGet, "/api/v1/tasks/all", APIPermissions{"projects_tasks": []string{"read_all"}}))
+	})
+	t.Run("existing permissions don't allow opt-in routes", func(t *testing.T) {
+		// A token with every permission of the groups which existed before
+		all := []string{"create", "read_one", "read_all", "update", "delete"}
+		permissions := APIPermissions{
+			"tasks":               all,
+			"tasks_attachments":   all,
+			"projects_background": all,
+		}
+		assert.False(t, canDo(http.MethodGet, "/api/v1/projects/:project/tasks", permissions))
+		assert.False(t, canDo(http.MethodGet, "/api/v1/tasks/:task/attachments/:attachment", permissions))
+		assert.False(t, canDo(http.MethodGet, "/api/v1/projects/:project/background", permissions))
+		assert.True(t, canDo(http.MethodGet, "/api/v1/tasks/all", permissions))
+		assert.True(t, canDo(http.MethodGet, "/api/v1/tasks/:task/attachments", permissions))
+		assert.Nil(t, apiTokenRoutes["tasks_attachments"].ReadOne)
+		_, has := apiTokenRoutes["projects_background"]
+		assert.False(t, has)
+	})
+	t.Run("route which is not available for tokens", func(t *testing.T) {
+		_, has := apiTokenRoutePermissions["PUT /api/v1/tasks/:task/attachments"]
+		assert.False(t, has)
+		assert.False(t, canDo(http.MethodPut, "/api/v1/tasks/:task/attachments", APIPermissions{"tasks_attachments": []string{"create"}}))
+	})
+	t.Run("permission of another group", func(t *testing.T) {
+		assert.False(t, canDo(http.MethodGet, "/api/v1/tasks/all", APIPermissions{"projects": []string{"read_all"}}))
+	})
+}
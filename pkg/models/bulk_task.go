@@ -31,6 +31,11 @@ type BulkTask struct {
 	Task
 }
 
+// Etag is empty because a bulk update changes multiple tasks with different versions
+func (bt *BulkTask) Etag() string {
+	return ""
+}
+
 func (bt *BulkTask) checkIfTasksAreOnTheSameProject(s *xorm.Session) (err error) {
 	// Get the tasks
 	err = bt.GetTasksByIDs(s)
@@ -102,6 +107,11 @@ func (bt *BulkTask) Update(s *xorm.Session, a web.Auth) (err error) {
 			oldtask.Done = false
 		}
 
+		oldtask.Version, err = bumpVersion(s, "tasks", oldtask.ID, "")
+		if err != nil {
+			return err
+		}
+
 		_, err = s.ID(oldtask.ID).
 			Cols("title",
 				"description",
@@ -0,0 +1,222 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/web"
+
+	"xorm.io/xorm"
+)
+
+// BulkTaskResult is the result of a bulk operation for a single task
+type BulkTaskResult struct {
+	// The position of the task in the request.
+	Index int `json:"index"`
+	// The id of the task. Empty when creating tasks and the operation failed.
+	TaskID int64 `json:"task_id"`
+	// The created or moved task. Only returned if all tasks of the operation succeeded.
+	Task *Task `json:"task,omitempty"`
+	// The reason the operation failed for this task.
+	Error *web.HTTPError `json:"error,omitempty"`
+}
+
+// BulkTaskCreate holds multiple tasks to create at once
+type BulkTaskCreate struct {
+	// The tasks to create. Each task needs a project id, labels, assignees and reminders are created with the task.
+	Tasks []*Task `json:"tasks"`
+}
+
+// BulkTaskDelete holds the ids of multiple tasks to delete at once
+type BulkTaskDelete struct {
+	// The ids of the tasks to delete.
+	TaskIDs []int64 `json:"task_ids"`
+}
+
+// BulkTaskMove holds multiple tasks to move into another project or bucket at once
+type BulkTaskMove struct {
+	// The ids of the tasks to move.
+	TaskIDs []int64 `json:"task_ids"`
+	// The project to move the tasks to. If empty, the tasks stay in their project.
+	ProjectID int64 `json:"project_id"`
+	// The bucket to move the tasks to. If empty and the tasks are moved into another project, they are put in the
+	// default bucket of that project.
+	BucketID int64 `json:"bucket_id"`
+}
+
+func checkBulkTaskCount(count int) error {
+	if count == 0 {
+		return ErrBulkTasksNeedAtLeastOne{}
+	}
+	maxTasks := config.ServiceMaxItemsPerPage.GetInt()
+	if count > maxTasks {
+		return ErrBulkTasksTooMany{Count: count, Max: maxTasks}
+	}
+	return nil
+}
+
+// setError sets the error of a bulk task result. Errors which are not meant for the user abort the whole operation.
+func (r *BulkTaskResult) setError(err error) error {
+	httpErr, is := err.(web.HTTPErrorProcessor)
+	if !is {
+		return err
+	}
+	details := httpErr.HTTPError()
+	r.Error = &details
+	return nil
+}
+
+func (r *BulkTaskResult) checkRight(can bool, err error) error {
+	if err != nil {
+		return r.setError(err)
+	}
+	if !can {
+		return r.setError(ErrGenericForbidden{})
+	}
+	return nil
+}
+
+// runBulkTaskOperation first checks all tasks with check and only runs the operation if all checks passed.
+// Returns true if the operation failed for at least one task. In that case, nothing should be saved.
+func runBulkTaskOperation(results []*BulkTaskResult, check func(r *BulkTaskResult) error, run func(r *BulkTaskResult) error) (failed bool, err error) {
+	for _, steps := range []func(r *BulkTaskResult) error{check, run} {
+		for _, r := range results {
+			err = steps(r)
+			if err != nil {
+				return true, err
+			}
+			if r.Error != nil {
+				failed = true
+			}
+		}
+		if failed {
+			for _, r := range results {
+				r.Task = nil
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Create creates all tasks. If one of them could not be created, no task is created.
+func (bt *BulkTaskCreate) Create(s *xorm.Session, a web.Auth) (results []*BulkTaskResult, failed bool, err error) {
+	err = checkBulkTaskCount(len(bt.Tasks))
+	if err != nil {
+		return nil, true, err
+	}
+
+	results = make([]*BulkTaskResult, 0, len(bt.Tasks))
+	for i, t := range bt.Tasks {
+		results = append(results, &BulkTaskResult{Index: i, Task: t})
+	}
+
+	failed, err = runBulkTaskOperation(
+		results,
+		func(r *BulkTaskResult) error {
+			if r.Task.Title == "" && !r.Task.QuickAdd {
+				return r.setError(ErrTaskCannotBeEmpty{})
+			}
+			return r.checkRight(r.Task.CanCreate(s, a))
+		},
+		func(r *BulkTaskResult) error {
+			labels := r.Task.Labels
+			r.Task.Labels = nil
+
+			err := r.Task.Create(s, a)
+			if err != nil {
+				return r.setError(err)
+			}
+			r.TaskID = r.Task.ID
+
+			if len(labels) == 0 {
+				return nil
+			}
+			err = r.Task.UpdateTaskLabels(s, a, append(r.Task.Labels, labels...))
+			if err != nil {
+				return r.setError(err)
+			}
+			return nil
+		},
+	)
+	return
+}
+
+// Delete deletes all tasks. If one of them could not be deleted, no task is deleted.
+func (bt *BulkTaskDelete) Delete(s *xorm.Session, a web.Auth) (results []*BulkTaskResult, failed bool, err error) {
+	err = checkBulkTaskCount(len(bt.TaskIDs))
+	if err != nil {
+		return nil, true, err
+	}
+
+	results = make([]*BulkTaskResult, 0, len(bt.TaskIDs))
+	for i, id := range bt.TaskIDs {
+		results = append(results, &BulkTaskResult{Index: i, TaskID: id})
+	}
+
+	failed, err = runBulkTaskOperation(
+		results,
+		func(r *BulkTaskResult) error {
+			t := &Task{ID: r.TaskID}
+			return r.checkRight(t.CanDelete(s, a))
+		},
+		func(r *BulkTaskResult) error {
+			t := &Task{ID: r.TaskID}
+			err := t.Delete(s, a)
+			if err != nil {
+				return r.setError(err)
+			}
+			return nil
+		},
+	)
+	return
+}
+
+// Move moves all tasks into another project or bucket. If one of them could not be moved, no task is moved.
+func (bt *BulkTaskMove) Move(s *xorm.Session, a web.Auth) (results []*BulkTaskResult, failed bool, err error) {
+	err = checkBulkTaskCount(len(bt.TaskIDs))
+	if err != nil {
+		return nil, true, err
+	}
+
+	results = make([]*BulkTaskResult, 0, len(bt.TaskIDs))
+	for i, id := range bt.TaskIDs {
+		results = append(results, &BulkTaskResult{Index: i, TaskID: id})
+	}
+
+	failed, err = runBulkTaskOperation(
+		results,
+		func(r *BulkTaskResult) error {
+			t := &Task{ID: r.TaskID, ProjectID: bt.ProjectID}
+			return r.checkRight(t.CanUpdate(s, a))
+		},
+		func(r *BulkTaskResult) error {
+			t, err := GetTaskByIDSimple(s, r.TaskID)
+			if err != nil {
+				return r.setError(err)
+			}
+			err = moveTask(s, a, &t, bt.ProjectID, bt.BucketID)
+			if err != nil {
+				return r.setError(err)
+			}
+			r.Task = &t
+			return nil
+		},
+	)
+	return
+}
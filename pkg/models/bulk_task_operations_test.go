@@ -0,0 +1,253 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkTaskCreate_Create(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskCreate{
+			Tasks: []*Task{
+				{Title: "bulk 1", ProjectID: 1, Labels: []*Label{{ID: 1}}},
+				{Title: "bulk 2", ProjectID: 1},
+			},
+		}
+		results, failed, err := bt.Create(s, u)
+		assert.NoError(t, err)
+		assert.False(t, failed)
+		assert.Len(t, results, 2)
+		assert.NotZero(t, results[0].TaskID)
+		assert.Equal(t, results[0].TaskID, results[0].Task.ID)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         results[0].TaskID,
+			"title":      "bulk 1",
+			"project_id": 1,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         results[1].TaskID,
+			"title":      "bulk 2",
+			"project_id": 1,
+		}, false)
+		db.AssertExists(t, "label_tasks", map[string]interface{}{
+			"task_id":  results[0].TaskID,
+			"label_id": 1,
+		}, false)
+	})
+	t.Run("one task without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskCreate{
+			Tasks: []*Task{
+				{Title: "bulk 1", ProjectID: 1},
+				{Title: "bulk 2", ProjectID: 2},
+				{ProjectID: 1},
+			},
+		}
+		results, failed, err := bt.Create(s, u)
+		assert.NoError(t, err)
+		assert.True(t, failed)
+		assert.Nil(t, results[0].Error)
+		assert.Nil(t, results[0].Task)
+		assert.Equal(t, ErrorCodeGenericForbidden, results[1].Error.Code)
+		assert.Equal(t, ErrCodeTaskCannotBeEmpty, results[2].Error.Code)
+
+		db.AssertMissing(t, "tasks", map[string]interface{}{
+			"title": "bulk 1",
+		})
+	})
+	t.Run("one task failing while creating", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+		defer events.CleanupPending(s)
+		events.Fake()
+
+		err := s.Begin()
+		require.NoError(t, err)
+
+		bt := &BulkTaskCreate{
+			Tasks: []*Task{
+				{Title: "bulk 1", ProjectID: 1},
+				{Title: "bulk 2", ProjectID: 1, Labels: []*Label{{ID: 9999}}},
+			},
+		}
+		results, failed, err := bt.Create(s, u)
+		require.NoError(t, err)
+		assert.True(t, failed)
+		assert.Nil(t, results[0].Error)
+		assert.NotNil(t, results[1].Error)
+		err = s.Rollback()
+		require.NoError(t, err)
+
+		db.AssertMissing(t, "tasks", map[string]interface{}{
+			"title": "bulk 1",
+		})
+		events.AssertNotDispatched(t, &TaskCreatedEvent{})
+	})
+	t.Run("no tasks", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskCreate{}
+		_, _, err := bt.Create(s, u)
+		assert.Error(t, err)
+		assert.True(t, IsErrBulkTasksNeedAtLeastOne(err))
+	})
+}
+
+func TestBulkTaskDelete_Delete(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskDelete{TaskIDs: []int64{10, 11}}
+		results, failed, err := bt.Delete(s, u)
+		assert.NoError(t, err)
+		assert.False(t, failed)
+		assert.Len(t, results, 2)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertMissing(t, "tasks", map[string]interface{}{"id": 10})
+		db.AssertMissing(t, "tasks", map[string]interface{}{"id": 11})
+	})
+	t.Run("one task without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskDelete{TaskIDs: []int64{10, 13, 9999}}
+		results, failed, err := bt.Delete(s, u)
+		assert.NoError(t, err)
+		assert.True(t, failed)
+		assert.Nil(t, results[0].Error)
+		assert.Equal(t, ErrorCodeGenericForbidden, results[1].Error.Code)
+		assert.Equal(t, ErrCodeTaskDoesNotExist, results[2].Error.Code)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{"id": 10}, false)
+	})
+}
+
+func TestBulkTaskMove_Move(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("into another project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskMove{TaskIDs: []int64{10, 11}, ProjectID: 10, BucketID: 26}
+		results, failed, err := bt.Move(s, u)
+		assert.NoError(t, err)
+		assert.False(t, failed)
+		assert.Equal(t, int64(10), results[0].Task.ProjectID)
+		assert.Equal(t, int64(26), results[0].Task.BucketID)
+		assert.NotEqual(t, results[0].Task.Index, results[1].Task.Index)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         10,
+			"project_id": 10,
+			"bucket_id":  26,
+			"version":    1,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         11,
+			"project_id": 10,
+			"bucket_id":  26,
+		}, false)
+	})
+	t.Run("into the done bucket", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskMove{TaskIDs: []int64{10}, BucketID: 3}
+		results, failed, err := bt.Move(s, u)
+		assert.NoError(t, err)
+		assert.False(t, failed)
+		assert.True(t, results[0].Task.Done)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         10,
+			"project_id": 1,
+			"bucket_id":  3,
+			"done":       true,
+		}, false)
+	})
+	t.Run("into an archived project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskMove{TaskIDs: []int64{10}, ProjectID: 21}
+		results, failed, err := bt.Move(s, u)
+		assert.NoError(t, err)
+		assert.True(t, failed)
+		assert.Equal(t, ErrCodeProjectIsArchived, results[0].Error.Code)
+	})
+	t.Run("into a project without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskMove{TaskIDs: []int64{10, 11}, ProjectID: 2}
+		results, failed, err := bt.Move(s, u)
+		assert.NoError(t, err)
+		assert.True(t, failed)
+		assert.Equal(t, ErrorCodeGenericForbidden, results[0].Error.Code)
+		assert.Equal(t, ErrorCodeGenericForbidden, results[1].Error.Code)
+	})
+	t.Run("into a bucket of another project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		bt := &BulkTaskMove{TaskIDs: []int64{10}, BucketID: 19}
+		results, failed, err := bt.Move(s, u)
+		assert.NoError(t, err)
+		assert.True(t, failed)
+		assert.Equal(t, ErrCodeBucketDoesNotBelongToProject, results[0].Error.Code)
+	})
+}
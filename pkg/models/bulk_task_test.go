@@ -21,6 +21,8 @@ import (
 
 	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestBulkTask_Update(t *testing.T) {
@@ -92,3 +94,31 @@ func TestBulkTask_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkTask_Update_Version(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	u := &user.User{ID: 1}
+	bt := &BulkTask{
+		IDs:  []int64{10, 11},
+		Task: Task{Title: "bulkupdated"},
+	}
+	allowed, err := bt.CanUpdate(s, u)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	err = bt.Update(s, u)
+	require.NoError(t, err)
+	err = s.Commit()
+	require.NoError(t, err)
+
+	// The ETag of every changed task has to change as well
+	for _, id := range bt.IDs {
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":      id,
+			"title":   "bulkupdated",
+			"version": 1,
+		}, false)
+	}
+}
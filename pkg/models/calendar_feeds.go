@@ -0,0 +1,287 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/api/pkg/utils"
+
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+// CalendarFeedEventDate is the date of a task an event in a calendar feed is based on
+type CalendarFeedEventDate string
+
+const (
+	// CalendarFeedEventDateNone means the feed does not contain any events
+	CalendarFeedEventDateNone CalendarFeedEventDate = ""
+	// CalendarFeedEventDateDueDate creates an event at the due date of each task
+	CalendarFeedEventDateDueDate CalendarFeedEventDate = "due_date"
+	// CalendarFeedEventDateStartEndDate creates an event from the start to the end date of each task
+	CalendarFeedEventDateStartEndDate CalendarFeedEventDate = "start_end_date"
+)
+
+// CalendarFeed is a secret url which serves the tasks of a project or saved filter as iCalendar file. It allows
+// calendar apps which can't use CalDAV to subscribe to Vikunja.
+type CalendarFeed struct {
+	// The unique, numeric id of this calendar feed.
+	ID int64 `xorm:"bigint autoincr not null unique pk" json:"id" param:"feed"`
+	// The secret token of this feed. The feed is available at `/api/v1/feeds/<token>.ics` for everyone who knows it.
+	Token string `xorm:"varchar(40) not null unique" json:"token"`
+
+	// The project this feed contains the tasks of. Either this or a filter id must be provided.
+	ProjectID int64 `xorm:"bigint not null default 0 index" json:"project_id"`
+	// The saved filter this feed contains the tasks of. Either this or a project id must be provided.
+	FilterID int64 `xorm:"bigint not null default 0 index" json:"filter_id"`
+
+	// Whether the feed contains all tasks as VTODO. Most calendar apps ignore those.
+	IncludeTodos bool `xorm:"bool not null default false" json:"include_todos"`
+	// The date the events of the feed are based on. Either `due_date` to create an event at the due date of a task,
+	// `start_end_date` to create an event from the start until the end date of a task or empty to not create any events.
+	// Tasks without that date are not added as events.
+	EventDate CalendarFeedEventDate `xorm:"varchar(20) not null default ''" json:"event_date"`
+	// Whether done tasks are part of the feed.
+	IncludeDone bool `xorm:"bool not null default false" json:"include_done"`
+
+	OwnerID int64 `xorm:"bigint not null index" json:"-"`
+
+	// A timestamp when this feed was created. You cannot change this value.
+	Created time.Time `xorm:"created not null" json:"created"`
+	// A timestamp when this feed was last updated. You cannot change this value.
+	Updated time.Time `xorm:"updated not null" json:"updated"`
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+// TableName holds the table name for calendar feeds
+func (*CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+func getCalendarFeedByID(s *xorm.Session, id int64) (feed *CalendarFeed, err error) {
+	feed = &CalendarFeed{}
+	exists, err := s.Where("id = ?", id).Get(feed)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ErrCalendarFeedDoesNotExist{ID: id}
+	}
+	return
+}
+
+// GetCalendarFeedByToken returns the calendar feed with the given secret token
+func GetCalendarFeedByToken(s *xorm.Session, token string) (feed *CalendarFeed, err error) {
+	feed = &CalendarFeed{}
+	exists, err := s.Where("token = ?", token).Get(feed)
+	if err != nil {
+		return nil, err
+	}
+	if !exists || token == "" {
+		return nil, &ErrCalendarFeedDoesNotExist{}
+	}
+	return
+}
+
+func (feed *CalendarFeed) validate() error {
+	switch feed.EventDate {
+	case CalendarFeedEventDateNone,
+		CalendarFeedEventDateDueDate,
+		CalendarFeedEventDateStartEndDate:
+	default:
+		return InvalidFieldError([]string{"event_date"})
+	}
+
+	if !feed.IncludeTodos && feed.EventDate == CalendarFeedEventDateNone {
+		return InvalidFieldError([]string{"include_todos", "event_date"})
+	}
+
+	return nil
+}
+
+// The pseudo project of a saved filter can be used as project id, it is stored as filter id instead.
+func (feed *CalendarFeed) normalizeSource() {
+	if filterID := getSavedFilterIDFromProjectID(feed.ProjectID); filterID > 0 {
+		feed.FilterID = filterID
+		feed.ProjectID = 0
+	}
+}
+
+// Create creates a new calendar feed
+// @Summary Create a calendar feed
+// @Description Creates a secret iCalendar feed url for the tasks of a project or saved filter. Calendar apps like Google Calendar or Outlook can subscribe to it without any credentials, everyone who knows the url can read the tasks in it. Delete the feed to revoke the url.
+// @tags user
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param feed body models.CalendarFeed true "The calendar feed with the project or filter and the feed settings."
+// @Success 201 {object} models.CalendarFeed "The created calendar feed."
+// @Failure 400 {object} web.HTTPError "Invalid calendar feed object provided."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project or saved filter."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/calendar-feeds [put]
+func (feed *CalendarFeed) Create(s *xorm.Session, a web.Auth) (err error) {
+	err = feed.validate()
+	if err != nil {
+		return err
+	}
+
+	feed.ID = 0
+	feed.OwnerID = a.GetID()
+	feed.Token, err = utils.CryptoRandomString(40)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Insert(feed)
+	return
+}
+
+// ReadAll returns all calendar feeds of the current user
+// @Summary Get all calendar feeds
+// @Description Returns all calendar feeds the current user has created.
+// @tags user
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {array} models.CalendarFeed "The calendar feeds."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/calendar-feeds [get]
+func (feed *CalendarFeed) ReadAll(s *xorm.Session, a web.Auth, _ string, _ int, _ int) (result interface{}, resultCount int, numberOfTotalItems int64, err error) {
+	if _, is := a.(*LinkSharing); is {
+		return nil, 0, 0, ErrGenericForbidden{}
+	}
+
+	feeds := []*CalendarFeed{}
+	err = s.
+		Where("owner_id = ?", a.GetID()).
+		OrderBy("id asc").
+		Find(&feeds)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return feeds, len(feeds), int64(len(feeds)), nil
+}
+
+// Update updates the settings of a calendar feed
+// @Summary Update a calendar feed
+// @Description Updates which tasks a calendar feed contains and how. The project, filter and url of a feed can't be changed.
+// @tags user
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param feed path int true "Calendar feed ID"
+// @Param feedObject body models.CalendarFeed true "The calendar feed."
+// @Success 200 {object} models.CalendarFeed "The updated calendar feed."
+// @Failure 400 {object} web.HTTPError "Invalid calendar feed object provided."
+// @Failure 404 {object} web.HTTPError "The calendar feed does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/calendar-feeds/{feed} [post]
+func (feed *CalendarFeed) Update(s *xorm.Session, _ web.Auth) (err error) {
+	err = feed.validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.
+		Where("id = ?", feed.ID).
+		Cols("include_todos", "event_date", "include_done").
+		Update(feed)
+	if err != nil {
+		return err
+	}
+
+	updated, err := getCalendarFeedByID(s, feed.ID)
+	if err != nil {
+		return err
+	}
+	*feed = *updated
+	return nil
+}
+
+// Delete removes a calendar feed
+// @Summary Delete a calendar feed
+// @Description Deletes a calendar feed. Its url stops working immediately.
+// @tags user
+// @Produce json
+// @Security JWTKeyAuth
+// @Param feed path int true "Calendar feed ID"
+// @Success 200 {object} models.Message "The calendar feed was deleted successfully."
+// @Failure 404 {object} web.HTTPError "The calendar feed does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/calendar-feeds/{feed} [delete]
+func (feed *CalendarFeed) Delete(s *xorm.Session, _ web.Auth) (err error) {
+	_, err = s.Where("id = ?", feed.ID).Delete(&CalendarFeed{})
+	return
+}
+
+// GetTasks returns the title of the project or saved filter of the feed and all tasks in it, as seen by the owner
+// of the feed. If the owner can't access the project or filter anymore, the feed does not exist anymore.
+func (feed *CalendarFeed) GetTasks(s *xorm.Session) (title string, tasks []*Task, err error) {
+	owner, err := user.GetUserByID(s, feed.OwnerID)
+	if err != nil {
+		if user.IsErrUserDoesNotExist(err) {
+			return "", nil, &ErrCalendarFeedDoesNotExist{ID: feed.ID}
+		}
+		return "", nil, err
+	}
+	if owner.Status == user.StatusDisabled {
+		return "", nil, &ErrCalendarFeedDoesNotExist{ID: feed.ID}
+	}
+
+	projectID := feed.ProjectID
+	if feed.FilterID != 0 {
+		projectID = getProjectIDFromSavedFilterID(feed.FilterID)
+	}
+
+	project := &Project{ID: projectID}
+	can, _, err := project.CanRead(s, owner)
+	if err != nil && !IsErrProjectDoesNotExist(err) && !IsErrSavedFilterDoesNotExist(err) {
+		return "", nil, err
+	}
+	if !can {
+		return "", nil, &ErrCalendarFeedDoesNotExist{ID: feed.ID}
+	}
+
+	title = project.Title
+	if feed.FilterID != 0 {
+		// Checking the rights of a saved filter only sets the filter, not its pseudo project.
+		sf, err := getSavedFilterSimpleByID(s, feed.FilterID)
+		if err != nil {
+			return "", nil, err
+		}
+		title = sf.Title
+	}
+
+	result, _, _, err := (&TaskCollection{ProjectID: projectID}).ReadAll(s, owner, "", -1, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, t := range result.([]*Task) {
+		if t.Done && !feed.IncludeDone {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	return title, tasks, nil
+}
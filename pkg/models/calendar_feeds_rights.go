@@ -0,0 +1,66 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+// CanCreate checks if a user can create a calendar feed for a project or saved filter, which they need to be able to read.
+func (feed *CalendarFeed) CanCreate(s *xorm.Session, a web.Auth) (bool, error) {
+	if _, is := a.(*LinkSharing); is {
+		return false, nil
+	}
+
+	feed.normalizeSource()
+	if (feed.ProjectID == 0) == (feed.FilterID == 0) {
+		return false, InvalidFieldError([]string{"project_id", "filter_id"})
+	}
+
+	if feed.FilterID != 0 {
+		can, _, err := (&SavedFilter{ID: feed.FilterID}).CanRead(s, a)
+		return can, err
+	}
+
+	can, _, err := (&Project{ID: feed.ProjectID}).CanRead(s, a)
+	return can, err
+}
+
+// CanUpdate checks if a user can update a calendar feed
+func (feed *CalendarFeed) CanUpdate(s *xorm.Session, a web.Auth) (bool, error) {
+	return feed.isOwner(s, a)
+}
+
+// CanDelete checks if a user can delete a calendar feed
+func (feed *CalendarFeed) CanDelete(s *xorm.Session, a web.Auth) (bool, error) {
+	return feed.isOwner(s, a)
+}
+
+// Only the user who created a feed can change it.
+func (feed *CalendarFeed) isOwner(s *xorm.Session, a web.Auth) (bool, error) {
+	if _, is := a.(*LinkSharing); is {
+		return false, nil
+	}
+
+	existing, err := getCalendarFeedByID(s, feed.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return existing.OwnerID == a.GetID(), nil
+}
@@ -0,0 +1,331 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarFeed_Create(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("project feed", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID: 1,
+			EventDate: CalendarFeedEventDateDueDate,
+		}
+		can, err := feed.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = feed.Create(s, u)
+		assert.NoError(t, err)
+		assert.Len(t, feed.Token, 40)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "calendar_feeds", map[string]interface{}{
+			"id":         feed.ID,
+			"token":      feed.Token,
+			"project_id": 1,
+			"filter_id":  0,
+			"event_date": "due_date",
+			"owner_id":   1,
+		}, false)
+	})
+	t.Run("saved filter pseudo project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID:    -2,
+			IncludeTodos: true,
+		}
+		can, err := feed.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = feed.Create(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "calendar_feeds", map[string]interface{}{
+			"id":         feed.ID,
+			"project_id": 0,
+			"filter_id":  1,
+		}, false)
+	})
+	t.Run("project without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID:    2,
+			IncludeTodos: true,
+		}
+		can, err := feed.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+	t.Run("project and filter", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID:    1,
+			FilterID:     1,
+			IncludeTodos: true,
+		}
+		_, err := feed.CanCreate(s, u)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"project_id", "filter_id"}, err.(ValidationHTTPError).InvalidFields)
+	})
+	t.Run("empty feed", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{ProjectID: 1}
+		err := feed.Create(s, u)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"include_todos", "event_date"}, err.(ValidationHTTPError).InvalidFields)
+	})
+	t.Run("invalid event date", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID: 1,
+			EventDate: "created",
+		}
+		err := feed.Create(s, u)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"event_date"}, err.(ValidationHTTPError).InvalidFields)
+	})
+	t.Run("link share", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ProjectID:    1,
+			IncludeTodos: true,
+		}
+		can, err := feed.CanCreate(s, &LinkSharing{ID: 1, ProjectID: 1, Right: RightRead})
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+}
+
+func TestCalendarFeed_ReadAll(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	feed := &CalendarFeed{}
+	result, _, _, err := feed.ReadAll(s, &user.User{ID: 1}, "", 1, 50)
+	assert.NoError(t, err)
+	feeds := result.([]*CalendarFeed)
+	assert.Len(t, feeds, 3)
+	for _, f := range feeds {
+		assert.Equal(t, int64(1), f.OwnerID)
+	}
+}
+
+func TestCalendarFeed_Update(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{
+			ID:          1,
+			ProjectID:   3,
+			Token:       "changed",
+			EventDate:   CalendarFeedEventDateStartEndDate,
+			IncludeDone: true,
+		}
+		can, err := feed.CanUpdate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = feed.Update(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), feed.ProjectID)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "calendar_feeds", map[string]interface{}{
+			"id":            1,
+			"token":         "u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y",
+			"project_id":    1,
+			"include_todos": false,
+			"event_date":    "start_end_date",
+			"include_done":  true,
+		}, false)
+	})
+	t.Run("feed of another user", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{ID: 4, IncludeTodos: true}
+		can, err := feed.CanUpdate(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+	t.Run("nonexisting", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{ID: 9999, IncludeTodos: true}
+		_, err := feed.CanUpdate(s, u)
+		assert.Error(t, err)
+		assert.True(t, IsErrCalendarFeedDoesNotExist(err))
+	})
+}
+
+func TestCalendarFeed_Delete(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{ID: 1}
+		can, err := feed.CanDelete(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = feed.Delete(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertMissing(t, "calendar_feeds", map[string]interface{}{
+			"id": 1,
+		})
+	})
+	t.Run("feed of another user", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed := &CalendarFeed{ID: 4}
+		can, err := feed.CanDelete(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+	t.Run("with saved filter", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sf := &SavedFilter{ID: 1}
+		err := sf.Delete(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertMissing(t, "calendar_feeds", map[string]interface{}{
+			"id": 2,
+		})
+	})
+}
+
+func TestCalendarFeed_GetTasks(t *testing.T) {
+	t.Run("project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed, err := GetCalendarFeedByToken(s, "u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y")
+		assert.NoError(t, err)
+		title, tasks, err := feed.GetTasks(s)
+		assert.NoError(t, err)
+		assert.Equal(t, "Test1", title)
+		assert.NotEmpty(t, tasks)
+		for _, task := range tasks {
+			assert.Equal(t, int64(1), task.ProjectID)
+			assert.False(t, task.Done)
+		}
+	})
+	t.Run("with done tasks", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed, err := GetCalendarFeedByToken(s, "u1p1Ar9NbXjfLrN4pWqTyVkHzE82sMdC6gQe0o7Y")
+		assert.NoError(t, err)
+		feed.IncludeDone = true
+		_, tasks, err := feed.GetTasks(s)
+		assert.NoError(t, err)
+		var hasDone bool
+		for _, task := range tasks {
+			if task.Done {
+				hasDone = true
+			}
+		}
+		assert.True(t, hasDone)
+	})
+	t.Run("saved filter", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed, err := GetCalendarFeedByToken(s, "u1f1Kd3WmPq8ZsXv5RtYbN0cLhGj7eFa2uIo9T4w")
+		assert.NoError(t, err)
+		title, tasks, err := feed.GetTasks(s)
+		assert.NoError(t, err)
+		assert.Equal(t, "testfilter1", title)
+		assert.NotEmpty(t, tasks)
+	})
+	t.Run("owner lost access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		feed, err := GetCalendarFeedByToken(s, "u1p2Hs6QeLw1RbNx8ZkTcVy3MgJp5DaU0fOi7E2r")
+		assert.NoError(t, err)
+		_, _, err = feed.GetTasks(s)
+		assert.Error(t, err)
+		assert.True(t, IsErrCalendarFeedDoesNotExist(err))
+	})
+	t.Run("nonexisting token", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		_, err := GetCalendarFeedByToken(s, "doesnotexist")
+		assert.Error(t, err)
+		assert.True(t, IsErrCalendarFeedDoesNotExist(err))
+	})
+}
@@ -0,0 +1,198 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+// ChatDestinations is a wrapper around the crud operations that come with a chat destination.
+// Destinations with a project id belong to that project, all others to the user who created them.
+type ChatDestinations struct {
+	notifications.ChatDestination
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+func (c *ChatDestinations) validate() error {
+	if err := notifications.CheckChatURL(c.URL); err != nil {
+		return InvalidFieldError([]string{"url"})
+	}
+
+	switch c.Type {
+	case notifications.ChatTypeMatrix:
+		if c.Room == "" {
+			return InvalidFieldError([]string{"room"})
+		}
+		if c.Token == "" {
+			return InvalidFieldError([]string{"token"})
+		}
+	case notifications.ChatTypeNtfy:
+		if c.Room == "" {
+			return InvalidFieldError([]string{"room"})
+		}
+	case notifications.ChatTypeGotify:
+		if c.Token == "" {
+			return InvalidFieldError([]string{"token"})
+		}
+	}
+
+	return nil
+}
+
+// Create creates a new chat destination
+// @Summary Create a chat destination
+// @Description Adds a chat room or topic where notifications are sent to. Destinations created at the user settings route receive all notifications of the current user, destinations of a project receive notifications about everything happening in that project.
+// @tags subscriptions
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param destination body notifications.ChatDestination true "The chat destination."
+// @Success 201 {object} notifications.ChatDestination "The created chat destination."
+// @Failure 400 {object} web.HTTPError "Invalid chat destination object provided."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/chat-destinations [put]
+// @Router /projects/{project}/chat-destinations [put]
+func (c *ChatDestinations) Create(s *xorm.Session, a web.Auth) (err error) {
+	err = c.validate()
+	if err != nil {
+		return err
+	}
+
+	c.ID = 0
+	c.UserID = 0
+	if c.ProjectID == 0 {
+		c.UserID = a.GetID()
+	}
+
+	_, err = s.Insert(&c.ChatDestination)
+	c.Token = ""
+	return
+}
+
+// ReadAll returns all chat destinations of the current user or a project
+// @Summary Get all chat destinations
+// @Description Returns all chat destinations of the current user or a project. Tokens are never returned.
+// @tags subscriptions
+// @Produce json
+// @Security JWTKeyAuth
+// @Param project path int false "Project ID"
+// @Success 200 {array} notifications.ChatDestination "The chat destinations."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/chat-destinations [get]
+// @Router /projects/{project}/chat-destinations [get]
+func (c *ChatDestinations) ReadAll(s *xorm.Session, a web.Auth, _ string, _ int, _ int) (result interface{}, resultCount int, numberOfTotalItems int64, err error) {
+	can, err := c.canDoChatDestination(s, a)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if !can {
+		return nil, 0, 0, ErrGenericForbidden{}
+	}
+
+	var destinations []*notifications.ChatDestination
+	if c.ProjectID != 0 {
+		destinations, err = notifications.GetChatDestinationsForProject(s, c.ProjectID)
+	} else {
+		destinations, err = notifications.GetChatDestinationsForUser(s, a.GetID())
+	}
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	for _, destination := range destinations {
+		destination.Token = ""
+	}
+
+	return destinations, len(destinations), int64(len(destinations)), nil
+}
+
+// Update updates a chat destination
+// @Summary Update a chat destination
+// @Description Updates a chat destination. If no token is provided, the existing one is kept.
+// @tags subscriptions
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param project path int false "Project ID"
+// @Param destination path int true "Chat destination ID"
+// @Param destinationObject body notifications.ChatDestination true "The chat destination."
+// @Success 200 {object} notifications.ChatDestination "The updated chat destination."
+// @Failure 400 {object} web.HTTPError "Invalid chat destination object provided."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the chat destination."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/chat-destinations/{destination} [post]
+// @Router /projects/{project}/chat-destinations/{destination} [post]
+func (c *ChatDestinations) Update(s *xorm.Session, _ web.Auth) (err error) {
+	cols := []string{"type", "url", "room"}
+	if c.Token != "" {
+		cols = append(cols, "token")
+	} else {
+		existing := &notifications.ChatDestination{}
+		_, err = s.Where("id = ?", c.ID).Get(existing)
+		if err != nil {
+			return err
+		}
+		c.Token = existing.Token
+	}
+
+	err = c.validate()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.
+		Where("id = ?", c.ID).
+		Cols(cols...).
+		Update(&c.ChatDestination)
+	c.Token = ""
+	return
+}
+
+// Delete removes a chat destination
+// @Summary Delete a chat destination
+// @Description Removes a chat destination of the current user or a project.
+// @tags subscriptions
+// @Produce json
+// @Security JWTKeyAuth
+// @Param project path int false "Project ID"
+// @Param destination path int true "Chat destination ID"
+// @Success 200 {object} models.Message "The chat destination was deleted successfully."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the chat destination."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /user/settings/chat-destinations/{destination} [delete]
+// @Router /projects/{project}/chat-destinations/{destination} [delete]
+func (c *ChatDestinations) Delete(s *xorm.Session, _ web.Auth) (err error) {
+	_, err = s.Where("id = ?", c.ID).Delete(&notifications.ChatDestination{})
+	return
+}
+
+// notifyProjectChat sends a notification once to all chat destinations of a project.
+func notifyProjectChat(s *xorm.Session, projectID int64, n notifications.Notification) error {
+	destinations, err := notifications.GetChatDestinationsForProject(s, projectID)
+	if err != nil {
+		return err
+	}
+
+	notifications.SendChat(destinations, n)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+func (c *ChatDestinations) CanCreate(s *xorm.Session, a web.Auth) (bool, error) {
+	return c.canDoChatDestination(s, a)
+}
+
+func (c *ChatDestinations) CanUpdate(s *xorm.Session, a web.Auth) (bool, error) {
+	return c.canDoExistingChatDestination(s, a)
+}
+
+func (c *ChatDestinations) CanDelete(s *xorm.Session, a web.Auth) (bool, error) {
+	return c.canDoExistingChatDestination(s, a)
+}
+
+func (c *ChatDestinations) canDoChatDestination(s *xorm.Session, a web.Auth) (bool, error) {
+	if _, is := a.(*LinkSharing); is {
+		return false, nil
+	}
+
+	if c.ProjectID == 0 {
+		return true, nil
+	}
+
+	p := &Project{ID: c.ProjectID}
+	return p.CanUpdate(s, a)
+}
+
+func (c *ChatDestinations) canDoExistingChatDestination(s *xorm.Session, a web.Auth) (bool, error) {
+	existing := &notifications.ChatDestination{}
+	exists, err := s.Where("id = ?", c.ID).Get(existing)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	// The route decides if this is about a user or a project destination, it must match the stored one.
+	if existing.ProjectID != c.ProjectID {
+		return false, nil
+	}
+	if existing.ProjectID == 0 && existing.UserID != a.GetID() {
+		return false, nil
+	}
+
+	return c.canDoChatDestination(s, a)
+}
@@ -0,0 +1,201 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatDestinations_Create(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("user destination", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			Type: notifications.ChatTypeSlack,
+			URL:  "https://hooks.example.com/services/abc",
+		}}
+		can, err := c.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = c.Create(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "chat_destinations", map[string]interface{}{
+			"id":         c.ID,
+			"user_id":    1,
+			"project_id": 0,
+			"type":       "slack",
+		}, false)
+	})
+	t.Run("project destination", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			ProjectID: 1,
+			Type:      notifications.ChatTypeMatrix,
+			URL:       "https://matrix.example.com",
+			Token:     "secret",
+			Room:      "!room:example.com",
+		}}
+		can, err := c.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = c.Create(s, u)
+		assert.NoError(t, err)
+		assert.Empty(t, c.Token)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "chat_destinations", map[string]interface{}{
+			"id":         c.ID,
+			"user_id":    0,
+			"project_id": 1,
+			"token":      "secret",
+		}, false)
+	})
+	t.Run("project destination without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			ProjectID: 2,
+			Type:      notifications.ChatTypeSlack,
+			URL:       "https://hooks.example.com/services/abc",
+		}}
+		can, err := c.CanCreate(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+	t.Run("internal url", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			Type: notifications.ChatTypeSlack,
+			URL:  "http://169.254.169.254/latest/meta-data",
+		}}
+		err := c.Create(s, u)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"url"}, err.(ValidationHTTPError).InvalidFields)
+	})
+	t.Run("matrix without room", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			Type:  notifications.ChatTypeMatrix,
+			URL:   "https://matrix.example.com",
+			Token: "secret",
+		}}
+		err := c.Create(s, u)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"room"}, err.(ValidationHTTPError).InvalidFields)
+	})
+}
+
+func TestChatDestinations_Update(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	createDestination := func(t *testing.T, userID, projectID int64) *notifications.ChatDestination {
+		s := db.NewSession()
+		defer s.Close()
+		d := &notifications.ChatDestination{
+			UserID:    userID,
+			ProjectID: projectID,
+			Type:      notifications.ChatTypeGotify,
+			URL:       "https://gotify.example.com",
+			Token:     "secret",
+		}
+		_, err := s.Insert(d)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Commit())
+		return d
+	}
+
+	t.Run("keeps the token", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		d := createDestination(t, 1, 0)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{
+			ID:   d.ID,
+			Type: notifications.ChatTypeGotify,
+			URL:  "https://gotify.example.org",
+		}}
+		can, err := c.CanUpdate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = c.Update(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "chat_destinations", map[string]interface{}{
+			"id":    d.ID,
+			"url":   "https://gotify.example.org",
+			"token": "secret",
+		}, false)
+	})
+	t.Run("other user's destination", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		d := createDestination(t, 2, 0)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{ID: d.ID}}
+		can, err := c.CanUpdate(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+		can, err = c.CanDelete(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+	})
+	t.Run("project destination through user route", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		d := createDestination(t, 0, 1)
+		s := db.NewSession()
+		defer s.Close()
+
+		c := &ChatDestinations{ChatDestination: notifications.ChatDestination{ID: d.ID}}
+		can, err := c.CanDelete(s, u)
+		assert.NoError(t, err)
+		assert.False(t, can)
+
+		c.ProjectID = 1
+		can, err = c.CanDelete(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+	})
+}
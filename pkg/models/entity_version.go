@@ -0,0 +1,113 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"xorm.io/xorm"
+)
+
+// GetEtag returns the ETag of a task, project, bucket or comment with the given id and version.
+func GetEtag(id, version int64) string {
+	return `"` + strconv.FormatInt(id, 10) + `-` + strconv.FormatInt(version, 10) + `"`
+}
+
+// Etagger is implemented by everything which has a version to base an ETag on. The api sets the ETag header for
+// all responses containing one of them.
+type Etagger interface {
+	Etag() string
+}
+
+// Etag returns the ETag of the task
+func (t *Task) Etag() string {
+	return GetEtag(t.ID, t.Version)
+}
+
+// Etag returns the ETag of the project
+func (p *Project) Etag() string {
+	return GetEtag(p.ID, p.Version)
+}
+
+// Etag returns the ETag of the bucket
+func (b *Bucket) Etag() string {
+	return GetEtag(b.ID, b.Version)
+}
+
+// Etag returns the ETag of the comment
+func (tc *TaskComment) Etag() string {
+	return GetEtag(tc.ID, tc.Version)
+}
+
+// parseIfMatch returns the version an If-Match header refers to. It accepts both the ETag of an entity
+// and its plain version number. check is false if there is nothing to check against.
+func parseIfMatch(ifMatch string) (version int64, check bool, valid bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, false, true
+	}
+
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	ifMatch = strings.Trim(ifMatch, `"`)
+	if i := strings.LastIndex(ifMatch, "-"); i != -1 {
+		ifMatch = ifMatch[i+1:]
+	}
+
+	version, err := strconv.ParseInt(ifMatch, 10, 64)
+	if err != nil {
+		return 0, true, false
+	}
+
+	return version, true, true
+}
+
+// bumpVersion increases the version of an entity. If the client sent an If-Match header, the version is only
+// increased if it still matches the current version, otherwise an ErrVersionConflict is returned.
+// Because the row is updated before everything else, concurrent updates of the same entity wait for each other.
+func bumpVersion(s *xorm.Session, table string, id int64, ifMatch string) (version int64, err error) {
+	expected, check, valid := parseIfMatch(ifMatch)
+
+	if valid {
+		cond := s.
+			Table(table).
+			Where("id = ?", id).
+			Incr("version").
+			NoAutoTime()
+		if check {
+			cond = cond.And("version = ?", expected)
+		}
+
+		var updated int64
+		updated, err = cond.Update(map[string]interface{}{})
+		if err != nil {
+			return 0, err
+		}
+		if updated > 0 {
+			_, err = s.Table(table).Where("id = ?", id).Cols("version").Get(&version)
+			return version, err
+		}
+	}
+
+	exists, err := s.Table(table).Where("id = ?", id).Cols("version").Get(&version)
+	if err != nil || !exists {
+		// Entities which don't exist are handled by the caller
+		return 0, err
+	}
+
+	return 0, &ErrVersionConflict{ID: id, Version: version, IfMatch: ifMatch}
+}
@@ -0,0 +1,45 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	for _, tc := range []struct {
+		ifMatch string
+		version int64
+		check   bool
+		valid   bool
+	}{
+		{"", 0, false, true},
+		{"*", 0, false, true},
+		{"3", 3, true, true},
+		{`"3"`, 3, true, true},
+		{`"12-3"`, 3, true, true},
+		{`W/"12-3"`, 3, true, true},
+		{`"abc"`, 0, true, false},
+	} {
+		version, check, valid := parseIfMatch(tc.ifMatch)
+		assert.Equal(t, tc.version, version, tc.ifMatch)
+		assert.Equal(t, tc.check, check, tc.ifMatch)
+		assert.Equal(t, tc.valid, valid, tc.ifMatch)
+	}
+}
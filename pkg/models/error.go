@@ -48,6 +48,35 @@ func (err ErrGenericForbidden) HTTPError() web.HTTPError {
 	return web.HTTPError{HTTPCode: http.StatusForbidden, Code: ErrorCodeGenericForbidden, Message: "You're not allowed to do this."}
 }
 
+// ErrVersionConflict represents an error where an entity was changed since the version a client based its update on.
+type ErrVersionConflict struct {
+	ID      int64
+	Version int64
+	IfMatch string
+}
+
+// IsErrVersionConflict checks if an error is ErrVersionConflict.
+func IsErrVersionConflict(err error) bool {
+	_, ok := err.(*ErrVersionConflict)
+	return ok
+}
+
+func (err *ErrVersionConflict) Error() string {
+	return fmt.Sprintf("Version conflict [ID: %d, Version: %d, If-Match: %s]", err.ID, err.Version, err.IfMatch)
+}
+
+// ErrCodeVersionConflict holds the unique world-error code of this error
+const ErrCodeVersionConflict = 0002
+
+// HTTPError holds the http error description
+func (err *ErrVersionConflict) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusPreconditionFailed,
+		Code:     ErrCodeVersionConflict,
+		Message:  fmt.Sprintf("This was changed in the meantime, the current version is %s.", GetEtag(err.ID, err.Version)),
+	}
+}
+
 // ===================
 // Empty things errors
 // ===================
@@ -1003,6 +1032,169 @@ func (err ErrReminderRelativeToMissing) HTTPError() web.HTTPError {
 	}
 }
 
+// ErrInvalidTaskAggregateGroupBy represents an error where tasks should be grouped by an unknown dimension
+type ErrInvalidTaskAggregateGroupBy struct {
+	GroupBy string
+}
+
+// IsErrInvalidTaskAggregateGroupBy checks if an error is ErrInvalidTaskAggregateGroupBy.
+func IsErrInvalidTaskAggregateGroupBy(err error) bool {
+	_, ok := err.(ErrInvalidTaskAggregateGroupBy)
+	return ok
+}
+
+func (err ErrInvalidTaskAggregateGroupBy) Error() string {
+	return fmt.Sprintf("Task group by %s is invalid", err.GroupBy)
+}
+
+// ErrCodeInvalidTaskAggregateGroupBy holds the unique world-error code of this error
+const ErrCodeInvalidTaskAggregateGroupBy = 4023
+
+// HTTPError holds the http error description
+func (err ErrInvalidTaskAggregateGroupBy) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidTaskAggregateGroupBy,
+		Message:  fmt.Sprintf("Tasks cannot be grouped by %s.", err.GroupBy),
+	}
+}
+
+// ErrInvalidTaskAggregateMetric represents an error where an unknown metric should be calculated for a group of tasks
+type ErrInvalidTaskAggregateMetric struct {
+	Metric string
+}
+
+// IsErrInvalidTaskAggregateMetric checks if an error is ErrInvalidTaskAggregateMetric.
+func IsErrInvalidTaskAggregateMetric(err error) bool {
+	_, ok := err.(ErrInvalidTaskAggregateMetric)
+	return ok
+}
+
+func (err ErrInvalidTaskAggregateMetric) Error() string {
+	return fmt.Sprintf("Task aggregate metric %s is invalid", err.Metric)
+}
+
+// ErrCodeInvalidTaskAggregateMetric holds the unique world-error code of this error
+const ErrCodeInvalidTaskAggregateMetric = 4024
+
+// HTTPError holds the http error description
+func (err ErrInvalidTaskAggregateMetric) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidTaskAggregateMetric,
+		Message:  fmt.Sprintf("The task metric %s is invalid.", err.Metric),
+	}
+}
+
+// ErrInvalidCursor represents an error where a pagination or sync cursor is invalid
+type ErrInvalidCursor struct {
+	Cursor string
+}
+
+// IsErrInvalidCursor checks if an error is ErrInvalidCursor.
+func IsErrInvalidCursor(err error) bool {
+	_, ok := err.(ErrInvalidCursor)
+	return ok
+}
+
+func (err ErrInvalidCursor) Error() string {
+	return fmt.Sprintf("Cursor %s is invalid", err.Cursor)
+}
+
+// ErrCodeInvalidCursor holds the unique world-error code of this error
+const ErrCodeInvalidCursor = 4025
+
+// HTTPError holds the http error description
+func (err ErrInvalidCursor) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidCursor,
+		Message:  "The cursor is invalid. Cursors can only be used with the same sort parameters they were created with.",
+	}
+}
+
+// ErrSyncCursorExpired represents an error where a sync cursor is older than the tombstones which are kept
+type ErrSyncCursorExpired struct {
+	Cursor string
+}
+
+// IsErrSyncCursorExpired checks if an error is ErrSyncCursorExpired.
+func IsErrSyncCursorExpired(err error) bool {
+	_, ok := err.(ErrSyncCursorExpired)
+	return ok
+}
+
+func (err ErrSyncCursorExpired) Error() string {
+	return fmt.Sprintf("Sync cursor %s is expired", err.Cursor)
+}
+
+// ErrCodeSyncCursorExpired holds the unique world-error code of this error
+const ErrCodeSyncCursorExpired = 4028
+
+// HTTPError holds the http error description
+func (err ErrSyncCursorExpired) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusGone,
+		Code:     ErrCodeSyncCursorExpired,
+		Message:  "The last sync is too long ago to know everything which was deleted since then. Please do a full sync without a cursor.",
+	}
+}
+
+// ErrBulkTasksTooMany represents an error where a bulk operation contains more tasks than allowed
+type ErrBulkTasksTooMany struct {
+	Count int
+	Max   int
+}
+
+// IsErrBulkTasksTooMany checks if an error is ErrBulkTasksTooMany.
+func IsErrBulkTasksTooMany(err error) bool {
+	_, ok := err.(ErrBulkTasksTooMany)
+	return ok
+}
+
+func (err ErrBulkTasksTooMany) Error() string {
+	return fmt.Sprintf("Too many tasks for a bulk operation [Count: %d, Max: %d]", err.Count, err.Max)
+}
+
+// ErrCodeBulkTasksTooMany holds the unique world-error code of this error
+const ErrCodeBulkTasksTooMany = 4026
+
+// HTTPError holds the http error description
+func (err ErrBulkTasksTooMany) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeBulkTasksTooMany,
+		Message:  fmt.Sprintf("A bulk operation can contain at most %d tasks.", err.Max),
+	}
+}
+
+// ErrTaskIdentifierDoesNotExist represents an error where no task has or had an identifier
+type ErrTaskIdentifierDoesNotExist struct {
+	Identifier string
+}
+
+// IsErrTaskIdentifierDoesNotExist checks if an error is ErrTaskIdentifierDoesNotExist.
+func IsErrTaskIdentifierDoesNotExist(err error) bool {
+	_, ok := err.(ErrTaskIdentifierDoesNotExist)
+	return ok
+}
+
+func (err ErrTaskIdentifierDoesNotExist) Error() string {
+	return fmt.Sprintf("Task identifier does not exist [Identifier: %s]", err.Identifier)
+}
+
+// ErrCodeTaskIdentifierDoesNotExist holds the unique world-error code of this error
+const ErrCodeTaskIdentifierDoesNotExist = 4027
+
+// HTTPError holds the http error description
+func (err ErrTaskIdentifierDoesNotExist) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusNotFound,
+		Code:     ErrCodeTaskIdentifierDoesNotExist,
+		Message:  fmt.Sprintf("There is no task with the identifier %s.", err.Identifier),
+	}
+}
+
 // ============
 // Team errors
 // ============
@@ -1724,3 +1916,205 @@ func (err ErrInvalidAPITokenPermission) HTTPError() web.HTTPError {
 		Message:  fmt.Sprintf("The permission %s of group %s is invalid.", err.Permission, err.Group),
 	}
 }
+
+// ======
+// Search
+// ======
+
+// ErrInvalidSearchType represents an error where an unknown search result type was requested
+type ErrInvalidSearchType struct {
+	Type string
+}
+
+// IsErrInvalidSearchType checks if an error is ErrInvalidSearchType.
+func IsErrInvalidSearchType(err error) bool {
+	_, ok := err.(*ErrInvalidSearchType)
+	return ok
+}
+
+func (err *ErrInvalidSearchType) Error() string {
+	return fmt.Sprintf("Search type %s is invalid", err.Type)
+}
+
+// ErrCodeInvalidSearchType holds the unique world-error code of this error
+const ErrCodeInvalidSearchType = 15001
+
+// HTTPError holds the http error description
+func (err ErrInvalidSearchType) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidSearchType,
+		Message:  fmt.Sprintf("The search type %s is invalid.", err.Type),
+	}
+}
+
+// =========
+// Migration
+// =========
+
+// ErrInvalidCSVImport represents an error where a csv file or its column mapping can't be imported
+type ErrInvalidCSVImport struct {
+	Reason string
+}
+
+// IsErrInvalidCSVImport checks if an error is ErrInvalidCSVImport.
+func IsErrInvalidCSVImport(err error) bool {
+	_, ok := err.(*ErrInvalidCSVImport)
+	return ok
+}
+
+func (err *ErrInvalidCSVImport) Error() string {
+	return fmt.Sprintf("Invalid csv import: %s", err.Reason)
+}
+
+// ErrCodeInvalidCSVImport holds the unique world-error code of this error
+const ErrCodeInvalidCSVImport = 16001
+
+// HTTPError holds the http error description
+func (err *ErrInvalidCSVImport) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidCSVImport,
+		Message:  "The csv file can't be imported: " + err.Reason,
+	}
+}
+
+// ErrInvalidMigrationFile represents an error where a file can't be imported by a file migrator
+type ErrInvalidMigrationFile struct {
+	Migrator string
+	Reason   string
+}
+
+// IsErrInvalidMigrationFile checks if an error is ErrInvalidMigrationFile.
+func IsErrInvalidMigrationFile(err error) bool {
+	_, ok := err.(*ErrInvalidMigrationFile)
+	return ok
+}
+
+func (err *ErrInvalidMigrationFile) Error() string {
+	return fmt.Sprintf("Invalid %s migration file: %s", err.Migrator, err.Reason)
+}
+
+// ErrCodeInvalidMigrationFile holds the unique world-error code of this error
+const ErrCodeInvalidMigrationFile = 16002
+
+// HTTPError holds the http error description
+func (err *ErrInvalidMigrationFile) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusBadRequest,
+		Code:     ErrCodeInvalidMigrationFile,
+		Message:  "The file can't be imported by the " + err.Migrator + " migration: " + err.Reason,
+	}
+}
+
+// ErrMigrationJobDoesNotExist represents an error where a migration job does not exist
+type ErrMigrationJobDoesNotExist struct {
+	JobID int64
+}
+
+// IsErrMigrationJobDoesNotExist checks if an error is ErrMigrationJobDoesNotExist.
+func IsErrMigrationJobDoesNotExist(err error) bool {
+	_, ok := err.(*ErrMigrationJobDoesNotExist)
+	return ok
+}
+
+func (err *ErrMigrationJobDoesNotExist) Error() string {
+	return fmt.Sprintf("Migration job does not exist [JobID: %d]", err.JobID)
+}
+
+// ErrCodeMigrationJobDoesNotExist holds the unique world-error code of this error
+const ErrCodeMigrationJobDoesNotExist = 16003
+
+// HTTPError holds the http error description
+func (err *ErrMigrationJobDoesNotExist) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusNotFound,
+		Code:     ErrCodeMigrationJobDoesNotExist,
+		Message:  "This migration job does not exist.",
+	}
+}
+
+// ErrMigrationJobCancelled represents an error where a migration job was cancelled while it was running
+type ErrMigrationJobCancelled struct {
+	JobID int64
+}
+
+// IsErrMigrationJobCancelled checks if an error is ErrMigrationJobCancelled.
+func IsErrMigrationJobCancelled(err error) bool {
+	_, ok := err.(*ErrMigrationJobCancelled)
+	return ok
+}
+
+func (err *ErrMigrationJobCancelled) Error() string {
+	return fmt.Sprintf("Migration job was cancelled [JobID: %d]", err.JobID)
+}
+
+// ErrCodeMigrationJobCancelled holds the unique world-error code of this error
+const ErrCodeMigrationJobCancelled = 16004
+
+// HTTPError holds the http error description
+func (err *ErrMigrationJobCancelled) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusConflict,
+		Code:     ErrCodeMigrationJobCancelled,
+		Message:  "This migration job was cancelled.",
+	}
+}
+
+// ErrMigrationJobFinished represents an error where a migration job can't be cancelled because it is already finished
+type ErrMigrationJobFinished struct {
+	JobID int64
+}
+
+// IsErrMigrationJobFinished checks if an error is ErrMigrationJobFinished.
+func IsErrMigrationJobFinished(err error) bool {
+	_, ok := err.(*ErrMigrationJobFinished)
+	return ok
+}
+
+func (err *ErrMigrationJobFinished) Error() string {
+	return fmt.Sprintf("Migration job is already finished [JobID: %d]", err.JobID)
+}
+
+// ErrCodeMigrationJobFinished holds the unique world-error code of this error
+const ErrCodeMigrationJobFinished = 16005
+
+// HTTPError holds the http error description
+func (err *ErrMigrationJobFinished) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusPreconditionFailed,
+		Code:     ErrCodeMigrationJobFinished,
+		Message:  "This migration job is already finished and can't be cancelled anymore.",
+	}
+}
+
+// ==============
+// Calendar Feeds
+// ==============
+
+// ErrCalendarFeedDoesNotExist represents an error where a calendar feed does not exist
+type ErrCalendarFeedDoesNotExist struct {
+	ID int64
+}
+
+// IsErrCalendarFeedDoesNotExist checks if an error is ErrCalendarFeedDoesNotExist.
+func IsErrCalendarFeedDoesNotExist(err error) bool {
+	_, ok := err.(*ErrCalendarFeedDoesNotExist)
+	return ok
+}
+
+func (err *ErrCalendarFeedDoesNotExist) Error() string {
+	return fmt.Sprintf("Calendar feed does not exist [ID: %d]", err.ID)
+}
+
+// ErrCodeCalendarFeedDoesNotExist holds the unique world-error code of this error
+const ErrCodeCalendarFeedDoesNotExist = 17001
+
+// HTTPError holds the http error description
+func (err *ErrCalendarFeedDoesNotExist) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusNotFound,
+		Code:     ErrCodeCalendarFeedDoesNotExist,
+		Message:  "This calendar feed does not exist.",
+	}
+}
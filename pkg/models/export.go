@@ -34,6 +34,7 @@ import (
 	"code.vikunja.io/api/pkg/user"
 	"code.vikunja.io/api/pkg/utils"
 	"code.vikunja.io/api/pkg/version"
+	"code.vikunja.io/web"
 	"xorm.io/xorm"
 )
 
@@ -141,7 +142,22 @@ func exportProjectsAndTasks(s *xorm.Session, u *user.User, wr *zip.Writer) (task
 		return
 	}
 
-	projects := []*ProjectWithTasksAndBuckets{}
+	projects, taskIDs, err := getProjectsWithTasksAndBuckets(s, u, rawProjects)
+	if err != nil {
+		return taskIDs, err
+	}
+
+	data, err := json.Marshal(projects)
+	if err != nil {
+		return taskIDs, err
+	}
+
+	return taskIDs, utils.WriteBytesToZip("data.json", data, wr)
+}
+
+// getProjectsWithTasksAndBuckets loads all tasks with their comments and all buckets of the given projects.
+func getProjectsWithTasksAndBuckets(s *xorm.Session, a web.Auth, rawProjects []*Project) (projects []*ProjectWithTasksAndBuckets, taskIDs []int64, err error) {
+	projects = []*ProjectWithTasksAndBuckets{}
 	projectsMap := make(map[int64]*ProjectWithTasksAndBuckets, len(rawProjects))
 	projectIDs := []int64{}
 	for _, p := range rawProjects {
@@ -153,12 +169,12 @@ func exportProjectsAndTasks(s *xorm.Session, u *user.User, wr *zip.Writer) (task
 		projectIDs = append(projectIDs, p.ID)
 	}
 
-	tasks, _, _, err := getTasksForProjects(s, rawProjects, u, &taskSearchOptions{
+	tasks, _, _, err := getTasksForProjects(s, rawProjects, a, &taskSearchOptions{
 		page:    0,
 		perPage: -1,
 	})
 	if err != nil {
-		return taskIDs, err
+		return nil, nil, err
 	}
 
 	taskMap := make(map[int64]*TaskWithComments, len(tasks))
@@ -167,7 +183,7 @@ func exportProjectsAndTasks(s *xorm.Session, u *user.User, wr *zip.Writer) (task
 			Task: *t,
 		}
 		if _, exists := projectsMap[t.ProjectID]; !exists {
-			log.Debugf("[User Data Export] Project %d does not exist for task %d, omitting", t.ProjectID, t.ID)
+			log.Debugf("[Export] Project %d does not exist for task %d, omitting", t.ProjectID, t.ID)
 			continue
 		}
 		projectsMap[t.ProjectID].Tasks = append(projectsMap[t.ProjectID].Tasks, taskMap[t.ID])
@@ -180,12 +196,12 @@ func exportProjectsAndTasks(s *xorm.Session, u *user.User, wr *zip.Writer) (task
 		In("tasks.project_id", projectIDs).
 		Find(&comments)
 	if err != nil {
-		return
+		return nil, nil, err
 	}
 
 	for _, c := range comments {
 		if _, exists := taskMap[c.TaskID]; !exists {
-			log.Debugf("[User Data Export] Task %d does not exist for comment %d, omitting", c.TaskID, c.ID)
+			log.Debugf("[Export] Task %d does not exist for comment %d, omitting", c.TaskID, c.ID)
 			continue
 		}
 		taskMap[c.TaskID].Comments = append(taskMap[c.TaskID].Comments, c)
@@ -194,23 +210,18 @@ func exportProjectsAndTasks(s *xorm.Session, u *user.User, wr *zip.Writer) (task
 	buckets := []*Bucket{}
 	err = s.In("project_id", projectIDs).Find(&buckets)
 	if err != nil {
-		return
+		return nil, nil, err
 	}
 
 	for _, b := range buckets {
 		if _, exists := projectsMap[b.ProjectID]; !exists {
-			log.Debugf("[User Data Export] Project %d does not exist for bucket %d, omitting", b.ProjectID, b.ID)
+			log.Debugf("[Export] Project %d does not exist for bucket %d, omitting", b.ProjectID, b.ID)
 			continue
 		}
 		projectsMap[b.ProjectID].Buckets = append(projectsMap[b.ProjectID].Buckets, b)
 	}
 
-	data, err := json.Marshal(projects)
-	if err != nil {
-		return taskIDs, err
-	}
-
-	return taskIDs, utils.WriteBytesToZip("data.json", data, wr)
+	return projects, taskIDs, nil
 }
 
 func exportTaskAttachments(s *xorm.Session, wr *zip.Writer, taskIDs []int64) (err error) {
@@ -0,0 +1,118 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"regexp"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+
+	"xorm.io/builder"
+	"xorm.io/xorm/schemas"
+)
+
+const fullTextIndexPrefix = "idx_tasks_fulltext_"
+
+// Postgres text search configurations are plain identifiers. Because the configuration needs to be
+// part of the index definition, it is not passed as a parameter and therefore has to be validated.
+var fullTextSearchLanguageRegex = regexp.MustCompile(`^[a-z_]+$`)
+
+func isFullTextSearchEnabled() bool {
+	return config.DatabaseFullTextSearch.GetBool() && db.Type() == schemas.POSTGRES
+}
+
+// getTaskFullTextDocument returns the expression used to build the search document of a task.
+// Postgres only uses the index if the query uses exactly the same expression as the index definition.
+func getTaskFullTextDocument() string {
+	language := "'" + config.DatabaseFullTextSearchLanguage.GetString() + "'::regconfig"
+	return "setweight(to_tsvector(" + language + ", coalesce(title, '')), 'A') || " +
+		"setweight(to_tsvector(" + language + ", coalesce(description, '')), 'B') || " +
+		"setweight(to_tsvector('simple'::regconfig, \"index\"::text), 'C')"
+}
+
+func getTaskFullTextQuery() string {
+	return "websearch_to_tsquery('" + config.DatabaseFullTextSearchLanguage.GetString() + "'::regconfig, ?)"
+}
+
+func getTaskFullTextSearchCond(search string) builder.Cond {
+	return builder.Expr("("+getTaskFullTextDocument()+") @@ "+getTaskFullTextQuery(), search)
+}
+
+// getTaskFullTextOrderBy returns an order by statement which sorts the best matching tasks first.
+func getTaskFullTextOrderBy() string {
+	return "ts_rank(" + getTaskFullTextDocument() + ", " + getTaskFullTextQuery() + ") DESC, id ASC"
+}
+
+// InitFullTextSearch creates the full text search index for the configured language if it does not exist yet
+// and removes indexes created for other languages.
+func InitFullTextSearch() {
+	if !isFullTextSearchEnabled() {
+		return
+	}
+
+	language := config.DatabaseFullTextSearchLanguage.GetString()
+	if !fullTextSearchLanguageRegex.MatchString(language) {
+		log.Fatalf("Invalid full text search language %s", language)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	exists, err := s.Table("pg_ts_config").Where("cfgname = ?", language).Exist()
+	if err != nil {
+		log.Fatalf("Could not check full text search language: %s", err)
+	}
+	if !exists {
+		log.Fatalf("Full text search language %s is not available in Postgres", language)
+	}
+
+	indexes := []string{}
+	err = s.Table("pg_indexes").
+		Where("tablename = ? AND indexname LIKE ?", "tasks", fullTextIndexPrefix+"%").
+		Cols("indexname").
+		Find(&indexes)
+	if err != nil {
+		log.Fatalf("Could not get full text search indexes: %s", err)
+	}
+
+	indexName := fullTextIndexPrefix + language
+	var indexExists bool
+	for _, index := range indexes {
+		if index == indexName {
+			indexExists = true
+			continue
+		}
+
+		log.Infof("Removing full text search index %s", index)
+		_, err = s.Exec("DROP INDEX IF EXISTS " + index)
+		if err != nil {
+			log.Fatalf("Could not remove full text search index %s: %s", index, err)
+		}
+	}
+
+	if indexExists {
+		return
+	}
+
+	log.Infof("Creating full text search index for %s, this may take a while…", language)
+	_, err = s.Exec("CREATE INDEX " + indexName + " ON tasks USING GIN ((" + getTaskFullTextDocument() + "))")
+	if err != nil {
+		log.Fatalf("Could not create full text search index: %s", err)
+	}
+}
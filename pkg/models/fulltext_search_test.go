@@ -0,0 +1,72 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
+	"xorm.io/xorm/schemas"
+)
+
+func TestFullTextSearch(t *testing.T) {
+	t.Run("search condition", func(t *testing.T) {
+		sql, args, err := builder.ToSQL(getTaskFullTextSearchCond("lorem -ipsum"))
+		require.NoError(t, err)
+		assert.Equal(t, "(setweight(to_tsvector('english'::regconfig, coalesce(title, '')), 'A') || "+
+			"setweight(to_tsvector('english'::regconfig, coalesce(description, '')), 'B') || "+
+			"setweight(to_tsvector('simple'::regconfig, \"index\"::text), 'C')) @@ "+
+			"websearch_to_tsquery('english'::regconfig, ?)", sql)
+		assert.Equal(t, []interface{}{"lorem -ipsum"}, args)
+	})
+	t.Run("configured language", func(t *testing.T) {
+		config.DatabaseFullTextSearchLanguage.Set("german")
+		defer config.DatabaseFullTextSearchLanguage.Set("english")
+
+		assert.Contains(t, getTaskFullTextOrderBy(), "websearch_to_tsquery('german'::regconfig, ?)")
+		assert.Contains(t, getTaskFullTextOrderBy(), "to_tsvector('german'::regconfig, coalesce(title, ''))")
+	})
+	t.Run("only on postgres", func(t *testing.T) {
+		config.DatabaseFullTextSearch.Set(true)
+		defer config.DatabaseFullTextSearch.Set(false)
+
+		assert.Equal(t, db.Type() == schemas.POSTGRES, isFullTextSearchEnabled())
+	})
+	t.Run("falls back to like", func(t *testing.T) {
+		if db.Type() == schemas.POSTGRES {
+			t.Skip("full text search is used on postgres")
+		}
+
+		db.LoadAndAssertFixtures(t)
+		config.DatabaseFullTextSearch.Set(true)
+		defer config.DatabaseFullTextSearch.Set(false)
+
+		s := db.NewSession()
+		defer s.Close()
+
+		tc := &TaskCollection{ProjectID: 1}
+		result, _, _, err := tc.ReadAll(s, &user.User{ID: 1}, "prio", 0, 50)
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+}
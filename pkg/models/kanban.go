@@ -50,6 +50,11 @@ type Bucket struct {
 	// A timestamp when this bucket was last updated. You cannot change this value.
 	Updated time.Time `xorm:"updated not null" json:"updated"`
 
+	// The version of this bucket. It is increased with every change, the bucket's ETag is based on it.
+	Version int64 `xorm:"bigint not null default 0" json:"version"`
+	// If set, the bucket is only updated if its version still matches. Bound from the If-Match header.
+	IfMatch string `xorm:"-" json:"-" header:"If-Match"`
+
 	// The user who initially created the bucket.
 	CreatedBy   *user.User `xorm:"-" json:"created_by" valid:"-"`
 	CreatedByID int64      `xorm:"bigint not null" json:"-"`
@@ -231,6 +236,36 @@ func (b *Bucket) ReadAll(s *xorm.Session, auth web.Auth, search string, page int
 	return buckets, len(buckets), int64(len(buckets)), nil
 }
 
+// ReadOne returns a single bucket without its tasks
+// @Summary Get one kanban bucket
+// @Description Returns a single kanban bucket of a project without its tasks.
+// @tags project
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param projectID path int true "Project Id"
+// @Param bucketID path int true "Bucket Id"
+// @Success 200 {object} models.Bucket "The bucket"
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 404 {object} web.HTTPError "The bucket does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /projects/{projectID}/buckets/{bucketID} [get]
+func (b *Bucket) ReadOne(s *xorm.Session, _ web.Auth) (err error) {
+	bb, err := getBucketByID(s, b.ID)
+	if err != nil {
+		return
+	}
+
+	users, err := getUsersOrLinkSharesFromIDs(s, []int64{bb.CreatedByID})
+	if err != nil {
+		return
+	}
+	bb.CreatedBy = users[bb.CreatedByID]
+
+	*b = *bb
+	return
+}
+
 // Create creates a new bucket
 // @Summary Create a new bucket
 // @Description Creates a new kanban bucket on a project.
@@ -272,12 +307,19 @@ func (b *Bucket) Create(s *xorm.Session, a web.Auth) (err error) {
 // @Param projectID path int true "Project Id"
 // @Param bucketID path int true "Bucket Id"
 // @Param bucket body models.Bucket true "The bucket object"
+// @Param If-Match header string false "The ETag or version of the bucket the changes are based on. If the bucket was changed since then, it is not updated."
 // @Success 200 {object} models.Bucket "The created bucket object."
 // @Failure 400 {object} web.HTTPError "Invalid bucket object provided."
 // @Failure 404 {object} web.HTTPError "The bucket does not exist."
+// @Failure 412 {object} models.Bucket "The bucket was changed in the meantime. Returns the current bucket."
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /projects/{projectID}/buckets/{bucketID} [post]
 func (b *Bucket) Update(s *xorm.Session, _ web.Auth) (err error) {
+	b.Version, err = bumpVersion(s, "buckets", b.ID, b.IfMatch)
+	if err != nil {
+		return
+	}
+
 	_, err = s.
 		Where("id = ?", b.ID).
 		Cols(
@@ -322,6 +364,11 @@ func (b *Bucket) Delete(s *xorm.Session, _ web.Auth) (err error) {
 		return
 	}
 
+	err = addBucketTombstone(s, b)
+	if err != nil {
+		return
+	}
+
 	// Get the default bucket
 	p, err := GetProjectSimpleByID(s, b.ProjectID)
 	if err != nil {
@@ -336,6 +383,7 @@ func (b *Bucket) Delete(s *xorm.Session, _ web.Auth) (err error) {
 	_, err = s.
 		Where("bucket_id = ?", b.ID).
 		Cols("bucket_id").
+		Incr("version").
 		Update(&Task{BucketID: defaultBucketID})
 	return
 }
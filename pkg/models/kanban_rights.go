@@ -27,6 +27,19 @@ func (b *Bucket) CanCreate(s *xorm.Session, a web.Auth) (bool, error) {
 	return l.CanWrite(s, a)
 }
 
+// CanRead checks if a user can read a single bucket
+func (b *Bucket) CanRead(s *xorm.Session, a web.Auth) (bool, int, error) {
+	bb, err := getBucketByID(s, b.ID)
+	if err != nil {
+		return false, 0, err
+	}
+	if b.ProjectID != 0 && bb.ProjectID != b.ProjectID {
+		return false, 0, ErrBucketDoesNotBelongToProject{BucketID: b.ID, ProjectID: b.ProjectID}
+	}
+	l := &Project{ID: bb.ProjectID}
+	return l.CanRead(s, a)
+}
+
 // CanUpdate checks if a user can update an existing bucket
 func (b *Bucket) CanUpdate(s *xorm.Session, a web.Auth) (bool, error) {
 	return b.canDoBucket(s, a)
@@ -217,4 +217,45 @@ func TestBucket_Update(t *testing.T) {
 
 		testAndAssertBucketUpdate(t, b, s)
 	})
+	t.Run("with If-Match", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		b := &Bucket{
+			ID:      1,
+			Title:   "New Name",
+			IfMatch: "0",
+		}
+
+		testAndAssertBucketUpdate(t, b, s)
+		assert.Equal(t, int64(1), b.Version)
+	})
+	t.Run("with outdated If-Match", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		b := &Bucket{
+			ID:      1,
+			Title:   "New Name",
+			IfMatch: GetEtag(1, 5),
+		}
+		err := b.Update(s, &user.User{ID: 1})
+		assert.Error(t, err)
+		assert.True(t, IsErrVersionConflict(err))
+	})
+}
+
+func TestBucket_ReadOne(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	b := &Bucket{ID: 1}
+	err := b.ReadOne(s, &user.User{ID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "testbucket1", b.Title)
+	assert.Equal(t, int64(1), b.ProjectID)
+	assert.Equal(t, int64(1), b.CreatedBy.ID)
 }
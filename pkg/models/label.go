@@ -130,6 +130,17 @@ func (l *Label) Update(s *xorm.Session, a web.Auth) (err error) {
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /labels/{id} [delete]
 func (l *Label) Delete(s *xorm.Session, _ web.Auth) (err error) {
+	label, err := getLabelByIDSimple(s, l.ID)
+	if err != nil && !IsErrLabelDoesNotExist(err) {
+		return err
+	}
+	if err == nil {
+		err = addLabelTombstones(s, label)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = s.ID(l.ID).Delete(&Label{})
 	return err
 }
@@ -66,7 +66,12 @@ func (LabelTask) TableName() string {
 // @Router /tasks/{task}/labels/{label} [delete]
 func (lt *LabelTask) Delete(s *xorm.Session, _ web.Auth) (err error) {
 	_, err = s.Delete(&LabelTask{LabelID: lt.LabelID, TaskID: lt.TaskID})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// There are no events for labels on tasks, so the index is updated right away
+	return updateTaskInSearchIndex(s, lt.TaskID)
 }
 
 // Create adds a label to a task
@@ -101,7 +106,11 @@ func (lt *LabelTask) Create(s *xorm.Session, _ web.Auth) (err error) {
 	}
 
 	err = updateProjectByTaskID(s, lt.TaskID)
-	return
+	if err != nil {
+		return err
+	}
+
+	return updateTaskInSearchIndex(s, lt.TaskID)
 }
 
 // ReadAll gets all labels on a task
@@ -286,7 +295,10 @@ func (t *Task) UpdateTaskLabels(s *xorm.Session, creator web.Auth, labels []*Lab
 	if len(labels) == 0 && len(t.Labels) > 0 {
 		_, err = s.Where("task_id = ?", t.ID).
 			Delete(LabelTask{})
-		return err
+		if err != nil {
+			return err
+		}
+		return updateTaskInSearchIndex(s, t.ID)
 	}
 
 	// If we didn't change anything (from 0 to zero) don't do anything.
@@ -366,7 +378,11 @@ func (t *Task) UpdateTaskLabels(s *xorm.Session, creator web.Auth, labels []*Lab
 	}
 
 	err = updateProjectLastUpdated(s, &Project{ID: t.ProjectID})
-	return
+	if err != nil {
+		return err
+	}
+
+	return updateTaskInSearchIndex(s, t.ID)
 }
 
 // LabelTaskBulk is a helper struct to update a bunch of labels at once
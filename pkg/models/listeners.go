@@ -66,6 +66,16 @@ func RegisterListeners() {
 		events.RegisterListener((&TaskDeletedEvent{}).Name(), &RemoveTaskFromTypesense{})
 		events.RegisterListener((&TaskCreatedEvent{}).Name(), &AddTaskToTypesense{})
 	}
+	if isSearchIndexEnabled() {
+		events.RegisterListener((&TaskCreatedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskUpdatedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskCommentCreatedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskCommentUpdatedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskCommentDeletedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskAttachmentCreatedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskAttachmentDeletedEvent{}).Name(), &UpdateTaskInSearchIndex{})
+		events.RegisterListener((&TaskDeletedEvent{}).Name(), &RemoveTaskFromSearchIndex{})
+	}
 	if config.WebhooksEnabled.GetBool() {
 		RegisterEventForWebhook(&TaskCreatedEvent{})
 		RegisterEventForWebhook(&TaskUpdatedEvent{})
@@ -220,7 +230,11 @@ func (s *SendTaskCommentNotification) Handle(msg *message.Message) (err error) {
 		}
 	}
 
-	return
+	return notifyProjectChat(sess, event.Task.ProjectID, &TaskCommentNotification{
+		Doer:    event.Doer,
+		Task:    event.Task,
+		Comment: event.Comment,
+	})
 }
 
 // HandleTaskCommentEditMentions  represents a listener
@@ -301,7 +315,11 @@ func (s *SendTaskAssignedNotification) Handle(msg *message.Message) (err error)
 		}
 	}
 
-	return nil
+	return notifyProjectChat(sess, task.ProjectID, &TaskAssignedNotification{
+		Doer:     event.Doer,
+		Task:     &task,
+		Assignee: event.Assignee,
+	})
 }
 
 // SendTaskDeletedNotification  represents a listener
@@ -352,7 +370,10 @@ func (s *SendTaskDeletedNotification) Handle(msg *message.Message) (err error) {
 		}
 	}
 
-	return nil
+	return notifyProjectChat(sess, event.Task.ProjectID, &TaskDeletedNotification{
+		Doer: event.Doer,
+		Task: event.Task,
+	})
 }
 
 type SubscribeAssigneeToTask struct {
@@ -465,7 +486,7 @@ func (s *HandleTaskUpdateLastUpdated) Handle(msg *message.Message) (err error) {
 		return err
 	}
 
-	task, is := event["Task"].(map[string]interface{})
+	task, is := event["task"].(map[string]interface{})
 	if !is {
 		log.Errorf("Event payload does not contain task ID")
 		return
@@ -625,7 +646,15 @@ func (s *SendProjectCreatedNotification) Handle(msg *message.Message) (err error
 		}
 	}
 
-	return nil
+	if event.Project.ParentProjectID == 0 {
+		return nil
+	}
+
+	// A new project has no destinations of its own yet, so the parent project's destinations are notified instead.
+	return notifyProjectChat(sess, event.Project.ParentProjectID, &ProjectCreatedNotification{
+		Doer:    event.Doer,
+		Project: event.Project,
+	})
 }
 
 // WebhookListener represents a listener
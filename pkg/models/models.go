@@ -60,6 +60,9 @@ func GetTables() []interface{} {
 		&Favorite{},
 		&APIToken{},
 		&TypesenseSync{},
+		&Tombstone{},
+		&TaskIdentifierRedirect{},
+		&CalendarFeed{},
 	}
 }
 
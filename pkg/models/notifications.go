@@ -23,13 +23,48 @@ import (
 	"strings"
 	"time"
 
-	"code.vikunja.io/api/pkg/utils"
-
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/i18n"
+	"code.vikunja.io/api/pkg/log"
 	"code.vikunja.io/api/pkg/notifications"
 	"code.vikunja.io/api/pkg/user"
 )
 
+// ConfigurableNotificationNames returns the names of all notifications users can configure
+// per channel and project in their notification preferences.
+func ConfigurableNotificationNames() []string {
+	return []string{
+		(&TaskCommentNotification{}).Name(),
+		(&TaskAssignedNotification{}).Name(),
+		(&TaskDeletedNotification{}).Name(),
+		(&ProjectCreatedNotification{}).Name(),
+		(&TeamMemberAddedNotification{}).Name(),
+		(&UserMentionedInTaskNotification{}).Name(),
+	}
+}
+
+func getDigestGroupForTask(task *Task) *notifications.DigestGroup {
+	group := &notifications.DigestGroup{
+		ProjectID: task.ProjectID,
+		TaskID:    task.ID,
+		TaskTitle: task.Title,
+		TaskURL:   task.GetFrontendURL(),
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	project, err := GetProjectSimpleByID(s, task.ProjectID)
+	if err != nil {
+		log.Errorf("Could not get project %d for notification digest: %s", task.ProjectID, err)
+		return group
+	}
+
+	group.ProjectTitle = project.Title
+	return group
+}
+
 // ReminderDueNotification represents a ReminderDueNotification notification
 type ReminderDueNotification struct {
 	User    *user.User `json:"user"`
@@ -38,14 +73,21 @@ type ReminderDueNotification struct {
 }
 
 // ToMail returns the mail notification for ReminderDueNotification
-func (n *ReminderDueNotification) ToMail() *notifications.Mail {
-	return notifications.NewMail().
+func (n *ReminderDueNotification) ToMail(lang string) *notifications.Mail {
+	mail := notifications.NewMail().
+		Template("task.reminder").
 		To(n.User.Email).
-		Subject(`Reminder for "`+n.Task.Title+`" (`+n.Project.Title+`)`).
-		Greeting("Hi "+n.User.GetName()+",").
-		Line(`This is a friendly reminder of the task "`+n.Task.Title+`" (`+n.Project.Title+`).`).
-		Action("Open Task", config.ServiceFrontendurl.GetString()+"tasks/"+strconv.FormatInt(n.Task.ID, 10)).
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.task.reminder.subject", n.Task.Title, n.Project.Title)).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.task.reminder.message", n.Task.Title, n.Project.Title))
+
+	if !n.Task.DueDate.IsZero() {
+		mail.Line(i18n.T(lang, "notifications.task.reminder.due", i18n.FormatDateTime(lang, n.Task.DueDate, n.User.Timezone)))
+	}
+
+	return mail.
+		Action(i18n.T(lang, "notifications.common.actions.open_task"), config.ServiceFrontendurl.GetString()+"tasks/"+strconv.FormatInt(n.Task.ID, 10)).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the ReminderDueNotification notification in a format which can be saved in the db
@@ -53,11 +95,25 @@ func (n *ReminderDueNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the ReminderDueNotification notification as web push message
+func (n *ReminderDueNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: `Reminder for "` + n.Task.Title + `"`,
+		Body:  `This is a friendly reminder of the task "` + n.Task.Title + `" (` + n.Project.Title + `).`,
+		URL:   n.Task.GetFrontendURL(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *ReminderDueNotification) Name() string {
 	return ""
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *ReminderDueNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
 // TaskCommentNotification represents a TaskCommentNotification notification
 type TaskCommentNotification struct {
 	Doer      *user.User   `json:"doer"`
@@ -70,16 +126,26 @@ func (n *TaskCommentNotification) SubjectID() int64 {
 	return n.Comment.ID
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *TaskCommentNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
+// DigestGroup returns the project and task the notification is grouped under in a digest mail
+func (n *TaskCommentNotification) DigestGroup() *notifications.DigestGroup {
+	return getDigestGroupForTask(n.Task)
+}
+
 // ToMail returns the mail notification for TaskCommentNotification
-func (n *TaskCommentNotification) ToMail() *notifications.Mail {
+func (n *TaskCommentNotification) ToMail(lang string) *notifications.Mail {
 
 	mail := notifications.NewMail().
 		From(n.Doer.GetNameAndFromEmail())
 
-	subject := "Re: " + n.Task.Title
+	subject := i18n.T(lang, "notifications.task.comment.subject", n.Task.Title)
 	if n.Mentioned {
-		subject = n.Doer.GetName() + ` mentioned you in a comment in "` + n.Task.Title + `"`
-		mail.Line("**" + n.Doer.GetName() + "** mentioned you in a comment:")
+		subject = i18n.T(lang, "notifications.task.comment.mentioned_subject", n.Doer.GetName(), n.Task.Title)
+		mail.Line(i18n.T(lang, "notifications.task.comment.mentioned_message", n.Doer.GetName()))
 	}
 
 	mail.Subject(subject)
@@ -90,7 +156,7 @@ func (n *TaskCommentNotification) ToMail() *notifications.Mail {
 	}
 
 	return mail.
-		Action("View Task", n.Task.GetFrontendURL())
+		Action(i18n.T(lang, "notifications.common.actions.view_task"), n.Task.GetFrontendURL())
 }
 
 // ToDB returns the TaskCommentNotification notification in a format which can be saved in the db
@@ -98,6 +164,20 @@ func (n *TaskCommentNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the TaskCommentNotification notification as web push message
+func (n *TaskCommentNotification) ToPush() *notifications.Push {
+	title := n.Doer.GetName() + ` commented on "` + n.Task.Title + `"`
+	if n.Mentioned {
+		title = n.Doer.GetName() + ` mentioned you in a comment in "` + n.Task.Title + `"`
+	}
+
+	return &notifications.Push{
+		Title: title,
+		Body:  n.Comment.Comment,
+		URL:   n.Task.GetFrontendURL(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *TaskCommentNotification) Name() string {
 	return "task.comment"
@@ -111,11 +191,11 @@ type TaskAssignedNotification struct {
 }
 
 // ToMail returns the mail notification for TaskAssignedNotification
-func (n *TaskAssignedNotification) ToMail() *notifications.Mail {
+func (n *TaskAssignedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject(n.Task.Title+"("+n.Task.GetFullIdentifier()+")"+" has been assigned to "+n.Assignee.GetName()).
-		Line(n.Doer.GetName()+" has assigned this task to "+n.Assignee.GetName()+".").
-		Action("View Task", n.Task.GetFrontendURL())
+		Subject(i18n.T(lang, "notifications.task.assigned.subject", n.Task.Title, n.Task.GetFullIdentifier(), n.Assignee.GetName())).
+		Line(i18n.T(lang, "notifications.task.assigned.message", n.Doer.GetName(), n.Assignee.GetName())).
+		Action(i18n.T(lang, "notifications.common.actions.view_task"), n.Task.GetFrontendURL())
 }
 
 // ToDB returns the TaskAssignedNotification notification in a format which can be saved in the db
@@ -123,11 +203,30 @@ func (n *TaskAssignedNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the TaskAssignedNotification notification as web push message
+func (n *TaskAssignedNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: n.Task.Title + " (" + n.Task.GetFullIdentifier() + ")" + " has been assigned to " + n.Assignee.GetName(),
+		Body:  n.Doer.GetName() + " has assigned this task to " + n.Assignee.GetName() + ".",
+		URL:   n.Task.GetFrontendURL(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *TaskAssignedNotification) Name() string {
 	return "task.assigned"
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *TaskAssignedNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
+// DigestGroup returns the project and task the notification is grouped under in a digest mail
+func (n *TaskAssignedNotification) DigestGroup() *notifications.DigestGroup {
+	return getDigestGroupForTask(n.Task)
+}
+
 // TaskDeletedNotification represents a TaskDeletedNotification notification
 type TaskDeletedNotification struct {
 	Doer *user.User `json:"doer"`
@@ -135,10 +234,10 @@ type TaskDeletedNotification struct {
 }
 
 // ToMail returns the mail notification for TaskDeletedNotification
-func (n *TaskDeletedNotification) ToMail() *notifications.Mail {
+func (n *TaskDeletedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject(n.Task.Title + " (" + n.Task.GetFullIdentifier() + ")" + " has been deleted").
-		Line(n.Doer.GetName() + " has deleted the task " + n.Task.Title + " (" + n.Task.GetFullIdentifier() + ")")
+		Subject(i18n.T(lang, "notifications.task.deleted.subject", n.Task.Title, n.Task.GetFullIdentifier())).
+		Line(i18n.T(lang, "notifications.task.deleted.message", n.Doer.GetName(), n.Task.Title, n.Task.GetFullIdentifier()))
 }
 
 // ToDB returns the TaskDeletedNotification notification in a format which can be saved in the db
@@ -146,11 +245,24 @@ func (n *TaskDeletedNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the TaskDeletedNotification notification as web push message
+func (n *TaskDeletedNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: n.Task.Title + " (" + n.Task.GetFullIdentifier() + ")" + " has been deleted",
+		Body:  n.Doer.GetName() + " has deleted the task " + n.Task.Title + " (" + n.Task.GetFullIdentifier() + ")",
+	}
+}
+
 // Name returns the name of the notification
 func (n *TaskDeletedNotification) Name() string {
 	return "task.deleted"
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *TaskDeletedNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
 // ProjectCreatedNotification represents a ProjectCreatedNotification notification
 type ProjectCreatedNotification struct {
 	Doer    *user.User `json:"doer"`
@@ -158,11 +270,11 @@ type ProjectCreatedNotification struct {
 }
 
 // ToMail returns the mail notification for ProjectCreatedNotification
-func (n *ProjectCreatedNotification) ToMail() *notifications.Mail {
+func (n *ProjectCreatedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject(n.Doer.GetName()+` created the project "`+n.Project.Title+`"`).
-		Line(n.Doer.GetName()+` created the project "`+n.Project.Title+`"`).
-		Action("View Project", config.ServiceFrontendurl.GetString()+"projects/")
+		Subject(i18n.T(lang, "notifications.project.created.subject", n.Doer.GetName(), n.Project.Title)).
+		Line(i18n.T(lang, "notifications.project.created.message", n.Doer.GetName(), n.Project.Title)).
+		Action(i18n.T(lang, "notifications.common.actions.view_project"), config.ServiceFrontendurl.GetString()+"projects/")
 }
 
 // ToDB returns the ProjectCreatedNotification notification in a format which can be saved in the db
@@ -170,11 +282,25 @@ func (n *ProjectCreatedNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the ProjectCreatedNotification notification as web push message
+func (n *ProjectCreatedNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: n.Doer.GetName() + ` created the project "` + n.Project.Title + `"`,
+		Body:  n.Doer.GetName() + ` created the project "` + n.Project.Title + `"`,
+		URL:   config.ServiceFrontendurl.GetString() + "projects/" + strconv.FormatInt(n.Project.ID, 10),
+	}
+}
+
 // Name returns the name of the notification
 func (n *ProjectCreatedNotification) Name() string {
 	return "project.created"
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *ProjectCreatedNotification) ProjectID() int64 {
+	return n.Project.ID
+}
+
 // TeamMemberAddedNotification represents a TeamMemberAddedNotification notification
 type TeamMemberAddedNotification struct {
 	Member *user.User `json:"member"`
@@ -183,13 +309,13 @@ type TeamMemberAddedNotification struct {
 }
 
 // ToMail returns the mail notification for TeamMemberAddedNotification
-func (n *TeamMemberAddedNotification) ToMail() *notifications.Mail {
+func (n *TeamMemberAddedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject(n.Doer.GetName()+" added you to the "+n.Team.Name+" team in Vikunja").
+		Subject(i18n.T(lang, "notifications.team.member_added.subject", n.Doer.GetName(), n.Team.Name)).
 		From(n.Doer.GetNameAndFromEmail()).
-		Greeting("Hi "+n.Member.GetName()+",").
-		Line(n.Doer.GetName()+" has just added you to the "+n.Team.Name+" team in Vikunja.").
-		Action("View Team", config.ServiceFrontendurl.GetString()+"teams/"+strconv.FormatInt(n.Team.ID, 10)+"/edit")
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.Member.GetName())).
+		Line(i18n.T(lang, "notifications.team.member_added.message", n.Doer.GetName(), n.Team.Name)).
+		Action(i18n.T(lang, "notifications.common.actions.view_team"), config.ServiceFrontendurl.GetString()+"teams/"+strconv.FormatInt(n.Team.ID, 10)+"/edit")
 }
 
 // ToDB returns the TeamMemberAddedNotification notification in a format which can be saved in the db
@@ -197,6 +323,15 @@ func (n *TeamMemberAddedNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the TeamMemberAddedNotification notification as web push message
+func (n *TeamMemberAddedNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: n.Doer.GetName() + " added you to the " + n.Team.Name + " team",
+		Body:  n.Doer.GetName() + " has just added you to the " + n.Team.Name + " team in Vikunja.",
+		URL:   config.ServiceFrontendurl.GetString() + "teams/" + strconv.FormatInt(n.Team.ID, 10) + "/edit",
+	}
+}
+
 // Name returns the name of the notification
 func (n *TeamMemberAddedNotification) Name() string {
 	return "team.member.added"
@@ -210,14 +345,15 @@ type UndoneTaskOverdueNotification struct {
 }
 
 // ToMail returns the mail notification for UndoneTaskOverdueNotification
-func (n *UndoneTaskOverdueNotification) ToMail() *notifications.Mail {
+func (n *UndoneTaskOverdueNotification) ToMail(lang string) *notifications.Mail {
 	until := time.Until(n.Task.DueDate).Round(1*time.Hour) * -1
 	return notifications.NewMail().
-		Subject(`Task "`+n.Task.Title+`" (`+n.Project.Title+`) is overdue`).
-		Greeting("Hi "+n.User.GetName()+",").
-		Line(`This is a friendly reminder of the task "`+n.Task.Title+`" (`+n.Project.Title+`) which is overdue since `+utils.HumanizeDuration(until)+` and not yet done.`).
-		Action("Open Task", config.ServiceFrontendurl.GetString()+"tasks/"+strconv.FormatInt(n.Task.ID, 10)).
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.task.overdue.subject", n.Task.Title, n.Project.Title)).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.task.overdue.message", n.Task.Title, n.Project.Title, i18n.HumanizeDuration(lang, until))).
+		Line(i18n.T(lang, "notifications.task.overdue.due", i18n.FormatDateTime(lang, n.Task.DueDate, n.User.Timezone))).
+		Action(i18n.T(lang, "notifications.common.actions.open_task"), config.ServiceFrontendurl.GetString()+"tasks/"+strconv.FormatInt(n.Task.ID, 10)).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the UndoneTaskOverdueNotification notification in a format which can be saved in the db
@@ -225,11 +361,26 @@ func (n *UndoneTaskOverdueNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the UndoneTaskOverdueNotification notification as web push message
+func (n *UndoneTaskOverdueNotification) ToPush() *notifications.Push {
+	until := time.Until(n.Task.DueDate).Round(1*time.Hour) * -1
+	return &notifications.Push{
+		Title: `Task "` + n.Task.Title + `" is overdue`,
+		Body:  `The task "` + n.Task.Title + `" (` + n.Project.Title + `) is overdue since ` + i18n.HumanizeDuration(i18n.DefaultLanguage, until) + ` and not yet done.`,
+		URL:   n.Task.GetFrontendURL(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *UndoneTaskOverdueNotification) Name() string {
 	return "task.undone.overdue"
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *UndoneTaskOverdueNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
 // UndoneTasksOverdueNotification represents a UndoneTasksOverdueNotification notification
 type UndoneTasksOverdueNotification struct {
 	User     *user.User
@@ -238,7 +389,7 @@ type UndoneTasksOverdueNotification struct {
 }
 
 // ToMail returns the mail notification for UndoneTasksOverdueNotification
-func (n *UndoneTasksOverdueNotification) ToMail() *notifications.Mail {
+func (n *UndoneTasksOverdueNotification) ToMail(lang string) *notifications.Mail {
 
 	sortedTasks := make([]*Task, 0, len(n.Tasks))
 	for _, task := range n.Tasks {
@@ -252,16 +403,22 @@ func (n *UndoneTasksOverdueNotification) ToMail() *notifications.Mail {
 	overdueLine := ""
 	for _, task := range sortedTasks {
 		until := time.Until(task.DueDate).Round(1*time.Hour) * -1
-		overdueLine += `* [` + task.Title + `](` + config.ServiceFrontendurl.GetString() + "tasks/" + strconv.FormatInt(task.ID, 10) + `) (` + n.Projects[task.ProjectID].Title + `), overdue since ` + utils.HumanizeDuration(until) + "\n"
+		overdueLine += i18n.T(lang, "notifications.task.overdue.multiple_task",
+			task.Title,
+			config.ServiceFrontendurl.GetString()+"tasks/"+strconv.FormatInt(task.ID, 10),
+			n.Projects[task.ProjectID].Title,
+			i18n.HumanizeDuration(lang, until),
+		) + "\n"
 	}
 
 	return notifications.NewMail().
-		Subject(`Your overdue tasks`).
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("You have the following overdue tasks:").
+		Template("task.undone.overdue.multiple").
+		Subject(i18n.T(lang, "notifications.task.overdue.multiple_subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.task.overdue.multiple_message")).
 		Line(overdueLine).
-		Action("Open Vikunja", config.ServiceFrontendurl.GetString()).
-		Line("Have a nice day!")
+		Action(i18n.T(lang, "notifications.common.actions.open_vikunja"), config.ServiceFrontendurl.GetString()).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the UndoneTasksOverdueNotification notification in a format which can be saved in the db
@@ -269,6 +426,15 @@ func (n *UndoneTasksOverdueNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the UndoneTasksOverdueNotification notification as web push message
+func (n *UndoneTasksOverdueNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: "Your overdue tasks",
+		Body:  "You have " + strconv.Itoa(len(n.Tasks)) + " overdue tasks.",
+		URL:   config.ServiceFrontendurl.GetString(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *UndoneTasksOverdueNotification) Name() string {
 	return "task.undone.overdue"
@@ -285,17 +451,22 @@ func (n *UserMentionedInTaskNotification) SubjectID() int64 {
 	return n.Task.ID
 }
 
+// ProjectID returns the id of the project the notification is about
+func (n *UserMentionedInTaskNotification) ProjectID() int64 {
+	return n.Task.ProjectID
+}
+
 // ToMail returns the mail notification for UserMentionedInTaskNotification
-func (n *UserMentionedInTaskNotification) ToMail() *notifications.Mail {
-	subject := n.Doer.GetName() + ` mentioned you in a new task "` + n.Task.Title + `"`
+func (n *UserMentionedInTaskNotification) ToMail(lang string) *notifications.Mail {
+	subject := i18n.T(lang, "notifications.task.mentioned.subject", n.Doer.GetName(), n.Task.Title)
 	if n.IsNew {
-		subject = n.Doer.GetName() + ` mentioned you in a task "` + n.Task.Title + `"`
+		subject = i18n.T(lang, "notifications.task.mentioned.subject_new", n.Doer.GetName(), n.Task.Title)
 	}
 
 	mail := notifications.NewMail().
 		From(n.Doer.GetNameAndFromEmail()).
 		Subject(subject).
-		Line("**" + n.Doer.GetName() + "** mentioned you in a task:")
+		Line(i18n.T(lang, "notifications.task.mentioned.message", n.Doer.GetName()))
 
 	lines := bufio.NewScanner(strings.NewReader(n.Task.Description))
 	for lines.Scan() {
@@ -303,7 +474,7 @@ func (n *UserMentionedInTaskNotification) ToMail() *notifications.Mail {
 	}
 
 	return mail.
-		Action("View Task", n.Task.GetFrontendURL())
+		Action(i18n.T(lang, "notifications.common.actions.view_task"), n.Task.GetFrontendURL())
 }
 
 // ToDB returns the UserMentionedInTaskNotification notification in a format which can be saved in the db
@@ -311,6 +482,15 @@ func (n *UserMentionedInTaskNotification) ToDB() interface{} {
 	return n
 }
 
+// ToPush returns the UserMentionedInTaskNotification notification as web push message
+func (n *UserMentionedInTaskNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: n.Doer.GetName() + ` mentioned you in the task "` + n.Task.Title + `"`,
+		Body:  n.Task.Description,
+		URL:   n.Task.GetFrontendURL(),
+	}
+}
+
 // Name returns the name of the notification
 func (n *UserMentionedInTaskNotification) Name() string {
 	return "task.mentioned"
@@ -322,14 +502,14 @@ type DataExportReadyNotification struct {
 }
 
 // ToMail returns the mail notification for DataExportReadyNotification
-func (n *DataExportReadyNotification) ToMail() *notifications.Mail {
+func (n *DataExportReadyNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Your Vikunja Data Export is ready").
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("Your Vikunja Data Export is ready for you to download. Click the button below to download it:").
-		Action("Download", config.ServiceFrontendurl.GetString()+"user/export/download").
-		Line("The download will be available for the next 7 days.").
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.data_export.ready.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.data_export.ready.message")).
+		Action(i18n.T(lang, "notifications.data_export.ready.action"), config.ServiceFrontendurl.GetString()+"user/export/download").
+		Line(i18n.T(lang, "notifications.data_export.ready.availability")).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the DataExportReadyNotification notification in a format which can be saved in the db
@@ -337,6 +517,15 @@ func (n *DataExportReadyNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the DataExportReadyNotification notification as web push message
+func (n *DataExportReadyNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: "Your Vikunja Data Export is ready",
+		Body:  "The download will be available for the next 7 days.",
+		URL:   config.ServiceFrontendurl.GetString() + "user/export/download",
+	}
+}
+
 // Name returns the name of the notification
 func (n *DataExportReadyNotification) Name() string {
 	return "data.export.ready"
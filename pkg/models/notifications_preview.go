@@ -0,0 +1,84 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/api/pkg/user"
+)
+
+// GetMailPreviewNotifications returns one notification of every type which sends a mail,
+// filled with fixture data. They are used to preview mail templates without sending mails.
+func GetMailPreviewNotifications() []notifications.Notification {
+	now := time.Now()
+
+	doer := &user.User{ID: 1, Username: "jane", Name: "Jane Doe", Email: "jane@example.com"}
+	recipient := &user.User{ID: 2, Username: "john", Name: "John Doe", Email: "john@example.com"}
+	project := &Project{ID: 1, Title: "Website relaunch", Identifier: "WEB"}
+	otherProject := &Project{ID: 2, Title: "Marketing"}
+	task := &Task{
+		ID:         1,
+		Title:      "Write the release announcement",
+		Index:      42,
+		Identifier: "WEB-42",
+		ProjectID:  project.ID,
+		DueDate:    now.Add(-26 * time.Hour),
+	}
+	otherTask := &Task{
+		ID:        2,
+		Title:     "Plan the launch campaign",
+		Index:     7,
+		ProjectID: otherProject.ID,
+		DueDate:   now.Add(-3 * time.Hour),
+	}
+	comment := &TaskComment{
+		ID:       1,
+		Comment:  "Looks good to me, @john can you take a last look?",
+		Author:   doer,
+		AuthorID: doer.ID,
+		TaskID:   task.ID,
+	}
+	team := &Team{ID: 1, Name: "Web team"}
+
+	return []notifications.Notification{
+		&ReminderDueNotification{User: recipient, Task: task, Project: project},
+		&TaskCommentNotification{Doer: doer, Task: task, Comment: comment},
+		&TaskAssignedNotification{Doer: doer, Task: task, Assignee: recipient},
+		&TaskDeletedNotification{Doer: doer, Task: task},
+		&ProjectCreatedNotification{Doer: doer, Project: project},
+		&TeamMemberAddedNotification{Doer: doer, Member: recipient, Team: team},
+		&UndoneTaskOverdueNotification{User: recipient, Task: task, Project: project},
+		&UndoneTasksOverdueNotification{
+			User:     recipient,
+			Tasks:    map[int64]*Task{task.ID: task, otherTask.ID: otherTask},
+			Projects: map[int64]*Project{project.ID: project, otherProject.ID: otherProject},
+		},
+		&UserMentionedInTaskNotification{Doer: doer, Task: task},
+		&DataExportReadyNotification{User: recipient},
+		&user.EmailConfirmNotification{User: recipient, IsNew: true, ConfirmToken: "preview-token"},
+		&user.PasswordChangedNotification{User: recipient},
+		&user.ResetPasswordNotification{User: recipient, Token: &user.Token{Token: "preview-token"}},
+		&user.InvalidTOTPNotification{User: recipient},
+		&user.PasswordAccountLockedAfterInvalidTOTOPNotification{User: recipient},
+		&user.FailedLoginAttemptNotification{User: recipient},
+		&user.AccountDeletionConfirmNotification{User: recipient, ConfirmToken: "preview-token"},
+		&user.AccountDeletionNotification{User: recipient, NotificationNumber: 2},
+		&user.AccountDeletedNotification{User: recipient},
+	}
+}
@@ -0,0 +1,40 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/notifications"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMailPreviewNotifications(t *testing.T) {
+	templates := make(map[string]bool)
+	for _, n := range GetMailPreviewNotifications() {
+		template, opts, err := notifications.RenderNotificationPreview(n, "en")
+		require.NoError(t, err)
+		require.NotNil(t, opts, "%T has no mail", n)
+		assert.NotEmpty(t, template, "%T has no template name", n)
+		assert.False(t, templates[template], "template %s is used by more than one preview", template)
+		templates[template] = true
+		assert.NotEmpty(t, opts.Subject)
+		assert.Contains(t, opts.HTMLMessage, `src="logo.png"`)
+	}
+}
@@ -0,0 +1,103 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+// PushSubscriptions is a wrapper around the crud operations that come with a web push subscription.
+type PushSubscriptions struct {
+	notifications.PushSubscription
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+// CanCreate checks if a user can subscribe to push notifications.
+func (p *PushSubscriptions) CanCreate(_ *xorm.Session, a web.Auth) (bool, error) {
+	if _, is := a.(*LinkSharing); is {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Create registers a new web push subscription for the current user
+// @Summary Subscribe to web push notifications
+// @Description Registers the push subscription of a browser or device. All notifications the user did not disable for the push channel will be sent to it. If the endpoint was already registered, the subscription is replaced.
+// @tags subscriptions
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param subscription body notifications.PushSubscription true "The push subscription as returned by the browser."
+// @Success 201 {object} notifications.PushSubscription "The created push subscription."
+// @Failure 400 {object} web.HTTPError "Invalid push subscription object provided."
+// @Failure 403 {object} web.HTTPError "Link shares cannot subscribe to push notifications."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /notifications/push/subscriptions [put]
+func (p *PushSubscriptions) Create(s *xorm.Session, a web.Auth) (err error) {
+	p.NotifiableID = a.GetID()
+	return notifications.AddPushSubscription(s, &p.PushSubscription)
+}
+
+// ReadAll returns all push subscriptions of the current user
+// @Summary Get all push subscriptions of the current user
+// @Description Returns all web push subscriptions of the current user.
+// @tags subscriptions
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {array} notifications.PushSubscription "The push subscriptions."
+// @Failure 403 {object} web.HTTPError "Link shares cannot have push subscriptions."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /notifications/push/subscriptions [get]
+func (p *PushSubscriptions) ReadAll(s *xorm.Session, a web.Auth, _ string, _ int, _ int) (result interface{}, resultCount int, numberOfTotalItems int64, err error) {
+	if _, is := a.(*LinkSharing); is {
+		return nil, 0, 0, ErrGenericForbidden{}
+	}
+
+	subscriptions, err := notifications.GetPushSubscriptions(s, a.GetID())
+	return subscriptions, len(subscriptions), int64(len(subscriptions)), err
+}
+
+// CanDelete checks if a user can delete a push subscription. Only their own subscriptions can be deleted.
+func (p *PushSubscriptions) CanDelete(s *xorm.Session, a web.Auth) (bool, error) {
+	if _, is := a.(*LinkSharing); is {
+		return false, nil
+	}
+
+	return s.
+		Where("id = ? AND notifiable_id = ?", p.ID, a.GetID()).
+		Exist(&notifications.PushSubscription{})
+}
+
+// Delete removes a push subscription
+// @Summary Unsubscribe from web push notifications
+// @Description Removes a web push subscription of the current user.
+// @tags subscriptions
+// @Produce json
+// @Security JWTKeyAuth
+// @Param subscription path int true "Push subscription ID"
+// @Success 200 {object} models.Message "The push subscription was deleted successfully."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the push subscription."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /notifications/push/subscriptions/{subscription} [delete]
+func (p *PushSubscriptions) Delete(s *xorm.Session, a web.Auth) (err error) {
+	return notifications.DeletePushSubscription(s, p.ID, a.GetID())
+}
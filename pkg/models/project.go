@@ -27,6 +27,7 @@ import (
 	"code.vikunja.io/api/pkg/events"
 	"code.vikunja.io/api/pkg/files"
 	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/notifications"
 	"code.vikunja.io/api/pkg/user"
 	"code.vikunja.io/api/pkg/utils"
 
@@ -85,6 +86,11 @@ type Project struct {
 	// A timestamp when this project was last updated. You cannot change this value.
 	Updated time.Time `xorm:"updated not null" json:"updated"`
 
+	// The version of this project. It is increased with every change, the project's ETag is based on it.
+	Version int64 `xorm:"bigint not null default 0" json:"version"`
+	// If set, the project is only updated if its version still matches. Bound from the If-Match header.
+	IfMatch string `xorm:"-" json:"-" header:"If-Match"`
+
 	web.CRUDable `xorm:"-" json:"-"`
 	web.Rights   `xorm:"-" json:"-"`
 }
@@ -792,6 +798,11 @@ func UpdateProject(s *xorm.Session, project *Project, auth web.Auth, updateProje
 		}
 	}
 
+	_, err = bumpVersion(s, "projects", project.ID, project.IfMatch)
+	if err != nil {
+		return err
+	}
+
 	// We need to specify the cols we want to update here to be able to un-archive projects
 	colsToUpdate := []string{
 		"title",
@@ -836,6 +847,15 @@ func UpdateProject(s *xorm.Session, project *Project, auth web.Auth, updateProje
 
 	project.HexColor = utils.NormalizeHex(project.HexColor)
 
+	oldProject := &Project{}
+	_, err = s.
+		ID(project.ID).
+		Cols("parent_project_id").
+		Get(oldProject)
+	if err != nil {
+		return err
+	}
+
 	_, err = s.
 		ID(project.ID).
 		Cols(colsToUpdate...).
@@ -844,6 +864,13 @@ func UpdateProject(s *xorm.Session, project *Project, auth web.Auth, updateProje
 		return err
 	}
 
+	if oldProject.ParentProjectID > 0 && oldProject.ParentProjectID != project.ParentProjectID {
+		err = addMovedProjectTombstones(s, project.ID, oldProject.ParentProjectID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = events.Dispatch(&ProjectUpdatedEvent{
 		Project: project,
 		Doer:    auth,
@@ -899,9 +926,11 @@ func recalculateProjectPositions(s *xorm.Session, parentProjectID int64) (err er
 // @Security JWTKeyAuth
 // @Param id path int true "Project ID"
 // @Param project body models.Project true "The project with updated values you want to update."
+// @Param If-Match header string false "The ETag or version of the project the changes are based on. If the project was changed since then, it is not updated."
 // @Success 200 {object} models.Project "The updated project."
 // @Failure 400 {object} web.HTTPError "Invalid project object provided."
 // @Failure 403 {object} web.HTTPError "The user does not have access to the project"
+// @Failure 412 {object} models.Project "The project was changed in the meantime. Returns the current project."
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /projects/{id} [post]
 func (p *Project) Update(s *xorm.Session, a web.Auth) (err error) {
@@ -1027,6 +1056,21 @@ func (p *Project) Delete(s *xorm.Session, a web.Auth) (err error) {
 		}
 	}
 
+	_, err = s.Where("project_id = ?", p.ID).Delete(&notifications.ChatDestination{})
+	if err != nil {
+		return
+	}
+
+	_, err = s.Where("project_id = ?", p.ID).Delete(&CalendarFeed{})
+	if err != nil {
+		return
+	}
+
+	err = addProjectTombstones(s, fullProject)
+	if err != nil {
+		return
+	}
+
 	// Delete the project
 	_, err = s.ID(p.ID).Delete(&Project{})
 	if err != nil {
@@ -0,0 +1,233 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/web"
+	"xorm.io/xorm"
+)
+
+// ProjectExport holds a single project with all of its child projects, tasks, comments, attachments and buckets.
+type ProjectExport struct {
+	ProjectWithTasksAndBuckets
+	// The contents of all attachments and project backgrounds in the export, with the file id as key.
+	Files map[int64][]byte `json:"files"`
+}
+
+// GetProjectExport loads a project with all its child projects and everything in them for an export.
+func GetProjectExport(s *xorm.Session, a web.Auth, projectID int64) (export *ProjectExport, err error) {
+	project := &Project{ID: projectID}
+	can, _, err := project.CanRead(s, a)
+	if err != nil {
+		return nil, err
+	}
+	if !can {
+		return nil, ErrGenericForbidden{}
+	}
+
+	project, err = GetProjectSimpleByID(s, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Child projects inherit the rights of their parent, everyone who can read the project can read its children.
+	rawProjects := []*Project{project}
+	seen := map[int64]bool{project.ID: true}
+	parentIDs := []int64{project.ID}
+	for len(parentIDs) > 0 {
+		children := []*Project{}
+		err = s.
+			In("parent_project_id", parentIDs).
+			OrderBy("position asc, id asc").
+			Find(&children)
+		if err != nil {
+			return nil, err
+		}
+
+		parentIDs = []int64{}
+		for _, c := range children {
+			if seen[c.ID] {
+				continue
+			}
+			seen[c.ID] = true
+			rawProjects = append(rawProjects, c)
+			parentIDs = append(parentIDs, c.ID)
+		}
+	}
+
+	projects, _, err := getProjectsWithTasksAndBuckets(s, a, rawProjects)
+	if err != nil {
+		return nil, err
+	}
+
+	export = &ProjectExport{
+		ProjectWithTasksAndBuckets: *projects[0],
+		Files:                      make(map[int64][]byte),
+	}
+	projectsMap := make(map[int64]*ProjectWithTasksAndBuckets, len(projects))
+	projectsMap[project.ID] = &export.ProjectWithTasksAndBuckets
+	for _, p := range projects[1:] {
+		projectsMap[p.ID] = p
+	}
+
+	for _, p := range projects {
+		pp := projectsMap[p.ID]
+		pp.BackgroundFileID = pp.Project.BackgroundFileID
+		if pp.BackgroundFileID != 0 {
+			if err := export.addFile(pp.BackgroundFileID); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, t := range pp.Tasks {
+			for _, ta := range t.Attachments {
+				if err := export.addFile(ta.FileID); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if pp.ID == project.ID {
+			continue
+		}
+		parent := projectsMap[pp.ParentProjectID]
+		parent.ChildProjects = append(parent.ChildProjects, pp)
+	}
+
+	return export, nil
+}
+
+func (pe *ProjectExport) addFile(fileID int64) (err error) {
+	if _, exists := pe.Files[fileID]; exists {
+		return nil
+	}
+
+	f := &files.File{ID: fileID}
+	err = f.LoadFileByID()
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Debugf("[Project Export] File %d does not exist, omitting", fileID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.File.Close()
+
+	pe.Files[fileID], err = io.ReadAll(f.File)
+	return err
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// WriteCSV writes all tasks of the export as flattened csv with one task per row. Tasks of child projects are
+// included, their project column contains the titles of all projects from the exported one down to theirs.
+func (pe *ProjectExport) WriteCSV(w io.Writer) (err error) {
+	wr := csv.NewWriter(w)
+	err = wr.Write([]string{
+		"project",
+		"identifier",
+		"title",
+		"description",
+		"done",
+		"done_at",
+		"due_date",
+		"start_date",
+		"end_date",
+		"priority",
+		"percent_done",
+		"labels",
+		"assignees",
+		"bucket",
+		"created",
+		"updated",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeProjectCSV(wr, &pe.ProjectWithTasksAndBuckets, pe.Title)
+	if err != nil {
+		return err
+	}
+
+	wr.Flush()
+	return wr.Error()
+}
+
+func writeProjectCSV(wr *csv.Writer, project *ProjectWithTasksAndBuckets, path string) (err error) {
+	buckets := make(map[int64]string, len(project.Buckets))
+	for _, b := range project.Buckets {
+		buckets[b.ID] = b.Title
+	}
+
+	for _, t := range project.Tasks {
+		labels := make([]string, 0, len(t.Labels))
+		for _, l := range t.Labels {
+			labels = append(labels, l.Title)
+		}
+		assignees := make([]string, 0, len(t.Assignees))
+		for _, u := range t.Assignees {
+			assignees = append(assignees, u.Username)
+		}
+
+		err = wr.Write([]string{
+			path,
+			t.Identifier,
+			t.Title,
+			t.Description,
+			strconv.FormatBool(t.Done),
+			formatExportTime(t.DoneAt),
+			formatExportTime(t.DueDate),
+			formatExportTime(t.StartDate),
+			formatExportTime(t.EndDate),
+			strconv.FormatInt(t.Priority, 10),
+			strconv.FormatFloat(t.PercentDone, 'f', -1, 64),
+			strings.Join(labels, ","),
+			strings.Join(assignees, ","),
+			buckets[t.BucketID],
+			formatExportTime(t.Created),
+			formatExportTime(t.Updated),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range project.ChildProjects {
+		err = writeProjectCSV(wr, child, path+" / "+child.Title)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
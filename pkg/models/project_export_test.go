@@ -0,0 +1,99 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProjectExport(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		files.InitTestFileFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		export, err := GetProjectExport(s, &user.User{ID: 1}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), export.ID)
+		assert.Len(t, export.Buckets, 3)
+		assert.NotEmpty(t, export.Tasks)
+		for _, task := range export.Tasks {
+			assert.Equal(t, int64(1), task.ProjectID)
+		}
+		assert.Equal(t, []byte("testfile1"), export.Files[1])
+		// The file of attachment 2 does not exist
+		assert.NotContains(t, export.Files, int64(9999))
+	})
+	t.Run("with child projects", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		export, err := GetProjectExport(s, &user.User{ID: 6}, 27)
+		assert.NoError(t, err)
+		assert.Len(t, export.ChildProjects, 1)
+		assert.Equal(t, int64(12), export.ChildProjects[0].ID)
+		assert.NotEmpty(t, export.ChildProjects[0].Buckets)
+	})
+	t.Run("no access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		_, err := GetProjectExport(s, &user.User{ID: 1}, 2)
+		assert.Error(t, err)
+		assert.True(t, IsErrGenericForbidden(err))
+	})
+	t.Run("nonexisting", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		_, err := GetProjectExport(s, &user.User{ID: 1}, 9999)
+		assert.Error(t, err)
+		assert.True(t, IsErrProjectDoesNotExist(err))
+	})
+}
+
+func TestProjectExport_WriteCSV(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	export, err := GetProjectExport(s, &user.User{ID: 1}, 1)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = export.WriteCSV(buf)
+	assert.NoError(t, err)
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, len(export.Tasks)+1)
+	assert.Equal(t, "project", rows[0][0])
+	assert.Equal(t, []string{"Test1", "test1-1", "task #1", "Lorem Ipsum", "false"}, rows[1][:5])
+	assert.Equal(t, "testbucket1", rows[1][13])
+}
@@ -162,6 +162,15 @@ func (tl *TeamProject) Delete(s *xorm.Session, _ web.Auth) (err error) {
 		return err
 	}
 
+	memberIDs, err := getTeamMemberIDs(s, tl.TeamID)
+	if err != nil {
+		return err
+	}
+	err = addLostAccessTombstones(s, []int64{tl.ProjectID}, memberIDs)
+	if err != nil {
+		return err
+	}
+
 	err = updateProjectLastUpdated(s, &Project{ID: tl.ProjectID})
 	return
 }
@@ -146,6 +146,19 @@ func TestProject_CreateOrUpdate(t *testing.T) {
 			_ = s.Close()
 
 		})
+		t.Run("outdated If-Match", func(t *testing.T) {
+			db.LoadAndAssertFixtures(t)
+			s := db.NewSession()
+			project := Project{
+				ID:      1,
+				Title:   "test",
+				IfMatch: GetEtag(1, 3),
+			}
+			err := project.Update(s, usr)
+			assert.Error(t, err)
+			assert.True(t, IsErrVersionConflict(err))
+			_ = s.Close()
+		})
 		t.Run("existing identifier", func(t *testing.T) {
 			db.LoadAndAssertFixtures(t)
 			s := db.NewSession()
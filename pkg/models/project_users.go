@@ -169,6 +169,11 @@ func (lu *ProjectUser) Delete(s *xorm.Session, _ web.Auth) (err error) {
 		return err
 	}
 
+	err = addLostAccessTombstones(s, []int64{lu.ProjectID}, []int64{lu.UserID})
+	if err != nil {
+		return err
+	}
+
 	err = updateProjectLastUpdated(s, &Project{ID: lu.ProjectID})
 	return
 }
@@ -204,7 +204,12 @@ func (sf *SavedFilter) Update(s *xorm.Session, _ web.Auth) error {
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /filters/{id} [delete]
 func (sf *SavedFilter) Delete(s *xorm.Session, _ web.Auth) error {
-	_, err := s.
+	_, err := s.Where("filter_id = ?", sf.ID).Delete(&CalendarFeed{})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.
 		Where("id = ?", sf.ID).
 		Delete(sf)
 	return err
@@ -0,0 +1,639 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// SearchResultType is the type of entity a search result refers to
+type SearchResultType string
+
+// All search result types
+const (
+	SearchResultTypeProject     SearchResultType = "project"
+	SearchResultTypeTask        SearchResultType = "task"
+	SearchResultTypeTaskComment SearchResultType = "task_comment"
+	SearchResultTypeLabel       SearchResultType = "label"
+	SearchResultTypeSavedFilter SearchResultType = "saved_filter"
+	SearchResultTypeTeam        SearchResultType = "team"
+)
+
+var searchResultTypes = []SearchResultType{
+	SearchResultTypeProject,
+	SearchResultTypeTask,
+	SearchResultTypeTaskComment,
+	SearchResultTypeLabel,
+	SearchResultTypeSavedFilter,
+	SearchResultTypeTeam,
+}
+
+// Every type only returns this many results, otherwise a search for a common word would need to load everything.
+const maxSearchResultsPerType = 50
+
+// The number of characters shown before and after a match in a snippet.
+const searchSnippetContext = 40
+
+// Search represents a search across all entities a user has access to.
+type Search struct {
+	// Only return results of these types. Possible values are `project`, `task`, `task_comment`, `label`,
+	// `saved_filter` and `team`. If none are provided, results of all types are returned.
+	Types []string `query:"types" json:"-"`
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+// SearchResult is one entity matching a search
+type SearchResult struct {
+	// The type of the entity.
+	Type SearchResultType `json:"type"`
+	// The id of the entity.
+	ID int64 `json:"id"`
+	// The title of the entity. For comments, this is the title of the task the comment belongs to.
+	Title string `json:"title"`
+	// The field of the entity matching the search.
+	Field string `json:"field"`
+	// A part of the matching field. Matches are wrapped in <mark> tags, everything else is html escaped.
+	Snippet string `json:"snippet"`
+	// How well the entity matches the search. Results are sorted by this, the best matches first.
+	Score float64 `json:"score"`
+	// The project the task or comment belongs to.
+	ProjectID int64 `json:"project_id,omitempty"`
+	// The task the comment belongs to.
+	TaskID int64 `json:"task_id,omitempty"`
+	// The entity itself, as it would be returned from its own endpoint.
+	Entity interface{} `json:"entity"`
+}
+
+// searchable holds everything needed to match an entity against a search.
+type searchable struct {
+	id          int64
+	title       string
+	description string
+	entity      interface{}
+}
+
+type searchFieldNames struct {
+	title       string
+	description string
+}
+
+var defaultSearchFieldNames = searchFieldNames{title: "title", description: "description"}
+
+// ReadAll searches all projects, tasks, comments, labels, saved filters and teams the user has access to
+// @Summary Search everything
+// @Description Searches all projects, tasks, task comments, labels, saved filters and teams the user has access to and returns the best matches first. Every type returns at most 50 results. Uses Typesense if it is enabled and the database otherwise.
+// @tags search
+// @Accept json
+// @Produce json
+// @Param s query string true "The search string."
+// @Param types query []string false "Only return results of these types. Possible values are `project`, `task`, `task_comment`, `label`, `saved_filter` and `team`." collectionFormat(multi)
+// @Param page query int false "The page number. Used for pagination. If not provided, the first page of results is returned."
+// @Param per_page query int false "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page."
+// @Security JWTKeyAuth
+// @Success 200 {array} models.SearchResult "The search results."
+// @Failure 400 {object} web.HTTPError "Invalid search type."
+// @Failure 403 {object} web.HTTPError "Link shares cannot use the search."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /search [get]
+func (sr *Search) ReadAll(s *xorm.Session, a web.Auth, search string, page int, perPage int) (result interface{}, resultCount int, numberOfTotalItems int64, err error) {
+	if _, is := a.(*LinkSharing); is {
+		return nil, 0, 0, ErrGenericForbidden{}
+	}
+
+	types, err := sr.getTypes()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return []*SearchResult{}, 0, 0, nil
+	}
+
+	u, err := user.GetUserByID(s, a.GetID())
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	results := []*SearchResult{}
+	for _, t := range types {
+		var found []*SearchResult
+		switch t {
+		case SearchResultTypeProject:
+			found, err = searchProjects(s, u, search)
+		case SearchResultTypeTask:
+			found, err = searchTasks(s, u, search)
+		case SearchResultTypeTaskComment:
+			found, err = searchTaskComments(s, u, search)
+		case SearchResultTypeLabel:
+			found, err = searchLabels(s, u, search)
+		case SearchResultTypeSavedFilter:
+			found, err = searchSavedFilters(s, u, search)
+		case SearchResultTypeTeam:
+			found, err = searchTeams(s, u, search)
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		results = append(results, found...)
+	}
+
+	// Results of the same type are already ordered by their relevance, a stable sort keeps that order for equal scores.
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	numberOfTotalItems = int64(len(results))
+	limit, start := getLimitFromPageIndex(page, perPage)
+	if limit > 0 {
+		if start >= len(results) {
+			return []*SearchResult{}, 0, numberOfTotalItems, nil
+		}
+		results = results[start:min(start+limit, len(results))]
+	}
+
+	return results, len(results), numberOfTotalItems, nil
+}
+
+func (sr *Search) getTypes() (types []SearchResultType, err error) {
+	if len(sr.Types) == 0 {
+		return searchResultTypes, nil
+	}
+
+	requested := make(map[SearchResultType]bool)
+	for _, raw := range sr.Types {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			valid := false
+			for _, known := range searchResultTypes {
+				if string(known) == t {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, &ErrInvalidSearchType{Type: t}
+			}
+			requested[SearchResultType(t)] = true
+		}
+	}
+
+	for _, t := range searchResultTypes {
+		if requested[t] {
+			types = append(types, t)
+		}
+	}
+	return
+}
+
+func getAllProjectsForSearch(s *xorm.Session, u *user.User) (projects []*Project, err error) {
+	all, _, _, err := getRawProjectsForUser(s, &projectOptions{
+		user:        u,
+		page:        -1,
+		getArchived: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projects = make([]*Project, 0, len(all))
+	for _, p := range all {
+		if p.ID == FavoritesPseudoProject.ID {
+			continue
+		}
+		projects = append(projects, p)
+	}
+	return
+}
+
+func searchProjects(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	projects, err := getAllProjectsForSearch(s, u)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*searchable, 0, len(projects))
+	for _, p := range projects {
+		items = append(items, &searchable{id: p.ID, title: p.Title, description: p.Description, entity: p})
+	}
+
+	return searchEntities(SearchResultTypeProject, typesenseCollectionProjects, defaultSearchFieldNames, search, items)
+}
+
+func searchTasks(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	projects, err := getAllProjectsForSearch(s, u)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, _, _, err := getTasksForProjects(s, projects, u, &taskSearchOptions{
+		search:  search,
+		page:    1,
+		perPage: maxSearchResultsPerType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results = make([]*SearchResult, 0, len(tasks))
+	for _, t := range tasks {
+		result := newSearchResult(SearchResultTypeTask, defaultSearchFieldNames, search, &searchable{
+			id:          t.ID,
+			title:       t.Title,
+			description: t.Description,
+			entity:      t,
+		})
+		result.ProjectID = t.ProjectID
+		results = append(results, result)
+	}
+
+	return
+}
+
+func searchTaskComments(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	projects, err := getAllProjectsForSearch(s, u)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, nil
+	}
+
+	projectIDs := make([]int64, 0, len(projects))
+	for _, p := range projects {
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	comments := []*TaskComment{}
+	highlights := map[int64]*typesenseSearchHit{}
+	if useTypesenseCollection(typesenseCollectionTaskComments) {
+		var hits []*typesenseSearchHit
+		hits, err = searchTypesenseCollection(typesenseCollectionTaskComments, search, "project_id", projectIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(hits) == 0 {
+			return nil, nil
+		}
+
+		ids := make([]int64, 0, len(hits))
+		for _, hit := range hits {
+			ids = append(ids, hit.id)
+			highlights[hit.id] = hit
+		}
+
+		err = s.
+			Select("task_comments.*").
+			Join("INNER", "tasks", "tasks.id = task_comments.task_id").
+			Where(builder.And(
+				builder.In("task_comments.id", ids),
+				builder.In("tasks.project_id", projectIDs),
+			)).
+			Find(&comments)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the order returned by Typesense
+		rank := make(map[int64]int, len(ids))
+		for i, id := range ids {
+			rank[id] = i
+		}
+		sort.Slice(comments, func(i, j int) bool {
+			return rank[comments[i].ID] < rank[comments[j].ID]
+		})
+	} else {
+		err = s.
+			Select("task_comments.*").
+			Join("INNER", "tasks", "tasks.id = task_comments.task_id").
+			Where(builder.And(
+				builder.In("tasks.project_id", projectIDs),
+				db.ILIKE("task_comments.comment", search),
+			)).
+			OrderBy("task_comments.id desc").
+			Limit(maxSearchResultsPerType).
+			Find(&comments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(comments) == 0 {
+		return nil, nil
+	}
+
+	taskIDs := make([]int64, 0, len(comments))
+	for _, c := range comments {
+		taskIDs = append(taskIDs, c.TaskID)
+	}
+	taskMap := make(map[int64]*Task, len(taskIDs))
+	err = s.In("id", taskIDs).Find(&taskMap)
+	if err != nil {
+		return nil, err
+	}
+
+	results = make([]*SearchResult, 0, len(comments))
+	for _, c := range comments {
+		task, has := taskMap[c.TaskID]
+		if !has {
+			continue
+		}
+
+		// The comment is the only field which can match, the task title is only shown for context.
+		result := newSearchResult(SearchResultTypeTaskComment, searchFieldNames{description: "comment"}, search, &searchable{
+			id:          c.ID,
+			description: c.Comment,
+			entity:      c,
+		})
+		result.Title = task.Title
+		result.TaskID = task.ID
+		result.ProjectID = task.ProjectID
+		highlights[c.ID].applyTo(result, searchFieldNames{description: "comment"})
+		results = append(results, result)
+	}
+
+	return
+}
+
+func searchLabels(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	labels, _, _, err := GetLabelsByTaskIDs(s, &LabelByTaskIDsOptions{
+		User:                u,
+		GetForUser:          u.ID,
+		Page:                -1,
+		GetUnusedLabels:     true,
+		GroupByLabelIDsOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*searchable, 0, len(labels))
+	for _, l := range labels {
+		label := &l.Label
+		items = append(items, &searchable{id: label.ID, title: label.Title, description: label.Description, entity: label})
+	}
+
+	return searchEntities(SearchResultTypeLabel, typesenseCollectionLabels, defaultSearchFieldNames, search, items)
+}
+
+func searchSavedFilters(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	filters, err := getSavedFiltersForUser(s, u)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*searchable, 0, len(filters))
+	for _, f := range filters {
+		items = append(items, &searchable{id: f.ID, title: f.Title, description: f.Description, entity: f})
+	}
+
+	return searchEntities(SearchResultTypeSavedFilter, typesenseCollectionSavedFilters, defaultSearchFieldNames, search, items)
+}
+
+func searchTeams(s *xorm.Session, u *user.User, search string) (results []*SearchResult, err error) {
+	teams := []*Team{}
+	err = s.
+		Select("teams.*").
+		Table("teams").
+		Join("INNER", "team_members", "team_members.team_id = teams.id").
+		Where("team_members.user_id = ?", u.ID).
+		Find(&teams)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*searchable, 0, len(teams))
+	for _, t := range teams {
+		items = append(items, &searchable{id: t.ID, title: t.Name, description: t.Description, entity: t})
+	}
+
+	return searchEntities(SearchResultTypeTeam, typesenseCollectionTeams, searchFieldNames{title: "name", description: "description"}, search, items)
+}
+
+// searchEntities returns all entities matching the search, the best matches first.
+// Typesense is used to find the matches if it is enabled and has the collection, the entities themselves are always the ones
+// passed in, which makes sure only entities the user has access to are returned.
+func searchEntities(t SearchResultType, collection string, fields searchFieldNames, search string, items []*searchable) (results []*SearchResult, err error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	if useTypesenseCollection(collection) {
+		itemMap := make(map[int64]*searchable, len(items))
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			itemMap[item.id] = item
+			ids = append(ids, item.id)
+		}
+
+		hits, err := searchTypesenseCollection(collection, search, "id", ids)
+		if err != nil {
+			return nil, err
+		}
+
+		results = make([]*SearchResult, 0, len(hits))
+		for _, hit := range hits {
+			item, has := itemMap[hit.id]
+			if !has {
+				continue
+			}
+			result := newSearchResult(t, fields, search, item)
+			hit.applyTo(result, fields)
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	results = []*SearchResult{}
+	for _, item := range items {
+		if !matchesSearch(search, item.title, item.description) {
+			continue
+		}
+		results = append(results, newSearchResult(t, fields, search, item))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > maxSearchResultsPerType {
+		results = results[:maxSearchResultsPerType]
+	}
+
+	return results, nil
+}
+
+// matchesSearch returns true if every word of the search is contained in one of the texts.
+func matchesSearch(search string, texts ...string) bool {
+	lowered := make([]string, 0, len(texts))
+	for _, text := range texts {
+		lowered = append(lowered, strings.ToLower(text))
+	}
+
+	for _, word := range strings.Fields(strings.ToLower(search)) {
+		found := false
+		for _, text := range lowered {
+			if strings.Contains(text, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newSearchResult(t SearchResultType, fields searchFieldNames, search string, item *searchable) *SearchResult {
+	result := &SearchResult{
+		Type:   t,
+		ID:     item.id,
+		Title:  item.title,
+		Entity: item.entity,
+	}
+
+	var titleMatched, descriptionMatched bool
+	if item.title != "" {
+		result.Snippet, titleMatched = getSearchSnippet(item.title, search)
+		result.Field = fields.title
+	}
+	if !titleMatched && item.description != "" {
+		var snippet string
+		snippet, descriptionMatched = getSearchSnippet(item.description, search)
+		if descriptionMatched || result.Snippet == "" {
+			result.Snippet = snippet
+			result.Field = fields.description
+		}
+	}
+
+	result.Score = getSearchScore(search, item.title, item.description, descriptionMatched)
+	return result
+}
+
+// getSearchScore ranks exact title matches first, then titles starting with the search, then titles containing
+// the search and then matches in other fields. Everything else was matched by a search backend which does
+// more than matching substrings, for example through stemming or typo tolerance.
+func getSearchScore(search, title, description string, descriptionMatched bool) float64 {
+	search = strings.ToLower(search)
+	title = strings.ToLower(title)
+
+	switch {
+	case title != "" && title == search:
+		return 1
+	case title != "" && strings.HasPrefix(title, search):
+		return 0.8
+	case title != "" && strings.Contains(title, search):
+		return 0.6
+	case matchesSearch(search, title):
+		return 0.5
+	case descriptionMatched:
+		return 0.4
+	case matchesSearch(search, title, description):
+		return 0.3
+	}
+
+	return 0.2
+}
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// getPlainText removes all html from a text and collapses whitespace.
+func getPlainText(text string) string {
+	text = html.UnescapeString(htmlTagRegex.ReplaceAllString(text, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// getSearchSnippet returns the part of the text around the first match of the search, or of one of its words, with
+// the match wrapped in <mark> tags. Html in the text is removed. If nothing matches, the start of the text is returned.
+func getSearchSnippet(text, search string) (snippet string, matched bool) {
+	text = getPlainText(text)
+	runes := []rune(text)
+	lowered := []rune(strings.Map(unicode.ToLower, text))
+
+	candidates := append([]string{search}, strings.Fields(search)...)
+	start, end := -1, -1
+	for _, candidate := range candidates {
+		needle := []rune(strings.Map(unicode.ToLower, candidate))
+		if len(needle) == 0 {
+			continue
+		}
+		if i := indexRunes(lowered, needle); i >= 0 {
+			start, end = i, i+len(needle)
+			break
+		}
+	}
+
+	if start < 0 {
+		if len(runes) > 2*searchSnippetContext {
+			return html.EscapeString(string(runes[:2*searchSnippetContext])) + "…", false
+		}
+		return html.EscapeString(text), false
+	}
+
+	from := max(0, start-searchSnippetContext)
+	to := min(len(runes), end+searchSnippetContext)
+
+	// Don't cut words in half
+	for from > 0 && from < start && runes[from-1] != ' ' {
+		from++
+	}
+	for to < len(runes) && to > end && runes[to] != ' ' {
+		to--
+	}
+
+	if from > 0 {
+		snippet = "…"
+	}
+	snippet += html.EscapeString(string(runes[from:start])) +
+		"<mark>" + html.EscapeString(string(runes[start:end])) + "</mark>" +
+		html.EscapeString(string(runes[end:to]))
+	if to < len(runes) {
+		snippet += "…"
+	}
+
+	return snippet, true
+}
+
+func indexRunes(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,414 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/cron"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/modules/searchindex"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"xorm.io/xorm"
+)
+
+var (
+	taskSearchIndex  *searchindex.Index
+	searchIndexInUse bool
+)
+
+// How much a match in each field counts towards the relevance of a task.
+var taskSearchIndexBoosts = map[string]float64{
+	"title":       3,
+	"identifier":  3,
+	"labels":      2,
+	"attachments": 1.5,
+	"description": 1,
+	"comments":    1,
+}
+
+const (
+	// The maximum number of tasks a search through the index returns.
+	maxSearchIndexResults = 1000
+	// How many tasks are loaded at once when rebuilding the index.
+	searchIndexBatchSize = 500
+)
+
+// InitSearchIndex opens the embedded search index if it is enabled.
+// If another Vikunja process has the index open already, it is left to that process.
+func InitSearchIndex() error {
+	if !config.SearchIndexEnabled.GetBool() {
+		return nil
+	}
+
+	language := config.SearchIndexLanguage.GetString()
+	if !searchindex.IsValidLanguage(language) {
+		return fmt.Errorf("invalid search index language %s", language)
+	}
+
+	index, err := searchindex.Open(config.SearchIndexPath.GetString(), language, taskSearchIndexBoosts)
+	if errors.Is(err, searchindex.ErrLocked) {
+		searchIndexInUse = true
+		log.Debugf("[Search Index] The search index is opened by another Vikunja process, searching through the database instead.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	taskSearchIndex = index
+
+	if taskSearchIndex.Count() == 0 {
+		log.Warning("The search index is empty. Run \"vikunja index\" to add all existing tasks to it.")
+	}
+	return nil
+}
+
+func isSearchIndexEnabled() bool {
+	return taskSearchIndex != nil
+}
+
+// IsSearchIndexInUse returns whether another Vikunja process has the search index open.
+func IsSearchIndexInUse() bool {
+	return searchIndexInUse
+}
+
+// RequestSearchIndexRebuild asks the Vikunja process which has the search index open to rebuild it.
+func RequestSearchIndexRebuild() error {
+	return searchindex.RequestRebuild(config.SearchIndexPath.GetString())
+}
+
+// RegisterSearchIndexRebuildCron rebuilds the search index when another process asked for it.
+func RegisterSearchIndexRebuildCron() {
+	if !isSearchIndexEnabled() {
+		return
+	}
+
+	err := cron.Schedule("* * * * *", func() {
+		if !taskSearchIndex.RebuildRequested() {
+			return
+		}
+
+		log.Infof("[Search Index] Rebuilding the search index…")
+		err := RebuildSearchIndex()
+		if err != nil {
+			log.Errorf("[Search Index] Could not rebuild the search index: %s", err)
+		}
+	})
+	if err != nil {
+		log.Errorf("Could not register search index rebuild cron: %s", err)
+	}
+}
+
+// getSearchDocumentsForTasks returns the search index documents for all tasks, including the
+// labels, comments and attachment file names of each task.
+func getSearchDocumentsForTasks(s *xorm.Session, tasks []*Task) (docs []*searchindex.Document, err error) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	taskIDs := make([]int64, 0, len(tasks))
+	projectIDs := make([]int64, 0, len(tasks))
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+		projectIDs = append(projectIDs, t.ProjectID)
+	}
+
+	projects, err := GetProjectsByIDs(s, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []*LabelWithTaskID{}
+	err = s.
+		Table("labels").
+		Select("labels.*, label_tasks.task_id").
+		Join("INNER", "label_tasks", "labels.id = label_tasks.label_id").
+		In("label_tasks.task_id", taskIDs).
+		Find(&labels)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []*TaskComment{}
+	err = s.In("task_id", taskIDs).Find(&comments)
+	if err != nil {
+		return nil, err
+	}
+
+	type attachmentWithName struct {
+		TaskID int64
+		Name   string
+	}
+	attachments := []*attachmentWithName{}
+	err = s.
+		Table("task_attachments").
+		Select("task_attachments.task_id, files.name").
+		Join("INNER", "files", "files.id = task_attachments.file_id").
+		In("task_attachments.task_id", taskIDs).
+		Find(&attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	labelTitles := make(map[int64][]string)
+	for _, l := range labels {
+		labelTitles[l.TaskID] = append(labelTitles[l.TaskID], l.Title)
+	}
+	commentTexts := make(map[int64][]string)
+	for _, c := range comments {
+		commentTexts[c.TaskID] = append(commentTexts[c.TaskID], c.Comment)
+	}
+	attachmentNames := make(map[int64][]string)
+	for _, a := range attachments {
+		attachmentNames[a.TaskID] = append(attachmentNames[a.TaskID], a.Name)
+	}
+
+	docs = make([]*searchindex.Document, 0, len(tasks))
+	for _, t := range tasks {
+		identifier := "#" + strconv.FormatInt(t.Index, 10)
+		if p, has := projects[t.ProjectID]; has && p.Identifier != "" {
+			identifier += " " + p.Identifier + "-" + strconv.FormatInt(t.Index, 10)
+		}
+
+		docs = append(docs, &searchindex.Document{
+			ID:    t.ID,
+			Group: t.ProjectID,
+			Fields: map[string]string{
+				"title":       t.Title,
+				"identifier":  identifier,
+				"description": t.Description,
+				"labels":      strings.Join(labelTitles[t.ID], "\n"),
+				"comments":    strings.Join(commentTexts[t.ID], "\n"),
+				"attachments": strings.Join(attachmentNames[t.ID], "\n"),
+			},
+		})
+	}
+
+	return docs, nil
+}
+
+// updateTaskInSearchIndex adds the current state of a task to the search index,
+// or removes it from the index if it does not exist anymore.
+func updateTaskInSearchIndex(s *xorm.Session, taskID int64) error {
+	if !isSearchIndexEnabled() {
+		return nil
+	}
+
+	task, err := GetTaskByIDSimple(s, taskID)
+	if IsErrTaskDoesNotExist(err) {
+		return taskSearchIndex.Delete(taskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	docs, err := getSearchDocumentsForTasks(s, []*Task{&task})
+	if err != nil {
+		return err
+	}
+
+	return taskSearchIndex.Put(docs[0])
+}
+
+// RebuildSearchIndex removes everything from the search index and adds all tasks to it.
+func RebuildSearchIndex() (err error) {
+	if !isSearchIndexEnabled() {
+		return fmt.Errorf("the search index is not enabled")
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	docs := []*searchindex.Document{}
+	var lastID int64
+	for {
+		tasks := []*Task{}
+		err = s.
+			Where("id > ?", lastID).
+			OrderBy("id asc").
+			Limit(searchIndexBatchSize).
+			Find(&tasks)
+		if err != nil {
+			return fmt.Errorf("could not get tasks: %w", err)
+		}
+		if len(tasks) == 0 {
+			break
+		}
+
+		batch, err := getSearchDocumentsForTasks(s, tasks)
+		if err != nil {
+			return fmt.Errorf("could not get task details: %w", err)
+		}
+		docs = append(docs, batch...)
+		lastID = tasks[len(tasks)-1].ID
+
+		log.Debugf("[Search Index] Loaded %d tasks", len(docs))
+	}
+
+	err = taskSearchIndex.Replace(docs)
+	if err != nil {
+		return fmt.Errorf("could not save the search index: %w", err)
+	}
+
+	log.Infof("[Search Index] Indexed %d tasks", len(docs))
+	return nil
+}
+
+// CloseSearchIndex saves all pending changes of the search index to disk and closes it.
+func CloseSearchIndex() error {
+	if !isSearchIndexEnabled() {
+		return nil
+	}
+	return taskSearchIndex.Close()
+}
+
+type indexTaskSearcher struct {
+	db *dbTaskSearcher
+}
+
+// search returns the best matching tasks from the index. Only tasks in the searched projects
+// and favorite tasks of the user are considered, so that other tasks don't take up the results.
+func (t *indexTaskSearcher) search(opts *taskSearchOptions) (results []*searchindex.Result, err error) {
+	filter := &searchindex.Filter{Groups: opts.projectIDs}
+
+	if t.db.hasFavoritesProject {
+		err = t.db.s.
+			Table("favorites").
+			Cols("entity_id").
+			Where("user_id = ? AND kind = ?", t.db.a.GetID(), FavoriteKindTask).
+			Find(&filter.IDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results = taskSearchIndex.Search(opts.search, filter)
+	if len(results) > maxSearchIndexResults {
+		results = results[:maxSearchIndexResults]
+	}
+	return results, nil
+}
+
+// Search finds all tasks matching the search string through the index and then applies all other
+// filters in the database. Results are sorted by relevance unless another sort order was requested.
+func (t *indexTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error) {
+	if opts.search == "" {
+		return t.db.Search(opts)
+	}
+
+	results, err := t.search(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(results) == 0 {
+		return []*Task{}, 0, nil
+	}
+
+	rank := make(map[int64]int, len(results))
+	t.db.taskIDs = make([]int64, 0, len(results))
+	for i, r := range results {
+		rank[r.ID] = i
+		t.db.taskIDs = append(t.db.taskIDs, r.ID)
+	}
+
+	if !opts.isSortedByRelevance() || opts.cursor != nil {
+		return t.db.Search(opts)
+	}
+
+	allOpts := *opts
+	allOpts.page = 0
+	tasks, totalCount, err = t.db.Search(&allOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return rank[tasks[i].ID] < rank[tasks[j].ID]
+	})
+
+	limit, start := getLimitFromPageIndex(opts.page, opts.perPage)
+	if limit > 0 {
+		if start >= len(tasks) {
+			return []*Task{}, totalCount, nil
+		}
+		tasks = tasks[start:min(start+limit, len(tasks))]
+	}
+
+	return tasks, totalCount, nil
+}
+
+// UpdateTaskInSearchIndex represents a listener
+type UpdateTaskInSearchIndex struct {
+}
+
+// Name defines the name for the UpdateTaskInSearchIndex listener
+func (l *UpdateTaskInSearchIndex) Name() string {
+	return "update.task.in.search.index"
+}
+
+// Handle is executed when the event UpdateTaskInSearchIndex listens on is fired
+func (l *UpdateTaskInSearchIndex) Handle(msg *message.Message) (err error) {
+	// All task events have the task under the same key
+	event := &struct {
+		Task *Task `json:"task"`
+	}{}
+	err = json.Unmarshal(msg.Payload, event)
+	if err != nil {
+		return err
+	}
+
+	if event.Task == nil {
+		return nil
+	}
+
+	log.Debugf("[Search Index] Updating task %d", event.Task.ID)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	return updateTaskInSearchIndex(s, event.Task.ID)
+}
+
+// RemoveTaskFromSearchIndex represents a listener
+type RemoveTaskFromSearchIndex struct {
+}
+
+// Name defines the name for the RemoveTaskFromSearchIndex listener
+func (l *RemoveTaskFromSearchIndex) Name() string {
+	return "remove.task.from.search.index"
+}
+
+// Handle is executed when the event RemoveTaskFromSearchIndex listens on is fired
+func (l *RemoveTaskFromSearchIndex) Handle(msg *message.Message) (err error) {
+	event := &TaskDeletedEvent{}
+	err = json.Unmarshal(msg.Payload, event)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("[Search Index] Removing task %d", event.Task.ID)
+
+	return taskSearchIndex.Delete(event.Task.ID)
+}
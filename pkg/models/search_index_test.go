@@ -0,0 +1,139 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/modules/searchindex"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSearchIndex(t *testing.T) {
+	var err error
+	taskSearchIndex, err = searchindex.Open(t.TempDir(), "english", taskSearchIndexBoosts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = taskSearchIndex.Close()
+		taskSearchIndex = nil
+	})
+
+	err = RebuildSearchIndex()
+	require.NoError(t, err)
+}
+
+func searchTasksThroughIndex(t *testing.T, search string) []int64 {
+	return searchProjectTasksThroughIndex(t, 1, search)
+}
+
+func searchProjectTasksThroughIndex(t *testing.T, projectID int64, search string) []int64 {
+	s := db.NewSession()
+	defer s.Close()
+
+	tc := &TaskCollection{ProjectID: projectID}
+	result, _, _, err := tc.ReadAll(s, &user.User{ID: 1}, search, 0, 50)
+	require.NoError(t, err)
+
+	ids := []int64{}
+	for _, task := range result.([]*Task) {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func TestSearchIndex(t *testing.T) {
+	t.Run("title", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		assert.ElementsMatch(t, []int64{3, 4}, searchTasksThroughIndex(t, "prio"))
+	})
+	t.Run("stemming", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		assert.ElementsMatch(t, []int64{5, 6, 7, 8, 9, 27}, searchTasksThroughIndex(t, "dates"))
+	})
+	t.Run("comment with typo", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		assert.Equal(t, []int64{1}, searchTasksThroughIndex(t, "dolr"))
+	})
+	t.Run("relevance", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		ids := searchTasksThroughIndex(t, "start date")
+		require.Len(t, ids, 3)
+		assert.Equal(t, int64(7), ids[0])
+	})
+	t.Run("task update", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		s := db.NewSession()
+		defer s.Close()
+		_, err := s.Where("id = ?", 1).Cols("title").Update(&Task{Title: "something completely different"})
+		require.NoError(t, err)
+		err = updateTaskInSearchIndex(s, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{1}, searchTasksThroughIndex(t, "completely"))
+	})
+	t.Run("task removed", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		err := taskSearchIndex.Delete(3)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{4}, searchTasksThroughIndex(t, "prio"))
+	})
+	t.Run("favorites", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		assert.Equal(t, []int64{15}, searchProjectTasksThroughIndex(t, FavoritesPseudoProject.ID, "#15"))
+	})
+	t.Run("only searched projects", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		setupSearchIndex(t)
+
+		s := db.NewSession()
+		defer s.Close()
+
+		// Tasks in other projects must not count towards the maximum number of results
+		searcher := &indexTaskSearcher{db: &dbTaskSearcher{s: s, a: &user.User{ID: 1}}}
+		results, err := searcher.search(&taskSearchOptions{search: "task", projectIDs: []int64{2}})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		for _, r := range results {
+			task, err := GetTaskByIDSimple(s, r.ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), task.ProjectID)
+		}
+
+		results, err = searcher.search(&taskSearchOptions{search: "task", projectIDs: []int64{}})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,185 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch_ReadAll(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	search := func(t *testing.T, sr *Search, query string) []*SearchResult {
+		s := db.NewSession()
+		defer s.Close()
+
+		result, _, _, err := sr.ReadAll(s, u, query, -1, 0)
+		require.NoError(t, err)
+		return result.([]*SearchResult)
+	}
+
+	t.Run("all types", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{}, "test")
+		types := map[SearchResultType]bool{}
+		for _, r := range results {
+			types[r.Type] = true
+		}
+		assert.True(t, types[SearchResultTypeProject])
+		assert.True(t, types[SearchResultTypeSavedFilter])
+		assert.True(t, types[SearchResultTypeTeam])
+	})
+	t.Run("tasks", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{}, "high prio")
+		require.Len(t, results, 1)
+		assert.Equal(t, SearchResultTypeTask, results[0].Type)
+		assert.Equal(t, int64(3), results[0].ID)
+		assert.Equal(t, int64(1), results[0].ProjectID)
+		assert.Equal(t, "task #3 <mark>high prio</mark>", results[0].Snippet)
+	})
+	t.Run("only requested types", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{Types: []string{"label"}}, "label")
+		require.NotEmpty(t, results)
+		ids := []int64{}
+		for _, r := range results {
+			assert.Equal(t, SearchResultTypeLabel, r.Type)
+			ids = append(ids, r.ID)
+		}
+		assert.Contains(t, ids, int64(1))
+		// Label 3 belongs to another user and is not used on any task the user has access to
+		assert.NotContains(t, ids, int64(3))
+	})
+	t.Run("multiple types in one parameter", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{Types: []string{"team,saved_filter"}}, "test")
+		for _, r := range results {
+			assert.Contains(t, []SearchResultType{SearchResultTypeTeam, SearchResultTypeSavedFilter}, r.Type)
+		}
+		assert.NotEmpty(t, results)
+	})
+	t.Run("invalid type", func(t *testing.T) {
+		s := db.NewSession()
+		defer s.Close()
+
+		_, _, _, err := (&Search{Types: []string{"foo"}}).ReadAll(s, u, "test", -1, 0)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidSearchType(err))
+	})
+	t.Run("best matches first", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{Types: []string{"saved_filter", "project"}}, "testfilter1")
+		require.NotEmpty(t, results)
+		assert.Equal(t, SearchResultTypeSavedFilter, results[0].Type)
+		assert.Equal(t, int64(1), results[0].ID)
+		assert.Equal(t, "<mark>testfilter1</mark>", results[0].Snippet)
+		assert.Equal(t, "title", results[0].Field)
+		assert.Equal(t, float64(1), results[0].Score)
+	})
+	t.Run("comments", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{Types: []string{"task_comment"}}, "dolor")
+		require.Len(t, results, 1)
+		assert.Equal(t, int64(1), results[0].ID)
+		assert.Equal(t, int64(1), results[0].TaskID)
+		assert.Equal(t, int64(1), results[0].ProjectID)
+		assert.Equal(t, "task #1", results[0].Title)
+		assert.Equal(t, "comment", results[0].Field)
+		assert.Equal(t, "Lorem Ipsum <mark>Dolor</mark> Sit Amet", results[0].Snippet)
+	})
+	t.Run("typesense collection missing", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		config.TypesenseEnabled.Set(true)
+		defer config.TypesenseEnabled.Set(false)
+
+		// The database is used for collections which don't exist in Typesense
+		results := search(t, &Search{Types: []string{"task_comment", "label"}}, "dolor")
+		require.Len(t, results, 1)
+		assert.Equal(t, int64(1), results[0].ID)
+	})
+	t.Run("no access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		results := search(t, &Search{Types: []string{"task_comment"}}, "comment 2")
+		assert.Empty(t, results)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		result, count, total, err := (&Search{}).ReadAll(s, u, "test", 1, 2)
+		require.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, 2, count)
+		assert.Greater(t, total, int64(2))
+	})
+	t.Run("link share", func(t *testing.T) {
+		s := db.NewSession()
+		defer s.Close()
+
+		_, _, _, err := (&Search{}).ReadAll(s, &LinkSharing{ID: 1}, "test", -1, 0)
+		require.Error(t, err)
+		assert.True(t, IsErrGenericForbidden(err))
+	})
+}
+
+func TestGetSearchSnippet(t *testing.T) {
+	t.Run("match in the middle", func(t *testing.T) {
+		snippet, matched := getSearchSnippet("<p>Some <b>text</b> with a match & more</p>", "MATCH")
+		assert.True(t, matched)
+		assert.Equal(t, "Some text with a <mark>match</mark> &amp; more", snippet)
+	})
+	t.Run("one of the words", func(t *testing.T) {
+		snippet, matched := getSearchSnippet("Lorem ipsum", "foo ipsum")
+		assert.True(t, matched)
+		assert.Equal(t, "Lorem <mark>ipsum</mark>", snippet)
+	})
+	t.Run("long text", func(t *testing.T) {
+		text := "Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt ut labore et dolore magna aliquyam erat"
+		snippet, matched := getSearchSnippet(text, "invidunt")
+		assert.True(t, matched)
+		assert.Equal(t, "…elitr, sed diam nonumy eirmod tempor <mark>invidunt</mark> ut labore et dolore magna aliquyam erat", snippet)
+	})
+	t.Run("no match", func(t *testing.T) {
+		snippet, matched := getSearchSnippet("<script>", "foo")
+		assert.False(t, matched)
+		assert.Equal(t, "", snippet)
+
+		snippet, matched = getSearchSnippet("a < b", "foo")
+		assert.False(t, matched)
+		assert.Equal(t, "a &lt; b", snippet)
+	})
+}
+
+func TestEscapeTypesenseSnippet(t *testing.T) {
+	assert.Equal(t, "&lt;b&gt;<mark>foo</mark> &amp; bar", escapeTypesenseSnippet("<b><mark>foo</mark> & bar"))
+}
@@ -0,0 +1,323 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// Sync holds all projects, tasks, labels and buckets which were created, changed or deleted since the last sync.
+type Sync struct {
+	// The cursor returned by the last sync. If empty, everything is returned.
+	Since string `query:"since" json:"-"`
+	// The maximum number of tasks returned at once.
+	PerPage int `query:"per_page" json:"-"`
+
+	// All projects created or updated since the last sync.
+	Projects []*Project `json:"projects"`
+	// All tasks created or updated since the last sync.
+	Tasks []*Task `json:"tasks"`
+	// All labels created or updated since the last sync.
+	Labels []*Label `json:"labels"`
+	// All kanban buckets created or updated since the last sync.
+	Buckets []*Bucket `json:"buckets"`
+	// All projects, tasks, labels and buckets deleted since the last sync. When a project was deleted,
+	// its tasks and buckets are deleted as well.
+	Deleted []*Tombstone `json:"deleted"`
+
+	// Pass this as `since` to the next sync to only get what changed after this one.
+	Cursor string `json:"cursor"`
+	// Whether there are more tasks. If true, pass the cursor as `since` right away to get the next page of tasks.
+	// Everything other than tasks is only returned with the first page.
+	HasMore bool `json:"has_more"`
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+type syncCursor struct {
+	// Unix timestamp of the sync
+	Time int64 `json:"t"`
+	// Only set while paging through the tasks of a sync: the unix timestamp of the last sync
+	// and the id of the last task returned.
+	Since     int64 `json:"s,omitempty"`
+	AfterTask int64 `json:"a,omitempty"`
+}
+
+func decodeSyncCursor(cursor string) (c *syncCursor, err error) {
+	c = &syncCursor{}
+	if cursor == "" {
+		return c, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor{Cursor: cursor}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if err := decoder.Decode(c); err != nil || c.Time <= 0 || c.Since < 0 || c.AfterTask < 0 {
+		return nil, ErrInvalidCursor{Cursor: cursor}
+	}
+
+	return c, nil
+}
+
+func encodeSyncCursor(c *syncCursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func unixOrZero(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(timestamp, 0)
+}
+
+// CanRead checks if the auth can sync. Everyone can sync what they have access to.
+func (sy *Sync) CanRead(_ *xorm.Session, _ web.Auth) (bool, int, error) {
+	return true, int(RightRead), nil
+}
+
+// ReadOne returns everything which changed since the last sync
+// @Summary Sync changes
+// @Description Returns all projects, tasks, labels and kanban buckets the user has access to which were created or updated since the last sync, together with tombstones for everything which was deleted or the user lost access to. Offline-first clients can use this to sync incrementally. Pass the returned `cursor` as `since` to the next sync. Without `since`, everything is returned. Changes made during a sync might be returned again with the next one. Tasks are paginated: as long as `has_more` is true, pass the returned `cursor` as `since` right away to get the next page. Deletions are only kept for the configured number of days, older cursors need a full sync.
+// @tags sync
+// @Accept json
+// @Produce json
+// @Param since query string false "The cursor returned by the last sync."
+// @Param per_page query int false "The maximum number of tasks per page. Note this parameter is limited by the configured maximum of items per page."
+// @Security JWTKeyAuth
+// @Success 200 {object} models.Sync "Everything which changed since the last sync."
+// @Failure 400 {object} web.HTTPError "The cursor is invalid."
+// @Failure 410 {object} web.HTTPError "The cursor is older than the deletions which are kept."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /sync [get]
+func (sy *Sync) ReadOne(s *xorm.Session, a web.Auth) (err error) {
+	cursor, err := decodeSyncCursor(sy.Since)
+	if err != nil {
+		return err
+	}
+
+	// The database only stores seconds, everything changed during this second will be part of the next sync as well.
+	now := time.Now().Truncate(time.Second)
+	since := unixOrZero(cursor.Time)
+	firstPage := cursor.AfterTask == 0
+	if !firstPage {
+		// All pages of a sync return what changed between the same points in time
+		now = time.Unix(cursor.Time, 0)
+		since = unixOrZero(cursor.Since)
+	}
+
+	retention := getSyncRetention()
+	if retention > 0 && !since.IsZero() && since.Before(time.Now().Add(-retention)) {
+		return ErrSyncCursorExpired{Cursor: sy.Since}
+	}
+
+	perPage := sy.PerPage
+	maxPerPage := config.ServiceMaxItemsPerPage.GetInt()
+	if perPage <= 0 || perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	// Dates need to be passed to the database as strings in its time zone to compare them correctly on all databases.
+	sinceDB := since.In(s.Engine().GetTZDatabase()).Format(dbTimeFormat)
+
+	projects, userID, err := getProjectsForSync(s, a)
+	if err != nil {
+		return err
+	}
+
+	projectIDs := make([]int64, 0, len(projects))
+	for _, p := range projects {
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	opts := &taskSearchOptions{
+		sortby:       []*sortParam{{sortBy: taskPropertyID, orderBy: orderAscending}},
+		filterConcat: filterConcatAnd,
+		// One more than requested to know if there are more
+		perPage: perPage + 1,
+		cursor:  &taskCursor{},
+	}
+	if !firstPage {
+		opts.cursor = &taskCursor{
+			Fields: []string{taskPropertyID},
+			Values: []interface{}{json.Number(strconv.FormatInt(cursor.AfterTask, 10))},
+			raw:    sy.Since,
+		}
+	}
+	if !since.IsZero() {
+		opts.filters = []*taskFilter{{
+			field:      taskPropertyUpdated,
+			value:      sinceDB,
+			comparator: taskFilterComparatorGreateEquals,
+		}}
+	}
+	sy.Tasks, _, _, err = getTasksForProjects(s, projects, a, opts)
+	if err != nil {
+		return err
+	}
+	if sy.Tasks == nil {
+		sy.Tasks = []*Task{}
+	}
+
+	next := &syncCursor{Time: now.Unix()}
+	if len(sy.Tasks) > perPage {
+		sy.Tasks = sy.Tasks[:perPage]
+		sy.HasMore = true
+		next.Since = cursor.Since
+		if firstPage {
+			next.Since = cursor.Time
+		}
+		next.AfterTask = sy.Tasks[len(sy.Tasks)-1].ID
+	}
+	sy.Cursor, err = encodeSyncCursor(next)
+	if err != nil {
+		return err
+	}
+
+	if !firstPage {
+		sy.Projects = []*Project{}
+		sy.Labels = []*Label{}
+		sy.Buckets = []*Bucket{}
+		sy.Deleted = []*Tombstone{}
+		return nil
+	}
+
+	sy.Projects = []*Project{}
+	for _, p := range projects {
+		if !p.Updated.Before(since) {
+			sy.Projects = append(sy.Projects, p)
+		}
+	}
+	if len(sy.Projects) > 0 {
+		err = addProjectDetails(s, sy.Projects, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	sy.Labels = []*Label{}
+	if userID > 0 {
+		u, err := user.GetUserByID(s, userID)
+		if err != nil {
+			return err
+		}
+		labels, _, _, err := GetLabelsByTaskIDs(s, &LabelByTaskIDsOptions{
+			User:                u,
+			GetForUser:          u.ID,
+			GetUnusedLabels:     true,
+			GroupByLabelIDsOnly: true,
+		})
+		if err != nil {
+			return err
+		}
+		for _, l := range labels {
+			if !l.Updated.Before(since) {
+				label := l.Label
+				sy.Labels = append(sy.Labels, &label)
+			}
+		}
+	}
+
+	sy.Buckets = []*Bucket{}
+	if len(projectIDs) > 0 {
+		err = s.
+			Where(builder.And(
+				builder.In("project_id", projectIDs),
+				builder.Gte{"updated": sinceDB},
+			)).
+			OrderBy("project_id asc, position asc").
+			Find(&sy.Buckets)
+		if err != nil {
+			return err
+		}
+
+		userIDs := make([]int64, 0, len(sy.Buckets))
+		for _, b := range sy.Buckets {
+			userIDs = append(userIDs, b.CreatedByID)
+		}
+		users, err := getUsersOrLinkSharesFromIDs(s, userIDs)
+		if err != nil {
+			return err
+		}
+		for _, b := range sy.Buckets {
+			b.CreatedBy = users[b.CreatedByID]
+		}
+	}
+
+	sy.Deleted = []*Tombstone{}
+	if !since.IsZero() {
+		sy.Deleted, err = getTombstonesSince(s, sinceDB, userID, projectIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getSyncRetention() time.Duration {
+	return time.Duration(config.ServiceSyncRetentionDays.GetInt()) * 24 * time.Hour
+}
+
+// getProjectsForSync returns all projects the auth has access to, including archived ones, and the id of the user
+// if the auth is one.
+func getProjectsForSync(s *xorm.Session, a web.Auth) (projects []*Project, userID int64, err error) {
+	if shareAuth, is := a.(*LinkSharing); is {
+		project, err := GetProjectSimpleByID(s, shareAuth.ProjectID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []*Project{project}, 0, nil
+	}
+
+	allProjects, _, _, err := getRawProjectsForUser(s, &projectOptions{
+		user:        &user.User{ID: a.GetID()},
+		page:        -1,
+		getArchived: true,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	projects = make([]*Project, 0, len(allProjects))
+	for _, p := range allProjects {
+		// Pseudo projects like favorites only contain tasks which are part of other projects as well
+		if p.ID > 0 {
+			projects = append(projects, p)
+		}
+	}
+
+	return projects, a.GetID(), nil
+}
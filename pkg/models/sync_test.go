@@ -0,0 +1,262 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync_ReadOne(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("initial sync", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{}
+		err := sy.ReadOne(s, u)
+		require.NoError(t, err)
+		assert.NotEmpty(t, sy.Projects)
+		assert.NotEmpty(t, sy.Tasks)
+		assert.NotEmpty(t, sy.Labels)
+		assert.NotEmpty(t, sy.Buckets)
+		assert.Empty(t, sy.Deleted)
+		assert.NotEmpty(t, sy.Cursor)
+
+		for _, task := range sy.Tasks {
+			assert.NotEqual(t, int64(34), task.ID, "returned a task the user has no access to")
+		}
+	})
+	t.Run("only changes", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{}
+		err := sy.ReadOne(s, u)
+		require.NoError(t, err)
+
+		task := &Task{ID: 1, Title: "changed"}
+		err = task.Update(s, u)
+		require.NoError(t, err)
+		task = &Task{ID: 2}
+		err = task.Delete(s, u)
+		require.NoError(t, err)
+
+		sy = &Sync{Since: sy.Cursor}
+		err = sy.ReadOne(s, u)
+		require.NoError(t, err)
+		// Changing tasks updates their project as well
+		require.Len(t, sy.Projects, 1)
+		assert.Equal(t, int64(1), sy.Projects[0].ID)
+		assert.Empty(t, sy.Labels)
+		require.Len(t, sy.Tasks, 1)
+		assert.Equal(t, int64(1), sy.Tasks[0].ID)
+		require.Len(t, sy.Deleted, 1)
+		assert.Equal(t, TombstoneKindTask, sy.Deleted[0].Kind)
+		assert.Equal(t, int64(2), sy.Deleted[0].EntityID)
+	})
+	t.Run("visible tombstones", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		config.ServiceSyncRetentionDays.Set(0)
+		defer config.ServiceSyncRetentionDays.Set(90)
+
+		since, err := encodeSyncCursor(&syncCursor{Time: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Unix()})
+		require.NoError(t, err)
+
+		sy := &Sync{Since: since}
+		err = sy.ReadOne(s, u)
+		require.NoError(t, err)
+		require.Len(t, sy.Deleted, 3)
+		assert.Equal(t, TombstoneKindTask, sy.Deleted[0].Kind)
+		assert.Equal(t, int64(100), sy.Deleted[0].EntityID)
+		assert.Equal(t, TombstoneKindProject, sy.Deleted[1].Kind)
+		assert.Equal(t, int64(102), sy.Deleted[1].EntityID)
+		assert.Equal(t, TombstoneKindLabel, sy.Deleted[2].Kind)
+		assert.Equal(t, int64(104), sy.Deleted[2].EntityID)
+	})
+	t.Run("expired cursor", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		since, err := encodeSyncCursor(&syncCursor{Time: time.Now().Add(-91 * 24 * time.Hour).Unix()})
+		require.NoError(t, err)
+
+		sy := &Sync{Since: since}
+		err = sy.ReadOne(s, u)
+		require.Error(t, err)
+		assert.True(t, IsErrSyncCursorExpired(err))
+	})
+	t.Run("pagination", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{PerPage: 1}
+		err := sy.ReadOne(s, u)
+		require.NoError(t, err)
+		require.Len(t, sy.Tasks, 1)
+		assert.True(t, sy.HasMore)
+		assert.NotEmpty(t, sy.Projects)
+		firstTaskID := sy.Tasks[0].ID
+
+		sy = &Sync{Since: sy.Cursor, PerPage: 1}
+		err = sy.ReadOne(s, u)
+		require.NoError(t, err)
+		require.Len(t, sy.Tasks, 1)
+		assert.Greater(t, sy.Tasks[0].ID, firstTaskID)
+		assert.True(t, sy.HasMore)
+		// Only the first page contains everything except tasks
+		assert.Empty(t, sy.Projects)
+		assert.Empty(t, sy.Labels)
+		assert.Empty(t, sy.Buckets)
+	})
+	t.Run("share removed", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		u2 := &user.User{ID: 2}
+		sy := &Sync{}
+		err := sy.ReadOne(s, u2)
+		require.NoError(t, err)
+
+		pu := &ProjectUser{Username: "user2", ProjectID: 3}
+		err = pu.Delete(s, u)
+		require.NoError(t, err)
+
+		db.AssertExists(t, "tombstones", map[string]interface{}{
+			"kind":      TombstoneKindProject,
+			"entity_id": 3,
+			"user_id":   2,
+		}, false)
+
+		// The user still has access through a team
+		cursor := sy.Cursor
+		sy = &Sync{Since: cursor}
+		err = sy.ReadOne(s, u2)
+		require.NoError(t, err)
+		assert.Empty(t, sy.Deleted)
+
+		tp := &TeamProject{TeamID: 1, ProjectID: 3}
+		err = tp.Delete(s, u)
+		require.NoError(t, err)
+
+		sy = &Sync{Since: cursor}
+		err = sy.ReadOne(s, u2)
+		require.NoError(t, err)
+		require.Len(t, sy.Deleted, 1)
+		assert.Equal(t, TombstoneKindProject, sy.Deleted[0].Kind)
+		assert.Equal(t, int64(3), sy.Deleted[0].EntityID)
+	})
+	t.Run("task moved", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{}
+		err := sy.ReadOne(s, u)
+		require.NoError(t, err)
+
+		task := &Task{ID: 1, Title: "moved", ProjectID: 10}
+		err = task.Update(s, u)
+		require.NoError(t, err)
+
+		db.AssertExists(t, "tombstones", map[string]interface{}{
+			"kind":       TombstoneKindTask,
+			"entity_id":  1,
+			"project_id": 1,
+		}, false)
+
+		// The user can still see the task in its new project
+		sy = &Sync{Since: sy.Cursor}
+		err = sy.ReadOne(s, u)
+		require.NoError(t, err)
+		assert.Empty(t, sy.Deleted)
+		require.Len(t, sy.Tasks, 1)
+		assert.Equal(t, int64(1), sy.Tasks[0].ID)
+	})
+	t.Run("task moved through the move route", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{}
+		err := sy.ReadOne(s, u)
+		require.NoError(t, err)
+
+		tm := &TaskMove{TaskID: 1, ProjectID: 10}
+		err = tm.Update(s, u)
+		require.NoError(t, err)
+
+		db.AssertExists(t, "tombstones", map[string]interface{}{
+			"kind":       TombstoneKindTask,
+			"entity_id":  1,
+			"project_id": 1,
+		}, false)
+
+		sy = &Sync{Since: sy.Cursor}
+		err = sy.ReadOne(s, u)
+		require.NoError(t, err)
+		assert.Empty(t, sy.Deleted)
+		require.Len(t, sy.Tasks, 1)
+		assert.Equal(t, int64(1), sy.Tasks[0].ID)
+		assert.Equal(t, int64(10), sy.Tasks[0].ProjectID)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		sy := &Sync{Since: "invalid"}
+		err := sy.ReadOne(s, u)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidCursor(err))
+	})
+}
+
+func TestDeleteExpiredTombstones(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	err := addTombstones(s, []*Tombstone{{Kind: TombstoneKindTask, EntityID: 200, ProjectID: 1}})
+	require.NoError(t, err)
+
+	err = deleteExpiredTombstones(s)
+	require.NoError(t, err)
+
+	db.AssertMissing(t, "tombstones", map[string]interface{}{
+		"id": 1,
+	})
+	db.AssertExists(t, "tombstones", map[string]interface{}{
+		"entity_id": 200,
+	}, false)
+}
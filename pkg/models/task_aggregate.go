@@ -0,0 +1,529 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/web"
+
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/typesense/typesense-go/typesense/api/pointer"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// TaskAggregate holds the filters, group by dimensions and metrics to aggregate tasks.
+type TaskAggregate struct {
+	// All filters of a task collection are supported to limit the tasks which are aggregated.
+	TaskCollection
+
+	// The dimensions to group tasks by. Possible values are `project_id`, `bucket_id`, `done`, `priority`,
+	// `created_by_id`, `assignees` and `labels`. If none are provided, all tasks are aggregated in a single group.
+	GroupBy    []string `query:"group_by" json:"group_by"`
+	GroupByArr []string `query:"group_by[]" json:"-"`
+	// The metrics to calculate for each group. Defaults to `count`.
+	Metrics    []string `query:"metrics" json:"metrics"`
+	MetricsArr []string `query:"metrics[]" json:"-"`
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+// TaskAggregateGroup holds the metrics of one group of tasks.
+type TaskAggregateGroup struct {
+	// The value of every group by dimension for this group, keyed by the dimension.
+	// A null value groups all tasks without one, for example all tasks without assignees.
+	Group map[string]interface{} `json:"group"`
+	// The calculated metrics of all tasks in this group, keyed by the metric.
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+const (
+	taskAggregateMetricCount          = "count"
+	taskAggregateMetricSumPercentDone = "sum_percent_done"
+	taskAggregateMetricAvgPercentDone = "avg_percent_done"
+
+	// The maximum number of groups typesense returns for a facet
+	maxTaskAggregateFacetValues = 1000
+)
+
+type taskAggregateValueKind int
+
+const (
+	taskAggregateValueInt taskAggregateValueKind = iota
+	taskAggregateValueBool
+	taskAggregateValueFloat
+	taskAggregateValueDate
+)
+
+type taskAggregateDimension struct {
+	name   string
+	column string
+	kind   taskAggregateValueKind
+	// The table which needs to be joined to group by this dimension
+	joinTable string
+	// The field to facet by in typesense, empty if it's not possible to group by this dimension with typesense
+	typesenseField string
+}
+
+type taskAggregateMetric struct {
+	name       string
+	expression string
+	kind       taskAggregateValueKind
+}
+
+var taskAggregateDimensions = map[string]*taskAggregateDimension{
+	taskPropertyProjectID:   {column: "tasks.project_id", typesenseField: "project_id"},
+	taskPropertyBucketID:    {column: "tasks.bucket_id", typesenseField: "bucket_id"},
+	taskPropertyDone:        {column: "tasks.done", kind: taskAggregateValueBool, typesenseField: "done"},
+	taskPropertyPriority:    {column: "tasks.priority", typesenseField: "priority"},
+	taskPropertyCreatedByID: {column: "tasks.created_by_id", typesenseField: "created_by_id"},
+	// Tasks without assignees or labels are grouped as 0 since not all databases return null
+	// values from raw queries in a way we could tell them apart from 0.
+	"assignees": {column: "COALESCE(task_assignees.user_id, 0)", joinTable: "task_assignees"},
+	"labels":    {column: "COALESCE(label_tasks.label_id, 0)", joinTable: "label_tasks"},
+}
+
+var taskAggregateDateFields = []string{
+	taskPropertyDueDate,
+	taskPropertyStartDate,
+	taskPropertyEndDate,
+	taskPropertyDoneAt,
+	taskPropertyCreated,
+	taskPropertyUpdated,
+}
+
+type taskAggregateOptions struct {
+	dimensions []*taskAggregateDimension
+	metrics    []*taskAggregateMetric
+}
+
+func getTaskAggregateDimension(name string) (*taskAggregateDimension, error) {
+	dimension, exists := taskAggregateDimensions[name]
+	if !exists {
+		return nil, ErrInvalidTaskAggregateGroupBy{GroupBy: name}
+	}
+
+	d := *dimension
+	d.name = name
+	return &d, nil
+}
+
+func getTaskAggregateMetric(name string) (*taskAggregateMetric, error) {
+	switch name {
+	case taskAggregateMetricCount:
+		return &taskAggregateMetric{name: name, expression: "COUNT(DISTINCT tasks.id)", kind: taskAggregateValueInt}, nil
+	case taskAggregateMetricSumPercentDone:
+		return &taskAggregateMetric{name: name, expression: "COALESCE(SUM(tasks.percent_done), 0)", kind: taskAggregateValueFloat}, nil
+	case taskAggregateMetricAvgPercentDone:
+		return &taskAggregateMetric{name: name, expression: "AVG(tasks.percent_done)", kind: taskAggregateValueFloat}, nil
+	}
+
+	// min_<date field> and max_<date field>
+	function, field, found := strings.Cut(name, "_")
+	if found && (function == "min" || function == "max") {
+		for _, f := range taskAggregateDateFields {
+			if f == field {
+				return &taskAggregateMetric{
+					name:       name,
+					expression: strings.ToUpper(function) + "(tasks." + field + ")",
+					kind:       taskAggregateValueDate,
+				}, nil
+			}
+		}
+	}
+
+	return nil, ErrInvalidTaskAggregateMetric{Metric: name}
+}
+
+func getTaskAggregateOptions(ta *TaskAggregate) (opts *taskAggregateOptions, err error) {
+	opts = &taskAggregateOptions{}
+
+	seen := make(map[string]bool)
+	for _, name := range append(ta.GroupBy, ta.GroupByArr...) {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		dimension, err := getTaskAggregateDimension(name)
+		if err != nil {
+			return nil, err
+		}
+		opts.dimensions = append(opts.dimensions, dimension)
+	}
+
+	metrics := append(ta.Metrics, ta.MetricsArr...)
+	if len(metrics) == 0 {
+		metrics = []string{taskAggregateMetricCount}
+	}
+
+	seen = make(map[string]bool)
+	for _, name := range metrics {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		metric, err := getTaskAggregateMetric(name)
+		if err != nil {
+			return nil, err
+		}
+		opts.metrics = append(opts.metrics, metric)
+	}
+
+	return
+}
+
+// ReadAll aggregates all tasks matching the filters
+// @Summary Aggregate tasks
+// @Description Groups all tasks matching the filters by the provided dimensions and calculates metrics for each group, for example the number of open tasks per assignee. Accepts all filter parameters of the task collection.
+// @tags task
+// @Accept json
+// @Produce json
+// @Param projectID path int true "The project ID. Only available on the project route."
+// @Param page query int false "The page number. Used for pagination. If not provided, the first page of results is returned."
+// @Param per_page query int false "The maximum number of groups per page. Note this parameter is limited by the configured maximum of items per page."
+// @Param s query string false "Only aggregate tasks matching this search text."
+// @Param group_by query string false "The dimension to group tasks by. You can pass this multiple times to group by multiple dimensions. Possible values are `project_id`, `bucket_id`, `done`, `priority`, `created_by_id`, `assignees` and `labels`. If not provided, all tasks are aggregated in one group."
+// @Param metrics query string false "The metric to calculate per group. You can pass this multiple times. Possible values are `count`, `sum_percent_done`, `avg_percent_done` and `min_` or `max_` of `due_date`, `start_date`, `end_date`, `done_at`, `created` and `updated`, for example `max_due_date`. Defaults to `count`."
+// @Param filter_by query string false "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match."
+// @Param filter_value query string false "The value to filter for. You can use [grafana](https://grafana.com/docs/grafana/latest/dashboards/time-range-controls)- or [elasticsearch](https://www.elastic.co/guide/en/elasticsearch/reference/7.3/common-options.html#date-math)-style relative dates for all date fields like `due_date`, `start_date`, `end_date`, etc."
+// @Param filter_comparator query string false "The comparator to use for a filter. Available values are `equals`, `greater`, `greater_equals`, `less`, `less_equals`, `like` and `in`. `in` expects comma-separated values in `filter_value`. Defaults to `equals`"
+// @Param filter_concat query string false "The concatinator to use for filters. Available values are `and` or `or`. Defaults to `or`."
+// @Param filter_include_nulls query string false "If set to true the result will include filtered fields whose value is set to `null`. Available values are `true` or `false`. Defaults to `false`."
+// @Security JWTKeyAuth
+// @Success 200 {array} models.TaskAggregateGroup "The groups of tasks with their metrics"
+// @Failure 400 {object} web.HTTPError "Invalid group by dimension or metric."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/aggregate [get]
+// @Router /projects/{projectID}/tasks/aggregate [get]
+func (ta *TaskAggregate) ReadAll(s *xorm.Session, a web.Auth, search string, page int, perPage int) (result interface{}, resultCount int, totalItems int64, err error) {
+
+	aggregateOpts, err := getTaskAggregateOptions(ta)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	tc := &ta.TaskCollection
+	if tc.ProjectID < -1 {
+		tc, err = tc.getSavedFilterCollection(s)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	taskopts, err := getTaskFilterOptsFromCollection(tc)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	taskopts.search = search
+	if taskopts.filterConcat == "" {
+		taskopts.filterConcat = filterConcatOr
+	}
+
+	projects, err := tc.getProjects(s, a)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	groups := []*TaskAggregateGroup{}
+	if len(projects) > 0 {
+		groups, err = getTaskSearcher(s, a, projects, taskopts).Aggregate(taskopts, aggregateOpts)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	sortTaskAggregateGroups(groups, aggregateOpts.dimensions)
+
+	totalItems = int64(len(groups))
+	limit, start := getLimitFromPageIndex(page, perPage)
+	if limit > 0 {
+		if start > len(groups) {
+			start = len(groups)
+		}
+		end := start + limit
+		if end > len(groups) {
+			end = len(groups)
+		}
+		groups = groups[start:end]
+	}
+
+	return groups, len(groups), totalItems, nil
+}
+
+// Aggregate groups all tasks matching the search options with a GROUP BY query.
+func (d *dbTaskSearcher) Aggregate(opts *taskSearchOptions, aggregateOpts *taskAggregateOptions) (groups []*TaskAggregateGroup, err error) {
+	cond, err := d.getTaskCond(opts)
+	if err != nil || cond == nil {
+		return []*TaskAggregateGroup{}, err
+	}
+
+	selects := make([]string, 0, len(aggregateOpts.dimensions)+len(aggregateOpts.metrics))
+	groupBy := make([]string, 0, len(aggregateOpts.dimensions))
+	joins := make(map[string]bool)
+	for i, dimension := range aggregateOpts.dimensions {
+		selects = append(selects, dimension.column+" AS g"+strconv.Itoa(i))
+		groupBy = append(groupBy, dimension.column)
+		if dimension.joinTable != "" {
+			joins[dimension.joinTable] = true
+		}
+	}
+	for i, metric := range aggregateOpts.metrics {
+		selects = append(selects, metric.expression+" AS m"+strconv.Itoa(i))
+	}
+
+	// The task conditions are applied through a sub query because the joined tables have
+	// columns with the same names as the tasks table.
+	query := d.s.
+		Table("tasks").
+		Select(strings.Join(selects, ", ")).
+		Where(builder.In("tasks.id", builder.Select("id").From("tasks").Where(cond)))
+	for _, table := range []string{"task_assignees", "label_tasks"} {
+		if joins[table] {
+			query = query.Join("LEFT", table, table+".task_id = tasks.id")
+		}
+	}
+	if len(groupBy) > 0 {
+		query = query.GroupBy(strings.Join(groupBy, ", "))
+	}
+
+	rows, err := query.QueryInterface()
+	if err != nil {
+		return nil, err
+	}
+
+	dbTZ := d.s.Engine().GetTZDatabase()
+	groups = make([]*TaskAggregateGroup, 0, len(rows))
+	for _, row := range rows {
+		group := &TaskAggregateGroup{
+			Group:   make(map[string]interface{}, len(aggregateOpts.dimensions)),
+			Metrics: make(map[string]interface{}, len(aggregateOpts.metrics)),
+		}
+		for i, dimension := range aggregateOpts.dimensions {
+			value, err := convertTaskAggregateValue(row["g"+strconv.Itoa(i)], dimension.kind, dbTZ)
+			if err != nil {
+				return nil, err
+			}
+			if dimension.joinTable != "" && value == int64(0) {
+				value = nil
+			}
+			group.Group[dimension.name] = value
+		}
+		for i, metric := range aggregateOpts.metrics {
+			group.Metrics[metric.name], err = convertTaskAggregateValue(row["m"+strconv.Itoa(i)], metric.kind, dbTZ)
+			if err != nil {
+				return nil, err
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// Aggregate looks up matching tasks in the search index and aggregates them in the database.
+func (t *indexTaskSearcher) Aggregate(opts *taskSearchOptions, aggregateOpts *taskAggregateOptions) (groups []*TaskAggregateGroup, err error) {
+	if opts.search == "" {
+		return t.db.Aggregate(opts, aggregateOpts)
+	}
+
+	results, err := t.search(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t.db.taskIDs = make([]int64, 0, len(results))
+	for _, r := range results {
+		t.db.taskIDs = append(t.db.taskIDs, r.ID)
+	}
+
+	return t.db.Aggregate(opts, aggregateOpts)
+}
+
+// Aggregate uses typesense facets to count the tasks grouped by a single dimension.
+// All other aggregations are done in the database.
+func (t *typesenseTaskSearcher) Aggregate(opts *taskSearchOptions, aggregateOpts *taskAggregateOptions) (groups []*TaskAggregateGroup, err error) {
+	if len(aggregateOpts.dimensions) != 1 ||
+		aggregateOpts.dimensions[0].typesenseField == "" ||
+		len(aggregateOpts.metrics) != 1 ||
+		aggregateOpts.metrics[0].name != taskAggregateMetricCount {
+		return t.db.Aggregate(opts, aggregateOpts)
+	}
+
+	dimension := aggregateOpts.dimensions[0]
+
+	search := opts.search
+	if search == "" {
+		search = "*"
+	}
+
+	params := &api.SearchCollectionParams{
+		Q:                search,
+		QueryBy:          "title, identifier, description, comments.comment",
+		PerPage:          pointer.Int(0),
+		ExhaustiveSearch: pointer.True(),
+		FilterBy:         pointer.String(getTypesenseTaskFilterBy(opts)),
+		FacetBy:          pointer.String(dimension.typesenseField),
+		MaxFacetValues:   pointer.Int(maxTaskAggregateFacetValues),
+	}
+
+	result, err := typesenseClient.Collection("tasks").
+		Documents().
+		Search(params)
+	if err != nil {
+		return nil, err
+	}
+
+	groups = []*TaskAggregateGroup{}
+	if result.FacetCounts == nil {
+		return groups, nil
+	}
+
+	for _, facet := range *result.FacetCounts {
+		if facet.FieldName == nil || *facet.FieldName != dimension.typesenseField || facet.Counts == nil {
+			continue
+		}
+
+		for _, count := range *facet.Counts {
+			if count.Value == nil || count.Count == nil {
+				continue
+			}
+
+			value, err := convertTaskAggregateValue(*count.Value, dimension.kind, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			groups = append(groups, &TaskAggregateGroup{
+				Group:   map[string]interface{}{dimension.name: value},
+				Metrics: map[string]interface{}{taskAggregateMetricCount: int64(*count.Count)},
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// convertTaskAggregateValue converts a raw value returned by the database or typesense to the type of the
+// dimension or metric. Dates are returned by the database without time zone and are interpreted in dbTZ.
+func convertTaskAggregateValue(value interface{}, kind taskAggregateValueKind, dbTZ *time.Location) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if b, is := value.([]byte); is {
+		value = string(b)
+	}
+
+	// Null dates are returned as empty strings or zero times by some databases
+	if kind == taskAggregateValueDate {
+		if v, is := value.(string); is && v == "" {
+			return nil, nil
+		}
+		if v, is := value.(time.Time); is && v.IsZero() {
+			return nil, nil
+		}
+	}
+
+	switch kind {
+	case taskAggregateValueInt:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case int32:
+			return int64(v), nil
+		case int:
+			return int64(v), nil
+		case float64:
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case taskAggregateValueBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case taskAggregateValueFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case taskAggregateValueDate:
+		switch v := value.(type) {
+		case time.Time:
+			return time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), dbTZ).
+				In(config.GetTimeZone()), nil
+		case string:
+			for _, layout := range []string{
+				dbTimeFormat,
+				time.RFC3339Nano,
+				"2006-01-02 15:04:05.999999999-07:00",
+			} {
+				parsed, err := time.ParseInLocation(layout, v, dbTZ)
+				if err == nil {
+					return parsed.In(config.GetTimeZone()), nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("could not convert aggregated value %v (%T)", value, value)
+}
+
+// sortTaskAggregateGroups sorts groups ascending by the values of their dimensions, groups without a value come first.
+func sortTaskAggregateGroups(groups []*TaskAggregateGroup, dimensions []*taskAggregateDimension) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		for _, dimension := range dimensions {
+			a := groups[i].Group[dimension.name]
+			b := groups[j].Group[dimension.name]
+			if a == b {
+				continue
+			}
+			if a == nil || b == nil {
+				return a == nil
+			}
+
+			switch av := a.(type) {
+			case int64:
+				return av < b.(int64)
+			case bool:
+				return !av
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,165 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskAggregate_ReadAll(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	aggregate := func(t *testing.T, ta *TaskAggregate) []*TaskAggregateGroup {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		result, _, _, err := ta.ReadAll(s, u, "", 0, 50)
+		require.NoError(t, err)
+		return result.([]*TaskAggregateGroup)
+	}
+
+	t.Run("count without group by", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{TaskCollection: TaskCollection{ProjectID: 1}})
+		require.Len(t, groups, 1)
+		assert.Empty(t, groups[0].Group)
+		assert.Equal(t, int64(18), groups[0].Metrics["count"])
+	})
+	t.Run("group by done", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{
+			TaskCollection: TaskCollection{ProjectID: 1},
+			GroupBy:        []string{"done"},
+		})
+		require.Len(t, groups, 2)
+		assert.Equal(t, false, groups[0].Group["done"])
+		assert.Equal(t, int64(17), groups[0].Metrics["count"])
+		assert.Equal(t, true, groups[1].Group["done"])
+		assert.Equal(t, int64(1), groups[1].Metrics["count"])
+	})
+	t.Run("group by labels", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{
+			TaskCollection: TaskCollection{ProjectID: 1},
+			GroupBy:        []string{"labels"},
+		})
+		require.Len(t, groups, 2)
+		assert.Nil(t, groups[0].Group["labels"])
+		assert.Equal(t, int64(16), groups[0].Metrics["count"])
+		assert.Equal(t, int64(4), groups[1].Group["labels"])
+		assert.Equal(t, int64(2), groups[1].Metrics["count"])
+	})
+	t.Run("group by assignees and done", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{
+			TaskCollection: TaskCollection{ProjectID: 1},
+			GroupBy:        []string{"assignees", "done"},
+		})
+		require.Len(t, groups, 4)
+		assert.Equal(t, map[string]interface{}{"assignees": nil, "done": false}, groups[0].Group)
+		assert.Equal(t, int64(16), groups[0].Metrics["count"])
+		assert.Equal(t, map[string]interface{}{"assignees": nil, "done": true}, groups[1].Group)
+		assert.Equal(t, map[string]interface{}{"assignees": int64(1), "done": false}, groups[2].Group)
+		assert.Equal(t, map[string]interface{}{"assignees": int64(2), "done": false}, groups[3].Group)
+		assert.Equal(t, int64(1), groups[3].Metrics["count"])
+	})
+	t.Run("metrics", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{
+			TaskCollection: TaskCollection{ProjectID: 1},
+			Metrics:        []string{"count", "sum_percent_done", "min_due_date", "max_due_date", "max_done_at"},
+		})
+		require.Len(t, groups, 1)
+
+		s := db.NewSession()
+		defer s.Close()
+		task5, err := GetTaskByIDSimple(s, 5)
+		require.NoError(t, err)
+		task6, err := GetTaskByIDSimple(s, 6)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(18), groups[0].Metrics["count"])
+		assert.Equal(t, 0.5, groups[0].Metrics["sum_percent_done"])
+		assert.Equal(t, task6.DueDate.Unix(), groups[0].Metrics["min_due_date"].(time.Time).Unix())
+		assert.Equal(t, task5.DueDate.Unix(), groups[0].Metrics["max_due_date"].(time.Time).Unix())
+		assert.Nil(t, groups[0].Metrics["max_done_at"])
+	})
+	t.Run("with filter", func(t *testing.T) {
+		groups := aggregate(t, &TaskAggregate{
+			TaskCollection: TaskCollection{
+				ProjectID:        1,
+				FilterBy:         []string{"done"},
+				FilterValue:      []string{"false"},
+				FilterComparator: []string{"equals"},
+			},
+			GroupBy: []string{"priority"},
+		})
+		require.Len(t, groups, 3)
+		assert.Equal(t, int64(0), groups[0].Group["priority"])
+		assert.Equal(t, int64(15), groups[0].Metrics["count"])
+		assert.Equal(t, int64(1), groups[1].Group["priority"])
+		assert.Equal(t, int64(100), groups[2].Group["priority"])
+	})
+	t.Run("paginated", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ta := &TaskAggregate{
+			TaskCollection: TaskCollection{ProjectID: 1},
+			GroupBy:        []string{"done"},
+		}
+		result, resultCount, totalItems, err := ta.ReadAll(s, u, "", 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, resultCount)
+		assert.Equal(t, int64(2), totalItems)
+		assert.Equal(t, true, result.([]*TaskAggregateGroup)[0].Group["done"])
+	})
+	t.Run("invalid group by", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ta := &TaskAggregate{GroupBy: []string{"title"}}
+		_, _, _, err := ta.ReadAll(s, u, "", 0, 50)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidTaskAggregateGroupBy(err))
+	})
+	t.Run("invalid metric", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ta := &TaskAggregate{Metrics: []string{"min_title"}}
+		_, _, _, err := ta.ReadAll(s, u, "", 0, 50)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidTaskAggregateMetric(err))
+	})
+	t.Run("no access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ta := &TaskAggregate{TaskCollection: TaskCollection{ProjectID: 20}}
+		_, _, _, err := ta.ReadAll(s, u, "", 0, 50)
+		require.Error(t, err)
+		assert.True(t, IsErrUserDoesNotHaveAccessToProject(err))
+	})
+}
@@ -184,7 +184,7 @@ func (la *TaskAssginee) Delete(s *xorm.Session, a web.Auth) (err error) {
 	}
 
 	doer, _ := user.GetFromAuth(a)
-	return events.Dispatch(&TaskAssigneeDeletedEvent{
+	return events.DispatchOnCommit(s, &TaskAssigneeDeletedEvent{
 		Task:     &Task{ID: la.TaskID},
 		Assignee: &user.User{ID: la.UserID},
 		Doer:     doer,
@@ -252,7 +252,7 @@ func (t *Task) addNewAssigneeByID(s *xorm.Session, newAssigneeID int64, project
 	}
 
 	doer, _ := user.GetFromAuth(auth)
-	err = events.Dispatch(&TaskAssigneeCreatedEvent{
+	err = events.DispatchOnCommit(s, &TaskAssigneeCreatedEvent{
 		Task:     t,
 		Assignee: newAssignee,
 		Doer:     doer,
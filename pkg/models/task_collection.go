@@ -127,6 +127,7 @@ func getTaskFilterOptsFromCollection(tf *TaskCollection) (opts *taskSearchOption
 // @Param projectID path int true "The project ID."
 // @Param page query int false "The page number. Used for pagination. If not provided, the first page of results is returned."
 // @Param per_page query int false "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page."
+// @Param cursor query string false "Paginate with a cursor instead of page numbers. Pass an empty cursor for the first page and the `x-pagination-next-cursor` header of the last response for the following ones. Cursors only work with the same sort parameters they were created with."
 // @Param s query string false "Search tasks by task text."
 // @Param sort_by query string false "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parametes, along with `order_by`. Possible values to sort by are `id`, `title`, `description`, `done`, `done_at`, `due_date`, `created_by_id`, `project_id`, `repeat_after`, `priority`, `start_date`, `end_date`, `hex_color`, `percent_done`, `uid`, `created`, `updated`. Default is `id`."
 // @Param order_by query string false "The ordering parameter. Possible values to order by are `asc` or `desc`. Default is `asc`."
@@ -144,28 +145,11 @@ func (tf *TaskCollection) ReadAll(s *xorm.Session, a web.Auth, search string, pa
 	// If the project id is < -1 this means we're dealing with a saved filter - in that case we get and populate the filter
 	// -1 is the favorites project which works as intended
 	if tf.ProjectID < -1 {
-		sf, err := getSavedFilterSimpleByID(s, getSavedFilterIDFromProjectID(tf.ProjectID))
+		sfCollection, err := tf.getSavedFilterCollection(s)
 		if err != nil {
 			return nil, 0, 0, err
 		}
-
-		// By prepending sort options before the saved ones from the filter, we make sure the supplied sort
-		// options via query take precedence over the rest.
-
-		sortby := append(tf.SortBy, tf.SortByArr...)
-		sortby = append(sortby, sf.Filters.SortBy...)
-		sortby = append(sortby, sf.Filters.SortByArr...)
-
-		orderby := append(tf.OrderBy, tf.OrderByArr...)
-		orderby = append(orderby, sf.Filters.OrderBy...)
-		orderby = append(orderby, sf.Filters.OrderByArr...)
-
-		sf.Filters.SortBy = sortby
-		sf.Filters.SortByArr = nil
-		sf.Filters.OrderBy = orderby
-		sf.Filters.OrderByArr = nil
-
-		return sf.getTaskCollection().ReadAll(s, a, search, page, perPage)
+		return sfCollection.ReadAll(s, a, search, page, perPage)
 	}
 
 	taskopts, err := getTaskFilterOptsFromCollection(tf)
@@ -177,18 +161,54 @@ func (tf *TaskCollection) ReadAll(s *xorm.Session, a web.Auth, search string, pa
 	taskopts.page = page
 	taskopts.perPage = perPage
 
+	projects, err := tf.getProjects(s, a)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return getTasksForProjects(s, projects, a, taskopts)
+}
+
+// getSavedFilterCollection returns the task collection of the saved filter the collection's project id points to.
+// The sort options of the collection take precedence over the ones saved with the filter.
+func (tf *TaskCollection) getSavedFilterCollection(s *xorm.Session) (*TaskCollection, error) {
+	sf, err := getSavedFilterSimpleByID(s, getSavedFilterIDFromProjectID(tf.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+
+	// By prepending sort options before the saved ones from the filter, we make sure the supplied sort
+	// options via query take precedence over the rest.
+
+	sortby := append(tf.SortBy, tf.SortByArr...)
+	sortby = append(sortby, sf.Filters.SortBy...)
+	sortby = append(sortby, sf.Filters.SortByArr...)
+
+	orderby := append(tf.OrderBy, tf.OrderByArr...)
+	orderby = append(orderby, sf.Filters.OrderBy...)
+	orderby = append(orderby, sf.Filters.OrderByArr...)
+
+	sf.Filters.SortBy = sortby
+	sf.Filters.SortByArr = nil
+	sf.Filters.OrderBy = orderby
+	sf.Filters.OrderByArr = nil
+
+	return sf.getTaskCollection(), nil
+}
+
+// getProjects returns all projects whose tasks are part of the collection, checking the auth has access to them.
+func (tf *TaskCollection) getProjects(s *xorm.Session, a web.Auth) (projects []*Project, err error) {
 	shareAuth, is := a.(*LinkSharing)
 	if is {
 		project, err := GetProjectSimpleByID(s, shareAuth.ProjectID)
 		if err != nil {
-			return nil, 0, 0, err
+			return nil, err
 		}
-		return getTasksForProjects(s, []*Project{project}, a, taskopts)
+		return []*Project{project}, nil
 	}
 
 	// If the project ID is not set, we get all tasks for the user.
 	// This allows to use this function in Task.ReadAll with a possibility to deprecate the latter at some point.
-	var projects []*Project
 	if tf.ProjectID == 0 {
 		projects, _, _, err = getRawProjectsForUser(
 			s,
@@ -197,21 +217,17 @@ func (tf *TaskCollection) ReadAll(s *xorm.Session, a web.Auth, search string, pa
 				page: -1,
 			},
 		)
-		if err != nil {
-			return nil, 0, 0, err
-		}
-	} else {
-		// Check the project exists and the user has access on it
-		project := &Project{ID: tf.ProjectID}
-		canRead, _, err := project.CanRead(s, a)
-		if err != nil {
-			return nil, 0, 0, err
-		}
-		if !canRead {
-			return nil, 0, 0, ErrUserDoesNotHaveAccessToProject{ProjectID: tf.ProjectID}
-		}
-		projects = []*Project{{ID: tf.ProjectID}}
+		return projects, err
 	}
 
-	return getTasksForProjects(s, projects, a, taskopts)
+	// Check the project exists and the user has access on it
+	project := &Project{ID: tf.ProjectID}
+	canRead, _, err := project.CanRead(s, a)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserDoesNotHaveAccessToProject{ProjectID: tf.ProjectID}
+	}
+	return []*Project{{ID: tf.ProjectID}}, nil
 }
@@ -40,6 +40,10 @@ type TaskComment struct {
 	Created time.Time `xorm:"created" json:"created"`
 	Updated time.Time `xorm:"updated" json:"updated"`
 
+	// The version of this comment. It is increased with every change, the comment's ETag is based on it.
+	Version int64  `xorm:"bigint not null default 0" json:"version"`
+	IfMatch string `xorm:"-" json:"-" header:"If-Match"`
+
 	web.CRUDable `xorm:"-" json:"-"`
 	web.Rights   `xorm:"-" json:"-"`
 }
@@ -130,20 +134,31 @@ func (tc *TaskComment) Delete(s *xorm.Session, _ web.Auth) error {
 // @Security JWTKeyAuth
 // @Param taskID path int true "Task ID"
 // @Param commentID path int true "Comment ID"
+// @Param If-Match header string false "The ETag or version of the comment the changes are based on. If the comment was changed since then, it is not updated."
 // @Success 200 {object} models.TaskComment "The updated task comment object."
 // @Failure 400 {object} web.HTTPError "Invalid task comment object provided."
 // @Failure 404 {object} web.HTTPError "The task comment was not found."
+// @Failure 412 {object} models.TaskComment "The comment was changed in the meantime. Returns the current comment."
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /tasks/{taskID}/comments/{commentID} [post]
 func (tc *TaskComment) Update(s *xorm.Session, _ web.Auth) error {
-	updated, err := s.
-		ID(tc.ID).
-		Cols("comment").
-		Update(tc)
-	if updated == 0 {
+	exists, err := s.Where("id = ?", tc.ID).Exist(&TaskComment{})
+	if err != nil {
+		return err
+	}
+	if !exists {
 		return ErrTaskCommentDoesNotExist{ID: tc.ID}
 	}
 
+	tc.Version, err = bumpVersion(s, "task_comments", tc.ID, tc.IfMatch)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.
+		ID(tc.ID).
+		Cols("comment").
+		Update(tc)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,274 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/web"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// taskCursor holds the position after the last task of a page when paginating tasks with a cursor.
+// Because it holds the values of all sort parameters instead of an offset, tasks which are created,
+// changed or deleted between two requests don't shift the following pages.
+type taskCursor struct {
+	// The sort parameters the cursor was created with, empty for the first page.
+	Fields []string `json:"f"`
+	// The values of all sort parameters of the last task, in the same order.
+	Values []interface{} `json:"v"`
+
+	raw string
+}
+
+func decodeTaskCursor(cursor string) (*taskCursor, error) {
+	c := &taskCursor{raw: cursor}
+	if cursor == "" {
+		return c, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor{Cursor: cursor}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(c); err != nil || len(c.Fields) != len(c.Values) {
+		return nil, ErrInvalidCursor{Cursor: cursor}
+	}
+
+	return c, nil
+}
+
+func encodeTaskCursor(task *Task, sortby []*sortParam) (string, error) {
+	c := &taskCursor{
+		Fields: make([]string, 0, len(sortby)),
+		Values: make([]interface{}, 0, len(sortby)),
+	}
+	for _, param := range sortby {
+		c.Fields = append(c.Fields, param.sortBy)
+		c.Values = append(c.Values, getTaskSortValue(task, param.sortBy))
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func getTaskCursorTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// getTaskSortValue returns the value of the task property a task list can be sorted by.
+// Unset dates are returned as nil since they are null in the database.
+func getTaskSortValue(t *Task, field string) interface{} {
+	switch field {
+	case taskPropertyID:
+		return t.ID
+	case taskPropertyTitle:
+		return t.Title
+	case taskPropertyDescription:
+		return t.Description
+	case taskPropertyDone:
+		return t.Done
+	case taskPropertyDoneAt:
+		return getTaskCursorTime(t.DoneAt)
+	case taskPropertyDueDate:
+		return getTaskCursorTime(t.DueDate)
+	case taskPropertyCreatedByID:
+		return t.CreatedByID
+	case taskPropertyProjectID:
+		return t.ProjectID
+	case taskPropertyRepeatAfter:
+		return t.RepeatAfter
+	case taskPropertyPriority:
+		return t.Priority
+	case taskPropertyStartDate:
+		return getTaskCursorTime(t.StartDate)
+	case taskPropertyEndDate:
+		return getTaskCursorTime(t.EndDate)
+	case taskPropertyHexColor:
+		return t.HexColor
+	case taskPropertyPercentDone:
+		return t.PercentDone
+	case taskPropertyUID:
+		return t.UID
+	case taskPropertyCreated:
+		return getTaskCursorTime(t.Created)
+	case taskPropertyUpdated:
+		return getTaskCursorTime(t.Updated)
+	case taskPropertyPosition:
+		return t.Position
+	case taskPropertyKanbanPosition:
+		return t.KanbanPosition
+	case taskPropertyBucketID:
+		return t.BucketID
+	case taskPropertyIndex:
+		return t.Index
+	}
+	return nil
+}
+
+// getTaskCursorValue converts a value decoded from a cursor back to the type of the task property.
+func getTaskCursorValue(field string, value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+
+	switch field {
+	case taskPropertyTitle, taskPropertyDescription, taskPropertyHexColor, taskPropertyUID:
+		v, is := value.(string)
+		return v, is
+	case taskPropertyDone:
+		v, is := value.(bool)
+		return v, is
+	case taskPropertyDoneAt, taskPropertyDueDate, taskPropertyStartDate, taskPropertyEndDate, taskPropertyCreated, taskPropertyUpdated:
+		v, is := value.(string)
+		if !is {
+			return nil, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t.In(config.GetTimeZone()), err == nil
+	case taskPropertyPercentDone, taskPropertyPosition, taskPropertyKanbanPosition:
+		v, is := value.(json.Number)
+		if !is {
+			return nil, false
+		}
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		v, is := value.(json.Number)
+		if !is {
+			return nil, false
+		}
+		i, err := v.Int64()
+		return i, err == nil
+	}
+}
+
+// getTaskCursorCond returns the condition matching all tasks after the cursor in the sort order of the search options.
+// Null values are always sorted last, tasks with the same values are sorted by the next sort parameter.
+// Dates are compared in the database time zone dbTZ.
+func getTaskCursorCond(opts *taskSearchOptions, dbTZ *time.Location) (builder.Cond, error) {
+	c := opts.cursor
+	if len(c.Fields) != len(opts.sortby) {
+		return nil, ErrInvalidCursor{Cursor: c.raw}
+	}
+
+	after := []builder.Cond{}
+	equal := []builder.Cond{}
+	for i, param := range opts.sortby {
+		if c.Fields[i] != param.sortBy {
+			return nil, ErrInvalidCursor{Cursor: c.raw}
+		}
+
+		value, valid := getTaskCursorValue(param.sortBy, c.Values[i])
+		if !valid {
+			return nil, ErrInvalidCursor{Cursor: c.raw}
+		}
+
+		if t, is := value.(time.Time); is {
+			value = t.In(dbTZ).Format(dbTimeFormat)
+		}
+
+		column := "`" + param.sortBy + "`"
+		if value == nil {
+			// Nothing is sorted after a null value, only other tasks with a null value can follow.
+			equal = append(equal, builder.IsNull{column})
+			continue
+		}
+
+		var next builder.Cond = builder.Gt{column: value}
+		if param.orderBy == orderDescending {
+			next = builder.Lt{column: value}
+		}
+
+		conds := make([]builder.Cond, 0, len(equal)+1)
+		conds = append(conds, equal...)
+		conds = append(conds, builder.Or(next, builder.IsNull{column}))
+		after = append(after, builder.And(conds...))
+		equal = append(equal, builder.Eq{column: value})
+	}
+
+	return builder.Or(after...), nil
+}
+
+// ReadAllByCursor gets one page of tasks of the collection after the cursor. It returns the cursor for the next page,
+// which is empty if there are no more tasks. An empty cursor returns the first page.
+// Contrary to ReadAll, tasks are never sorted by search relevance.
+func (tf *TaskCollection) ReadAllByCursor(s *xorm.Session, a web.Auth, search string, cursor string, perPage int) (tasks []*Task, nextCursor string, err error) {
+
+	if tf.ProjectID < -1 {
+		sfCollection, err := tf.getSavedFilterCollection(s)
+		if err != nil {
+			return nil, "", err
+		}
+		return sfCollection.ReadAllByCursor(s, a, search, cursor, perPage)
+	}
+
+	taskopts, err := getTaskFilterOptsFromCollection(tf)
+	if err != nil {
+		return nil, "", err
+	}
+
+	taskopts.cursor, err = decodeTaskCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	maxPerPage := config.ServiceMaxItemsPerPage.GetInt()
+	if perPage <= 0 || perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	taskopts.search = search
+	// Getting one more task than requested tells us if there is another page.
+	taskopts.perPage = perPage + 1
+
+	projects, err := tf.getProjects(s, a)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tasks, _, _, err = getTasksForProjects(s, projects, a, taskopts)
+	if err != nil {
+		return nil, "", err
+	}
+	if tasks == nil {
+		tasks = []*Task{}
+	}
+
+	if len(tasks) > perPage {
+		tasks = tasks[:perPage]
+		nextCursor, err = encodeTaskCursor(tasks[len(tasks)-1], taskopts.sortby)
+	}
+
+	return tasks, nextCursor, err
+}
@@ -0,0 +1,132 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCollection_ReadAllByCursor(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	getAllPages := func(t *testing.T, tc TaskCollection, perPage int) (ids []int64) {
+		s := db.NewSession()
+		defer s.Close()
+
+		cursor := ""
+		for i := 0; i < 100; i++ {
+			collection := tc
+			tasks, next, err := collection.ReadAllByCursor(s, u, "", cursor, perPage)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(tasks), perPage)
+			for _, task := range tasks {
+				ids = append(ids, task.ID)
+			}
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+		t.Fatal("pagination did not end")
+		return
+	}
+
+	getAllIDs := func(t *testing.T, tc TaskCollection) (ids []int64) {
+		s := db.NewSession()
+		defer s.Close()
+
+		result, _, _, err := tc.ReadAll(s, u, "", 0, 0)
+		require.NoError(t, err)
+		for _, task := range result.([]*Task) {
+			ids = append(ids, task.ID)
+		}
+		return
+	}
+
+	t.Run("all pages", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		tc := TaskCollection{ProjectID: 1}
+		ids := getAllPages(t, tc, 5)
+		assert.Len(t, ids, 18)
+		assert.Equal(t, getAllIDs(t, tc), ids)
+	})
+	t.Run("sorted with null values", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		tc := TaskCollection{
+			SortBy:  []string{"due_date", "done"},
+			OrderBy: []string{"desc", "asc"},
+		}
+		ids := getAllPages(t, tc, 3)
+		expected := getAllIDs(t, TaskCollection{
+			SortBy:  []string{"due_date", "done"},
+			OrderBy: []string{"desc", "asc"},
+		})
+		assert.Equal(t, expected, ids)
+	})
+	t.Run("stable when tasks are deleted", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tc := &TaskCollection{ProjectID: 1}
+		tasks, next, err := tc.ReadAllByCursor(s, u, "", "", 5)
+		require.NoError(t, err)
+		require.Len(t, tasks, 5)
+		assert.Equal(t, int64(5), tasks[4].ID)
+
+		_, err = s.ID(1).Delete(&Task{})
+		require.NoError(t, err)
+
+		tc = &TaskCollection{ProjectID: 1}
+		tasks, _, err = tc.ReadAllByCursor(s, u, "", next, 5)
+		require.NoError(t, err)
+		require.Len(t, tasks, 5)
+		assert.Equal(t, int64(6), tasks[0].ID)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tc := &TaskCollection{ProjectID: 1}
+		_, _, err := tc.ReadAllByCursor(s, u, "", "invalid", 5)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidCursor(err))
+	})
+	t.Run("cursor with different sort", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tc := &TaskCollection{ProjectID: 1}
+		_, next, err := tc.ReadAllByCursor(s, u, "", "", 5)
+		require.NoError(t, err)
+
+		tc = &TaskCollection{ProjectID: 1, SortBy: []string{"title"}}
+		_, _, err = tc.ReadAllByCursor(s, u, "", next, 5)
+		require.Error(t, err)
+		assert.True(t, IsErrInvalidCursor(err))
+	})
+}
@@ -0,0 +1,360 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web"
+
+	"xorm.io/xorm"
+)
+
+// TaskIdentifierRedirect holds an identifier a task had before it was moved into another project
+type TaskIdentifierRedirect struct {
+	ID int64 `xorm:"bigint autoincr not null unique pk" json:"-"`
+	// The task which had this identifier.
+	TaskID int64 `xorm:"bigint not null index" json:"task_id"`
+	// The project the task was in.
+	ProjectID int64 `xorm:"bigint not null index" json:"project_id"`
+	// The index the task had in that project.
+	Index int64 `xorm:"bigint not null" json:"index"`
+	// The old identifier of the task, for example `OPS-42`.
+	Identifier string `xorm:"varchar(250) not null index" json:"identifier"`
+	// When the task was moved.
+	Created time.Time `xorm:"created not null" json:"created"`
+}
+
+// TableName returns the table name for task identifier redirects
+func (*TaskIdentifierRedirect) TableName() string {
+	return "task_identifier_redirects"
+}
+
+// addTaskIdentifierRedirect records the identifier a task had before it was moved out of its project, so
+// references to the old identifier still lead to the task.
+func addTaskIdentifierRedirect(s *xorm.Session, t *Task) (err error) {
+	project, err := GetProjectSimpleByID(s, t.ProjectID)
+	if err != nil {
+		return err
+	}
+	old := *t
+	old.setIdentifier(project)
+
+	_, err = s.Insert(&TaskIdentifierRedirect{
+		TaskID:     t.ID,
+		ProjectID:  t.ProjectID,
+		Index:      t.Index,
+		Identifier: old.Identifier,
+	})
+	return
+}
+
+// getTaskByIdentifier returns the task with an identifier like `OPS-42` the user has access to. If there is no task
+// with that identifier in the project right now, the task which had the identifier before it was moved is returned.
+// Tasks the user can't read are treated as if they didn't exist, to not reveal which identifiers are in use.
+func getTaskByIdentifier(s *xorm.Session, a web.Auth, identifier string) (task Task, err error) {
+	i := strings.LastIndex(identifier, "-")
+	if i < 1 {
+		return task, ErrTaskIdentifierDoesNotExist{Identifier: identifier}
+	}
+	index, err := strconv.ParseInt(identifier[i+1:], 10, 64)
+	if err != nil {
+		return task, ErrTaskIdentifierDoesNotExist{Identifier: identifier}
+	}
+
+	projectIDs := []int64{}
+	err = s.
+		Table("projects").
+		Where("identifier = ?", identifier[:i]).
+		Cols("id").
+		Find(&projectIDs)
+	if err != nil {
+		return
+	}
+
+	// Several projects can have the same identifier
+	taskIDs := []int64{}
+	if len(projectIDs) > 0 {
+		err = s.
+			Table("tasks").
+			In("project_id", projectIDs).
+			And("`index` = ?", index).
+			Cols("id").
+			Find(&taskIDs)
+		if err != nil {
+			return
+		}
+	}
+
+	redirectTaskIDs := []int64{}
+	err = s.
+		Table("task_identifier_redirects").
+		Where("identifier = ?", identifier).
+		OrderBy("id desc").
+		Cols("task_id").
+		Find(&redirectTaskIDs)
+	if err != nil {
+		return
+	}
+	taskIDs = append(taskIDs, redirectTaskIDs...)
+
+	for _, id := range taskIDs {
+		t := &Task{ID: id}
+		can, _, err := t.CanRead(s, a)
+		if err != nil && !IsErrTaskDoesNotExist(err) {
+			return task, err
+		}
+		if can {
+			return *t, nil
+		}
+	}
+
+	return task, ErrTaskIdentifierDoesNotExist{Identifier: identifier}
+}
+
+// TaskMove is used to move a task into another project or bucket
+type TaskMove struct {
+	// The id of the task to move, from the url.
+	TaskID int64 `xorm:"-" json:"-" param:"projecttask"`
+	// The project to move the task to. If empty, the task stays in its project.
+	ProjectID int64 `json:"project_id"`
+	// The bucket to move the task to. If empty and the task is moved into another project, done tasks are put into
+	// the done bucket of that project, other tasks into a bucket with the same title as their current bucket or the
+	// default bucket if there is none.
+	BucketID int64 `json:"bucket_id"`
+	// The moved task. Ignored when sending.
+	Task *Task `json:"task"`
+
+	web.CRUDable `xorm:"-" json:"-"`
+	web.Rights   `xorm:"-" json:"-"`
+}
+
+// CanUpdate checks if the user can move the task. This needs write access to the project of the task and to the
+// project it is moved into.
+func (tm *TaskMove) CanUpdate(s *xorm.Session, a web.Auth) (bool, error) {
+	t := &Task{ID: tm.TaskID, ProjectID: tm.ProjectID}
+	return t.CanUpdate(s, a)
+}
+
+// Update moves a task
+// @Summary Move a task
+// @Description Moves a task into another project and/or kanban bucket. A task moved into another project gets a new index in that project, its old identifier keeps working with the `/tasks/by-identifier/{identifier}` endpoint.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param id path int true "The task id"
+// @Param move body models.TaskMove true "Where to move the task"
+// @Success 200 {object} models.TaskMove "The moved task."
+// @Failure 400 {object} web.HTTPError "Invalid move provided."
+// @Failure 403 {object} web.HTTPError "The user does not have write access to the task or the project it should be moved into."
+// @Failure 404 {object} web.HTTPError "The task, project or bucket does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/{id}/move [post]
+func (tm *TaskMove) Update(s *xorm.Session, a web.Auth) (err error) {
+	t, err := GetTaskByIDSimple(s, tm.TaskID)
+	if err != nil {
+		return err
+	}
+
+	err = moveTask(s, a, &t, tm.ProjectID, tm.BucketID)
+	if err != nil {
+		return err
+	}
+
+	tm.ProjectID = t.ProjectID
+	tm.BucketID = t.BucketID
+	tm.Task = &Task{ID: t.ID}
+	return tm.Task.ReadOne(s, a)
+}
+
+// TaskByIdentifier is used to get a task by its identifier, for example `OPS-42`
+type TaskByIdentifier struct {
+	// The identifier to look up, from the url.
+	LookupIdentifier string `xorm:"-" json:"-" param:"identifier"`
+	Task
+}
+
+// CanRead checks if the user can read the task with the identifier
+func (ti *TaskByIdentifier) CanRead(s *xorm.Session, a web.Auth) (bool, int, error) {
+	t, err := getTaskByIdentifier(s, a, ti.LookupIdentifier)
+	if err != nil {
+		return false, 0, err
+	}
+	ti.Task.ID = t.ID
+	return ti.Task.CanRead(s, a)
+}
+
+// ReadOne returns the task with the identifier
+// @Summary Get one task by its identifier
+// @Description Returns a task by its identifier, for example `OPS-42`. If the task was moved into another project, its old identifiers still resolve to it.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param identifier path string true "The task identifier"
+// @Success 200 {object} models.Task "The task"
+// @Failure 404 {object} web.HTTPError "There is no task with this identifier or the user does not have access to it."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/by-identifier/{identifier} [get]
+func (ti *TaskByIdentifier) ReadOne(s *xorm.Session, a web.Auth) (err error) {
+	return ti.Task.ReadOne(s, a)
+}
+
+// getBucketIDForMovedTask returns the bucket a task should be put into when it is moved into another project.
+// Done tasks go into the done bucket, other tasks into a bucket with the same title as their current one.
+func getBucketIDForMovedTask(s *xorm.Session, t *Task, project *Project) (bucketID int64, err error) {
+	if t.Done && project.DoneBucketID != 0 {
+		return project.DoneBucketID, nil
+	}
+
+	if t.BucketID != 0 {
+		current, err := getBucketByID(s, t.BucketID)
+		if err != nil && !IsErrBucketDoesNotExist(err) {
+			return 0, err
+		}
+		if err == nil {
+			bucket := &Bucket{}
+			exists, err := s.
+				Where("project_id = ? AND title = ?", project.ID, current.Title).
+				OrderBy("position asc").
+				Get(bucket)
+			if err != nil {
+				return 0, err
+			}
+			if exists {
+				return bucket.ID, nil
+			}
+		}
+	}
+
+	return getDefaultBucketID(s, project)
+}
+
+// moveTask moves a task into another project and/or bucket. The task keeps its bucket if it stays in the
+// same project and no bucket is given. The old identifier of tasks moved into another project is kept as redirect.
+func moveTask(s *xorm.Session, a web.Auth, t *Task, projectID, bucketID int64) (err error) {
+	if projectID == 0 {
+		projectID = t.ProjectID
+	}
+	if projectID == t.ProjectID && (bucketID == 0 || bucketID == t.BucketID) {
+		return nil
+	}
+
+	ot := *t
+	project, err := GetProjectSimpleByID(s, projectID)
+	if err != nil {
+		return err
+	}
+	if bucketID == 0 {
+		bucketID, err = getBucketIDForMovedTask(s, t, project)
+		if err != nil {
+			return err
+		}
+	}
+	bucket, err := getBucketByID(s, bucketID)
+	if err != nil {
+		return err
+	}
+
+	t.ProjectID = projectID
+	t.BucketID = bucketID
+	err = checkBucketAndTaskBelongToSameProject(t, bucket)
+	if err != nil {
+		return err
+	}
+	if bucketID != ot.BucketID {
+		err = checkBucketLimit(s, t, bucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	colsToUpdate := []string{"project_id", "bucket_id"}
+	if projectID != ot.ProjectID {
+		err = addTaskIdentifierRedirect(s, &ot)
+		if err != nil {
+			return err
+		}
+		err = addMovedTaskTombstone(s, t.ID, ot.ProjectID)
+		if err != nil {
+			return err
+		}
+		t.Index, err = getNextTaskIndex(s, projectID)
+		if err != nil {
+			return err
+		}
+		colsToUpdate = append(colsToUpdate, "index")
+	}
+
+	// Moving a task into the done bucket marks it as done, just like it does when updating the task
+	if bucket.ID == project.DoneBucketID && !ot.Done {
+		ot.Reminders, err = getRemindersForTasks(s, []int64{t.ID})
+		if err != nil {
+			return err
+		}
+		t.Reminders = ot.Reminders
+		t.Done = true
+		updateDone(&ot, t)
+
+		// Repeating tasks are rescheduled instead and stay in their bucket
+		if !t.Done {
+			t.BucketID = ot.BucketID
+			if ot.ProjectID != projectID {
+				t.BucketID, err = getDefaultBucketID(s, project)
+				if err != nil {
+					return err
+				}
+			}
+			err = ot.updateReminders(s, t)
+			if err != nil {
+				return err
+			}
+		}
+		colsToUpdate = append(colsToUpdate, "done", "done_at", "due_date", "start_date", "end_date")
+	}
+
+	_, err = s.ID(t.ID).
+		Cols(colsToUpdate...).
+		Incr("version").
+		Update(t)
+	if err != nil {
+		return err
+	}
+	t.Version++
+
+	err = updateProjectLastUpdated(s, &Project{ID: t.ProjectID})
+	if err != nil {
+		return err
+	}
+	if ot.ProjectID != t.ProjectID {
+		err = updateProjectLastUpdated(s, &Project{ID: ot.ProjectID})
+		if err != nil {
+			return err
+		}
+	}
+
+	doer, _ := user.GetFromAuth(a)
+	return events.DispatchOnCommit(s, &TaskUpdatedEvent{
+		Task: t,
+		Doer: doer,
+	})
+}
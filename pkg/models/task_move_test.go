@@ -0,0 +1,169 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskMove_Update(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("into another project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tm := &TaskMove{TaskID: 10, ProjectID: 10}
+		can, err := tm.CanUpdate(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = tm.Update(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), tm.Task.ProjectID)
+		assert.Equal(t, "test10-2", tm.Task.Identifier)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         10,
+			"project_id": 10,
+			"index":      2,
+		}, false)
+		db.AssertExists(t, "task_identifier_redirects", map[string]interface{}{
+			"task_id":    10,
+			"project_id": 1,
+			"index":      10,
+			"identifier": "test1-10",
+		}, false)
+	})
+	t.Run("into a bucket with the same title", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		b := &Bucket{Title: "testbucket1", ProjectID: 10}
+		err := b.Create(s, u)
+		assert.NoError(t, err)
+
+		tm := &TaskMove{TaskID: 10, ProjectID: 10}
+		err = tm.Update(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, b.ID, tm.BucketID)
+	})
+	t.Run("index of the moved task is not reused", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		latest := &Task{}
+		_, err := s.Where("project_id = ?", 1).OrderBy("`index` desc").Get(latest)
+		assert.NoError(t, err)
+
+		tm := &TaskMove{TaskID: latest.ID, ProjectID: 10}
+		err = tm.Update(s, u)
+		assert.NoError(t, err)
+
+		task := &Task{Title: "new", ProjectID: 1}
+		err = task.Create(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, latest.Index+1, task.Index)
+	})
+	t.Run("into a project without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tm := &TaskMove{TaskID: 10, ProjectID: 2}
+		can, _ := tm.CanUpdate(s, u)
+		assert.False(t, can)
+	})
+}
+
+func TestTaskByIdentifier_ReadOne(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("current identifier", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ti := &TaskByIdentifier{LookupIdentifier: "test1-1"}
+		can, _, err := ti.CanRead(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = ti.ReadOne(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), ti.ID)
+	})
+	t.Run("old identifier", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ti := &TaskByIdentifier{LookupIdentifier: "test5-99"}
+		can, _, err := ti.CanRead(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		err = ti.ReadOne(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), ti.ID)
+		assert.Equal(t, "test1-1", ti.Identifier)
+	})
+	t.Run("identifier of a moved task", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		tm := &TaskMove{TaskID: 10, ProjectID: 10}
+		err := tm.Update(s, u)
+		assert.NoError(t, err)
+
+		ti := &TaskByIdentifier{LookupIdentifier: "test1-10"}
+		can, _, err := ti.CanRead(s, u)
+		assert.NoError(t, err)
+		assert.True(t, can)
+		assert.Equal(t, int64(10), ti.ID)
+	})
+	t.Run("nonexistent", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		ti := &TaskByIdentifier{LookupIdentifier: "test1-9999"}
+		_, _, err := ti.CanRead(s, u)
+		assert.Error(t, err)
+		assert.True(t, IsErrTaskIdentifierDoesNotExist(err))
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		// Must not be distinguishable from an identifier which does not exist
+		ti := &TaskByIdentifier{LookupIdentifier: "test2-1"}
+		can, _, err := ti.CanRead(s, u)
+		assert.Error(t, err)
+		assert.False(t, can)
+		assert.True(t, IsErrTaskIdentifierDoesNotExist(err))
+	})
+}
@@ -0,0 +1,307 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/modules/quickadd"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web"
+
+	"xorm.io/xorm"
+)
+
+// QuickAdd is a quick add text and the task parsed from it
+type QuickAdd struct {
+	// The text to parse, for example "Buy milk tomorrow 5pm *errands @alice +Home !3 every week".
+	Text string `json:"text"`
+	// The project the task would be created in if the text does not contain a project.
+	ProjectID int64 `json:"project_id"`
+
+	// The task as it would be created. Labels which don't exist yet don't have an id, they are created with the task.
+	Task *Task `json:"task"`
+	// All parts of the text which were used to set a task property. They are not part of the task title.
+	Tokens []*quickadd.Token `json:"tokens"`
+	// All parts of the text which look like a label, assignee or project but could not be used, for example
+	// because no such user has access to the project. They are left in the task title.
+	Unconsumed []*quickadd.Token `json:"unconsumed"`
+}
+
+// ParseQuickAdd parses a quick add text into a task without saving anything.
+func ParseQuickAdd(s *xorm.Session, a web.Auth, qa *QuickAdd) (err error) {
+	qa.Task = &Task{
+		Title:     qa.Text,
+		ProjectID: qa.ProjectID,
+	}
+	qa.Tokens, qa.Unconsumed, err = qa.Task.applyQuickAdd(s, a)
+	return
+}
+
+func getQuickAddLocation(u *user.User) *time.Location {
+	if u != nil && u.Timezone != "" {
+		loc, err := time.LoadLocation(u.Timezone)
+		if err == nil {
+			return loc
+		}
+	}
+	return config.GetTimeZone()
+}
+
+// applyQuickAdd parses the title of the task and sets all properties found in it. Labels, assignees and projects
+// which can't be found are left in the title and returned as unconsumed. Labels which don't exist yet are added
+// without an id. Only users with access to the project of the task can be assigned.
+// Link shares can only use dates, priorities and repeating intervals.
+func (t *Task) applyQuickAdd(s *xorm.Session, a web.Auth) (tokens []*quickadd.Token, unconsumed []*quickadd.Token, err error) {
+	var u *user.User
+	if _, is := a.(*LinkSharing); !is {
+		u, err = user.GetUserByID(s, a.GetID())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	text := t.Title
+	parsed := quickadd.Parse(text, time.Now().In(getQuickAddLocation(u)))
+
+	var labels []*Label
+	var projects []*Project
+	assigneeTokens := []*quickadd.Token{}
+	tokens = []*quickadd.Token{}
+	unconsumed = []*quickadd.Token{}
+	t.Labels = []*Label{}
+	t.Assignees = []*user.User{}
+
+	for _, token := range parsed {
+		switch token.Type {
+		case quickadd.TokenTypeLabel:
+			if u == nil {
+				unconsumed = append(unconsumed, token)
+				continue
+			}
+			if labels == nil {
+				labels, err = getLabelsForQuickAdd(s, u)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			t.addQuickAddLabel(labels, token.Value)
+		case quickadd.TokenTypeAssignee:
+			if u == nil {
+				unconsumed = append(unconsumed, token)
+				continue
+			}
+			// Assignees are resolved once the project of the task is known
+			assigneeTokens = append(assigneeTokens, token)
+			continue
+		case quickadd.TokenTypeProject:
+			if u == nil {
+				unconsumed = append(unconsumed, token)
+				continue
+			}
+			if projects == nil {
+				projects, _, _, err = getRawProjectsForUser(s, &projectOptions{user: u, page: -1})
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			project := findProjectByTitle(projects, token.Value)
+			if project == nil {
+				unconsumed = append(unconsumed, token)
+				continue
+			}
+			t.ProjectID = project.ID
+		case quickadd.TokenTypePriority:
+			t.Priority = token.Priority
+		case quickadd.TokenTypeDate:
+			t.DueDate = *token.Date
+		case quickadd.TokenTypeRepeat:
+			t.RepeatAfter = token.RepeatAfter
+			if token.RepeatMonthly {
+				t.RepeatMode = TaskRepeatModeMonth
+			}
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	if len(assigneeTokens) > 0 {
+		assignable, err := getAssignableUsersForQuickAdd(s, a, t.ProjectID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, token := range assigneeTokens {
+			assignee := findUserByUsername(assignable, token.Value)
+			if assignee == nil {
+				unconsumed = append(unconsumed, token)
+				continue
+			}
+			t.addQuickAddAssignee(assignee)
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.Slice(unconsumed, func(i, j int) bool {
+		return unconsumed[i].Start < unconsumed[j].Start
+	})
+
+	t.Title = quickadd.Title(text, tokens)
+	return tokens, unconsumed, nil
+}
+
+// getAssignableUsersForQuickAdd returns all users with access to the project, if the user can see the project.
+func getAssignableUsersForQuickAdd(s *xorm.Session, a web.Auth, projectID int64) (users []*user.User, err error) {
+	project, err := GetProjectSimpleByID(s, projectID)
+	if IsErrProjectDoesNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	canRead, _, err := project.CanRead(s, a)
+	if err != nil || !canRead {
+		return nil, err
+	}
+
+	return ListUsersFromProject(s, project, "")
+}
+
+// findUserByUsername prefers users with exactly the same username over ones which only differ in case.
+func findUserByUsername(users []*user.User, username string) *user.User {
+	var found *user.User
+	for _, u := range users {
+		if u.Username == username {
+			return u
+		}
+		if found == nil && strings.EqualFold(u.Username, username) {
+			found = u
+		}
+	}
+	return found
+}
+
+func getLabelsForQuickAdd(s *xorm.Session, u *user.User) (labels []*Label, err error) {
+	all, _, _, err := GetLabelsByTaskIDs(s, &LabelByTaskIDsOptions{
+		User:                u,
+		GetForUser:          u.ID,
+		Page:                -1,
+		GetUnusedLabels:     true,
+		GroupByLabelIDsOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels = make([]*Label, 0, len(all))
+	for _, l := range all {
+		labels = append(labels, &l.Label)
+	}
+	return
+}
+
+func (t *Task) addQuickAddLabel(existing []*Label, title string) {
+	for _, l := range t.Labels {
+		if strings.EqualFold(l.Title, title) {
+			return
+		}
+	}
+
+	for _, l := range existing {
+		if strings.EqualFold(l.Title, title) {
+			t.Labels = append(t.Labels, l)
+			return
+		}
+	}
+
+	t.Labels = append(t.Labels, &Label{Title: title})
+}
+
+func (t *Task) addQuickAddAssignee(assignee *user.User) {
+	for _, a := range t.Assignees {
+		if a.ID == assignee.ID {
+			return
+		}
+	}
+	t.Assignees = append(t.Assignees, assignee)
+}
+
+// findProjectByTitle prefers projects with exactly the same title over ones which only differ in case.
+func findProjectByTitle(projects []*Project, title string) *Project {
+	var found *Project
+	for _, p := range projects {
+		if p.ID == FavoritesPseudoProject.ID || p.IsArchived {
+			continue
+		}
+		if p.Title == title {
+			return p
+		}
+		if found == nil && strings.EqualFold(p.Title, title) {
+			found = p
+		}
+	}
+	return found
+}
+
+// createTaskWithQuickAdd parses the title of the task before creating it and creates all labels which don't exist yet.
+func createTaskWithQuickAdd(s *xorm.Session, t *Task, a web.Auth) (err error) {
+	projectID := t.ProjectID
+	_, _, err = t.applyQuickAdd(s, a)
+	if err != nil {
+		return err
+	}
+
+	// The permission to create the task was checked for the project it was originally created in
+	if t.ProjectID != projectID {
+		project := &Project{ID: t.ProjectID}
+		canWrite, err := project.CanWrite(s, a)
+		if err != nil {
+			return err
+		}
+		if !canWrite {
+			return ErrGenericForbidden{}
+		}
+	}
+
+	labels := t.Labels
+	t.Labels = nil
+
+	err = createTask(s, t, a, true)
+	if err != nil {
+		return err
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	for _, l := range labels {
+		if l.ID != 0 {
+			continue
+		}
+		err = l.Create(s, a)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.UpdateTaskLabels(s, a, labels)
+}
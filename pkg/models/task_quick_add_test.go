@@ -0,0 +1,184 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("everything", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		qa := &QuickAdd{
+			Text:      `Lorem *"Label #1" *new @user1 +Test1 !3 tomorrow every day`,
+			ProjectID: 2,
+		}
+		err := ParseQuickAdd(s, u, qa)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Lorem", qa.Task.Title)
+		assert.Len(t, qa.Tokens, 7)
+		assert.Equal(t, int64(1), qa.Task.ProjectID)
+		assert.Equal(t, int64(3), qa.Task.Priority)
+		assert.Equal(t, int64(86400), qa.Task.RepeatAfter)
+		tomorrow := time.Now().In(config.GetTimeZone()).AddDate(0, 0, 1)
+		assert.Equal(t, tomorrow.Format("2006-01-02")+" 12:00", qa.Task.DueDate.Format("2006-01-02 15:04"))
+
+		require.Len(t, qa.Task.Labels, 2)
+		assert.Equal(t, int64(1), qa.Task.Labels[0].ID)
+		assert.Equal(t, int64(0), qa.Task.Labels[1].ID)
+		assert.Equal(t, "new", qa.Task.Labels[1].Title)
+		require.Len(t, qa.Task.Assignees, 1)
+		assert.Equal(t, int64(1), qa.Task.Assignees[0].ID)
+		assert.Empty(t, qa.Unconsumed)
+	})
+	t.Run("unknown assignee and project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		qa := &QuickAdd{Text: "Lorem @doesnotexist +Doesnotexist +Test1", ProjectID: 1}
+		err := ParseQuickAdd(s, u, qa)
+		require.NoError(t, err)
+		assert.Equal(t, "Lorem @doesnotexist +Doesnotexist +Test1", qa.Task.Title)
+		assert.Empty(t, qa.Tokens)
+		require.Len(t, qa.Unconsumed, 2)
+		assert.Equal(t, "doesnotexist", qa.Unconsumed[0].Value)
+		assert.Equal(t, "Doesnotexist", qa.Unconsumed[1].Value)
+	})
+	t.Run("assignee without access to the project", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		qa := &QuickAdd{Text: "Lorem @user2", ProjectID: 1}
+		err := ParseQuickAdd(s, u, qa)
+		require.NoError(t, err)
+		assert.Equal(t, "Lorem @user2", qa.Task.Title)
+		assert.Empty(t, qa.Task.Assignees)
+		require.Len(t, qa.Unconsumed, 1)
+		assert.Equal(t, "user2", qa.Unconsumed[0].Value)
+	})
+	t.Run("assignee in a project without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		// Users must not be found through projects the user can't see
+		qa := &QuickAdd{Text: "Lorem @user3", ProjectID: 2}
+		err := ParseQuickAdd(s, u, qa)
+		require.NoError(t, err)
+		assert.Empty(t, qa.Task.Assignees)
+		require.Len(t, qa.Unconsumed, 1)
+	})
+	t.Run("project without access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		qa := &QuickAdd{Text: "Lorem +Test2", ProjectID: 1}
+		err := ParseQuickAdd(s, u, qa)
+		require.NoError(t, err)
+		assert.Equal(t, "Lorem +Test2", qa.Task.Title)
+		assert.Equal(t, int64(1), qa.Task.ProjectID)
+	})
+	t.Run("link share", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		qa := &QuickAdd{Text: "Lorem *label !2", ProjectID: 1}
+		err := ParseQuickAdd(s, &LinkSharing{ID: 1, ProjectID: 1}, qa)
+		require.NoError(t, err)
+		assert.Equal(t, "Lorem *label", qa.Task.Title)
+		assert.Equal(t, int64(2), qa.Task.Priority)
+		assert.Empty(t, qa.Task.Labels)
+	})
+}
+
+func TestTask_CreateWithQuickAdd(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		task := &Task{
+			Title:     `Lorem Ipsum *"Label #1" *quickaddlabel @user1 !4`,
+			ProjectID: 1,
+			QuickAdd:  true,
+		}
+		err := task.Create(s, u)
+		require.NoError(t, err)
+		err = s.Commit()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Lorem Ipsum", task.Title)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":       task.ID,
+			"title":    "Lorem Ipsum",
+			"priority": 4,
+		}, false)
+		db.AssertExists(t, "labels", map[string]interface{}{
+			"title":         "quickaddlabel",
+			"created_by_id": 1,
+		}, false)
+		db.AssertExists(t, "label_tasks", map[string]interface{}{
+			"task_id":  task.ID,
+			"label_id": 1,
+		}, false)
+		db.AssertExists(t, "task_assignees", map[string]interface{}{
+			"task_id": task.ID,
+			"user_id": 1,
+		}, false)
+		assert.Len(t, task.Labels, 2)
+	})
+	t.Run("no title left", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		task := &Task{Title: "*label tomorrow", ProjectID: 1, QuickAdd: true}
+		err := task.Create(s, u)
+		require.Error(t, err)
+		assert.True(t, IsErrTaskCannotBeEmpty(err))
+	})
+	t.Run("project without write access", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		task := &Task{Title: "Lorem +Test6", ProjectID: 1, QuickAdd: true}
+		err := task.Create(s, u)
+		require.Error(t, err)
+		assert.True(t, IsErrGenericForbidden(err))
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
 	"code.vikunja.io/api/pkg/user"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,6 +47,33 @@ func TestTaskRelation_Create(t *testing.T) {
 			"created_by_id": 1,
 		}, false)
 	})
+	t.Run("Updates the task version", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		u := &user.User{ID: 1}
+		rel := &TaskRelation{
+			TaskID:       1,
+			OtherTaskID:  2,
+			RelationKind: RelationKindSubtask,
+		}
+		err := rel.Create(s, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		events.TestListener(t, &TaskRelationCreatedEvent{
+			Task:     &Task{ID: 1},
+			Relation: rel,
+			Doer:     u,
+		}, &HandleTaskUpdateLastUpdated{})
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":      1,
+			"version": 1,
+		}, false)
+	})
 	t.Run("Two Tasks In Different Projects", func(t *testing.T) {
 		db.LoadAndAssertFixtures(t)
 		s := db.NewSession()
@@ -33,12 +33,15 @@ import (
 
 type taskSearcher interface {
 	Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error)
+	Aggregate(opts *taskSearchOptions, aggregateOpts *taskAggregateOptions) (groups []*TaskAggregateGroup, err error)
 }
 
 type dbTaskSearcher struct {
 	s                   *xorm.Session
 	a                   web.Auth
 	hasFavoritesProject bool
+	// If set, only these tasks are returned instead of searching the title and description.
+	taskIDs []int64
 }
 
 func getOrderByDBStatement(opts *taskSearchOptions) (orderby string, err error) {
@@ -74,13 +77,11 @@ func getOrderByDBStatement(opts *taskSearchOptions) (orderby string, err error)
 	return
 }
 
+// getTaskCond builds the condition matching all tasks for the given search options.
+// It returns a nil condition if the options can never match any task.
+//
 //nolint:gocyclo
-func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error) {
-
-	orderby, err := getOrderByDBStatement(opts)
-	if err != nil {
-		return nil, 0, err
-	}
+func (d *dbTaskSearcher) getTaskCond(opts *taskSearchOptions) (cond builder.Cond, err error) {
 
 	// Some filters need a special treatment since they are in a separate table
 	reminderFilters := []builder.Cond{}
@@ -100,7 +101,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 				isNumeric:  f.isNumeric,
 			}, opts.filterIncludeNulls)
 			if err != nil {
-				return nil, totalCount, err
+				return nil, err
 			}
 			reminderFilters = append(reminderFilters, filter)
 			continue
@@ -108,7 +109,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 
 		if f.field == "assignees" {
 			if f.comparator == taskFilterComparatorLike {
-				return nil, totalCount, err
+				return nil, err
 			}
 			filter, err := getFilterCond(&taskFilter{
 				// recreating the struct here to avoid modifying it when reusing the opts struct
@@ -118,7 +119,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 				isNumeric:  f.isNumeric,
 			}, opts.filterIncludeNulls)
 			if err != nil {
-				return nil, totalCount, err
+				return nil, err
 			}
 			assigneeFilters = append(assigneeFilters, filter)
 			continue
@@ -133,7 +134,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 				isNumeric:  f.isNumeric,
 			}, opts.filterIncludeNulls)
 			if err != nil {
-				return nil, totalCount, err
+				return nil, err
 			}
 			labelFilters = append(labelFilters, filter)
 			continue
@@ -148,7 +149,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 				isNumeric:  f.isNumeric,
 			}, opts.filterIncludeNulls)
 			if err != nil {
-				return nil, totalCount, err
+				return nil, err
 			}
 			projectFilters = append(projectFilters, filter)
 			continue
@@ -156,7 +157,7 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 
 		filter, err := getFilterCond(f, opts.filterIncludeNulls)
 		if err != nil {
-			return nil, totalCount, err
+			return nil, err
 		}
 		filters = append(filters, filter)
 	}
@@ -164,12 +165,20 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 	// Then return all tasks for that projects
 	var where builder.Cond
 
-	if opts.search != "" {
-		where =
-			builder.Or(
-				db.ILIKE("title", opts.search),
-				db.ILIKE("description", opts.search),
-			)
+	if d.taskIDs != nil {
+		where = builder.In("id", d.taskIDs)
+	}
+
+	if d.taskIDs == nil && opts.search != "" {
+		if isFullTextSearchEnabled() {
+			where = getTaskFullTextSearchCond(opts.search)
+		} else {
+			where =
+				builder.Or(
+					db.ILIKE("title", opts.search),
+					db.ILIKE("description", opts.search),
+				)
+		}
 
 		searchIndex := getTaskIndexFromSearchString(opts.search)
 		if searchIndex > 0 {
@@ -244,16 +253,46 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 		}
 	}
 
-	limit, start := getLimitFromPageIndex(opts.page, opts.perPage)
-	cond := builder.And(builder.Or(projectIDCond, favoritesCond), where, filterCond)
+	return builder.And(builder.Or(projectIDCond, favoritesCond), where, filterCond), nil
+}
 
+func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error) {
+
+	orderby, err := getOrderByDBStatement(opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cond, err := d.getTaskCond(opts)
+	if err != nil || cond == nil {
+		return nil, totalCount, err
+	}
+
+	limit, start := getLimitFromPageIndex(opts.page, opts.perPage)
 	query := d.s.Where(cond)
+	if opts.cursor != nil {
+		var cursorCond builder.Cond
+		if len(opts.cursor.Fields) > 0 {
+			cursorCond, err = getTaskCursorCond(opts, d.s.Engine().GetTZDatabase())
+			if err != nil {
+				return nil, totalCount, err
+			}
+		}
+		query = d.s.Where(builder.And(cond, cursorCond))
+		limit, start = opts.perPage, 0
+	}
 	if limit > 0 {
 		query = query.Limit(limit, start)
 	}
 
+	if d.taskIDs == nil && opts.search != "" && isFullTextSearchEnabled() && opts.isSortedByRelevance() && opts.cursor == nil {
+		query = query.OrderBy(getTaskFullTextOrderBy(), opts.search)
+	} else {
+		query = query.OrderBy(orderby)
+	}
+
 	tasks = []*Task{}
-	err = query.OrderBy(orderby).Find(&tasks)
+	err = query.Find(&tasks)
 	if err != nil {
 		return nil, totalCount, err
 	}
@@ -271,6 +310,8 @@ func (d *dbTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCo
 
 type typesenseTaskSearcher struct {
 	s *xorm.Session
+	// Used for everything typesense can't do
+	db *dbTaskSearcher
 }
 
 func convertFilterValues(value interface{}) string {
@@ -302,31 +343,8 @@ func convertFilterValues(value interface{}) string {
 	return ""
 }
 
-func (t *typesenseTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error) {
-
-	var sortbyFields []string
-	for i, param := range opts.sortby {
-		// Validate the params
-		if err := param.validate(); err != nil {
-			return nil, totalCount, err
-		}
-
-		// Typesense does not allow sorting by ID, so we sort by created timestamp instead
-		if param.sortBy == "id" {
-			param.sortBy = "created"
-		}
-
-		sortbyFields = append(sortbyFields, param.sortBy+"(missing_values:last):"+param.orderBy.String())
-
-		if i == 2 {
-			// Typesense supports up to 3 sorting parameters
-			// https://typesense.org/docs/0.25.0/api/search.html#ranking-and-sorting-parameters
-			break
-		}
-	}
-
-	sortby := strings.Join(sortbyFields, ",")
-
+// getTypesenseTaskFilterBy converts the project ids and filters of the search options to a typesense filter_by string.
+func getTypesenseTaskFilterBy(opts *taskSearchOptions) string {
 	projectIDStrings := []string{}
 	for _, id := range opts.projectIDs {
 		projectIDStrings = append(projectIDStrings, strconv.FormatInt(id, 10))
@@ -383,6 +401,39 @@ func (t *typesenseTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task,
 		filterBy = append(filterBy, filter)
 	}
 
+	return strings.Join(filterBy, " && ")
+}
+
+func (t *typesenseTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task, totalCount int64, err error) {
+
+	// Typesense can't paginate with a cursor
+	if opts.cursor != nil {
+		return t.db.Search(opts)
+	}
+
+	var sortbyFields []string
+	for i, param := range opts.sortby {
+		// Validate the params
+		if err := param.validate(); err != nil {
+			return nil, totalCount, err
+		}
+
+		// Typesense does not allow sorting by ID, so we sort by created timestamp instead
+		if param.sortBy == "id" {
+			param.sortBy = "created"
+		}
+
+		sortbyFields = append(sortbyFields, param.sortBy+"(missing_values:last):"+param.orderBy.String())
+
+		if i == 2 {
+			// Typesense supports up to 3 sorting parameters
+			// https://typesense.org/docs/0.25.0/api/search.html#ranking-and-sorting-parameters
+			break
+		}
+	}
+
+	sortby := strings.Join(sortbyFields, ",")
+
 	////////////////
 	// Actual search
 
@@ -395,7 +446,7 @@ func (t *typesenseTaskSearcher) Search(opts *taskSearchOptions) (tasks []*Task,
 		QueryBy:          "title, identifier, description, comments.comment",
 		Page:             pointer.Int(opts.page),
 		ExhaustiveSearch: pointer.True(),
-		FilterBy:         pointer.String(strings.Join(filterBy, " && ")),
+		FilterBy:         pointer.String(getTypesenseTaskFilterBy(opts)),
 	}
 
 	if opts.perPage > 0 {
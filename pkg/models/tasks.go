@@ -103,6 +103,11 @@ type Task struct {
 	// True if a task is a favorite task. Favorite tasks show up in a separate "Important" project. This value depends on the user making the call to the api.
 	IsFavorite bool `xorm:"-" json:"is_favorite"`
 
+	// If true, the title is parsed for dates, labels, assignees, a project, a priority and a repeating interval,
+	// for example "Buy milk tomorrow 5pm *errands @alice +Home !3 every week". Everything which was found is removed from the title.
+	// Labels which don't exist yet are created. Only used when creating a task, can also be passed as query parameter.
+	QuickAdd bool `xorm:"-" json:"quick_add,omitempty"`
+
 	// The subscription status for the user reading this task. You can only read this property, use the subscription endpoints to modify it.
 	// Will only returned when retrieving one task.
 	Subscription *Subscription `xorm:"-" json:"subscription,omitempty"`
@@ -112,6 +117,11 @@ type Task struct {
 	// A timestamp when this task was last updated. You cannot change this value.
 	Updated time.Time `xorm:"updated not null" json:"updated"`
 
+	// The version of this task. It is increased with every change, the task's ETag is based on it.
+	Version int64 `xorm:"bigint not null default 0" json:"version"`
+	// If set, the task is only updated if its version still matches. Bound from the If-Match header.
+	IfMatch string `xorm:"-" json:"-" header:"If-Match"`
+
 	// BucketID is the ID of the kanban bucket this task belongs to.
 	BucketID int64 `xorm:"bigint null" json:"bucket_id"`
 
@@ -175,6 +185,14 @@ type taskSearchOptions struct {
 	filterConcat       taskFilterConcatinator
 	filterIncludeNulls bool
 	projectIDs         []int64
+	// If set, tasks are paginated with this cursor instead of page numbers
+	cursor *taskCursor
+}
+
+// isSortedByRelevance returns true if no sort order other than the default one was requested.
+// In that case search results are sorted by how well they match the search string.
+func (opts *taskSearchOptions) isSortedByRelevance() bool {
+	return len(opts.sortby) == 1 && opts.sortby[0].sortBy == taskPropertyID && opts.sortby[0].orderBy == orderAscending
 }
 
 // ReadAll is a dummy function to still have that endpoint documented
@@ -185,6 +203,7 @@ type taskSearchOptions struct {
 // @Produce json
 // @Param page query int false "The page number. Used for pagination. If not provided, the first page of results is returned."
 // @Param per_page query int false "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page."
+// @Param cursor query string false "Paginate with a cursor instead of page numbers. Pass an empty cursor for the first page and the `x-pagination-next-cursor` header of the last response for the following ones. Cursors only work with the same sort parameters they were created with."
 // @Param s query string false "Search tasks by task text."
 // @Param sort_by query string false "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parameters, along with `order_by`. Possible values to sort by are `id`, `title`, `description`, `done`, `done_at`, `due_date`, `created_by_id`, `project_id`, `repeat_after`, `priority`, `start_date`, `end_date`, `hex_color`, `percent_done`, `uid`, `created`, `updated`. Default is `id`."
 // @Param order_by query string false "The ordering parameter. Possible values to order by are `asc` or `desc`. Default is `asc`."
@@ -278,6 +297,22 @@ func getRawTasksForProjects(s *xorm.Session, projects []*Project, a web.Auth, op
 		opts.filterConcat = filterConcatOr
 	}
 
+	// Add the id parameter as the last parameter to sortby by default, but only if it is not already passed as the last parameter.
+	if len(opts.sortby) == 0 ||
+		len(opts.sortby) > 0 && opts.sortby[len(opts.sortby)-1].sortBy != taskPropertyID {
+		opts.sortby = append(opts.sortby, &sortParam{
+			sortBy:  taskPropertyID,
+			orderBy: orderAscending,
+		})
+	}
+
+	tasks, totalItems, err = getTaskSearcher(s, a, projects, opts).Search(opts)
+	return tasks, len(tasks), totalItems, err
+}
+
+// getTaskSearcher sets the project ids of the search options and returns the searcher
+// for the configured search backend.
+func getTaskSearcher(s *xorm.Session, a web.Auth, projects []*Project, opts *taskSearchOptions) taskSearcher {
 	// Get all project IDs and get the tasks
 	opts.projectIDs = []int64{}
 	var hasFavoritesProject bool
@@ -289,28 +324,24 @@ func getRawTasksForProjects(s *xorm.Session, projects []*Project, a web.Auth, op
 		opts.projectIDs = append(opts.projectIDs, p.ID)
 	}
 
-	// Add the id parameter as the last parameter to sortby by default, but only if it is not already passed as the last parameter.
-	if len(opts.sortby) == 0 ||
-		len(opts.sortby) > 0 && opts.sortby[len(opts.sortby)-1].sortBy != taskPropertyID {
-		opts.sortby = append(opts.sortby, &sortParam{
-			sortBy:  taskPropertyID,
-			orderBy: orderAscending,
-		})
-	}
-
-	var searcher taskSearcher = &dbTaskSearcher{
+	dbSearcher := &dbTaskSearcher{
 		s:                   s,
 		a:                   a,
 		hasFavoritesProject: hasFavoritesProject,
 	}
 	if config.TypesenseEnabled.GetBool() {
-		searcher = &typesenseTaskSearcher{
-			s: s,
+		return &typesenseTaskSearcher{
+			s:  s,
+			db: dbSearcher,
+		}
+	}
+	if isSearchIndexEnabled() {
+		return &indexTaskSearcher{
+			db: dbSearcher,
 		}
 	}
 
-	tasks, totalItems, err = searcher.Search(opts)
-	return tasks, len(tasks), totalItems, err
+	return dbSearcher
 }
 
 func getTasksForProjects(s *xorm.Session, projects []*Project, a web.Auth, opts *taskSearchOptions) (tasks []*Task, resultCount int, totalItems int64, err error) {
@@ -707,6 +738,19 @@ func getNextTaskIndex(s *xorm.Session, projectID int64) (nextIndex int64, err er
 		return 0, err
 	}
 
+	// Tasks moved out of the project keep their old identifier, it must not be given to another task
+	latestRedirect := &TaskIdentifierRedirect{}
+	_, err = s.
+		Where("project_id = ?", projectID).
+		OrderBy("`index` desc").
+		Get(latestRedirect)
+	if err != nil {
+		return 0, err
+	}
+
+	if latestRedirect.Index > latestTask.Index {
+		return latestRedirect.Index + 1, nil
+	}
 	return latestTask.Index + 1, nil
 }
 
@@ -719,12 +763,16 @@ func getNextTaskIndex(s *xorm.Session, projectID int64) (nextIndex int64, err er
 // @Security JWTKeyAuth
 // @Param id path int true "Project ID"
 // @Param task body models.Task true "The task object"
+// @Param quick_add query bool false "If true, the title is parsed for task properties. See the `quick_add` property of the task."
 // @Success 201 {object} models.Task "The created task object."
 // @Failure 400 {object} web.HTTPError "Invalid task object provided."
 // @Failure 403 {object} web.HTTPError "The user does not have access to the project"
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /projects/{id}/tasks [put]
 func (t *Task) Create(s *xorm.Session, a web.Auth) (err error) {
+	if t.QuickAdd {
+		return createTaskWithQuickAdd(s, t, a)
+	}
 	return createTask(s, t, a, true)
 }
 
@@ -799,7 +847,7 @@ func createTask(s *xorm.Session, t *Task, a web.Auth, updateAssignees bool) (err
 		}
 	}
 
-	err = events.Dispatch(&TaskCreatedEvent{
+	err = events.DispatchOnCommit(s, &TaskCreatedEvent{
 		Task: t,
 		Doer: createdBy,
 	})
@@ -820,9 +868,11 @@ func createTask(s *xorm.Session, t *Task, a web.Auth, updateAssignees bool) (err
 // @Security JWTKeyAuth
 // @Param ID path int true "The Task ID"
 // @Param task body models.Task true "The task object"
+// @Param If-Match header string false "The ETag or version of the task the changes are based on. If the task was changed since then, it is not updated."
 // @Success 200 {object} models.Task "The updated task object."
 // @Failure 400 {object} web.HTTPError "Invalid task object provided."
 // @Failure 403 {object} web.HTTPError "The user does not have access to the task (aka its project)"
+// @Failure 412 {object} models.Task "The task was changed in the meantime. Returns the current task."
 // @Failure 500 {object} models.Message "Internal error"
 // @Router /tasks/{ID} [post]
 //
@@ -835,6 +885,11 @@ func (t *Task) Update(s *xorm.Session, a web.Auth) (err error) {
 		return
 	}
 
+	version, err := bumpVersion(s, "tasks", t.ID, t.IfMatch)
+	if err != nil {
+		return err
+	}
+
 	if t.ProjectID == 0 {
 		t.ProjectID = ot.ProjectID
 	}
@@ -900,6 +955,14 @@ func (t *Task) Update(s *xorm.Session, a web.Auth) (err error) {
 
 	// If the task is being moved between projects, make sure to move the bucket + index as well
 	if t.ProjectID != 0 && ot.ProjectID != t.ProjectID {
+		err = addTaskIdentifierRedirect(s, &ot)
+		if err != nil {
+			return err
+		}
+		err = addMovedTaskTombstone(s, t.ID, ot.ProjectID)
+		if err != nil {
+			return err
+		}
 		t.Index, err = getNextTaskIndex(s, t.ProjectID)
 		if err != nil {
 			return err
@@ -1056,11 +1119,12 @@ func (t *Task) Update(s *xorm.Session, a web.Auth) (err error) {
 		return err
 	}
 	t.Updated = nt.Updated
+	t.Version = version
 	t.Position = nt.Position
 	t.KanbanPosition = nt.KanbanPosition
 
 	doer, _ := user.GetFromAuth(a)
-	err = events.Dispatch(&TaskUpdatedEvent{
+	err = events.DispatchOnCommit(s, &TaskUpdatedEvent{
 		Task: t,
 		Doer: doer,
 	})
@@ -1399,8 +1463,15 @@ func (t *Task) updateReminders(s *xorm.Session, task *Task) (err error) {
 	return
 }
 
-func updateTaskLastUpdated(s *xorm.Session, task *Task) error {
-	_, err := s.ID(task.ID).Cols("updated").Update(task)
+// updateTaskLastUpdated marks a task as changed, for example because one of its relations changed.
+// The version is increased as well so that clients holding an old ETag notice the change.
+func updateTaskLastUpdated(s *xorm.Session, task *Task) (err error) {
+	task.Version, err = bumpVersion(s, "tasks", task.ID, "")
+	if err != nil {
+		return err
+	}
+
+	_, err = s.ID(task.ID).Cols("updated").Update(task)
 	return err
 }
 
@@ -1429,6 +1500,11 @@ func (t *Task) Delete(s *xorm.Session, a web.Auth) (err error) {
 		return err
 	}
 
+	err = addTaskTombstone(s, fullTask)
+	if err != nil {
+		return err
+	}
+
 	// Delete assignees
 	if _, err = s.Where("task_id = ?", t.ID).Delete(TaskAssginee{}); err != nil {
 		return err
@@ -1477,8 +1553,14 @@ func (t *Task) Delete(s *xorm.Session, a web.Auth) (err error) {
 		return
 	}
 
+	// Delete all old identifiers
+	_, err = s.Where("task_id = ?", t.ID).Delete(&TaskIdentifierRedirect{})
+	if err != nil {
+		return
+	}
+
 	doer, _ := user.GetFromAuth(a)
-	err = events.Dispatch(&TaskDeletedEvent{
+	err = events.DispatchOnCommit(s, &TaskDeletedEvent{
 		Task: fullTask,
 		Doer: doer,
 	})
@@ -230,6 +230,60 @@ func TestTask_Update(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, IsErrTaskDoesNotExist(err))
 	})
+	t.Run("with matching If-Match", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		task := &Task{
+			ID:        1,
+			Title:     "test10000",
+			ProjectID: 1,
+			IfMatch:   GetEtag(1, 0),
+		}
+		err := task.Update(s, u)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), task.Version)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":      1,
+			"title":   "test10000",
+			"version": 1,
+		}, false)
+	})
+	t.Run("with outdated If-Match", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		task := &Task{
+			ID:        1,
+			Title:     "test10000",
+			ProjectID: 1,
+		}
+		err := task.Update(s, u)
+		assert.NoError(t, err)
+
+		task = &Task{
+			ID:        1,
+			Title:     "overwritten",
+			ProjectID: 1,
+			IfMatch:   "0",
+		}
+		err = task.Update(s, u)
+		assert.Error(t, err)
+		assert.True(t, IsErrVersionConflict(err))
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":      1,
+			"title":   "test10000",
+			"version": 1,
+		}, false)
+	})
 	t.Run("full bucket", func(t *testing.T) {
 		db.LoadAndAssertFixtures(t)
 		s := db.NewSession()
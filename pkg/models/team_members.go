@@ -106,7 +106,15 @@ func (tm *TeamMember) Delete(s *xorm.Session, _ web.Auth) (err error) {
 	tm.UserID = user.ID
 
 	_, err = s.Where("team_id = ? AND user_id = ?", tm.TeamID, tm.UserID).Delete(&TeamMember{})
-	return
+	if err != nil {
+		return
+	}
+
+	projectIDs, err := getTeamProjectIDs(s, tm.TeamID)
+	if err != nil {
+		return
+	}
+	return addLostAccessTombstones(s, projectIDs, []int64{tm.UserID})
 }
 
 // Update toggles a team member's admin status
@@ -120,6 +120,26 @@ func GetTeamByID(s *xorm.Session, id int64) (team *Team, err error) {
 	return
 }
 
+func getTeamMemberIDs(s *xorm.Session, teamID int64) (userIDs []int64, err error) {
+	userIDs = []int64{}
+	err = s.
+		Table("team_members").
+		Cols("user_id").
+		Where("team_id = ?", teamID).
+		Find(&userIDs)
+	return
+}
+
+func getTeamProjectIDs(s *xorm.Session, teamID int64) (projectIDs []int64, err error) {
+	projectIDs = []int64{}
+	err = s.
+		Table("team_projects").
+		Cols("project_id").
+		Where("team_id = ?", teamID).
+		Find(&projectIDs)
+	return
+}
+
 func addMoreInfoToTeams(s *xorm.Session, teams []*Team) (err error) {
 
 	if len(teams) == 0 {
@@ -295,6 +315,15 @@ func (t *Team) Create(s *xorm.Session, a web.Auth) (err error) {
 // @Router /teams/{id} [delete]
 func (t *Team) Delete(s *xorm.Session, a web.Auth) (err error) {
 
+	memberIDs, err := getTeamMemberIDs(s, t.ID)
+	if err != nil {
+		return
+	}
+	projectIDs, err := getTeamProjectIDs(s, t.ID)
+	if err != nil {
+		return
+	}
+
 	// Delete the team
 	_, err = s.ID(t.ID).Delete(&Team{})
 	if err != nil {
@@ -313,6 +342,11 @@ func (t *Team) Delete(s *xorm.Session, a web.Auth) (err error) {
 		return
 	}
 
+	err = addLostAccessTombstones(s, projectIDs, memberIDs)
+	if err != nil {
+		return
+	}
+
 	return events.Dispatch(&TeamDeletedEvent{
 		Team: t,
 		Doer: a,
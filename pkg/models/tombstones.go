@@ -0,0 +1,339 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"time"
+
+	"code.vikunja.io/api/pkg/cron"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// TombstoneKind is the kind of entity a tombstone was created for
+type TombstoneKind string
+
+// All kinds of deleted entities clients are notified about when syncing
+const (
+	TombstoneKindTask    TombstoneKind = "task"
+	TombstoneKindProject TombstoneKind = "project"
+	TombstoneKindLabel   TombstoneKind = "label"
+	TombstoneKindBucket  TombstoneKind = "bucket"
+)
+
+// Tombstone marks an entity as deleted so that clients which sync incrementally can remove it as well.
+type Tombstone struct {
+	ID int64 `xorm:"bigint autoincr not null unique pk" json:"-"`
+	// The kind of the deleted entity. Can be `task`, `project`, `label` or `bucket`.
+	Kind TombstoneKind `xorm:"varchar(50) not null" json:"kind"`
+	// The id of the deleted entity.
+	EntityID int64 `xorm:"bigint not null" json:"id"`
+
+	// The tombstone is visible to everyone with access to this project.
+	ProjectID int64 `xorm:"bigint not null default 0 index" json:"-"`
+	// The tombstone is visible to this user.
+	UserID int64 `xorm:"bigint not null default 0 index" json:"-"`
+
+	// A timestamp when the entity was deleted.
+	Created time.Time `xorm:"created not null index" json:"deleted"`
+}
+
+// TableName holds the table name for tombstones
+func (Tombstone) TableName() string {
+	return "tombstones"
+}
+
+func addTombstones(s *xorm.Session, tombstones []*Tombstone) (err error) {
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	_, err = s.Insert(&tombstones)
+	return
+}
+
+// addTaskTombstone records the deletion of a task for everyone with access to its project.
+func addTaskTombstone(s *xorm.Session, task *Task) error {
+	return addTombstones(s, []*Tombstone{{
+		Kind:      TombstoneKindTask,
+		EntityID:  task.ID,
+		ProjectID: task.ProjectID,
+	}})
+}
+
+// addBucketTombstone records the deletion of a bucket for everyone with access to its project.
+func addBucketTombstone(s *xorm.Session, bucket *Bucket) error {
+	return addTombstones(s, []*Tombstone{{
+		Kind:      TombstoneKindBucket,
+		EntityID:  bucket.ID,
+		ProjectID: bucket.ProjectID,
+	}})
+}
+
+// addProjectTombstones records the deletion of a project for every user who has access to it.
+// Since the project won't exist anymore afterwards, this needs to be called before deleting it.
+func addProjectTombstones(s *xorm.Session, project *Project) error {
+	users, err := ListUsersFromProject(s, project, "")
+	if err != nil {
+		return err
+	}
+
+	tombstones := make([]*Tombstone, 0, len(users))
+	for _, u := range users {
+		tombstones = append(tombstones, &Tombstone{
+			Kind:     TombstoneKindProject,
+			EntityID: project.ID,
+			UserID:   u.ID,
+		})
+	}
+
+	return addTombstones(s, tombstones)
+}
+
+// addLabelTombstones records the deletion of a label for its creator and everyone with access to a task
+// the label is associated with.
+func addLabelTombstones(s *xorm.Session, label *Label) error {
+	projectIDs := []int64{}
+	err := s.
+		Table("tasks").
+		Distinct("tasks.project_id").
+		Join("INNER", "label_tasks", "label_tasks.task_id = tasks.id").
+		Where("label_tasks.label_id = ?", label.ID).
+		Find(&projectIDs)
+	if err != nil {
+		return err
+	}
+
+	tombstones := []*Tombstone{{
+		Kind:     TombstoneKindLabel,
+		EntityID: label.ID,
+		UserID:   label.CreatedByID,
+	}}
+	for _, projectID := range projectIDs {
+		tombstones = append(tombstones, &Tombstone{
+			Kind:      TombstoneKindLabel,
+			EntityID:  label.ID,
+			ProjectID: projectID,
+		})
+	}
+
+	return addTombstones(s, tombstones)
+}
+
+// getProjectIDsWithChildren returns the ids of the projects and of all projects below them.
+func getProjectIDsWithChildren(s *xorm.Session, projectIDs []int64) (ids []int64, err error) {
+	seen := make(map[int64]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		seen[id] = true
+	}
+	ids = append([]int64{}, projectIDs...)
+
+	parents := projectIDs
+	for len(parents) > 0 {
+		children := []int64{}
+		err = s.
+			Table("projects").
+			Cols("id").
+			In("parent_project_id", parents).
+			Find(&children)
+		if err != nil {
+			return nil, err
+		}
+
+		parents = []int64{}
+		for _, id := range children {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			parents = append(parents, id)
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// addLostAccessTombstones records that users lost access to projects and all projects below them, for example
+// because a share was removed. Users who still have access to one of the projects, for example through another
+// share, don't get its tombstone when syncing.
+func addLostAccessTombstones(s *xorm.Session, projectIDs []int64, userIDs []int64) error {
+	if len(projectIDs) == 0 || len(userIDs) == 0 {
+		return nil
+	}
+
+	allProjectIDs, err := getProjectIDsWithChildren(s, projectIDs)
+	if err != nil {
+		return err
+	}
+
+	tombstones := make([]*Tombstone, 0, len(allProjectIDs)*len(userIDs))
+	for _, projectID := range allProjectIDs {
+		for _, userID := range userIDs {
+			tombstones = append(tombstones, &Tombstone{
+				Kind:     TombstoneKindProject,
+				EntityID: projectID,
+				UserID:   userID,
+			})
+		}
+	}
+
+	return addTombstones(s, tombstones)
+}
+
+// addMovedTaskTombstone records that a task was moved to another project for everyone with access to the
+// project it was moved out of. Users who can see the new project don't get the tombstone when syncing.
+func addMovedTaskTombstone(s *xorm.Session, taskID int64, oldProjectID int64) error {
+	return addTombstones(s, []*Tombstone{{
+		Kind:      TombstoneKindTask,
+		EntityID:  taskID,
+		ProjectID: oldProjectID,
+	}})
+}
+
+// addMovedProjectTombstones records that a project was moved away from its parent for everyone with access
+// to the parent. Users who still have access to the project or projects below it don't get their tombstones
+// when syncing.
+func addMovedProjectTombstones(s *xorm.Session, projectID int64, oldParentProjectID int64) error {
+	projectIDs, err := getProjectIDsWithChildren(s, []int64{projectID})
+	if err != nil {
+		return err
+	}
+
+	tombstones := make([]*Tombstone, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		tombstones = append(tombstones, &Tombstone{
+			Kind:      TombstoneKindProject,
+			EntityID:  id,
+			ProjectID: oldParentProjectID,
+		})
+	}
+
+	return addTombstones(s, tombstones)
+}
+
+// getTombstonesSince returns all tombstones visible to the user or in one of the projects which were
+// created at or after since, formatted in the database time zone. Tombstones of projects and tasks
+// the user still has access to are left out.
+func getTombstonesSince(s *xorm.Session, since string, userID int64, projectIDs []int64) (tombstones []*Tombstone, err error) {
+	visibleCond := []builder.Cond{
+		builder.In("project_id", projectIDs),
+	}
+	if userID > 0 {
+		visibleCond = append(visibleCond, builder.Eq{"user_id": userID})
+	}
+
+	all := []*Tombstone{}
+	err = s.
+		Where(builder.And(
+			builder.Gte{"created": since},
+			builder.Or(visibleCond...),
+		)).
+		OrderBy("id asc").
+		Find(&all)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects and tasks the user lost access to might be visible again, for example through another share
+	visibleProjects := make(map[int64]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		visibleProjects[id] = true
+	}
+	taskIDs := []int64{}
+	for _, t := range all {
+		if t.Kind == TombstoneKindTask {
+			taskIDs = append(taskIDs, t.EntityID)
+		}
+	}
+	visibleTasks := make(map[int64]bool)
+	if len(taskIDs) > 0 && len(projectIDs) > 0 {
+		ids := []int64{}
+		err = s.
+			Table("tasks").
+			Cols("id").
+			Where(builder.And(
+				builder.In("id", taskIDs),
+				builder.In("project_id", projectIDs),
+			)).
+			Find(&ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			visibleTasks[id] = true
+		}
+	}
+
+	// Labels have one tombstone per project they were used in
+	tombstones = make([]*Tombstone, 0, len(all))
+	seen := make(map[TombstoneKind]map[int64]bool)
+	for _, t := range all {
+		if t.Kind == TombstoneKindProject && visibleProjects[t.EntityID] ||
+			t.Kind == TombstoneKindTask && visibleTasks[t.EntityID] {
+			continue
+		}
+
+		if seen[t.Kind] == nil {
+			seen[t.Kind] = make(map[int64]bool)
+		}
+		if seen[t.Kind][t.EntityID] {
+			continue
+		}
+		seen[t.Kind][t.EntityID] = true
+		tombstones = append(tombstones, t)
+	}
+
+	return tombstones, nil
+}
+
+// RegisterTombstoneCleanupCron removes all tombstones which are older than the configured sync retention.
+func RegisterTombstoneCleanupCron() {
+	const logPrefix = "[Tombstone Cleanup Cron] "
+
+	err := cron.Schedule("0 * * * *", func() {
+		s := db.NewSession()
+		defer s.Close()
+
+		err := deleteExpiredTombstones(s)
+		if err != nil {
+			log.Errorf(logPrefix+"Could not delete old tombstones: %s", err)
+		}
+	})
+	if err != nil {
+		log.Fatalf(logPrefix+"Could not register tombstone cleanup cron: %s", err)
+	}
+}
+
+func deleteExpiredTombstones(s *xorm.Session) error {
+	if getSyncRetention() <= 0 {
+		return nil
+	}
+
+	before := time.Now().Add(-getSyncRetention()).In(s.Engine().GetTZDatabase()).Format(dbTimeFormat)
+	deleted, err := s.Where("created < ?", before).Delete(&Tombstone{})
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Debugf("[Tombstone Cleanup Cron] Deleted %d old tombstones", deleted)
+	}
+	return nil
+}
@@ -48,6 +48,11 @@ func InitTypesense() {
 	typesenseClient = typesense.NewClient(
 		typesense.WithServer(config.TypesenseURL.GetString()),
 		typesense.WithAPIKey(config.TypesenseAPIKey.GetString()))
+
+	err := createMissingTypesenseEntityCollections()
+	if err != nil {
+		log.Errorf("[Typesense] Could not create missing collections, searching through the database instead: %s", err)
+	}
 }
 
 func CreateTypesenseCollections() error {
@@ -71,8 +76,9 @@ func CreateTypesenseCollections() error {
 				Sort: pointer.True(),
 			},
 			{
-				Name: "done",
-				Type: "bool",
+				Name:  "done",
+				Type:  "bool",
+				Facet: pointer.True(),
 			},
 			{
 				Name:     "done_at",
@@ -85,8 +91,9 @@ func CreateTypesenseCollections() error {
 				Optional: pointer.True(),
 			},
 			{
-				Name: "project_id",
-				Type: "int64",
+				Name:  "project_id",
+				Type:  "int64",
+				Facet: pointer.True(),
 			},
 			{
 				Name: "repeat_after",
@@ -97,8 +104,9 @@ func CreateTypesenseCollections() error {
 				Type: "int32",
 			},
 			{
-				Name: "priority",
-				Type: "int64",
+				Name:  "priority",
+				Type:  "int64",
+				Facet: pointer.True(),
 			},
 			{
 				Name:     "start_date",
@@ -146,8 +154,9 @@ func CreateTypesenseCollections() error {
 				Type: "int64", // unix timestamp
 			},
 			{
-				Name: "bucket_id",
-				Type: "int64",
+				Name:  "bucket_id",
+				Type:  "int64",
+				Facet: pointer.True(),
 			},
 			{
 				Name: "position",
@@ -158,8 +167,9 @@ func CreateTypesenseCollections() error {
 				Type: "float",
 			},
 			{
-				Name: "created_by_id",
-				Type: "int64",
+				Name:  "created_by_id",
+				Type:  "int64",
+				Facet: pointer.True(),
 			},
 			{
 				Name:     "reminders",
@@ -198,7 +208,21 @@ func CreateTypesenseCollections() error {
 	_, _ = typesenseClient.Collection("tasks").Delete()
 
 	_, err := typesenseClient.Collections().Create(taskSchema)
-	return err
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range typesenseEntityCollections {
+		_, _ = typesenseClient.Collection(collection).Delete()
+
+		_, err = typesenseClient.Collections().Create(getTypesenseEntityCollectionSchema(collection))
+		if err != nil {
+			return err
+		}
+		typesenseAvailableCollections.Store(collection, true)
+	}
+
+	return nil
 }
 
 func ReindexAllTasks() (err error) {
@@ -548,7 +572,11 @@ func RegisterPeriodicTypesenseResyncCron() {
 	err := cron.Schedule("* * * * *", func() {
 		err := SyncUpdatedTasksIntoTypesense()
 		if err != nil {
-			log.Fatalf("[Typesense Sync] Could not sync updated tasks into typesense: %s", err)
+			log.Errorf("[Typesense Sync] Could not sync updated tasks into typesense: %s", err)
+		}
+		err = SyncUpdatedEntitiesIntoTypesense()
+		if err != nil {
+			log.Errorf("[Typesense Sync] Could not sync updated entities into typesense: %s", err)
 		}
 	})
 	if err != nil {
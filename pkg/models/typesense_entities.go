@@ -0,0 +1,413 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package models
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+
+	"github.com/typesense/typesense-go/typesense/api"
+	"github.com/typesense/typesense-go/typesense/api/pointer"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+const (
+	typesenseCollectionProjects     = "projects"
+	typesenseCollectionLabels       = "labels"
+	typesenseCollectionSavedFilters = "saved_filters"
+	typesenseCollectionTeams        = "teams"
+	typesenseCollectionTaskComments = "task_comments"
+)
+
+// All collections used by the global search in addition to the tasks collection.
+var typesenseEntityCollections = []string{
+	typesenseCollectionProjects,
+	typesenseCollectionLabels,
+	typesenseCollectionSavedFilters,
+	typesenseCollectionTeams,
+	typesenseCollectionTaskComments,
+}
+
+// Entity collections which exist in Typesense. Searches in collections which don't exist use the database.
+var typesenseAvailableCollections sync.Map
+
+func getTypesenseEntityCollectionSchema(collection string) *api.CollectionSchema {
+	return &api.CollectionSchema{
+		Name: collection,
+		Fields: []api.Field{
+			{
+				Name: "title",
+				Type: "string",
+			},
+			{
+				Name: "description",
+				Type: "string",
+			},
+			{
+				Name:     "project_id",
+				Type:     "int64",
+				Optional: pointer.True(),
+			},
+			{
+				Name:     "task_id",
+				Type:     "int64",
+				Optional: pointer.True(),
+			},
+			{
+				Name: "created",
+				Type: "int64",
+			},
+			{
+				Name: "updated",
+				Type: "int64",
+			},
+		},
+	}
+}
+
+// createMissingTypesenseEntityCollections creates all entity collections which don't exist yet,
+// for example because Typesense was set up before they were added.
+func createMissingTypesenseEntityCollections() error {
+	collections, err := typesenseClient.Collections().Retrieve()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(collections))
+	for _, c := range collections {
+		existing[c.Name] = true
+	}
+
+	for _, collection := range typesenseEntityCollections {
+		if !existing[collection] {
+			log.Infof("[Typesense] Creating missing collection %s", collection)
+			_, err = typesenseClient.Collections().Create(getTypesenseEntityCollectionSchema(collection))
+			if err != nil {
+				return err
+			}
+		}
+		typesenseAvailableCollections.Store(collection, true)
+	}
+
+	return nil
+}
+
+// useTypesenseCollection returns whether searches in a collection should use Typesense.
+func useTypesenseCollection(collection string) bool {
+	if !config.TypesenseEnabled.GetBool() {
+		return false
+	}
+	_, available := typesenseAvailableCollections.Load(collection)
+	return available
+}
+
+// typesenseDocument is the document stored for all entities other than tasks.
+// Deleted entities are not removed from Typesense until the next full reindex, but because
+// search results are always matched against what the user has access to, they are never returned.
+type typesenseDocument struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ProjectID   int64  `json:"project_id,omitempty"`
+	TaskID      int64  `json:"task_id,omitempty"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+}
+
+func newTypesenseDocument(id int64, title, description string, created, updated time.Time) *typesenseDocument {
+	return &typesenseDocument{
+		ID:          strconv.FormatInt(id, 10),
+		Title:       title,
+		Description: getPlainText(description),
+		Created:     created.UTC().Unix(),
+		Updated:     updated.UTC().Unix(),
+	}
+}
+
+// getTypesenseDocuments returns the documents of all entities in a collection which were updated since the
+// provided time, or of all entities if it is zero.
+func getTypesenseDocuments(s *xorm.Session, collection string, since time.Time) (documents []interface{}, err error) {
+	var cond builder.Cond = builder.Expr("1 = 1")
+	if !since.IsZero() {
+		cond = builder.Gte{"updated": since}
+	}
+
+	switch collection {
+	case typesenseCollectionProjects:
+		projects := []*Project{}
+		err = s.Where(cond).Find(&projects)
+		for _, p := range projects {
+			documents = append(documents, newTypesenseDocument(p.ID, p.Title, p.Description, p.Created, p.Updated))
+		}
+	case typesenseCollectionLabels:
+		labels := []*Label{}
+		err = s.Where(cond).Find(&labels)
+		for _, l := range labels {
+			documents = append(documents, newTypesenseDocument(l.ID, l.Title, l.Description, l.Created, l.Updated))
+		}
+	case typesenseCollectionSavedFilters:
+		filters := []*SavedFilter{}
+		err = s.Where(cond).Find(&filters)
+		for _, f := range filters {
+			documents = append(documents, newTypesenseDocument(f.ID, f.Title, f.Description, f.Created, f.Updated))
+		}
+	case typesenseCollectionTeams:
+		teams := []*Team{}
+		err = s.Where(cond).Find(&teams)
+		for _, t := range teams {
+			documents = append(documents, newTypesenseDocument(t.ID, t.Name, t.Description, t.Created, t.Updated))
+		}
+	case typesenseCollectionTaskComments:
+		comments := []*TaskComment{}
+		err = s.Where(cond).Find(&comments)
+		if err != nil || len(comments) == 0 {
+			return
+		}
+
+		taskIDs := make([]int64, 0, len(comments))
+		for _, c := range comments {
+			taskIDs = append(taskIDs, c.TaskID)
+		}
+		tasks := make(map[int64]*Task, len(taskIDs))
+		err = s.In("id", taskIDs).Find(&tasks)
+		if err != nil {
+			return
+		}
+
+		for _, c := range comments {
+			task, has := tasks[c.TaskID]
+			if !has {
+				continue
+			}
+			doc := newTypesenseDocument(c.ID, "", c.Comment, c.Created, c.Updated)
+			doc.TaskID = task.ID
+			doc.ProjectID = task.ProjectID
+			documents = append(documents, doc)
+		}
+	default:
+		return nil, fmt.Errorf("unknown typesense collection %s", collection)
+	}
+
+	return
+}
+
+func importTypesenseDocuments(collection string, documents []interface{}) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	_, err := typesenseClient.Collection(collection).
+		Documents().
+		Import(documents, &api.ImportDocumentsParams{
+			Action:    pointer.String("upsert"),
+			BatchSize: pointer.Int(100),
+		})
+	return err
+}
+
+// ReindexAllEntities indexes all projects, labels, saved filters, teams and task comments into Typesense.
+func ReindexAllEntities() (err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	for _, collection := range typesenseEntityCollections {
+		_, err = s.Where("collection = ?", collection).Delete(&TypesenseSync{})
+		if err != nil {
+			return fmt.Errorf("could not delete old sync status: %s", err.Error())
+		}
+
+		currentSync := &TypesenseSync{
+			Collection:    collection,
+			SyncStartedAt: time.Now(),
+		}
+		_, err = s.Insert(currentSync)
+		if err != nil {
+			return fmt.Errorf("could not update last sync: %s", err.Error())
+		}
+
+		documents, err := getTypesenseDocuments(s, collection, time.Time{})
+		if err != nil {
+			return fmt.Errorf("could not get all %s: %s", collection, err.Error())
+		}
+
+		err = importTypesenseDocuments(collection, documents)
+		if err != nil {
+			return fmt.Errorf("could not reindex all %s: %s", collection, err.Error())
+		}
+
+		currentSync.SyncFinishedAt = time.Now()
+		_, err = s.Where("collection = ?", collection).
+			Cols("sync_finished_at").
+			Update(currentSync)
+		if err != nil {
+			return fmt.Errorf("could update last sync state: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// SyncUpdatedEntitiesIntoTypesense indexes all entities other than tasks which changed since the last sync.
+func SyncUpdatedEntitiesIntoTypesense() (err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	// Creating the collections might have failed when Vikunja was started
+	err = createMissingTypesenseEntityCollections()
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range typesenseEntityCollections {
+		lastSync := &TypesenseSync{}
+		has, err := s.Where("collection = ?", collection).Get(lastSync)
+		if err != nil {
+			return err
+		}
+		if !has {
+			// Collections which were created after the last full index are indexed completely
+			log.Infof("[Typesense Sync] No typesense sync stats for %s yet, indexing all of them", collection)
+		}
+
+		currentSync := &TypesenseSync{
+			Collection:    collection,
+			SyncStartedAt: time.Now(),
+		}
+		documents, err := getTypesenseDocuments(s, collection, lastSync.SyncStartedAt)
+		if err != nil {
+			return err
+		}
+
+		if len(documents) > 0 {
+			log.Debugf("[Typesense Sync] Updating %d %s", len(documents), collection)
+		}
+
+		err = importTypesenseDocuments(collection, documents)
+		if err != nil {
+			return err
+		}
+
+		currentSync.SyncFinishedAt = time.Now()
+		if !has {
+			_, err = s.Insert(currentSync)
+		} else {
+			_, err = s.Where("collection = ?", collection).
+				Cols("sync_started_at", "sync_finished_at").
+				Update(currentSync)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type typesenseSearchHit struct {
+	id      int64
+	field   string
+	snippet string
+}
+
+// applyTo replaces the snippet of a search result with the one Typesense created, because Typesense
+// knows which words it matched through typo tolerance.
+func (h *typesenseSearchHit) applyTo(result *SearchResult, fields searchFieldNames) {
+	if h == nil || h.snippet == "" {
+		return
+	}
+
+	result.Snippet = h.snippet
+	switch h.field {
+	case "title":
+		result.Field = fields.title
+	case "description":
+		result.Field = fields.description
+	}
+}
+
+// escapeTypesenseSnippet escapes everything in a snippet except the <mark> tags Typesense added.
+func escapeTypesenseSnippet(snippet string) string {
+	parts := strings.Split(snippet, "<mark>")
+	for i, part := range parts {
+		marked := strings.Split(part, "</mark>")
+		for j, m := range marked {
+			marked[j] = html.EscapeString(m)
+		}
+		parts[i] = strings.Join(marked, "</mark>")
+	}
+	return strings.Join(parts, "<mark>")
+}
+
+// searchTypesenseCollection searches a collection, limited to documents where the filter field is one of the values.
+func searchTypesenseCollection(collection, search, filterField string, values []int64) (hits []*typesenseSearchHit, err error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	filterValues := make([]string, 0, len(values))
+	for _, v := range values {
+		filterValues = append(filterValues, strconv.FormatInt(v, 10))
+	}
+
+	result, err := typesenseClient.Collection(collection).
+		Documents().
+		Search(&api.SearchCollectionParams{
+			Q:                       search,
+			QueryBy:                 "title, description",
+			FilterBy:                pointer.String(filterField + ":[" + strings.Join(filterValues, ",") + "]"),
+			PerPage:                 pointer.Int(maxSearchResultsPerType),
+			HighlightAffixNumTokens: pointer.Int(8),
+		})
+	if err != nil {
+		return nil, err
+	}
+	if result.Hits == nil {
+		return nil, nil
+	}
+
+	for _, h := range *result.Hits {
+		document := *h.Document
+		id, err := strconv.ParseInt(document["id"].(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		hit := &typesenseSearchHit{id: id}
+		if h.Highlights != nil {
+			for _, highlight := range *h.Highlights {
+				if highlight.Snippet == nil || highlight.Field == nil {
+					continue
+				}
+				hit.field = *highlight.Field
+				hit.snippet = escapeTypesenseSnippet(*highlight.Snippet)
+				break
+			}
+		}
+		hits = append(hits, hit)
+	}
+
+	return hits, nil
+}
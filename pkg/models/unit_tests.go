@@ -65,6 +65,9 @@ func SetupTests() {
 		"subscriptions",
 		"favorites",
 		"api_tokens",
+		"tombstones",
+		"task_identifier_redirects",
+		"calendar_feeds",
 	)
 	if err != nil {
 		log.Fatal(err)
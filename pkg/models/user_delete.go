@@ -21,6 +21,7 @@ import (
 
 	"code.vikunja.io/api/pkg/cron"
 	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
 	"code.vikunja.io/api/pkg/log"
 	"code.vikunja.io/api/pkg/notifications"
 	"code.vikunja.io/api/pkg/user"
@@ -73,6 +74,7 @@ func deleteUsers() {
 		err = DeleteUser(s, u)
 		if err != nil {
 			_ = s.Rollback()
+			events.CleanupPending(s)
 			log.Errorf("Could not delete u %d: %s", u.ID, err)
 			return
 		}
@@ -81,9 +83,16 @@ func deleteUsers() {
 
 		err = s.Commit()
 		if err != nil {
+			events.CleanupPending(s)
 			log.Errorf("Could not commit transaction: %s", err)
 			return
 		}
+
+		err = events.DispatchPending(s)
+		if err != nil {
+			log.Errorf("Could not dispatch events: %s", err)
+			return
+		}
 	}
 }
 
@@ -145,6 +154,31 @@ func DeleteUser(s *xorm.Session, u *user.User) (err error) {
 		}
 	}
 
+	_, err = s.Where("user_id = ?", u.ID).Delete(&user.NotificationPreference{})
+	if err != nil {
+		return err
+	}
+
+	err = notifications.DeleteAllPushSubscriptions(s, u.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Where("user_id = ?", u.ID).Delete(&notifications.ChatDestination{})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Where("notifiable_id = ?", u.ID).Delete(&notifications.DigestNotification{})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Where("owner_id = ?", u.ID).Delete(&CalendarFeed{})
+	if err != nil {
+		return err
+	}
+
 	_, err = s.Where("id = ?", u.ID).Delete(&user.User{})
 	if err != nil {
 		return err
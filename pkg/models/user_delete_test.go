@@ -34,10 +34,14 @@ func TestDeleteUser(t *testing.T) {
 		notifications.Fake()
 
 		u := &user.User{ID: 6}
-		err := DeleteUser(s, u)
+		_, err := s.Insert(&notifications.DigestNotification{NotifiableID: u.ID, Name: "task.comment"})
+		assert.NoError(t, err)
+
+		err = DeleteUser(s, u)
 
 		assert.NoError(t, err)
 		db.AssertMissing(t, "users", map[string]interface{}{"id": u.ID})
+		db.AssertMissing(t, "notification_digest_queue", map[string]interface{}{"notifiable_id": u.ID})
 		db.AssertMissing(t, "projects", map[string]interface{}{"id": 24}) // only user6 had access to this project
 		db.AssertExists(t, "projects", map[string]interface{}{"id": 6}, false)
 		db.AssertExists(t, "projects", map[string]interface{}{"id": 7}, false)
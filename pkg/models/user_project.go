@@ -114,3 +114,74 @@ func ListUsersFromProject(s *xorm.Session, l *Project, search string) (users []*
 	})
 	return
 }
+
+// GetUserIDsSharingWith returns the ids of all users who are in a team with the user or have direct access to one
+// of the projects the user has access to.
+func GetUserIDsSharingWith(s *xorm.Session, u *user.User) (userIDs map[int64]bool, err error) {
+	userIDs = make(map[int64]bool)
+	add := func(ids []int64) {
+		for _, id := range ids {
+			userIDs[id] = true
+		}
+	}
+
+	teamMemberIDs := []int64{}
+	err = s.
+		Table("team_members").
+		Cols("user_id").
+		In("team_id", builder.Select("team_id").From("team_members").Where(builder.Eq{"user_id": u.ID})).
+		Find(&teamMemberIDs)
+	if err != nil {
+		return nil, err
+	}
+	add(teamMemberIDs)
+
+	projects, _, _, err := getRawProjectsForUser(s, &projectOptions{user: u, page: -1, getArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]int64, 0, len(projects))
+	for _, p := range projects {
+		if p.ID > 0 {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+	if len(projectIDs) == 0 {
+		return userIDs, nil
+	}
+
+	ownerIDs := []int64{}
+	err = s.
+		Table("projects").
+		Cols("owner_id").
+		In("id", projectIDs).
+		Find(&ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	add(ownerIDs)
+
+	sharedUserIDs := []int64{}
+	err = s.
+		Table("users_projects").
+		Cols("user_id").
+		In("project_id", projectIDs).
+		Find(&sharedUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	add(sharedUserIDs)
+
+	sharedTeamMemberIDs := []int64{}
+	err = s.
+		Table("team_members").
+		Cols("user_id").
+		In("team_id", builder.Select("team_id").From("team_projects").Where(builder.In("project_id", projectIDs))).
+		Find(&sharedTeamMemberIDs)
+	if err != nil {
+		return nil, err
+	}
+	add(sharedTeamMemberIDs)
+
+	return userIDs, nil
+}
@@ -35,7 +35,7 @@ func InsertFromStructure(str []*models.ProjectWithTasksAndBuckets, user *user.Us
 	s := db.NewSession()
 	defer s.Close()
 
-	err = insertFromStructure(s, str, user)
+	err = InsertFromStructureWithSession(s, str, user)
 	if err != nil {
 		log.Errorf("[creating structure] Error while creating structure: %s", err.Error())
 		_ = s.Rollback()
@@ -45,12 +45,15 @@ func InsertFromStructure(str []*models.ProjectWithTasksAndBuckets, user *user.Us
 	return s.Commit()
 }
 
-func insertFromStructure(s *xorm.Session, str []*models.ProjectWithTasksAndBuckets, user *user.User) (err error) {
+// InsertFromStructureWithSession creates everything like InsertFromStructure, but in an existing session. This allows
+// migrators to change the created projects and tasks in the same transaction.
+func InsertFromStructureWithSession(s *xorm.Session, str []*models.ProjectWithTasksAndBuckets, user *user.User) (err error) {
 
 	log.Debugf("[creating structure] Creating %d projects", len(str))
 
 	labels := make(map[string]*models.Label)
 	archivedProjects := []int64{}
+	progress := getJobProgress(user.ID)
 
 	childRelations := make(map[int64][]int64)          // old id is the key, slice of old children ids
 	projectsByOldID := make(map[int64]*models.Project) // old id is the key
@@ -63,11 +66,16 @@ func insertFromStructure(s *xorm.Session, str []*models.ProjectWithTasksAndBucke
 		}
 
 		p.ID = 0
-		err = createProject(s, p, &archivedProjects, labels, user)
+		err = createProject(s, p, &archivedProjects, labels, user, progress)
 		if err != nil {
 			return err
 		}
 		projectsByOldID[oldID] = &str[i].Project
+
+		err = createChildProjects(s, p, &archivedProjects, labels, user, progress)
+		if err != nil {
+			return err
+		}
 	}
 
 	// parent / child relations
@@ -107,8 +115,8 @@ func insertFromStructure(s *xorm.Session, str []*models.ProjectWithTasksAndBucke
 	return nil
 }
 
-func createProject(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, archivedProjectIDs *[]int64, labels map[string]*models.Label, user *user.User) (err error) {
-	err = createProjectWithEverything(s, project, archivedProjectIDs, labels, user)
+func createProject(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, archivedProjectIDs *[]int64, labels map[string]*models.Label, user *user.User, progress *jobProgress) (err error) {
+	err = createProjectWithEverything(s, project, archivedProjectIDs, labels, user, progress)
 	if err != nil {
 		return err
 	}
@@ -118,7 +126,26 @@ func createProject(s *xorm.Session, project *models.ProjectWithTasksAndBuckets,
 	return
 }
 
-func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, archivedProjects *[]int64, labels map[string]*models.Label, user *user.User) (err error) {
+// createChildProjects creates all child projects of an already created project, including their own child projects.
+func createChildProjects(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, archivedProjectIDs *[]int64, labels map[string]*models.Label, user *user.User, progress *jobProgress) (err error) {
+	for _, child := range project.ChildProjects {
+		child.ID = 0
+		child.ParentProjectID = project.ID
+		err = createProject(s, child, archivedProjectIDs, labels, user, progress)
+		if err != nil {
+			return err
+		}
+
+		err = createChildProjects(s, child, archivedProjectIDs, labels, user, progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, archivedProjects *[]int64, labels map[string]*models.Label, user *user.User, progress *jobProgress) (err error) {
 	// The tasks and bucket slices are going to be reset during the creation of the project, so we rescue it here
 	// to be able to still loop over them aftere the project was created.
 	tasks := project.Tasks
@@ -149,6 +176,11 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 
 	log.Debugf("[creating structure] Created project %d", project.ID)
 
+	err = progress.processed(jobProgressProjects)
+	if err != nil {
+		return err
+	}
+
 	bf, is := originalBackgroundInformation.(*bytes.Buffer)
 	if is {
 
@@ -162,6 +194,11 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 		}
 
 		log.Debugf("[creating structure] Created a background file for project %d", project.ID)
+
+		err = progress.processed(jobProgressFiles)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Create all buckets
@@ -189,6 +226,7 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 			task.BucketID = bucket.ID
 		} else if task.BucketID > 0 {
 			log.Debugf("[creating structure] No bucket created for original bucket id %d", task.BucketID)
+			progress.warn("The bucket of task \"%s\" in project \"%s\" does not exist, it was put in the default bucket.", task.Title, project.Title)
 			task.BucketID = 0
 		}
 		if !exists || task.BucketID == 0 {
@@ -210,6 +248,12 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 		tasksByOldID[oldid] = t
 
 		log.Debugf("[creating structure] Created task %d", t.ID)
+
+		err = progress.processed(jobProgressTasks)
+		if err != nil {
+			return err
+		}
+
 		if len(t.RelatedTasks) > 0 {
 			log.Debugf("[creating structure] Creating %d related task kinds", len(t.RelatedTasks))
 		}
@@ -259,6 +303,10 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 			log.Debugf("[creating structure] Creating %d attachments", len(t.Attachments))
 		}
 		for _, a := range t.Attachments {
+			if a.File == nil {
+				progress.warn("An attachment of task \"%s\" has no file and was not migrated.", t.Title)
+				continue
+			}
 			// Check if we have a file to create
 			if len(a.File.FileContent) > 0 {
 				a.TaskID = t.ID
@@ -268,7 +316,15 @@ func createProjectWithEverything(s *xorm.Session, project *models.ProjectWithTas
 					return
 				}
 				log.Debugf("[creating structure] Created new attachment %d", a.ID)
+
+				err = progress.processed(jobProgressFiles)
+				if err != nil {
+					return err
+				}
+				continue
 			}
+
+			progress.warn("The attachment \"%s\" of task \"%s\" has no content and was not migrated.", a.File.Name, t.Title)
 		}
 
 		// Create all labels
@@ -154,4 +154,54 @@ func TestInsertFromStructure(t *testing.T) {
 		assert.NotEqual(t, 0, testStructure[1].Tasks[0].BucketID) // Should get the default bucket
 		assert.NotEqual(t, 0, testStructure[1].Tasks[6].BucketID) // Should get the default bucket
 	})
+	t.Run("with child projects", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		testStructure := []*models.ProjectWithTasksAndBuckets{
+			{
+				Project: models.Project{
+					Title: "Migrated parent",
+				},
+				ChildProjects: []*models.ProjectWithTasksAndBuckets{
+					{
+						Project: models.Project{
+							Title: "Migrated child",
+						},
+						ChildProjects: []*models.ProjectWithTasksAndBuckets{
+							{
+								Project: models.Project{
+									Title: "Migrated grandchild",
+								},
+								Tasks: []*models.TaskWithComments{
+									{
+										Task: models.Task{
+											Title: "Task in grandchild",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		err := InsertFromStructure(testStructure, u)
+		assert.NoError(t, err)
+		parent := testStructure[0]
+		child := parent.ChildProjects[0]
+		grandchild := child.ChildProjects[0]
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             "Migrated child",
+			"parent_project_id": parent.ID,
+		}, false)
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                grandchild.ID,
+			"title":             "Migrated grandchild",
+			"parent_project_id": child.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":      "Task in grandchild",
+			"project_id": grandchild.ID,
+		}, false)
+	})
 }
@@ -0,0 +1,483 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+)
+
+const previewRows = 5
+
+// Migrator imports tasks from any csv or tsv file, using a mapping of the file's columns to task fields.
+type Migrator struct {
+}
+
+// Preview holds the columns and the first rows of a csv file
+type Preview struct {
+	// The detected delimiter between the columns.
+	Delimiter string `json:"delimiter"`
+	// The column names from the first row of the file.
+	Columns []string `json:"columns"`
+	// The first rows after the column names.
+	Rows [][]string `json:"rows"`
+}
+
+// Mapping maps the columns of a csv file to task fields. All task fields hold the name of a column from the first
+// row of the file, fields without a column are not imported.
+type Mapping struct {
+	// The delimiter between the columns. If empty, it is detected from the first row.
+	Delimiter string `json:"delimiter"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     string `json:"due_date"`
+	// The format of the due dates, for example `DD.MM.YYYY HH:mm`. If empty, due dates need to be in RFC3339 or YYYY-MM-DD format.
+	DueDateFormat string `json:"due_date_format"`
+	Labels        string `json:"labels"`
+	// The delimiter between multiple labels in the labels column. Defaults to a comma.
+	LabelDelimiter string `json:"label_delimiter"`
+	// Either a number from 0 to 5 or one of low, medium, high, urgent and do now.
+	Priority string `json:"priority"`
+	// Tasks are done if this column contains true, yes, y, x, done or 1.
+	Done string `json:"done"`
+	// The path of the project of a task, for example `Work / Clients`. Missing projects are created as child projects
+	// of the project holding the import, tasks without a project are imported into that project directly.
+	Project string `json:"project"`
+	// The delimiter between the project titles in the project column. Defaults to a slash.
+	ProjectDelimiter string `json:"project_delimiter"`
+	// The title of the kanban bucket of a task. Missing buckets are created.
+	Bucket string `json:"bucket"`
+}
+
+// RowError holds the reason why a single row was not imported
+type RowError struct {
+	// The line of the row in the file. The first row with the column names is line 1.
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// Report holds the result of a csv import
+type Report struct {
+	// The number of imported tasks.
+	Imported int `json:"imported"`
+	// The rows which could not be imported.
+	Errors []*RowError `json:"errors"`
+}
+
+var priorities = map[string]int64{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+	"urgent": 4,
+	"do now": 5,
+}
+
+var dateFormatTokens = strings.NewReplacer(
+	"YYYY", "2006",
+	"YY", "06",
+	"MM", "01",
+	"DD", "02",
+	"HH", "15",
+	"mm", "04",
+	"ss", "05",
+)
+
+// Name is used to get the name of the csv migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/csv/status [get]
+func (m *Migrator) Name() string {
+	return "csv"
+}
+
+func detectDelimiter(file io.ReaderAt, size int64) (rune, error) {
+	firstLine, err := bufio.NewReader(io.NewSectionReader(file, 0, size)).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+
+	delimiter := ','
+	count := strings.Count(firstLine, ",")
+	for _, d := range []rune{'\t', ';'} {
+		if c := strings.Count(firstLine, string(d)); c > count {
+			delimiter = d
+			count = c
+		}
+	}
+	return delimiter, nil
+}
+
+func newReader(file io.ReaderAt, size int64, delimiter rune) (r *csv.Reader, columns []string, err error) {
+	r = csv.NewReader(io.NewSectionReader(file, 0, size))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	columns, err = r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, &models.ErrInvalidCSVImport{Reason: "The file is empty."}
+	}
+	if err != nil {
+		return nil, nil, &models.ErrInvalidCSVImport{Reason: err.Error()}
+	}
+	// Spreadsheet applications like to start their csv exports with a byte order mark
+	columns[0] = strings.TrimPrefix(columns[0], "\uFEFF")
+
+	return r, columns, nil
+}
+
+// Preview returns the columns and the first rows of a csv file to build a mapping for the import.
+// @Summary Preview a csv file
+// @Description Returns the detected delimiter, the columns and the first rows of a csv or tsv file. Use the columns to build the mapping for the import.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The csv or tsv file."
+// @Success 200 {object} csv.Preview "The columns and first rows of the file."
+// @Failure 400 {object} web.HTTPError "The file is not a valid csv file."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/csv/preview [put]
+func (m *Migrator) Preview(file io.ReaderAt, size int64) (preview *Preview, err error) {
+	delimiter, err := detectDelimiter(file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	r, columns, err := newReader(file, size, delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	preview = &Preview{
+		Delimiter: string(delimiter),
+		Columns:   columns,
+		Rows:      [][]string{},
+	}
+	for len(preview.Rows) < previewRows {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &models.ErrInvalidCSVImport{Reason: err.Error()}
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+
+	return preview, nil
+}
+
+type rowParser struct {
+	mapping    *Mapping
+	columns    map[string]int
+	dateLayout string
+	location   *time.Location
+}
+
+func newRowParser(mapping *Mapping, columns []string, location *time.Location) (p *rowParser, err error) {
+	p = &rowParser{
+		mapping:  mapping,
+		columns:  make(map[string]int, len(columns)),
+		location: location,
+	}
+	for i, c := range columns {
+		p.columns[c] = i
+	}
+
+	if mapping.Title == "" {
+		return nil, &models.ErrInvalidCSVImport{Reason: "The title needs to be mapped to a column."}
+	}
+	for _, c := range []string{
+		mapping.Title,
+		mapping.Description,
+		mapping.DueDate,
+		mapping.Labels,
+		mapping.Priority,
+		mapping.Done,
+		mapping.Project,
+		mapping.Bucket,
+	} {
+		if _, exists := p.columns[c]; c != "" && !exists {
+			return nil, &models.ErrInvalidCSVImport{Reason: "The column " + c + " does not exist."}
+		}
+	}
+
+	if mapping.DueDateFormat != "" {
+		p.dateLayout = dateFormatTokens.Replace(mapping.DueDateFormat)
+	}
+	if mapping.LabelDelimiter == "" {
+		mapping.LabelDelimiter = ","
+	}
+	if mapping.ProjectDelimiter == "" {
+		mapping.ProjectDelimiter = "/"
+	}
+
+	return p, nil
+}
+
+// value returns the trimmed value of the column mapped to a task field or an empty string if the field is not mapped.
+func (p *rowParser) value(row []string, column string) string {
+	if column == "" {
+		return ""
+	}
+	i := p.columns[column]
+	if i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func (p *rowParser) parseDate(value string) (time.Time, error) {
+	if p.dateLayout != "" {
+		return time.ParseInLocation(p.dateLayout, value, p.location)
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return date, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, p.location)
+}
+
+func splitAndTrim(value, delimiter string) (parts []string) {
+	for _, part := range strings.Split(value, delimiter) {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return
+}
+
+func (p *rowParser) parseTask(row []string) (task *models.Task, projectPath []string, bucket string, err error) {
+	task = &models.Task{
+		Title:       p.value(row, p.mapping.Title),
+		Description: p.value(row, p.mapping.Description),
+	}
+	if task.Title == "" {
+		return nil, nil, "", errors.New("the title is empty")
+	}
+
+	if dueDate := p.value(row, p.mapping.DueDate); dueDate != "" {
+		task.DueDate, err = p.parseDate(dueDate)
+		if err != nil {
+			return nil, nil, "", errors.New("invalid due date " + dueDate)
+		}
+	}
+
+	for _, l := range splitAndTrim(p.value(row, p.mapping.Labels), p.mapping.LabelDelimiter) {
+		task.Labels = append(task.Labels, &models.Label{Title: l})
+	}
+
+	if priority := strings.ToLower(p.value(row, p.mapping.Priority)); priority != "" {
+		var is bool
+		task.Priority, is = priorities[priority]
+		if !is {
+			task.Priority, err = strconv.ParseInt(priority, 10, 64)
+			if err != nil || task.Priority < 0 || task.Priority > 5 {
+				return nil, nil, "", errors.New("invalid priority " + priority)
+			}
+		}
+	}
+
+	switch done := strings.ToLower(p.value(row, p.mapping.Done)); done {
+	case "true", "yes", "y", "x", "done", "1":
+		task.Done = true
+	case "", "false", "no", "n", "0":
+	default:
+		return nil, nil, "", errors.New("invalid done value " + done)
+	}
+
+	projectPath = splitAndTrim(p.value(row, p.mapping.Project), p.mapping.ProjectDelimiter)
+	bucket = p.value(row, p.mapping.Bucket)
+
+	return task, projectPath, bucket, nil
+}
+
+type structureBuilder struct {
+	root         *models.ProjectWithTasksAndBuckets
+	projects     map[string]*models.ProjectWithTasksAndBuckets // project path is the key
+	buckets      map[*models.ProjectWithTasksAndBuckets]map[string]int64
+	lastBucketID int64
+}
+
+func (b *structureBuilder) getProject(path []string) *models.ProjectWithTasksAndBuckets {
+	if len(path) == 0 {
+		return b.root
+	}
+
+	key := strings.Join(path, "\x00")
+	project, exists := b.projects[key]
+	if exists {
+		return project
+	}
+
+	parent := b.getProject(path[:len(path)-1])
+	project = &models.ProjectWithTasksAndBuckets{
+		Project: models.Project{
+			Title: path[len(path)-1],
+		},
+	}
+	parent.ChildProjects = append(parent.ChildProjects, project)
+	b.projects[key] = project
+	return project
+}
+
+// getBucketID returns the id of the bucket with that title in the project. The ids are only used to map tasks to
+// buckets until the buckets are created.
+func (b *structureBuilder) getBucketID(project *models.ProjectWithTasksAndBuckets, title string) int64 {
+	if _, exists := b.buckets[project]; !exists {
+		b.buckets[project] = make(map[string]int64)
+	}
+
+	id, exists := b.buckets[project][title]
+	if exists {
+		return id
+	}
+
+	b.lastBucketID++
+	project.Buckets = append(project.Buckets, &models.Bucket{
+		ID:    b.lastBucketID,
+		Title: title,
+	})
+	b.buckets[project][title] = b.lastBucketID
+	return b.lastBucketID
+}
+
+// Migrate imports all rows of a csv file as tasks, using the mapping to get the task fields from the columns.
+// Rows which can't be imported are skipped and returned in the report.
+// @Summary Import tasks from a csv file
+// @Description Imports all rows of a csv or tsv file as tasks into a new project. Use the preview endpoint to get the columns of the file and pass a mapping from the columns to task fields. Rows which can't be imported are skipped and returned with the reason in the report.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The csv or tsv file."
+// @Param mapping formData string true "The mapping of the columns to task fields as json, see csv.Mapping."
+// @Success 200 {object} csv.Report "The number of imported tasks and the rows which were not imported."
+// @Failure 400 {object} web.HTTPError "The file is not a valid csv file or the mapping is invalid."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/csv/migrate [put]
+func (m *Migrator) Migrate(u *user.User, file io.ReaderAt, size int64, mapping *Mapping) (report *Report, err error) {
+	location := config.GetTimeZone()
+	if u.Timezone != "" {
+		location, err = time.LoadLocation(u.Timezone)
+		if err != nil {
+			location = config.GetTimeZone()
+		}
+	}
+
+	root, report, err := convertCSVToVikunja(file, size, mapping, location)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("[CSV Migration] Importing %d tasks, skipped %d rows", report.Imported, len(report.Errors))
+
+	if report.Imported == 0 {
+		return report, nil
+	}
+
+	return report, migration.InsertFromStructure([]*models.ProjectWithTasksAndBuckets{root}, u)
+}
+
+func convertCSVToVikunja(file io.ReaderAt, size int64, mapping *Mapping, location *time.Location) (root *models.ProjectWithTasksAndBuckets, report *Report, err error) {
+	var delimiter rune
+	switch {
+	case mapping.Delimiter == "":
+		delimiter, err = detectDelimiter(file, size)
+		if err != nil {
+			return nil, nil, err
+		}
+	case len([]rune(mapping.Delimiter)) == 1:
+		delimiter = []rune(mapping.Delimiter)[0]
+	default:
+		return nil, nil, &models.ErrInvalidCSVImport{Reason: "The delimiter must be a single character."}
+	}
+
+	r, columns, err := newReader(file, size, delimiter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parser, err := newRowParser(mapping, columns, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := &structureBuilder{
+		root: &models.ProjectWithTasksAndBuckets{
+			Project: models.Project{
+				Title: "Imported from CSV",
+			},
+		},
+		projects: make(map[string]*models.ProjectWithTasksAndBuckets),
+		buckets:  make(map[*models.ProjectWithTasksAndBuckets]map[string]int64),
+	}
+	report = &Report{
+		Errors: []*RowError{},
+	}
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+			report.Errors = append(report.Errors, &RowError{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := r.FieldPos(0)
+		task, projectPath, bucket, err := parser.parseTask(row)
+		if err != nil {
+			report.Errors = append(report.Errors, &RowError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		project := builder.getProject(projectPath)
+		if bucket != "" {
+			task.BucketID = builder.getBucketID(project, bucket)
+		}
+		project.Tasks = append(project.Tasks, &models.TaskWithComments{Task: *task})
+		report.Imported++
+	}
+
+	return builder.root, report, nil
+}
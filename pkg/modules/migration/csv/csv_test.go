@@ -0,0 +1,114 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package csv
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreview(t *testing.T) {
+	t.Run("tsv", func(t *testing.T) {
+		file := strings.NewReader("\uFEFFName\tNotes\nFirst\tSome notes\nSecond\t\n")
+		preview, err := (&Migrator{}).Preview(file, file.Size())
+		require.NoError(t, err)
+		assert.Equal(t, "\t", preview.Delimiter)
+		assert.Equal(t, []string{"Name", "Notes"}, preview.Columns)
+		assert.Equal(t, [][]string{{"First", "Some notes"}, {"Second", ""}}, preview.Rows)
+	})
+	t.Run("empty file", func(t *testing.T) {
+		file := strings.NewReader("")
+		_, err := (&Migrator{}).Preview(file, file.Size())
+		assert.Error(t, err)
+		assert.True(t, models.IsErrInvalidCSVImport(err))
+	})
+}
+
+func TestConvertCSVToVikunja(t *testing.T) {
+	file := strings.NewReader(`Title;Due;Tags;Prio;Status;List;Column
+Buy milk;18.10.2026 10:30;home, shopping;high;x;;To Do
+Write report;;work;2;;Work / Reports;Doing
+;;;;;;
+Call Bob;not a date;;;;Work;
+Plan trip;;;;maybe;;
+`)
+	mapping := &Mapping{
+		Title:         "Title",
+		DueDate:       "Due",
+		DueDateFormat: "DD.MM.YYYY HH:mm",
+		Labels:        "Tags",
+		Priority:      "Prio",
+		Done:          "Status",
+		Project:       "List",
+		Bucket:        "Column",
+	}
+
+	root, report, err := convertCSVToVikunja(file, file.Size(), mapping, time.UTC)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, []*RowError{
+		{Line: 4, Error: "the title is empty"},
+		{Line: 5, Error: "invalid due date not a date"},
+		{Line: 6, Error: "invalid done value maybe"},
+	}, report.Errors)
+
+	assert.Equal(t, "Imported from CSV", root.Title)
+	require.Len(t, root.Tasks, 1)
+	task := root.Tasks[0]
+	assert.Equal(t, "Buy milk", task.Title)
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC), task.DueDate)
+	assert.Equal(t, int64(3), task.Priority)
+	assert.True(t, task.Done)
+	require.Len(t, task.Labels, 2)
+	assert.Equal(t, "home", task.Labels[0].Title)
+	assert.Equal(t, "shopping", task.Labels[1].Title)
+	require.Len(t, root.Buckets, 1)
+	assert.Equal(t, "To Do", root.Buckets[0].Title)
+	assert.Equal(t, root.Buckets[0].ID, task.BucketID)
+
+	require.Len(t, root.ChildProjects, 1)
+	assert.Equal(t, "Work", root.ChildProjects[0].Title)
+	require.Len(t, root.ChildProjects[0].ChildProjects, 1)
+	reports := root.ChildProjects[0].ChildProjects[0]
+	assert.Equal(t, "Reports", reports.Title)
+	require.Len(t, reports.Tasks, 1)
+	assert.Equal(t, "Write report", reports.Tasks[0].Title)
+	assert.Equal(t, int64(2), reports.Tasks[0].Priority)
+	assert.Equal(t, reports.Buckets[0].ID, reports.Tasks[0].BucketID)
+}
+
+func TestConvertCSVToVikunja_InvalidMapping(t *testing.T) {
+	t.Run("no title", func(t *testing.T) {
+		file := strings.NewReader("Title,Description\nTask,Text\n")
+		_, _, err := convertCSVToVikunja(file, file.Size(), &Mapping{Description: "Description"}, time.UTC)
+		assert.Error(t, err)
+		assert.True(t, models.IsErrInvalidCSVImport(err))
+	})
+	t.Run("nonexisting column", func(t *testing.T) {
+		file := strings.NewReader("Title,Description\nTask,Text\n")
+		_, _, err := convertCSVToVikunja(file, file.Size(), &Mapping{Title: "Title", DueDate: "Due"}, time.UTC)
+		assert.Error(t, err)
+		assert.True(t, models.IsErrInvalidCSVImport(err))
+	})
+}
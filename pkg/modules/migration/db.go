@@ -20,5 +20,6 @@ package migration
 func GetTables() []interface{} {
 	return []interface{}{
 		&Status{},
+		&Job{},
 	}
 }
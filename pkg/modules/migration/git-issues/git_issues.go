@@ -0,0 +1,397 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitissues
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+
+	"xorm.io/xorm"
+)
+
+const logPrefix = "[Git Issues Migration] "
+
+// Migrator imports the issues of GitHub migration archives, GitLab project exports and Gitea repository dumps
+type Migrator struct {
+}
+
+// repository holds the issues of one repository, independent of the service it was exported from
+type repository struct {
+	// The full name of the repository, for example owner/repo. Used to resolve references to its issues.
+	FullName    string
+	Title       string
+	Description string
+	Issues      []*issue
+}
+
+type issue struct {
+	Number    int64
+	Title     string
+	Body      string
+	Closed    bool
+	ClosedAt  time.Time
+	DueDate   time.Time
+	Milestone string
+	Labels    []*label
+	Comments  []*comment
+}
+
+type label struct {
+	Name  string
+	Color string
+}
+
+type comment struct {
+	Author string
+	Body   string
+}
+
+// archive holds the contents of all files in an export which might be relevant for the migration, with their path as key.
+type archive map[string][]byte
+
+func isRelevantFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".ndjson", ".yml", ".yaml":
+		return true
+	}
+	return false
+}
+
+func readZip(file io.ReaderAt, size int64) (a archive, err error) {
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	a = make(archive)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isRelevantFile(f.Name) {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		a[path.Clean(f.Name)], err = io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+func readTar(r io.Reader) (a archive, err error) {
+	tr := tar.NewReader(r)
+	a = make(archive)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return a, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg || !isRelevantFile(h.Name) {
+			continue
+		}
+		a[path.Clean(h.Name)], err = io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readArchive reads a zip, tar or gzip compressed tar archive.
+func readArchive(file io.ReaderAt, size int64) (archive, error) {
+	magic := make([]byte, 262)
+	n, _ := file.ReadAt(magic, 0)
+	magic = magic[:n]
+
+	switch {
+	case bytes.HasPrefix(magic, []byte("PK\x03\x04")):
+		return readZip(file, size)
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gr, err := gzip.NewReader(io.NewSectionReader(file, 0, size))
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		return readTar(gr)
+	case len(magic) == 262 && string(magic[257:262]) == "ustar":
+		return readTar(io.NewSectionReader(file, 0, size))
+	}
+
+	return nil, &models.ErrInvalidMigrationFile{Migrator: "git-issues", Reason: "The file is not a zip or tar archive."}
+}
+
+// Finds references to other issues like #12, owner/repo#12 or https://github.com/owner/repo/issues/12
+var issueReferenceRegex = regexp.MustCompile(`(?:^|[^\w/&])(?:([\w.-]+/[\w.-]+)#|https?://[^\s/]+/([\w.-]+/[\w.-]+)/(?:-/)?issues/|#)(\d+)\b`)
+
+type issueReference struct {
+	Repository string
+	Number     int64
+}
+
+func findIssueReferences(text, currentRepository string) (refs []*issueReference) {
+	for _, match := range issueReferenceRegex.FindAllStringSubmatch(text, -1) {
+		number, err := strconv.ParseInt(match[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		repository := currentRepository
+		if match[1] != "" {
+			repository = match[1]
+		}
+		if match[2] != "" {
+			repository = match[2]
+		}
+		refs = append(refs, &issueReference{Repository: strings.ToLower(repository), Number: number})
+	}
+	return
+}
+
+type conversion struct {
+	root *models.ProjectWithTasksAndBuckets
+	// Lowercase repository full name and issue number are the keys
+	tasks map[string]map[int64]*models.TaskWithComments
+}
+
+// convertRepositoriesToVikunja creates one project per repository with all its issues as tasks below a common parent
+// project. Milestones become kanban buckets.
+func convertRepositoriesToVikunja(title string, repositories []*repository) *conversion {
+	c := &conversion{
+		root: &models.ProjectWithTasksAndBuckets{
+			Project: models.Project{
+				Title: title,
+			},
+		},
+		tasks: make(map[string]map[int64]*models.TaskWithComments, len(repositories)),
+	}
+
+	for _, r := range repositories {
+		project := &models.ProjectWithTasksAndBuckets{
+			Project: models.Project{
+				Title:       r.Title,
+				Description: r.Description,
+			},
+		}
+		c.root.ChildProjects = append(c.root.ChildProjects, project)
+		tasks := make(map[int64]*models.TaskWithComments, len(r.Issues))
+		c.tasks[strings.ToLower(r.FullName)] = tasks
+
+		sort.Slice(r.Issues, func(i, j int) bool {
+			return r.Issues[i].Number < r.Issues[j].Number
+		})
+
+		buckets := make(map[string]int64)
+		for _, i := range r.Issues {
+			task := &models.TaskWithComments{
+				Task: models.Task{
+					Title:       i.Title,
+					Description: i.Body,
+					Done:        i.Closed,
+					DoneAt:      i.ClosedAt,
+					DueDate:     i.DueDate,
+				},
+			}
+			if task.Title == "" {
+				task.Title = "#" + strconv.FormatInt(i.Number, 10)
+			}
+
+			if i.Milestone != "" {
+				bucketID, exists := buckets[i.Milestone]
+				if !exists {
+					bucketID = int64(len(buckets) + 1)
+					buckets[i.Milestone] = bucketID
+					project.Buckets = append(project.Buckets, &models.Bucket{
+						ID:    bucketID,
+						Title: i.Milestone,
+					})
+				}
+				task.BucketID = bucketID
+			}
+
+			for _, l := range i.Labels {
+				task.Labels = append(task.Labels, &models.Label{
+					Title:    l.Name,
+					HexColor: strings.TrimPrefix(l.Color, "#"),
+				})
+			}
+
+			for _, com := range i.Comments {
+				body := com.Body
+				if com.Author != "" {
+					body = com.Author + ":\n\n" + body
+				}
+				task.Comments = append(task.Comments, &models.TaskComment{Comment: body})
+			}
+
+			project.Tasks = append(project.Tasks, task)
+			tasks[i.Number] = task
+		}
+	}
+
+	return c
+}
+
+// setTaskIndexes sets the index of all tasks to their issue number so that their identifier stays the same.
+func setTaskIndexes(s *xorm.Session, c *conversion) (err error) {
+	for _, tasks := range c.tasks {
+		for number, task := range tasks {
+			_, err = s.
+				Where("id = ?", task.ID).
+				Cols("index").
+				NoAutoTime().
+				Update(&models.Task{Index: number})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createRelations relates all issues which reference each other in their description or comments.
+func createRelations(s *xorm.Session, c *conversion, repositories []*repository, doer *user.User) (err error) {
+	for _, r := range repositories {
+		for _, i := range r.Issues {
+			task := c.tasks[strings.ToLower(r.FullName)][i.Number]
+
+			texts := []string{i.Body}
+			for _, com := range i.Comments {
+				texts = append(texts, com.Body)
+			}
+
+			for _, text := range texts {
+				for _, ref := range findIssueReferences(text, strings.ToLower(r.FullName)) {
+					other, has := c.tasks[ref.Repository][ref.Number]
+					if !has {
+						continue
+					}
+
+					rel := &models.TaskRelation{
+						TaskID:       task.ID,
+						OtherTaskID:  other.ID,
+						RelationKind: models.RelationKindRelated,
+					}
+					err = rel.Create(s, doer)
+					if err != nil && !models.IsErrRelationAlreadyExists(err) && !models.IsErrRelationTasksCannotBeTheSame(err) {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Name is used to get the name of the git issues migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/git-issues/status [get]
+func (m *Migrator) Name() string {
+	return "git-issues"
+}
+
+// Migrate takes a repository export archive, parses it and imports all issues in it into Vikunja.
+// @Summary Import all issues from a GitHub, GitLab or Gitea repository export
+// @Description Imports all issues of a GitHub migration archive, a GitLab project export or a Gitea repository dump (created with `gitea dump-repo`) into Vikunja, with one project per repository. Issues become tasks which keep their number as identifier, milestones become kanban buckets and comments are imported as task comments. Closed issues are marked as done and issues which reference each other are related.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The export archive, as zip or (gzip compressed) tar file."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 400 {object} web.HTTPError "The file is not a supported repository export."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/git-issues/migrate [put]
+func (m *Migrator) Migrate(u *user.User, file io.ReaderAt, size int64) (err error) {
+	a, err := readArchive(file, size)
+	if err != nil {
+		return err
+	}
+
+	var title string
+	var repositories []*repository
+	switch {
+	case isGitHubArchive(a):
+		title = "Migrated from GitHub"
+		repositories, err = parseGitHubArchive(a)
+	case isGitLabExport(a):
+		title = "Migrated from GitLab"
+		repositories, err = parseGitLabExport(a)
+	case isGiteaDump(a):
+		title = "Migrated from Gitea"
+		repositories, err = parseGiteaDump(a)
+	default:
+		return &models.ErrInvalidMigrationFile{Migrator: "git-issues", Reason: "The archive is not a GitHub, GitLab or Gitea repository export."}
+	}
+	if err != nil {
+		return &models.ErrInvalidMigrationFile{Migrator: "git-issues", Reason: err.Error()}
+	}
+
+	log.Debugf(logPrefix+"Importing %d repositories", len(repositories))
+
+	c := convertRepositoriesToVikunja(title, repositories)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	err = migration.InsertFromStructureWithSession(s, []*models.ProjectWithTasksAndBuckets{c.root}, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = setTaskIndexes(s, c)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = createRelations(s, c, repositories, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
@@ -0,0 +1,258 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitissues
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGitHubArchive = map[string]string{
+	"repositories_000001.json": `[{"url": "https://github.com/vikunja/api", "name": "api", "description": "The api"}]`,
+	"issues_000001.json": `[
+		{"url": "https://github.com/vikunja/api/issues/2", "repository": "https://github.com/vikunja/api", "title": "Fix the login", "body": "Caused by #1", "milestone": "https://github.com/vikunja/api/milestones/1", "labels": ["https://github.com/vikunja/api/labels/bug"], "closed_at": null},
+		{"url": "https://github.com/vikunja/api/issues/1", "repository": "https://github.com/vikunja/api", "title": "Add a login", "body": "", "milestone": null, "labels": [], "closed_at": "2026-10-01T12:00:00Z"}
+	]`,
+	"milestones_000001.json":     `[{"url": "https://github.com/vikunja/api/milestones/1", "title": "v1.0", "due_on": "2026-11-01T00:00:00Z"}]`,
+	"labels_000001.json":         `[{"url": "https://github.com/vikunja/api/labels/bug", "name": "bug", "color": "d73a4a"}]`,
+	"issue_comments_000001.json": `[{"issue": "https://github.com/vikunja/api/issues/2", "user": "https://github.com/konrad", "body": "Looking into it"}]`,
+}
+
+func createZip(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func createTarGz(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestFindIssueReferences(t *testing.T) {
+	refs := findIssueReferences("Fixes #12, see Vikunja/Frontend#3 and https://gitlab.com/vikunja/api/-/issues/7. Not abc#5.", "vikunja/api")
+	require.Len(t, refs, 3)
+	assert.Equal(t, &issueReference{Repository: "vikunja/api", Number: 12}, refs[0])
+	assert.Equal(t, &issueReference{Repository: "vikunja/frontend", Number: 3}, refs[1])
+	assert.Equal(t, &issueReference{Repository: "vikunja/api", Number: 7}, refs[2])
+}
+
+func TestReadArchive(t *testing.T) {
+	t.Run("zip", func(t *testing.T) {
+		content := createZip(t, map[string]string{"issues_000001.json": "[]", "README.md": "ignored"})
+		a, err := readArchive(bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		assert.Equal(t, archive{"issues_000001.json": []byte("[]")}, a)
+	})
+	t.Run("tar.gz", func(t *testing.T) {
+		content := createTarGz(t, map[string]string{"tree/project.json": "{}"})
+		a, err := readArchive(bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+		assert.Equal(t, archive{"tree/project.json": []byte("{}")}, a)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := readArchive(bytes.NewReader([]byte("nope")), 4)
+		assert.True(t, models.IsErrInvalidMigrationFile(err))
+	})
+}
+
+func TestParseGitHubArchive(t *testing.T) {
+	a := archive{}
+	for name, content := range testGitHubArchive {
+		a[name] = []byte(content)
+	}
+	require.True(t, isGitHubArchive(a))
+
+	repositories, err := parseGitHubArchive(a)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	r := repositories[0]
+	assert.Equal(t, "vikunja/api", r.FullName)
+	assert.Equal(t, "api", r.Title)
+	require.Len(t, r.Issues, 2)
+	assert.Equal(t, int64(2), r.Issues[0].Number)
+	assert.Equal(t, "v1.0", r.Issues[0].Milestone)
+	assert.False(t, r.Issues[0].DueDate.IsZero())
+	assert.Equal(t, []*label{{Name: "bug", Color: "d73a4a"}}, r.Issues[0].Labels)
+	assert.Equal(t, []*comment{{Author: "konrad", Body: "Looking into it"}}, r.Issues[0].Comments)
+	assert.True(t, r.Issues[1].Closed)
+}
+
+func TestParseGitLabExport(t *testing.T) {
+	a := archive{
+		"tree/project.json": []byte(`{"name": "api", "description": "The api"}`),
+		"tree/project/issues.ndjson": []byte(`{"iid": 1, "title": "First", "state": "closed", "closed_at": "2026-10-01T12:00:00Z", "due_date": "2026-10-05", "milestone": {"title": "v1.0"}, "label_links": [{"label": {"title": "bug", "color": "#ff0000"}}], "notes": [{"note": "added label", "system": true}, {"note": "Thanks", "author": {"name": "Konrad"}}]}
+{"iid": 2, "title": "Second", "state": "opened"}
+`),
+	}
+	require.True(t, isGitLabExport(a))
+
+	repositories, err := parseGitLabExport(a)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	r := repositories[0]
+	assert.Equal(t, "api", r.Title)
+	require.Len(t, r.Issues, 2)
+	assert.True(t, r.Issues[0].Closed)
+	assert.Equal(t, "2026-10-05", r.Issues[0].DueDate.Format("2006-01-02"))
+	assert.Equal(t, "v1.0", r.Issues[0].Milestone)
+	assert.Equal(t, []*label{{Name: "bug", Color: "#ff0000"}}, r.Issues[0].Labels)
+	assert.Equal(t, []*comment{{Author: "Konrad", Body: "Thanks"}}, r.Issues[0].Comments)
+	assert.False(t, r.Issues[1].Closed)
+}
+
+func TestParseGiteaDump(t *testing.T) {
+	a := archive{
+		"vikunja/api/repo.yml":      []byte("name: api\nowner: vikunja\ndescription: The api\n"),
+		"vikunja/api/milestone.yml": []byte("- title: v1.0\n  deadline: 2026-11-01T00:00:00Z\n"),
+		"vikunja/api/issue.yml": []byte(`- number: 1
+  title: First
+  content: Body
+  milestone: v1.0
+  state: closed
+  closed: 2026-10-01T12:00:00Z
+  labels:
+    - name: bug
+      color: ee0701
+`),
+		"vikunja/api/comments/1.yml": []byte("- issue_index: 1\n  poster_name: konrad\n  content: Done\n"),
+	}
+	require.True(t, isGiteaDump(a))
+
+	repositories, err := parseGiteaDump(a)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	r := repositories[0]
+	assert.Equal(t, "vikunja/api", r.FullName)
+	require.Len(t, r.Issues, 1)
+	i := r.Issues[0]
+	assert.True(t, i.Closed)
+	assert.Equal(t, "v1.0", i.Milestone)
+	assert.Equal(t, "2026-11-01", i.DueDate.Format("2006-01-02"))
+	assert.Equal(t, []*label{{Name: "bug", Color: "ee0701"}}, i.Labels)
+	assert.Equal(t, []*comment{{Author: "konrad", Body: "Done"}}, i.Comments)
+}
+
+func TestConvertRepositoriesToVikunja(t *testing.T) {
+	c := convertRepositoriesToVikunja("Migrated from GitHub", []*repository{
+		{
+			FullName: "Vikunja/API",
+			Title:    "api",
+			Issues: []*issue{
+				{Number: 2, Title: "Second", Milestone: "v1.0", Comments: []*comment{{Author: "konrad", Body: "Hi"}}},
+				{Number: 1, Title: "First", Closed: true, Labels: []*label{{Name: "bug", Color: "#ff0000"}}},
+			},
+		},
+	})
+
+	assert.Equal(t, "Migrated from GitHub", c.root.Title)
+	require.Len(t, c.root.ChildProjects, 1)
+	project := c.root.ChildProjects[0]
+	require.Len(t, project.Tasks, 2)
+	assert.Equal(t, "First", project.Tasks[0].Title)
+	assert.True(t, project.Tasks[0].Done)
+	assert.Equal(t, "ff0000", project.Tasks[0].Labels[0].HexColor)
+	require.Len(t, project.Buckets, 1)
+	assert.Equal(t, "v1.0", project.Buckets[0].Title)
+	assert.Equal(t, project.Buckets[0].ID, project.Tasks[1].BucketID)
+	assert.Equal(t, "konrad:\n\nHi", project.Tasks[1].Comments[0].Comment)
+	assert.Equal(t, project.Tasks[1], c.tasks["vikunja/api"][2])
+}
+
+func TestMigrate(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	content := createZip(t, testGitHubArchive)
+	u := &user.User{ID: 3, Username: "user3"}
+	file := bytes.NewReader(content)
+	err := (&Migrator{}).Migrate(u, file, file.Size())
+	require.NoError(t, err)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	project := &models.Project{}
+	has, err := s.Where("title = ? AND owner_id = ?", "api", 3).Get(project)
+	require.NoError(t, err)
+	require.True(t, has)
+	db.AssertExists(t, "projects", map[string]interface{}{
+		"id":    project.ParentProjectID,
+		"title": "Migrated from GitHub",
+	}, false)
+
+	tasks := make(map[int64]*models.Task)
+	err = s.Where("project_id = ?", project.ID).Find(&tasks)
+	require.NoError(t, err)
+	byIndex := make(map[int64]*models.Task, len(tasks))
+	for _, task := range tasks {
+		byIndex[task.Index] = task
+	}
+	require.Contains(t, byIndex, int64(1))
+	require.Contains(t, byIndex, int64(2))
+	assert.Equal(t, "Add a login", byIndex[1].Title)
+	assert.True(t, byIndex[1].Done)
+	assert.Equal(t, "Fix the login", byIndex[2].Title)
+	assert.False(t, byIndex[2].Done)
+
+	db.AssertExists(t, "task_relations", map[string]interface{}{
+		"task_id":       byIndex[2].ID,
+		"other_task_id": byIndex[1].ID,
+		"relation_kind": models.RelationKindRelated,
+	}, false)
+	db.AssertExists(t, "task_comments", map[string]interface{}{
+		"task_id": byIndex[2].ID,
+		"comment": "konrad:\n\nLooking into it",
+	}, false)
+	db.AssertExists(t, "buckets", map[string]interface{}{
+		"project_id": project.ID,
+		"title":      "v1.0",
+	}, false)
+}
+
+func TestMigrate_UnknownArchive(t *testing.T) {
+	content := createZip(t, map[string]string{"something.json": "{}"})
+	file := bytes.NewReader(content)
+	err := (&Migrator{}).Migrate(&user.User{ID: 1}, file, file.Size())
+	assert.True(t, models.IsErrInvalidMigrationFile(err))
+}
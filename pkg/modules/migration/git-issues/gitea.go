@@ -0,0 +1,145 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitissues
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type giteaIssue struct {
+	Number    int64      `yaml:"number"`
+	Title     string     `yaml:"title"`
+	Content   string     `yaml:"content"`
+	Milestone string     `yaml:"milestone"`
+	State     string     `yaml:"state"`
+	Closed    *time.Time `yaml:"closed"`
+	Labels    []struct {
+		Name  string `yaml:"name"`
+		Color string `yaml:"color"`
+	} `yaml:"labels"`
+}
+
+type giteaComment struct {
+	PosterName string `yaml:"poster_name"`
+	Content    string `yaml:"content"`
+}
+
+type giteaRepository struct {
+	Name        string `yaml:"name"`
+	Owner       string `yaml:"owner"`
+	Description string `yaml:"description"`
+}
+
+type giteaMilestone struct {
+	Title    string     `yaml:"title"`
+	Deadline *time.Time `yaml:"deadline"`
+}
+
+func isGiteaDump(a archive) bool {
+	for p := range a {
+		if path.Base(p) == "issue.yml" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGiteaDump parses the repository dumps created with gitea dump-repo. Each repository in the archive has its own
+// directory with an issue.yml file and the comments of each issue in comments/<issue number>.yml.
+func parseGiteaDump(a archive) (repositories []*repository, err error) {
+	dirs := []string{}
+	for p := range a {
+		if path.Base(p) == "issue.yml" {
+			dirs = append(dirs, path.Dir(p))
+		}
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		r := &repository{
+			FullName: path.Base(path.Dir(dir)) + "/" + path.Base(dir),
+			Title:    path.Base(dir),
+		}
+		if content, has := a[path.Join(dir, "repo.yml")]; has {
+			gr := &giteaRepository{}
+			if err := yaml.Unmarshal(content, gr); err != nil {
+				return nil, err
+			}
+			if gr.Name != "" {
+				r.Title = gr.Name
+				r.FullName = gr.Owner + "/" + gr.Name
+			}
+			r.Description = gr.Description
+		}
+
+		milestones := []*giteaMilestone{}
+		if content, has := a[path.Join(dir, "milestone.yml")]; has {
+			if err := yaml.Unmarshal(content, &milestones); err != nil {
+				return nil, err
+			}
+		}
+		dueDates := make(map[string]time.Time, len(milestones))
+		for _, m := range milestones {
+			if m.Deadline != nil {
+				dueDates[m.Title] = *m.Deadline
+			}
+		}
+
+		giteaIssues := []*giteaIssue{}
+		if err := yaml.Unmarshal(a[path.Join(dir, "issue.yml")], &giteaIssues); err != nil {
+			return nil, err
+		}
+
+		for _, gi := range giteaIssues {
+			i := &issue{
+				Number:    gi.Number,
+				Title:     gi.Title,
+				Body:      gi.Content,
+				Closed:    gi.State == "closed",
+				Milestone: gi.Milestone,
+				DueDate:   dueDates[gi.Milestone],
+			}
+			if gi.Closed != nil {
+				i.ClosedAt = *gi.Closed
+			}
+			for _, l := range gi.Labels {
+				i.Labels = append(i.Labels, &label{Name: l.Name, Color: l.Color})
+			}
+
+			if content, has := a[path.Join(dir, "comments", strconv.FormatInt(gi.Number, 10)+".yml")]; has {
+				comments := []*giteaComment{}
+				if err := yaml.Unmarshal(content, &comments); err != nil {
+					return nil, err
+				}
+				for _, c := range comments {
+					i.Comments = append(i.Comments, &comment{Author: c.PosterName, Body: c.Content})
+				}
+			}
+
+			r.Issues = append(r.Issues, i)
+		}
+
+		repositories = append(repositories, r)
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,208 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitissues
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The data of GitHub migration archives is split into files like issues_000001.json
+var gitHubArchiveFileRegex = regexp.MustCompile(`^([a-z_]+)_\d+\.json$`)
+
+type gitHubIssue struct {
+	URL        string     `json:"url"`
+	Repository string     `json:"repository"`
+	Title      string     `json:"title"`
+	Body       string     `json:"body"`
+	Milestone  string     `json:"milestone"`
+	Labels     []string   `json:"labels"`
+	ClosedAt   *time.Time `json:"closed_at"`
+}
+
+type gitHubComment struct {
+	Issue string `json:"issue"`
+	User  string `json:"user"`
+	Body  string `json:"body"`
+}
+
+type gitHubMilestone struct {
+	URL   string     `json:"url"`
+	Title string     `json:"title"`
+	DueOn *time.Time `json:"due_on"`
+}
+
+type gitHubLabel struct {
+	URL   string `json:"url"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type gitHubRepository struct {
+	URL         string `json:"url"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// gitHubArchiveFiles returns all files of a kind, like issues or labels, in a GitHub migration archive.
+func gitHubArchiveFiles(a archive, kind string) (files [][]byte) {
+	names := []string{}
+	for name := range a {
+		m := gitHubArchiveFileRegex.FindStringSubmatch(path.Base(name))
+		if m != nil && m[1] == kind {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, a[name])
+	}
+	return
+}
+
+func isGitHubArchive(a archive) bool {
+	return len(gitHubArchiveFiles(a, "issues")) > 0
+}
+
+// readGitHubArchiveFiles decodes all files of a kind into the slice pointed to by v.
+func readGitHubArchiveFiles[T any](a archive, kind string) (items []T, err error) {
+	for _, content := range gitHubArchiveFiles(a, kind) {
+		page := []T{}
+		if err := json.Unmarshal(content, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+// gitHubRepositoryName returns the full name of a repository, for example owner/repo for https://github.com/owner/repo.
+func gitHubRepositoryName(url string) string {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	if len(parts) < 2 {
+		return url
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+
+func gitHubIssueNumber(url string) int64 {
+	n, _ := strconv.ParseInt(path.Base(url), 10, 64)
+	return n
+}
+
+func parseGitHubArchive(a archive) (repositories []*repository, err error) {
+	ghRepositories, err := readGitHubArchiveFiles[*gitHubRepository](a, "repositories")
+	if err != nil {
+		return nil, err
+	}
+	ghIssues, err := readGitHubArchiveFiles[*gitHubIssue](a, "issues")
+	if err != nil {
+		return nil, err
+	}
+	ghComments, err := readGitHubArchiveFiles[*gitHubComment](a, "issue_comments")
+	if err != nil {
+		return nil, err
+	}
+	ghMilestones, err := readGitHubArchiveFiles[*gitHubMilestone](a, "milestones")
+	if err != nil {
+		return nil, err
+	}
+	ghLabels, err := readGitHubArchiveFiles[*gitHubLabel](a, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	milestones := make(map[string]*gitHubMilestone, len(ghMilestones))
+	for _, m := range ghMilestones {
+		milestones[m.URL] = m
+	}
+	labels := make(map[string]*gitHubLabel, len(ghLabels))
+	for _, l := range ghLabels {
+		labels[l.URL] = l
+	}
+
+	repositoriesByURL := make(map[string]*repository)
+	getRepository := func(url string) *repository {
+		r, exists := repositoriesByURL[url]
+		if !exists {
+			r = &repository{
+				FullName: gitHubRepositoryName(url),
+				Title:    gitHubRepositoryName(url),
+			}
+			repositoriesByURL[url] = r
+			repositories = append(repositories, r)
+		}
+		return r
+	}
+	for _, ghr := range ghRepositories {
+		r := getRepository(ghr.URL)
+		if ghr.Name != "" {
+			r.Title = ghr.Name
+		}
+		r.Description = ghr.Description
+	}
+
+	issues := make(map[string]*issue, len(ghIssues))
+	for _, ghi := range ghIssues {
+		i := &issue{
+			Number: gitHubIssueNumber(ghi.URL),
+			Title:  ghi.Title,
+			Body:   ghi.Body,
+			Closed: ghi.ClosedAt != nil,
+		}
+		if ghi.ClosedAt != nil {
+			i.ClosedAt = *ghi.ClosedAt
+		}
+		if m, has := milestones[ghi.Milestone]; has {
+			i.Milestone = m.Title
+			if m.DueOn != nil {
+				i.DueDate = *m.DueOn
+			}
+		}
+		for _, url := range ghi.Labels {
+			if l, has := labels[url]; has {
+				i.Labels = append(i.Labels, &label{Name: l.Name, Color: l.Color})
+			}
+		}
+
+		repositoryURL := ghi.Repository
+		if repositoryURL == "" {
+			repositoryURL = ghi.URL[:max(strings.LastIndex(ghi.URL, "/issues/"), 0)]
+		}
+		r := getRepository(repositoryURL)
+		r.Issues = append(r.Issues, i)
+		issues[ghi.URL] = i
+	}
+
+	for _, ghc := range ghComments {
+		i, has := issues[ghc.Issue]
+		if !has {
+			continue
+		}
+		i.Comments = append(i.Comments, &comment{
+			Author: path.Base(ghc.User),
+			Body:   ghc.Body,
+		})
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,147 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package gitissues
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path"
+	"time"
+)
+
+type gitLabIssue struct {
+	IID         int64      `json:"iid"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	DueDate     string     `json:"due_date"`
+	Milestone   *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	LabelLinks []struct {
+		Label struct {
+			Title string `json:"title"`
+			Color string `json:"color"`
+		} `json:"label"`
+	} `json:"label_links"`
+	Notes []struct {
+		Note   string `json:"note"`
+		System bool   `json:"system"`
+		Author *struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"notes"`
+}
+
+type gitLabProject struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Issues      []*gitLabIssue `json:"issues"`
+}
+
+// gitLabFile returns a file of a GitLab project export, which stores its data in a tree directory.
+func gitLabFile(a archive, name string) ([]byte, bool) {
+	for p, content := range a {
+		if p == "tree/"+name || path.Base(path.Dir(p)) == "tree" && path.Base(p) == name {
+			return content, true
+		}
+		if path.Base(path.Dir(path.Dir(p))) == "tree" && path.Base(path.Dir(p))+"/"+path.Base(p) == name {
+			return content, true
+		}
+	}
+	return nil, false
+}
+
+func isGitLabExport(a archive) bool {
+	_, has := gitLabFile(a, "project.json")
+	return has
+}
+
+// parseGitLabExport parses both the legacy export format with all issues in project.json and the newer format with
+// one issue per line in project/issues.ndjson.
+func parseGitLabExport(a archive) (repositories []*repository, err error) {
+	content, _ := gitLabFile(a, "project.json")
+	project := &gitLabProject{}
+	if err := json.Unmarshal(content, project); err != nil {
+		return nil, err
+	}
+
+	if ndjson, has := gitLabFile(a, "project/issues.ndjson"); has {
+		scanner := bufio.NewScanner(bytes.NewReader(ndjson))
+		scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			gli := &gitLabIssue{}
+			if err := json.Unmarshal(line, gli); err != nil {
+				return nil, err
+			}
+			project.Issues = append(project.Issues, gli)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &repository{
+		FullName:    project.Name,
+		Title:       project.Name,
+		Description: project.Description,
+	}
+	if r.Title == "" {
+		r.Title = "GitLab project"
+	}
+
+	for _, gli := range project.Issues {
+		i := &issue{
+			Number: gli.IID,
+			Title:  gli.Title,
+			Body:   gli.Description,
+			Closed: gli.State == "closed",
+		}
+		if gli.ClosedAt != nil {
+			i.ClosedAt = *gli.ClosedAt
+		}
+		if gli.DueDate != "" {
+			i.DueDate, _ = time.Parse("2006-01-02", gli.DueDate)
+		}
+		if gli.Milestone != nil {
+			i.Milestone = gli.Milestone.Title
+		}
+		for _, ll := range gli.LabelLinks {
+			i.Labels = append(i.Labels, &label{Name: ll.Label.Title, Color: ll.Label.Color})
+		}
+		for _, n := range gli.Notes {
+			// System notes are things like "changed the milestone", they are already part of the task
+			if n.System {
+				continue
+			}
+			c := &comment{Body: n.Note}
+			if n.Author != nil {
+				c.Author = n.Author.Name
+			}
+			i.Comments = append(i.Comments, c)
+		}
+		r.Issues = append(r.Issues, i)
+	}
+
+	return []*repository{r}, nil
+}
@@ -17,6 +17,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"code.vikunja.io/api/pkg/models"
@@ -42,6 +43,9 @@ func (mw *MigrationWeb) RegisterRoutes(g *echo.Group) {
 	g.GET("/"+ms.Name()+"/auth", mw.AuthURL)
 	g.GET("/"+ms.Name()+"/status", mw.Status)
 	g.POST("/"+ms.Name()+"/migrate", mw.Migrate)
+	registerJobRoutes(g, func() migration.MigratorName {
+		return mw.MigrationStruct()
+	}, mw.CreateJob)
 }
 
 // AuthURL is the web handler to get the auth url
@@ -80,6 +84,23 @@ func (mw *MigrationWeb) Migrate(c echo.Context) error {
 	return c.JSON(http.StatusOK, models.Message{Message: "Everything was migrated successfully."})
 }
 
+// CreateJob queues the migration as a background job. The request body is the same as for the migrate route.
+func (mw *MigrationWeb) CreateJob(c echo.Context) error {
+	ms := mw.MigrationStruct()
+
+	err := c.Bind(ms)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No or invalid model provided: "+err.Error())
+	}
+
+	payload, err := json.Marshal(ms)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return createJob(ms, c, string(payload), 0)
+}
+
 // Status returns whether or not a user has already done this migration
 func (mw *MigrationWeb) Status(c echo.Context) error {
 	ms := mw.MigrationStruct()
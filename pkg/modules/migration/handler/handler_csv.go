@@ -0,0 +1,106 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/modules/migration/csv"
+	user2 "code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web/handler"
+	"github.com/labstack/echo/v4"
+)
+
+// CSVMigratorWeb holds the routes of the csv migration, which needs a column mapping in addition to the file
+type CSVMigratorWeb struct {
+}
+
+// RegisterRoutes registers all routes for the csv migration
+func (cw *CSVMigratorWeb) RegisterRoutes(g *echo.Group) {
+	ms := &csv.Migrator{}
+	g.GET("/"+ms.Name()+"/status", cw.Status)
+	g.PUT("/"+ms.Name()+"/preview", cw.Preview)
+	g.PUT("/"+ms.Name()+"/migrate", cw.Migrate)
+}
+
+// Preview returns the columns and first rows of the uploaded file
+func (cw *CSVMigratorWeb) Preview(c echo.Context) error {
+	ms := &csv.Migrator{}
+
+	file, err := c.FormFile("import")
+	if err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	preview, err := ms.Preview(src, file.Size)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, preview)
+}
+
+// Migrate imports the uploaded file with the column mapping
+func (cw *CSVMigratorWeb) Migrate(c echo.Context) error {
+	ms := &csv.Migrator{}
+
+	// Get the user from context
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	mapping := &csv.Mapping{}
+	if err := json.Unmarshal([]byte(c.FormValue("mapping")), mapping); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No or invalid mapping provided: "+err.Error())
+	}
+
+	file, err := c.FormFile("import")
+	if err != nil {
+		return err
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Do the migration
+	report, err := ms.Migrate(user, src, file.Size, mapping)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	err = migration.SetMigrationStatus(ms, user)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// Status returns whether or not a user has already done this migration
+func (cw *CSVMigratorWeb) Status(c echo.Context) error {
+	return status(&csv.Migrator{}, c)
+}
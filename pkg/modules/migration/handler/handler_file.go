@@ -19,6 +19,8 @@ package handler
 import (
 	"net/http"
 
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/files"
 	"code.vikunja.io/api/pkg/models"
 	"code.vikunja.io/api/pkg/modules/migration"
 	user2 "code.vikunja.io/api/pkg/user"
@@ -35,6 +37,9 @@ func (fw *FileMigratorWeb) RegisterRoutes(g *echo.Group) {
 	ms := fw.MigrationStruct()
 	g.GET("/"+ms.Name()+"/status", fw.Status)
 	g.PUT("/"+ms.Name()+"/migrate", fw.Migrate)
+	registerJobRoutes(g, func() migration.MigratorName {
+		return fw.MigrationStruct()
+	}, fw.CreateJob)
 }
 
 // Migrate calls the migration method
@@ -71,6 +76,50 @@ func (fw *FileMigratorWeb) Migrate(c echo.Context) error {
 	return c.JSON(http.StatusOK, models.Message{Message: "Everything was migrated successfully."})
 }
 
+// CreateJob saves the uploaded file and queues the migration as a background job.
+// @Summary Start a migration in the background
+// @Description Queues a migration as a background job and returns the job right away instead of waiting until everything is migrated. File migrators take the file as multipart form file `import`, auth based migrators like todoist or trello take the same json body as their migrate route. Poll the job to get its progress, a notification is sent once it is done or failed.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param service path string true "The name of the migration service, for example trello or vikunja-file."
+// @Param import formData string false "The file to import, only for file migrators."
+// @Success 201 {object} migration.Job "The queued migration job."
+// @Failure 400 {object} web.HTTPError "No file or an invalid request body provided."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/{service}/jobs [put]
+func (fw *FileMigratorWeb) CreateJob(c echo.Context) error {
+	ms := fw.MigrationStruct()
+
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	file, err := c.FormFile("import")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No file provided: "+err.Error())
+	}
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	s := db.NewSession()
+	defer s.Close()
+
+	// The file is kept until the job is done, it is not subject to the attachment size limit just like a
+	// synchronous migration.
+	f, err := files.CreateWithMimeAndSession(s, src, file.Filename, uint64(file.Size), user, file.Header.Get("Content-Type"), false)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return createJob(ms, c, "", f.ID)
+}
+
 // Status returns whether or not a user has already done this migration
 func (fw *FileMigratorWeb) Status(c echo.Context) error {
 	ms := fw.MigrationStruct()
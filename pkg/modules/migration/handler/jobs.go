@@ -0,0 +1,161 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/modules/migration"
+	user2 "code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web/handler"
+	"github.com/labstack/echo/v4"
+)
+
+func registerJobRoutes(g *echo.Group, factory func() migration.MigratorName, create echo.HandlerFunc) {
+	migration.RegisterJobMigrator(factory)
+
+	ms := factory()
+	g.PUT("/"+ms.Name()+"/jobs", create)
+	g.GET("/"+ms.Name()+"/jobs", func(c echo.Context) error {
+		return listJobs(ms, c)
+	})
+	g.GET("/"+ms.Name()+"/jobs/:job", func(c echo.Context) error {
+		return getJob(ms, c)
+	})
+	g.DELETE("/"+ms.Name()+"/jobs/:job", func(c echo.Context) error {
+		return cancelJob(ms, c)
+	})
+}
+
+// createJob queues a migration job with either the request body of an auth based migrator or the uploaded file of a
+// file migrator.
+func createJob(ms migration.MigratorName, c echo.Context, payload string, fileID int64) error {
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	job, err := migration.CreateJob(s, ms, user, payload, fileID)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusCreated, job)
+}
+
+// listJobs returns all migration jobs of the current user for a migrator
+// @Summary Get all migration jobs
+// @Description Returns all migration jobs of the current user for this migration service, the latest first. Running jobs contain their current progress.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Param service path string true "The name of the migration service, for example trello or vikunja-file."
+// @Success 200 {array} migration.Job "The migration jobs"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/{service}/jobs [get]
+func listJobs(ms migration.MigratorName, c echo.Context) error {
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	jobs, err := migration.GetJobs(s, ms, user)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, jobs)
+}
+
+// getJob returns one migration job with its progress
+// @Summary Get a migration job
+// @Description Returns a migration job with how many projects, tasks and files it created so far and everything which could not be migrated as it was. Poll this endpoint to show the progress of a running migration.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Param service path string true "The name of the migration service, for example trello or vikunja-file."
+// @Param job path int true "The id of the migration job"
+// @Success 200 {object} migration.Job "The migration job"
+// @Failure 400 {object} web.HTTPError "Invalid job id."
+// @Failure 404 {object} web.HTTPError "The migration job does not exist."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/{service}/jobs/{job} [get]
+func getJob(ms migration.MigratorName, c echo.Context) error {
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	jobID, err := strconv.ParseInt(c.Param("job"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid job id")
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	job, err := migration.GetJob(s, ms, user, jobID)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// cancelJob cancels a queued or running migration job
+// @Summary Cancel a migration job
+// @Description Cancels a migration job. A queued job won't be started, a running job stops at the next project, task or file it creates. Everything it imported until then is kept. The job will have the status `cancelled` once it stopped.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Param service path string true "The name of the migration service, for example trello or vikunja-file."
+// @Param job path int true "The id of the migration job"
+// @Success 200 {object} migration.Job "The migration job"
+// @Failure 400 {object} web.HTTPError "Invalid job id."
+// @Failure 404 {object} web.HTTPError "The migration job does not exist."
+// @Failure 412 {object} web.HTTPError "The migration job is already finished."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/{service}/jobs/{job} [delete]
+func cancelJob(ms migration.MigratorName, c echo.Context) error {
+	user, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	jobID, err := strconv.ParseInt(c.Param("job"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid job id")
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	job, err := migration.CancelJob(s, ms, user, jobID)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
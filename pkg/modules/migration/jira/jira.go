@@ -0,0 +1,559 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jira
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"html"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+
+	"xorm.io/xorm"
+)
+
+const logPrefix = "[Jira Migration] "
+
+// Migrator imports Jira's xml or json issue exports
+type Migrator struct {
+}
+
+// issue holds everything the migration needs from an issue, independent of the format of the export
+type issue struct {
+	Key          string
+	ProjectKey   string
+	ProjectName  string
+	Summary      string
+	Description  string
+	ParentKey    string
+	Priority     string
+	Status       string
+	Done         bool
+	Assignee     string // The email address or username of the assignee in Jira
+	AssigneeName string
+	Labels       []string
+	Created      time.Time
+	Resolved     time.Time
+	DueDate      time.Time
+	SubtaskKeys  []string
+	Comments     []*comment
+	Links        []*link
+	Attachments  []*attachment
+}
+
+type comment struct {
+	Author     string // The email address or username of the author in Jira
+	AuthorName string
+	Body       string
+}
+
+type link struct {
+	Kind models.RelationKind
+	Key  string
+}
+
+type attachment struct {
+	ID       string
+	Filename string
+}
+
+var priorities = map[string]int64{
+	"trivial":  1,
+	"lowest":   1,
+	"minor":    1,
+	"low":      1,
+	"medium":   2,
+	"major":    3,
+	"high":     3,
+	"critical": 4,
+	"highest":  4,
+	"blocker":  5,
+}
+
+// getRelationKinds returns the relation kinds of the outward and inward links of a Jira issue link type.
+func getRelationKinds(linkType string) (outward, inward models.RelationKind) {
+	switch strings.ToLower(linkType) {
+	case "blocks":
+		return models.RelationKindBlocking, models.RelationKindBlocked
+	case "duplicate":
+		return models.RelationKindDuplicateOf, models.RelationKindDuplicates
+	case "cloners":
+		return models.RelationKindCopiedFrom, models.RelationKindCopiedTo
+	default:
+		return models.RelationKindRelated, models.RelationKindRelated
+	}
+}
+
+// keyNumber returns the number of an issue key, for example 12 for PRJ-12.
+func keyNumber(key string) int64 {
+	n, err := strconv.ParseInt(key[strings.LastIndex(key, "-")+1:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Name is used to get the name of the jira migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/jira/status [get]
+func (m *Migrator) Name() string {
+	return "jira"
+}
+
+func parseExport(r io.Reader) ([]*issue, error) {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return nil, &models.ErrInvalidMigrationFile{Migrator: "jira", Reason: "The file is empty."}
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		case '<':
+			return parseXMLExport(br)
+		case '{', '[':
+			return parseJSONExport(br)
+		default:
+			return nil, &models.ErrInvalidMigrationFile{Migrator: "jira", Reason: "The file is neither a xml nor a json export."}
+		}
+	}
+}
+
+// readExport returns the issues and, if the export is a zip archive, the archive with the attachments.
+func readExport(file io.ReaderAt, size int64) (issues []*issue, archive *zip.Reader, err error) {
+	magic := make([]byte, 4)
+	_, _ = file.ReadAt(magic, 0)
+	if !bytes.Equal(magic, []byte("PK\x03\x04")) {
+		issues, err = parseExport(io.NewSectionReader(file, 0, size))
+		return issues, nil, err
+	}
+
+	archive, err = zip.NewReader(file, size)
+	if err != nil {
+		return nil, nil, &models.ErrInvalidMigrationFile{Migrator: "jira", Reason: err.Error()}
+	}
+
+	for _, f := range archive.File {
+		ext := strings.ToLower(path.Ext(f.Name))
+		if ext != ".xml" && ext != ".json" {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer r.Close()
+
+		issues, err = parseExport(r)
+		return issues, archive, err
+	}
+
+	return nil, nil, &models.ErrInvalidMigrationFile{Migrator: "jira", Reason: "The archive does not contain a xml or json export."}
+}
+
+// findAttachment returns the file of an attachment in the archive. Attachments are either stored with their id as
+// name, like in Jira's attachment directory, or with their file name in a directory named after the issue key.
+func findAttachment(archive *zip.Reader, issueKey string, a *attachment) *zip.File {
+	if archive == nil {
+		return nil
+	}
+	for _, f := range archive.File {
+		name := path.Base(f.Name)
+		if a.ID != "" && name == a.ID {
+			return f
+		}
+		if name == a.Filename && path.Base(path.Dir(f.Name)) == issueKey {
+			return f
+		}
+	}
+	return nil
+}
+
+type conversion struct {
+	root *models.ProjectWithTasksAndBuckets
+	// Issue key is the key
+	tasks          map[string]*models.TaskWithComments
+	projects       map[*models.TaskWithComments]*models.ProjectWithTasksAndBuckets
+	assignees      map[*models.TaskWithComments]*user.User
+	commentAuthors map[*models.TaskComment]*user.User
+}
+
+func getTaskAttachment(archive *zip.Reader, i *issue, a *attachment) (*models.TaskAttachment, error) {
+	f := findAttachment(archive, i.Key, a)
+	if f == nil {
+		log.Debugf(logPrefix+"Could not find attachment %s of issue %s in the archive", a.Filename, i.Key)
+		return nil, nil
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskAttachment{
+		File: &files.File{
+			Name:        a.Filename,
+			Size:        uint64(len(content)),
+			FileContent: content,
+		},
+	}, nil
+}
+
+// convertJiraToVikunja creates one project per Jira project with all its issues as tasks below a common parent project.
+// Users holds all Vikunja users matching the assignees and comment authors, with their email address as key.
+func convertJiraToVikunja(issues []*issue, archive *zip.Reader, users map[string]*user.User) (c *conversion, err error) {
+	c = &conversion{
+		root: &models.ProjectWithTasksAndBuckets{
+			Project: models.Project{
+				Title: "Migrated from Jira",
+			},
+		},
+		tasks:          make(map[string]*models.TaskWithComments, len(issues)),
+		projects:       make(map[*models.TaskWithComments]*models.ProjectWithTasksAndBuckets, len(issues)),
+		assignees:      make(map[*models.TaskWithComments]*user.User),
+		commentAuthors: make(map[*models.TaskComment]*user.User),
+	}
+
+	projects := make(map[string]*models.ProjectWithTasksAndBuckets)
+	buckets := make(map[*models.ProjectWithTasksAndBuckets]map[string]int64)
+	var lastBucketID int64
+
+	for _, i := range issues {
+		project, exists := projects[i.ProjectKey]
+		if !exists {
+			project = &models.ProjectWithTasksAndBuckets{
+				Project: models.Project{
+					Title: i.ProjectName,
+				},
+			}
+			if project.Title == "" {
+				project.Title = i.ProjectKey
+			}
+			if len([]rune(i.ProjectKey)) <= 10 {
+				project.Identifier = i.ProjectKey
+			}
+			projects[i.ProjectKey] = project
+			buckets[project] = make(map[string]int64)
+			c.root.ChildProjects = append(c.root.ChildProjects, project)
+		}
+
+		task := &models.TaskWithComments{
+			Task: models.Task{
+				Title:       i.Summary,
+				Description: i.Description,
+				Done:        i.Done,
+				DoneAt:      i.Resolved,
+				DueDate:     i.DueDate,
+				Priority:    priorities[strings.ToLower(i.Priority)],
+			},
+		}
+		if task.Title == "" {
+			task.Title = i.Key
+		}
+
+		if i.Status != "" {
+			bucketID, exists := buckets[project][i.Status]
+			if !exists {
+				lastBucketID++
+				bucketID = lastBucketID
+				buckets[project][i.Status] = bucketID
+				project.Buckets = append(project.Buckets, &models.Bucket{
+					ID:    bucketID,
+					Title: i.Status,
+				})
+			}
+			task.BucketID = bucketID
+		}
+
+		for _, l := range i.Labels {
+			task.Labels = append(task.Labels, &models.Label{Title: l})
+		}
+
+		if u, has := users[strings.ToLower(i.Assignee)]; has {
+			c.assignees[task] = u
+		}
+
+		for _, com := range i.Comments {
+			tc := &models.TaskComment{Comment: com.Body}
+			if u, has := users[strings.ToLower(com.Author)]; has {
+				c.commentAuthors[tc] = u
+			} else if com.AuthorName != "" {
+				tc.Comment = "<p>" + html.EscapeString(com.AuthorName) + ":</p>" + tc.Comment
+			}
+			task.Comments = append(task.Comments, tc)
+		}
+
+		for _, a := range i.Attachments {
+			ta, err := getTaskAttachment(archive, i, a)
+			if err != nil {
+				return nil, err
+			}
+			if ta != nil {
+				task.Attachments = append(task.Attachments, ta)
+			}
+		}
+
+		project.Tasks = append(project.Tasks, task)
+		c.tasks[i.Key] = task
+		c.projects[task] = project
+	}
+
+	return c, nil
+}
+
+// setTaskIndexes sets the index of all tasks to the number of their issue key so that their identifier is the
+// same as in Jira. Tasks of issues with a key from another project are moved after all others.
+func setTaskIndexes(s *xorm.Session, c *conversion, issues []*issue) (err error) {
+	maxIndex := make(map[*models.ProjectWithTasksAndBuckets]int64)
+	indexes := make(map[*models.TaskWithComments]int64, len(issues))
+	for _, i := range issues {
+		task := c.tasks[i.Key]
+		project := c.projects[task]
+		n := keyNumber(i.Key)
+		if i.ProjectKey == "" || !strings.HasPrefix(i.Key, i.ProjectKey+"-") || n == 0 {
+			continue
+		}
+		indexes[task] = n
+		if n > maxIndex[project] {
+			maxIndex[project] = n
+		}
+	}
+
+	for _, i := range issues {
+		task := c.tasks[i.Key]
+		index, has := indexes[task]
+		if !has {
+			maxIndex[c.projects[task]]++
+			index = maxIndex[c.projects[task]]
+		}
+
+		_, err = s.
+			Where("id = ?", task.ID).
+			Cols("index").
+			NoAutoTime().
+			Update(&models.Task{Index: index})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createRelations(s *xorm.Session, c *conversion, issues []*issue, doer *user.User) (err error) {
+	for _, i := range issues {
+		links := i.Links
+		if i.ParentKey != "" {
+			links = append(links, &link{Kind: models.RelationKindParenttask, Key: i.ParentKey})
+		}
+		for _, key := range i.SubtaskKeys {
+			links = append(links, &link{Kind: models.RelationKindSubtask, Key: key})
+		}
+
+		for _, l := range links {
+			other, has := c.tasks[l.Key]
+			if !has {
+				log.Debugf(logPrefix+"Could not find issue %s linked from %s, skipping link", l.Key, i.Key)
+				continue
+			}
+
+			rel := &models.TaskRelation{
+				TaskID:       c.tasks[i.Key].ID,
+				OtherTaskID:  other.ID,
+				RelationKind: l.Kind,
+			}
+			err = rel.Create(s, doer)
+			if err != nil && !models.IsErrRelationAlreadyExists(err) && !models.IsErrRelationTasksCannotBeTheSame(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// assignUsers gives all assignees write access to the migrated projects and then assigns them to their tasks.
+func assignUsers(s *xorm.Session, c *conversion, doer *user.User) (err error) {
+	shared := make(map[int64]bool)
+	for task, u := range c.assignees {
+		if u.ID != doer.ID && !shared[u.ID] {
+			pu := &models.ProjectUser{
+				Username:  u.Username,
+				ProjectID: c.root.ID,
+				Right:     models.RightWrite,
+			}
+			err = pu.Create(s, doer)
+			if err != nil && !models.IsErrUserAlreadyHasAccess(err) {
+				return err
+			}
+			shared[u.ID] = true
+		}
+
+		ta := &models.TaskAssginee{
+			TaskID: task.ID,
+			UserID: u.ID,
+		}
+		err = ta.Create(s, doer)
+		if err != nil {
+			return err
+		}
+	}
+
+	for tc, u := range c.commentAuthors {
+		_, err = s.
+			Where("id = ?", tc.ID).
+			Cols("author_id").
+			NoAutoTime().
+			Update(&models.TaskComment{AuthorID: u.ID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getUsersByEmail returns all users with the email addresses of the assignees and comment authors of the issues.
+// Only users who already share a team or a project with the doer are returned, because assignees get access to the
+// imported projects and an export could otherwise be used to share projects with arbitrary users.
+func getUsersByEmail(s *xorm.Session, issues []*issue, doer *user.User) (users map[string]*user.User, err error) {
+	sharingUserIDs, err := models.GetUserIDsSharingWith(s, doer)
+	if err != nil {
+		return nil, err
+	}
+	sharingUserIDs[doer.ID] = true
+
+	users = make(map[string]*user.User)
+	checked := make(map[string]bool)
+	for _, i := range issues {
+		emails := []string{i.Assignee}
+		for _, com := range i.Comments {
+			emails = append(emails, com.Author)
+		}
+
+		for _, email := range emails {
+			email = strings.ToLower(email)
+			if !strings.Contains(email, "@") || checked[email] {
+				continue
+			}
+			checked[email] = true
+
+			u, err := user.GetUserWithEmail(s, &user.User{Email: email})
+			if user.IsErrUserDoesNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !sharingUserIDs[u.ID] {
+				log.Debugf(logPrefix+"Not matching user %d, they don't share a team or project with user %d", u.ID, doer.ID)
+				continue
+			}
+			users[email] = u
+		}
+	}
+
+	return users, nil
+}
+
+// Migrate takes a Jira export, parses it and imports everything in it into Vikunja.
+// @Summary Import all projects and issues from a Jira export
+// @Description Imports all issues of a Jira xml or json issue export into Vikunja, with one project per Jira project. Statuses become kanban buckets, issue keys are kept as task identifiers and subtasks and issue links become task relations. Assignees and comment authors are matched to Vikunja users by their email address if they already share a team or project with the importing user, matched assignees get write access to the imported projects. To import attachments, upload a zip archive with the export and the attachments, either named with their attachment id or with their file name in a directory named after the issue key.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The Jira xml or json export or a zip archive containing it."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 400 {object} web.HTTPError "The file is not a valid Jira export."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/jira/migrate [put]
+func (m *Migrator) Migrate(u *user.User, file io.ReaderAt, size int64) (err error) {
+	issues, archive, err := readExport(file, size)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(logPrefix+"Importing %d issues", len(issues))
+
+	s := db.NewSession()
+	defer s.Close()
+
+	users, err := getUsersByEmail(s, issues, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	c, err := convertJiraToVikunja(issues, archive, users)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = migration.InsertFromStructureWithSession(s, []*models.ProjectWithTasksAndBuckets{c.root}, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = setTaskIndexes(s, c, issues)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = createRelations(s, c, issues, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = assignUsers(s, c, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
@@ -0,0 +1,214 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// jsonTimeFormat is the format of timestamps in Jira's rest api, which is used for json exports.
+const jsonTimeFormat = "2006-01-02T15:04:05.000-0700"
+
+type jsonUser struct {
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type jsonIssueRef struct {
+	Key string `json:"key"`
+}
+
+type jsonIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		// Either a string or a document in the Atlassian Document Format.
+		Description json.RawMessage `json:"description"`
+		Project     struct {
+			Key  string `json:"key"`
+			Name string `json:"name"`
+		} `json:"project"`
+		Parent   *jsonIssueRef `json:"parent"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Status struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Assignee       *jsonUser       `json:"assignee"`
+		Labels         []string        `json:"labels"`
+		Created        string          `json:"created"`
+		ResolutionDate string          `json:"resolutiondate"`
+		DueDate        string          `json:"duedate"`
+		Subtasks       []*jsonIssueRef `json:"subtasks"`
+		IssueLinks     []struct {
+			Type struct {
+				Name string `json:"name"`
+			} `json:"type"`
+			InwardIssue  *jsonIssueRef `json:"inwardIssue"`
+			OutwardIssue *jsonIssueRef `json:"outwardIssue"`
+		} `json:"issuelinks"`
+		Comment struct {
+			Comments []struct {
+				Author *jsonUser       `json:"author"`
+				Body   json.RawMessage `json:"body"`
+			} `json:"comments"`
+		} `json:"comment"`
+		Attachments []struct {
+			ID       string `json:"id"`
+			Filename string `json:"filename"`
+		} `json:"attachment"`
+	} `json:"fields"`
+}
+
+func parseJSONTime(value string) time.Time {
+	t, err := time.Parse(jsonTimeFormat, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// documentNode is a node of a document in the Atlassian Document Format.
+type documentNode struct {
+	Type    string          `json:"type"`
+	Text    string          `json:"text"`
+	Content []*documentNode `json:"content"`
+}
+
+func (n *documentNode) text() string {
+	if n.Type == "text" {
+		return n.Text
+	}
+	if n.Type == "hardBreak" {
+		return "\n"
+	}
+	var b strings.Builder
+	for _, c := range n.Content {
+		b.WriteString(c.text())
+	}
+	return b.String()
+}
+
+// parseJSONText returns the html of a text field, which is either a string or a document in the Atlassian Document Format.
+func parseJSONText(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	doc := &documentNode{}
+	if err := json.Unmarshal(raw, doc); err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, block := range doc.Content {
+		b.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(block.text()), "\n", "<br>") + "</p>")
+	}
+	return b.String()
+}
+
+func parseJSONExport(r io.Reader) (issues []*issue, err error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exports are either the response of the search api with all issues in an "issues" array or a plain array of issues.
+	jsonIssues := []*jsonIssue{}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(content, &jsonIssues)
+	} else {
+		export := &struct {
+			Issues []*jsonIssue `json:"issues"`
+		}{}
+		err = json.Unmarshal(content, export)
+		jsonIssues = export.Issues
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issues = make([]*issue, 0, len(jsonIssues))
+	for _, ji := range jsonIssues {
+		f := ji.Fields
+		i := &issue{
+			Key:         ji.Key,
+			ProjectKey:  f.Project.Key,
+			ProjectName: f.Project.Name,
+			Summary:     f.Summary,
+			Description: parseJSONText(f.Description),
+			Status:      f.Status.Name,
+			Done:        f.Status.StatusCategory.Key == "done" || f.ResolutionDate != "",
+			Labels:      f.Labels,
+			Created:     parseJSONTime(f.Created),
+			Resolved:    parseJSONTime(f.ResolutionDate),
+		}
+		if f.DueDate != "" {
+			i.DueDate, _ = time.Parse("2006-01-02", f.DueDate)
+		}
+		if f.Parent != nil {
+			i.ParentKey = f.Parent.Key
+		}
+		if f.Priority != nil {
+			i.Priority = f.Priority.Name
+		}
+		if f.Assignee != nil {
+			i.Assignee = f.Assignee.EmailAddress
+			i.AssigneeName = f.Assignee.DisplayName
+		}
+		for _, st := range f.Subtasks {
+			i.SubtaskKeys = append(i.SubtaskKeys, st.Key)
+		}
+
+		for _, l := range f.IssueLinks {
+			outward, inward := getRelationKinds(l.Type.Name)
+			if l.OutwardIssue != nil {
+				i.Links = append(i.Links, &link{Kind: outward, Key: l.OutwardIssue.Key})
+			}
+			if l.InwardIssue != nil {
+				i.Links = append(i.Links, &link{Kind: inward, Key: l.InwardIssue.Key})
+			}
+		}
+
+		for _, c := range f.Comment.Comments {
+			com := &comment{Body: parseJSONText(c.Body)}
+			if c.Author != nil {
+				com.Author = c.Author.EmailAddress
+				com.AuthorName = c.Author.DisplayName
+			}
+			i.Comments = append(i.Comments, com)
+		}
+
+		for _, a := range f.Attachments {
+			i.Attachments = append(i.Attachments, &attachment{ID: a.ID, Filename: a.Filename})
+		}
+
+		issues = append(issues, i)
+	}
+
+	return issues, nil
+}
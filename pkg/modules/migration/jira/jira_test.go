@@ -0,0 +1,290 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jira
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testXMLExport = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92">
+<channel>
+	<title>Jira</title>
+	<item>
+		<title>[PRJ-1] Set up the server</title>
+		<project id="10000" key="PRJ">Platform</project>
+		<description>&lt;p&gt;We need a server.&lt;/p&gt;</description>
+		<key id="10001">PRJ-1</key>
+		<summary>Set up the server</summary>
+		<priority id="2">High</priority>
+		<status id="10001">Done</status>
+		<statusCategory id="3" key="done" colorName="green"/>
+		<assignee username="user1@example.com">User One</assignee>
+		<labels>
+			<label>infra</label>
+		</labels>
+		<created>Mon, 12 Oct 2026 10:00:00 +0200</created>
+		<resolved>Fri, 16 Oct 2026 17:30:00 +0200</resolved>
+		<due>Sat, 17 Oct 2026 00:00:00 +0000</due>
+		<comments>
+			<comment id="1" author="jane" created="Tue, 13 Oct 2026 09:00:00 +0200">&lt;p&gt;On it.&lt;/p&gt;</comment>
+		</comments>
+		<issuelinks>
+			<issuelinktype id="10000">
+				<name>Blocks</name>
+				<outwardlinks description="blocks">
+					<issuelink><issuekey id="10004">PRJ-4</issuekey></issuelink>
+				</outwardlinks>
+			</issuelinktype>
+		</issuelinks>
+		<subtasks>
+			<subtask id="10002">PRJ-2</subtask>
+		</subtasks>
+		<attachments>
+			<attachment id="10100" name="diagram.txt" size="7" author="jane" created="Mon, 12 Oct 2026 10:00:00 +0200"/>
+		</attachments>
+	</item>
+	<item>
+		<title>[PRJ-2] Install the database</title>
+		<project id="10000" key="PRJ">Platform</project>
+		<key id="10002">PRJ-2</key>
+		<summary>Install the database</summary>
+		<parent id="10001">PRJ-1</parent>
+		<priority id="3">Medium</priority>
+		<status id="3">In Progress</status>
+	</item>
+	<item>
+		<title>[PRJ-4] Deploy</title>
+		<project id="10000" key="PRJ">Platform</project>
+		<key id="10004">PRJ-4</key>
+		<summary>Deploy</summary>
+		<status id="3">In Progress</status>
+	</item>
+</channel>
+</rss>`
+
+const testJSONExport = `{"issues": [
+	{
+		"key": "WEB-3",
+		"fields": {
+			"summary": "Fix the login",
+			"description": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Login <fails>"}, {"type": "hardBreak"}, {"type": "text", "text": "sometimes"}]}
+			]},
+			"project": {"key": "WEB", "name": "Website"},
+			"priority": {"name": "Blocker"},
+			"status": {"name": "To Do", "statusCategory": {"key": "new"}},
+			"assignee": {"displayName": "User Two", "emailAddress": "User2@example.com"},
+			"labels": ["bug"],
+			"created": "2026-10-12T10:00:00.000+0200",
+			"resolutiondate": null,
+			"duedate": "2026-10-20",
+			"issuelinks": [
+				{"type": {"name": "Duplicate"}, "inwardIssue": {"key": "WEB-5"}}
+			],
+			"comment": {"comments": [
+				{"author": {"displayName": "User One", "emailAddress": "user1@example.com"}, "body": "Reproduced."}
+			]}
+		}
+	},
+	{
+		"key": "WEB-5",
+		"fields": {
+			"summary": "Login broken",
+			"project": {"key": "WEB", "name": "Website"},
+			"status": {"name": "Closed", "statusCategory": {"key": "done"}},
+			"resolutiondate": "2026-10-14T12:00:00.000+0200"
+		}
+	}
+]}`
+
+func TestParseXMLExport(t *testing.T) {
+	issues, err := parseExport(strings.NewReader(testXMLExport))
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+
+	i := issues[0]
+	assert.Equal(t, "PRJ-1", i.Key)
+	assert.Equal(t, "PRJ", i.ProjectKey)
+	assert.Equal(t, "Platform", i.ProjectName)
+	assert.Equal(t, "Set up the server", i.Summary)
+	assert.Equal(t, "<p>We need a server.</p>", i.Description)
+	assert.Equal(t, "High", i.Priority)
+	assert.Equal(t, "Done", i.Status)
+	assert.True(t, i.Done)
+	assert.Equal(t, "user1@example.com", i.Assignee)
+	assert.Equal(t, []string{"infra"}, i.Labels)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), i.DueDate.UTC())
+	assert.Equal(t, time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC), i.Resolved.UTC())
+	require.Len(t, i.Comments, 1)
+	assert.Equal(t, "jane", i.Comments[0].Author)
+	assert.Equal(t, "<p>On it.</p>", i.Comments[0].Body)
+	assert.Equal(t, []*link{{Kind: models.RelationKindBlocking, Key: "PRJ-4"}}, i.Links)
+	assert.Equal(t, []string{"PRJ-2"}, i.SubtaskKeys)
+	assert.Equal(t, []*attachment{{ID: "10100", Filename: "diagram.txt"}}, i.Attachments)
+
+	assert.Equal(t, "PRJ-1", issues[1].ParentKey)
+	assert.False(t, issues[1].Done)
+}
+
+func TestParseJSONExport(t *testing.T) {
+	issues, err := parseExport(strings.NewReader(testJSONExport))
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	i := issues[0]
+	assert.Equal(t, "WEB-3", i.Key)
+	assert.Equal(t, "Website", i.ProjectName)
+	assert.Equal(t, "<p>Login &lt;fails&gt;<br>sometimes</p>", i.Description)
+	assert.Equal(t, "Blocker", i.Priority)
+	assert.False(t, i.Done)
+	assert.Equal(t, "User2@example.com", i.Assignee)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), i.DueDate)
+	assert.Equal(t, []*link{{Kind: models.RelationKindDuplicates, Key: "WEB-5"}}, i.Links)
+	require.Len(t, i.Comments, 1)
+	assert.Equal(t, "Reproduced.", i.Comments[0].Body)
+	assert.Equal(t, "user1@example.com", i.Comments[0].Author)
+
+	assert.True(t, issues[1].Done)
+	assert.Equal(t, time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), issues[1].Resolved.UTC())
+}
+
+func TestParseExport_Invalid(t *testing.T) {
+	_, err := parseExport(strings.NewReader("Summary,Status\n"))
+	assert.Error(t, err)
+	assert.True(t, models.IsErrInvalidMigrationFile(err))
+}
+
+func TestConvertJiraToVikunja(t *testing.T) {
+	issues, err := parseExport(strings.NewReader(testXMLExport))
+	require.NoError(t, err)
+
+	u := &user.User{ID: 1}
+	c, err := convertJiraToVikunja(issues, nil, map[string]*user.User{"user1@example.com": u})
+	require.NoError(t, err)
+
+	require.Len(t, c.root.ChildProjects, 1)
+	project := c.root.ChildProjects[0]
+	assert.Equal(t, "Platform", project.Title)
+	assert.Equal(t, "PRJ", project.Identifier)
+	require.Len(t, project.Buckets, 2)
+	assert.Equal(t, "Done", project.Buckets[0].Title)
+	assert.Equal(t, "In Progress", project.Buckets[1].Title)
+
+	require.Len(t, project.Tasks, 3)
+	task := project.Tasks[0]
+	assert.Equal(t, "Set up the server", task.Title)
+	assert.Equal(t, int64(3), task.Priority)
+	assert.Equal(t, project.Buckets[0].ID, task.BucketID)
+	assert.Equal(t, u, c.assignees[task])
+	// The comment author is not a vikunja user
+	assert.Equal(t, "<p>jane:</p><p>On it.</p>", task.Comments[0].Comment)
+	assert.Equal(t, project.Buckets[1].ID, project.Tasks[1].BucketID)
+}
+
+func TestMigrate(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("export/issues.xml")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(testXMLExport))
+	require.NoError(t, err)
+	w, err = zw.Create("export/attachments/PRJ/10000/PRJ-1/10100")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("diagram"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	u := &user.User{ID: 3, Username: "user3"}
+	file := bytes.NewReader(buf.Bytes())
+	err = (&Migrator{}).Migrate(u, file, file.Size())
+	require.NoError(t, err)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	project := &models.Project{}
+	has, err := s.Where("title = ? AND owner_id = ?", "Platform", 3).Get(project)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	tasks := make(map[int64]*models.Task)
+	err = s.Where("project_id = ?", project.ID).Find(&tasks)
+	require.NoError(t, err)
+	byIndex := make(map[int64]*models.Task, len(tasks))
+	for _, task := range tasks {
+		byIndex[task.Index] = task
+	}
+	require.Contains(t, byIndex, int64(1))
+	require.Contains(t, byIndex, int64(2))
+	require.Contains(t, byIndex, int64(4))
+	assert.Equal(t, "Set up the server", byIndex[1].Title)
+	assert.True(t, byIndex[1].Done)
+	assert.Equal(t, "Deploy", byIndex[4].Title)
+
+	db.AssertExists(t, "task_relations", map[string]interface{}{
+		"task_id":       byIndex[1].ID,
+		"other_task_id": byIndex[2].ID,
+		"relation_kind": models.RelationKindSubtask,
+	}, false)
+	db.AssertExists(t, "task_relations", map[string]interface{}{
+		"task_id":       byIndex[1].ID,
+		"other_task_id": byIndex[4].ID,
+		"relation_kind": models.RelationKindBlocking,
+	}, false)
+	db.AssertExists(t, "task_assignees", map[string]interface{}{
+		"task_id": byIndex[1].ID,
+		"user_id": 1,
+	}, false)
+	db.AssertExists(t, "users_projects", map[string]interface{}{
+		"project_id": project.ParentProjectID,
+		"user_id":    1,
+		"right":      models.RightWrite,
+	}, false)
+	db.AssertExists(t, "task_attachments", map[string]interface{}{
+		"task_id": byIndex[1].ID,
+	}, false)
+}
+
+func TestGetUsersByEmail(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	issues := []*issue{
+		{Assignee: "user1@example.com"},
+		// Does not share a team or project with user 3
+		{Assignee: "user8@example.com"},
+	}
+	users, err := getUsersByEmail(s, issues, &user.User{ID: 3})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(1), users["user1@example.com"].ID)
+}
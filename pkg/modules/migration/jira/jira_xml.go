@@ -0,0 +1,134 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package jira
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// xmlTimeFormat is the format of all dates in Jira's xml (RSS) issue export.
+const xmlTimeFormat = "Mon, 2 Jan 2006 15:04:05 -0700"
+
+type xmlExport struct {
+	Items []*xmlIssue `xml:"channel>item"`
+}
+
+type xmlIssue struct {
+	Key     string `xml:"key"`
+	Project struct {
+		Key  string `xml:"key,attr"`
+		Name string `xml:",chardata"`
+	} `xml:"project"`
+	Summary        string `xml:"summary"`
+	Description    string `xml:"description"`
+	Parent         string `xml:"parent"`
+	Priority       string `xml:"priority"`
+	Status         string `xml:"status"`
+	StatusCategory struct {
+		Key string `xml:"key,attr"`
+	} `xml:"statusCategory"`
+	Assignee struct {
+		Username string `xml:"username,attr"`
+		Name     string `xml:",chardata"`
+	} `xml:"assignee"`
+	Labels   []string `xml:"labels>label"`
+	Created  string   `xml:"created"`
+	Resolved string   `xml:"resolved"`
+	Due      string   `xml:"due"`
+	Comments []struct {
+		Author  string `xml:"author,attr"`
+		Created string `xml:"created,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"comments>comment"`
+	Subtasks   []string `xml:"subtasks>subtask"`
+	IssueLinks []struct {
+		Name          string   `xml:"name"`
+		OutwardIssues []string `xml:"outwardlinks>issuelink>issuekey"`
+		InwardIssues  []string `xml:"inwardlinks>issuelink>issuekey"`
+	} `xml:"issuelinks>issuelinktype"`
+	Attachments []struct {
+		ID   string `xml:"id,attr"`
+		Name string `xml:"name,attr"`
+	} `xml:"attachments>attachment"`
+}
+
+func parseXMLTime(value string) time.Time {
+	t, err := time.Parse(xmlTimeFormat, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseXMLExport(r io.Reader) (issues []*issue, err error) {
+	export := &xmlExport{}
+	err = xml.NewDecoder(r).Decode(export)
+	if err != nil {
+		return nil, err
+	}
+
+	issues = make([]*issue, 0, len(export.Items))
+	for _, item := range export.Items {
+		i := &issue{
+			Key:          strings.TrimSpace(item.Key),
+			ProjectKey:   item.Project.Key,
+			ProjectName:  strings.TrimSpace(item.Project.Name),
+			Summary:      strings.TrimSpace(item.Summary),
+			Description:  strings.TrimSpace(item.Description),
+			ParentKey:    strings.TrimSpace(item.Parent),
+			Priority:     strings.TrimSpace(item.Priority),
+			Status:       strings.TrimSpace(item.Status),
+			Done:         item.StatusCategory.Key == "done" || strings.TrimSpace(item.Resolved) != "",
+			Assignee:     item.Assignee.Username,
+			AssigneeName: strings.TrimSpace(item.Assignee.Name),
+			Labels:       item.Labels,
+			Created:      parseXMLTime(item.Created),
+			Resolved:     parseXMLTime(item.Resolved),
+			DueDate:      parseXMLTime(item.Due),
+			SubtaskKeys:  item.Subtasks,
+		}
+
+		for _, c := range item.Comments {
+			i.Comments = append(i.Comments, &comment{
+				Author:     c.Author,
+				AuthorName: c.Author,
+				Body:       strings.TrimSpace(c.Body),
+			})
+		}
+
+		for _, lt := range item.IssueLinks {
+			outward, inward := getRelationKinds(lt.Name)
+			for _, key := range lt.OutwardIssues {
+				i.Links = append(i.Links, &link{Kind: outward, Key: strings.TrimSpace(key)})
+			}
+			for _, key := range lt.InwardIssues {
+				i.Links = append(i.Links, &link{Kind: inward, Key: strings.TrimSpace(key)})
+			}
+		}
+
+		for _, a := range item.Attachments {
+			i.Attachments = append(i.Attachments, &attachment{ID: a.ID, Filename: a.Name})
+		}
+
+		issues = append(issues, i)
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,411 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/api/pkg/user"
+
+	"xorm.io/xorm"
+)
+
+// The states a migration job can be in
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusDone      = "done"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Job is a migration which runs in the background
+type Job struct {
+	// The unique, numeric id of this migration job.
+	ID           int64  `xorm:"bigint autoincr not null unique pk" json:"id"`
+	UserID       int64  `xorm:"bigint not null index" json:"-"`
+	MigratorName string `xorm:"varchar(255) not null" json:"migrator_name"`
+	// The state of the job. One of queued, running, done, failed or cancelled.
+	Status string `xorm:"varchar(20) not null" json:"status"`
+
+	// The uploaded file of file migrators. It is removed once the job is finished.
+	FileID int64 `xorm:"bigint null" json:"-"`
+	// The request body of auth based migrators. It is removed once the job is finished.
+	Payload string `xorm:"longtext null" json:"-"`
+
+	// How many projects were created so far.
+	ProjectsProcessed int64 `xorm:"bigint not null default 0" json:"projects_processed"`
+	// How many tasks were created so far.
+	TasksProcessed int64 `xorm:"bigint not null default 0" json:"tasks_processed"`
+	// How many files like attachments or backgrounds were created so far.
+	FilesProcessed int64 `xorm:"bigint not null default 0" json:"files_processed"`
+	// Everything which could not be migrated as it was in the other service.
+	Warnings []string `xorm:"json null" json:"warnings"`
+	// Why the migration failed.
+	Error string `xorm:"text null" json:"error"`
+
+	// A timestamp when this job was created. You cannot change this value.
+	Created time.Time `xorm:"created not null" json:"created"`
+	// A timestamp when this job was last updated. You cannot change this value.
+	Updated time.Time `xorm:"updated not null" json:"updated"`
+	// A timestamp when this job was finished, cancelled or failed.
+	Finished time.Time `xorm:"null" json:"finished"`
+}
+
+// TableName returns the table name for migration jobs
+func (j *Job) TableName() string {
+	return "migration_jobs"
+}
+
+var (
+	jobMigrators     = make(map[string]func() MigratorName)
+	jobMigratorsLock sync.RWMutex
+)
+
+// RegisterJobMigrator makes a migrator available to run as a background job. The factory must return either a
+// Migrator or a FileMigrator.
+func RegisterJobMigrator(factory func() MigratorName) {
+	jobMigratorsLock.Lock()
+	defer jobMigratorsLock.Unlock()
+	jobMigrators[factory().Name()] = factory
+}
+
+func getJobMigrator(name string) (m MigratorName, exists bool) {
+	jobMigratorsLock.RLock()
+	defer jobMigratorsLock.RUnlock()
+	factory, exists := jobMigrators[name]
+	if !exists {
+		return nil, false
+	}
+	return factory(), true
+}
+
+// CreateJob queues a new migration job for a user. Auth based migrators need their request body as payload, file
+// migrators the id of the uploaded file.
+func CreateJob(s *xorm.Session, m MigratorName, u *user.User, payload string, fileID int64) (job *Job, err error) {
+	job = &Job{
+		UserID:       u.ID,
+		MigratorName: m.Name(),
+		Status:       JobStatusQueued,
+		FileID:       fileID,
+		Payload:      payload,
+		Warnings:     []string{},
+	}
+	_, err = s.Insert(job)
+	if err != nil {
+		return nil, err
+	}
+
+	err = events.Dispatch(&MigrationJobCreatedEvent{Job: job})
+	return job, err
+}
+
+// GetJob returns a migration job of a user with its current progress.
+func GetJob(s *xorm.Session, m MigratorName, u *user.User, jobID int64) (job *Job, err error) {
+	job = &Job{}
+	exists, err := s.
+		Where("id = ? AND user_id = ? AND migrator_name = ?", jobID, u.ID, m.Name()).
+		Get(job)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &models.ErrMigrationJobDoesNotExist{JobID: jobID}
+	}
+
+	if job.Status == JobStatusRunning {
+		(&jobProgress{jobID: job.ID}).loadInto(job)
+	}
+
+	return job, nil
+}
+
+// GetJobs returns all migration jobs of a user for one migrator, the latest first.
+func GetJobs(s *xorm.Session, m MigratorName, u *user.User) (jobs []*Job, err error) {
+	jobs = []*Job{}
+	err = s.
+		Where("user_id = ? AND migrator_name = ?", u.ID, m.Name()).
+		OrderBy("id desc").
+		Find(&jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs {
+		if job.Status == JobStatusRunning {
+			(&jobProgress{jobID: job.ID}).loadInto(job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// CancelJob cancels a migration job. A queued job won't be started anymore, a running job is stopped at the next
+// project, task or file it creates. Everything it migrated until then is kept.
+func CancelJob(s *xorm.Session, m MigratorName, u *user.User, jobID int64) (job *Job, err error) {
+	job, err = GetJob(s, m, u, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch job.Status {
+	case JobStatusQueued:
+		job.Status = JobStatusCancelled
+		job.Finished = time.Now()
+		job.Payload = ""
+		updated, err := s.
+			Where("id = ? AND status = ?", job.ID, JobStatusQueued).
+			Cols("status", "finished", "payload").
+			Update(job)
+		if err != nil {
+			return nil, err
+		}
+		if updated > 0 {
+			deleteJobFile(job)
+			return job, nil
+		}
+
+		// The job was started in the meantime
+		job.Status = JobStatusRunning
+		job.Finished = time.Time{}
+		fallthrough
+	case JobStatusRunning:
+		err = (&jobProgress{jobID: job.ID}).cancel()
+		return job, err
+	default:
+		return nil, &models.ErrMigrationJobFinished{JobID: job.ID}
+	}
+}
+
+func deleteJobFile(job *Job) {
+	if job.FileID == 0 {
+		return
+	}
+
+	err := (&files.File{ID: job.FileID}).Delete()
+	if err != nil && !files.IsErrFileDoesNotExist(err) {
+		log.Errorf("[Migration Job] Could not delete file %d of job %d: %s", job.FileID, job.ID, err)
+	}
+	job.FileID = 0
+}
+
+// ResumeJobs fails all migration jobs which were still running when Vikunja was stopped and starts all queued jobs
+// again, because the events which start them are lost when Vikunja stops. It can't tell if a job is running in
+// another instance, which is why it must only be called when Vikunja starts.
+func ResumeJobs() (err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	interrupted := []*Job{}
+	err = s.Where("status = ?", JobStatusRunning).Find(&interrupted)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range interrupted {
+		log.Infof("[Migration Job] Migration job %d was interrupted, marking it as failed", job.ID)
+
+		job.Status = JobStatusFailed
+		job.Error = "The migration was interrupted because Vikunja was stopped."
+		err = finishJob(s, job, &jobProgress{jobID: job.ID})
+		if err != nil {
+			return err
+		}
+
+		u, err := user.GetUserByID(s, job.UserID)
+		if user.IsErrUserDoesNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = notifications.Notify(u, &MigrationFailedNotification{User: u, Job: job})
+		if err != nil {
+			return err
+		}
+	}
+
+	queued := []*Job{}
+	err = s.Where("status = ?", JobStatusQueued).OrderBy("id asc").Find(&queued)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range queued {
+		log.Infof("[Migration Job] Starting queued migration job %d again", job.ID)
+
+		err = events.Dispatch(&MigrationJobCreatedEvent{Job: job})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunJob runs a queued migration job. Errors of the migration itself are saved in the job and not returned.
+func RunJob(jobID int64) (err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	job := &Job{}
+	exists, err := s.Where("id = ?", jobID).Get(job)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &models.ErrMigrationJobDoesNotExist{JobID: jobID}
+	}
+
+	// A job waits here while another job of the same user runs, it stays queued until then and can still be cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &jobProgress{jobID: job.ID, ctx: ctx}
+	registerJobProgress(job.UserID, p)
+	defer unregisterJobProgress(job.UserID)
+
+	// Starting the job only if it is still queued makes sure it does not run twice and was not cancelled.
+	job.Status = JobStatusRunning
+	started, err := s.
+		Where("id = ? AND status = ?", job.ID, JobStatusQueued).
+		Cols("status").
+		Update(job)
+	if err != nil {
+		return err
+	}
+	if started == 0 {
+		log.Debugf("[Migration Job] Job %d is not queued anymore, not running it", job.ID)
+		return nil
+	}
+
+	u, err := user.GetUserByID(s, job.UserID)
+	if err != nil {
+		job.Status = JobStatusFailed
+		job.Error = err.Error()
+		if finishErr := finishJob(s, job, p); finishErr != nil {
+			return finishErr
+		}
+		return err
+	}
+
+	log.Infof("[Migration Job] Starting %s migration job %d for user %d", job.MigratorName, job.ID, u.ID)
+
+	go p.cancelOnCancelled(cancel)
+	m, migrationErr := runJobMigrator(job, u)
+	if migrationErr != nil && ctx.Err() != nil {
+		// The migrator stopped because the job context was cancelled
+		migrationErr = &models.ErrMigrationJobCancelled{JobID: job.ID}
+	}
+
+	switch {
+	case migrationErr == nil:
+		job.Status = JobStatusDone
+	case models.IsErrMigrationJobCancelled(migrationErr):
+		job.Status = JobStatusCancelled
+	default:
+		log.Errorf("[Migration Job] Migration job %d failed: %s", job.ID, migrationErr)
+		job.Status = JobStatusFailed
+		job.Error = migrationErr.Error()
+	}
+
+	err = finishJob(s, job, p)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[Migration Job] Migration job %d finished with status %s", job.ID, job.Status)
+
+	switch job.Status {
+	case JobStatusDone:
+		err = SetMigrationStatus(m, u)
+		if err != nil {
+			return err
+		}
+		return notifications.Notify(u, &MigrationDoneNotification{User: u, Job: job})
+	case JobStatusFailed:
+		return notifications.Notify(u, &MigrationFailedNotification{User: u, Job: job})
+	}
+
+	return nil
+}
+
+// finishJob saves the final state of a job and removes its progress. The payload with the credentials for the other
+// service and the uploaded file are removed no matter how the job ended.
+func finishJob(s *xorm.Session, job *Job, p *jobProgress) error {
+	p.loadInto(job)
+	job.Finished = time.Now()
+	job.Payload = ""
+	deleteJobFile(job)
+
+	_, err := s.
+		Where("id = ?", job.ID).
+		Cols("status", "file_id", "payload", "projects_processed", "tasks_processed", "files_processed", "warnings", "error", "finished").
+		Update(job)
+	if err != nil {
+		return err
+	}
+
+	p.clear()
+	return nil
+}
+
+func runJobMigrator(job *Job, u *user.User) (m MigratorName, err error) {
+	// A panicking migrator must not leave the job running with its payload
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("the migration panicked: %v", r)
+		}
+	}()
+
+	m, exists := getJobMigrator(job.MigratorName)
+	if !exists {
+		return nil, models.ErrInvalidData{Message: "There is no migrator " + job.MigratorName}
+	}
+
+	switch mig := m.(type) {
+	case FileMigrator:
+		file := &files.File{ID: job.FileID}
+		err = file.LoadFileMetaByID()
+		if err != nil {
+			return m, err
+		}
+		err = file.LoadFileByID()
+		if err != nil {
+			return m, err
+		}
+		defer file.File.Close()
+		return m, mig.Migrate(u, file.File, int64(file.Size))
+	case Migrator:
+		err = json.Unmarshal([]byte(job.Payload), mig)
+		if err != nil {
+			return m, models.ErrInvalidData{Message: err.Error()}
+		}
+		return m, mig.Migrate(u)
+	}
+
+	return m, models.ErrInvalidData{Message: "The migrator " + job.MigratorName + " can't run as a job"}
+}
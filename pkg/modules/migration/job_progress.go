@@ -0,0 +1,211 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/keyvalue"
+	"code.vikunja.io/api/pkg/user"
+)
+
+// jobProgress keeps track of what a running migration job created so far. It is saved in the keyvalue store to make
+// it available to other instances and to let them cancel the job.
+// All methods can be called on a nil progress, which is used when a migration does not run as a job.
+type jobProgress struct {
+	jobID    int64
+	warnings []string
+	// ctx is cancelled once the job is cancelled or finished
+	ctx context.Context
+}
+
+// How often a running job checks if it was cancelled to cancel its context.
+var jobCancelCheckInterval = time.Second
+
+const (
+	jobProgressProjects = "projects"
+	jobProgressTasks    = "tasks"
+	jobProgressFiles    = "files"
+)
+
+var (
+	// The progress of all jobs running in this instance with the id of the user they run for as key. Migrators only
+	// get the user passed which is used to start the job, that way they don't need to know if they run as a job.
+	// Because of this, only one job per user can run at a time in an instance.
+	runningJobs     = make(map[int64]*jobProgress)
+	runningJobsLock sync.Mutex
+	runningJobsDone = sync.NewCond(&runningJobsLock)
+)
+
+// registerJobProgress waits until no other job of the user is running in this instance and then registers the
+// progress of the job.
+func registerJobProgress(userID int64, p *jobProgress) {
+	runningJobsLock.Lock()
+	defer runningJobsLock.Unlock()
+	for runningJobs[userID] != nil {
+		runningJobsDone.Wait()
+	}
+	runningJobs[userID] = p
+}
+
+func unregisterJobProgress(userID int64) {
+	runningJobsLock.Lock()
+	defer runningJobsLock.Unlock()
+	delete(runningJobs, userID)
+	runningJobsDone.Broadcast()
+}
+
+func getJobProgress(userID int64) *jobProgress {
+	runningJobsLock.Lock()
+	defer runningJobsLock.Unlock()
+	return runningJobs[userID]
+}
+
+// JobContext returns a context which is cancelled once the migration job running for the user is cancelled.
+// Migrators should use it for their requests to the other service. If the migration does not run as a job, the
+// context is never cancelled.
+func JobContext(u *user.User) context.Context {
+	p := getJobProgress(u.ID)
+	if p == nil || p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+// Warn saves something which could not be migrated as it was to the warnings of the migration job running for the
+// user. It does nothing if the migration does not run as a job.
+func Warn(u *user.User, format string, args ...interface{}) {
+	getJobProgress(u.ID).warn(format, args...)
+}
+
+// cancelOnCancelled checks regularly if the job was cancelled, possibly from another instance, and calls cancel if
+// it was. It returns once the job context is done.
+func (p *jobProgress) cancelOnCancelled(cancel context.CancelFunc) {
+	ticker := time.NewTicker(jobCancelCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			if models.IsErrMigrationJobCancelled(p.checkCancelled()) {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (p *jobProgress) key(kind string) string {
+	return "migration_job_" + strconv.FormatInt(p.jobID, 10) + "_" + kind
+}
+
+// processed counts a created project, task or file and returns an error if the job was cancelled in the meantime.
+func (p *jobProgress) processed(kind string) error {
+	if p == nil {
+		return nil
+	}
+
+	err := keyvalue.IncrBy(p.key(kind), 1)
+	if err != nil {
+		log.Errorf("[Migration Job] Could not update progress of job %d: %s", p.jobID, err)
+	}
+
+	return p.checkCancelled()
+}
+
+// warn saves something which could not be migrated as it was.
+func (p *jobProgress) warn(format string, args ...interface{}) {
+	if p == nil {
+		return
+	}
+
+	p.warnings = append(p.warnings, fmt.Sprintf(format, args...))
+	err := keyvalue.Put(p.key("warnings"), p.warnings)
+	if err != nil {
+		log.Errorf("[Migration Job] Could not save warnings of job %d: %s", p.jobID, err)
+	}
+}
+
+func (p *jobProgress) checkCancelled() error {
+	if p == nil {
+		return nil
+	}
+
+	_, cancelled, err := keyvalue.Get(p.key("cancelled"))
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		return &models.ErrMigrationJobCancelled{JobID: p.jobID}
+	}
+	return nil
+}
+
+func (p *jobProgress) cancel() error {
+	return keyvalue.Put(p.key("cancelled"), true)
+}
+
+func (p *jobProgress) count(kind string) int64 {
+	value, exists, err := keyvalue.Get(p.key(kind))
+	if err != nil {
+		log.Errorf("[Migration Job] Could not get progress of job %d: %s", p.jobID, err)
+		return 0
+	}
+	if !exists {
+		return 0
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return v
+	case string:
+		// Redis returns counters as strings
+		count, _ := strconv.ParseInt(v, 10, 64)
+		return count
+	}
+	return 0
+}
+
+// loadInto sets the current progress of the job.
+func (p *jobProgress) loadInto(job *Job) {
+	job.ProjectsProcessed = p.count(jobProgressProjects)
+	job.TasksProcessed = p.count(jobProgressTasks)
+	job.FilesProcessed = p.count(jobProgressFiles)
+
+	warnings := []string{}
+	_, err := keyvalue.GetWithValue(p.key("warnings"), &warnings)
+	if err != nil {
+		log.Errorf("[Migration Job] Could not get warnings of job %d: %s", p.jobID, err)
+	}
+	job.Warnings = warnings
+}
+
+func (p *jobProgress) clear() {
+	for _, kind := range []string{jobProgressProjects, jobProgressTasks, jobProgressFiles, "warnings", "cancelled"} {
+		err := keyvalue.Del(p.key(kind))
+		if err != nil {
+			log.Errorf("[Migration Job] Could not remove progress of job %d: %s", p.jobID, err)
+		}
+	}
+}
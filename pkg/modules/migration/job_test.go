@@ -0,0 +1,425 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFileMigrator struct {
+}
+
+func (m *testFileMigrator) Name() string {
+	return "test-file"
+}
+
+func (m *testFileMigrator) Migrate(u *user.User, file io.ReaderAt, size int64) error {
+	content := make([]byte, size)
+	_, err := file.ReadAt(content, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return InsertFromStructure([]*models.ProjectWithTasksAndBuckets{
+		{
+			Project: models.Project{Title: string(content)},
+			Tasks: []*models.TaskWithComments{
+				{Task: models.Task{Title: "First task"}},
+				{
+					Task: models.Task{
+						Title: "Task with attachment",
+						Attachments: []*models.TaskAttachment{
+							{File: &files.File{Name: "missing.txt"}},
+						},
+					},
+				},
+			},
+		},
+	}, u)
+}
+
+type testMigrator struct {
+	Title  string `json:"title"`
+	Fail   bool   `json:"fail"`
+	Cancel bool   `json:"cancel"`
+	Panic  bool   `json:"panic"`
+	Wait   bool   `json:"wait"`
+}
+
+func (m *testMigrator) Name() string {
+	return "test"
+}
+
+func (m *testMigrator) AuthURL() string {
+	return ""
+}
+
+func (m *testMigrator) Migrate(u *user.User) error {
+	if m.Fail {
+		return errors.New("the other service is down")
+	}
+	if m.Panic {
+		panic("unexpected response")
+	}
+	if m.Wait {
+		// Like a request to the other service which takes a long time
+		ctx := JobContext(u)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return errors.New("the job context was not cancelled")
+		}
+	}
+	if m.Cancel {
+		err := getJobProgress(u.ID).cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	return InsertFromStructure([]*models.ProjectWithTasksAndBuckets{
+		{Project: models.Project{Title: m.Title}},
+	}, u)
+}
+
+func init() {
+	RegisterJobMigrator(func() MigratorName { return &testFileMigrator{} })
+	RegisterJobMigrator(func() MigratorName { return &testMigrator{} })
+}
+
+func getTestJob(t *testing.T, id int64) *Job {
+	s := db.NewSession()
+	defer s.Close()
+
+	job := &Job{}
+	exists, err := s.Where("id = ?", id).Get(job)
+	require.NoError(t, err)
+	require.True(t, exists)
+	return job
+}
+
+func TestRunJob(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	t.Run("file migrator", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		content := []byte("Imported from a file")
+		f, err := files.Create(bytes.NewReader(content), "import.txt", uint64(len(content)), u)
+		require.NoError(t, err)
+
+		job, err := CreateJob(s, &testFileMigrator{}, u, "", f.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusQueued, job.Status)
+		events.AssertDispatched(t, &MigrationJobCreatedEvent{})
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusDone, job.Status)
+		assert.Equal(t, int64(1), job.ProjectsProcessed)
+		assert.Equal(t, int64(2), job.TasksProcessed)
+		assert.Equal(t, int64(0), job.FilesProcessed)
+		assert.Len(t, job.Warnings, 1)
+		assert.False(t, job.Finished.IsZero())
+		assert.Equal(t, int64(0), job.FileID)
+		db.AssertExists(t, "projects", map[string]interface{}{"title": "Imported from a file"}, false)
+		notifications.AssertSent(t, &MigrationDoneNotification{})
+
+		err = (&files.File{ID: f.ID}).LoadFileMetaByID()
+		assert.True(t, files.IsErrFileDoesNotExist(err))
+
+		status, err := GetMigrationStatus(&testFileMigrator{}, u)
+		require.NoError(t, err)
+		assert.NotZero(t, status.ID)
+	})
+	t.Run("auth migrator", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"title":"Imported with a token"}`, 0)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusDone, job.Status)
+		assert.Equal(t, int64(1), job.ProjectsProcessed)
+		assert.Empty(t, job.Payload)
+		db.AssertExists(t, "projects", map[string]interface{}{"title": "Imported with a token"}, false)
+	})
+	t.Run("failed", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"fail":true}`, 0)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusFailed, job.Status)
+		assert.Equal(t, "the other service is down", job.Error)
+		assert.Empty(t, job.Payload)
+		notifications.AssertSent(t, &MigrationFailedNotification{})
+	})
+	t.Run("panicked", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"panic":true}`, 0)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusFailed, job.Status)
+		assert.Equal(t, "the migration panicked: unexpected response", job.Error)
+		assert.Empty(t, job.Payload)
+	})
+	t.Run("user does not exist", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, &user.User{ID: 9999}, `{"title":"Imported with a token"}`, 0)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		assert.True(t, user.IsErrUserDoesNotExist(err))
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusFailed, job.Status)
+		assert.Empty(t, job.Payload)
+		assert.False(t, job.Finished.IsZero())
+	})
+	t.Run("cancelled while running", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"title":"Cancelled import","cancel":true}`, 0)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusCancelled, job.Status)
+		assert.Equal(t, int64(1), job.ProjectsProcessed)
+		assert.Empty(t, job.Error)
+	})
+	t.Run("cancelled while waiting for the other service", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		jobCancelCheckInterval = 10 * time.Millisecond
+		defer func() {
+			jobCancelCheckInterval = time.Second
+		}()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"wait":true}`, 0)
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			done <- RunJob(job.ID)
+		}()
+
+		assert.Eventually(t, func() bool {
+			s := db.NewSession()
+			defer s.Close()
+			j := &Job{}
+			_, err := s.Where("id = ?", job.ID).Get(j)
+			return err == nil && j.Status == JobStatusRunning
+		}, time.Second, 10*time.Millisecond)
+		_, err = CancelJob(s, &testMigrator{}, u, job.ID)
+		require.NoError(t, err)
+		require.NoError(t, <-done)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusCancelled, job.Status)
+		assert.Empty(t, job.Error)
+	})
+	t.Run("not queued", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		job, err := CreateJob(s, &testMigrator{}, u, `{"title":"Never imported"}`, 0)
+		require.NoError(t, err)
+		_, err = CancelJob(s, &testMigrator{}, u, job.ID)
+		require.NoError(t, err)
+
+		err = RunJob(job.ID)
+		require.NoError(t, err)
+
+		job = getTestJob(t, job.ID)
+		assert.Equal(t, JobStatusCancelled, job.Status)
+		db.AssertMissing(t, "projects", map[string]interface{}{"title": "Never imported"})
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	job, err := CreateJob(s, &testMigrator{}, u, `{}`, 0)
+	require.NoError(t, err)
+
+	t.Run("normal", func(t *testing.T) {
+		j, err := GetJob(s, &testMigrator{}, u, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, job.ID, j.ID)
+	})
+	t.Run("other user", func(t *testing.T) {
+		_, err := GetJob(s, &testMigrator{}, &user.User{ID: 2}, job.ID)
+		assert.True(t, models.IsErrMigrationJobDoesNotExist(err))
+	})
+	t.Run("other migrator", func(t *testing.T) {
+		_, err := GetJob(s, &testFileMigrator{}, u, job.ID)
+		assert.True(t, models.IsErrMigrationJobDoesNotExist(err))
+	})
+	t.Run("running job with progress", func(t *testing.T) {
+		p := &jobProgress{jobID: job.ID}
+		_, err := s.Where("id = ?", job.ID).Cols("status").Update(&Job{Status: JobStatusRunning})
+		require.NoError(t, err)
+		require.NoError(t, p.processed(jobProgressTasks))
+		p.warn("Something was skipped")
+		defer p.clear()
+
+		j, err := GetJob(s, &testMigrator{}, u, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), j.TasksProcessed)
+		assert.Equal(t, []string{"Something was skipped"}, j.Warnings)
+	})
+}
+
+func TestCancelJob(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	t.Run("running", func(t *testing.T) {
+		job, err := CreateJob(s, &testMigrator{}, u, `{}`, 0)
+		require.NoError(t, err)
+		_, err = s.Where("id = ?", job.ID).Cols("status").Update(&Job{Status: JobStatusRunning})
+		require.NoError(t, err)
+
+		p := &jobProgress{jobID: job.ID}
+		defer p.clear()
+		_, err = CancelJob(s, &testMigrator{}, u, job.ID)
+		require.NoError(t, err)
+		assert.True(t, models.IsErrMigrationJobCancelled(p.checkCancelled()))
+	})
+	t.Run("finished", func(t *testing.T) {
+		job, err := CreateJob(s, &testMigrator{}, u, `{}`, 0)
+		require.NoError(t, err)
+		require.NoError(t, RunJob(job.ID))
+
+		_, err = CancelJob(s, &testMigrator{}, u, job.ID)
+		assert.True(t, models.IsErrMigrationJobFinished(err))
+	})
+}
+
+func TestRegisterJobProgress(t *testing.T) {
+	first := &jobProgress{jobID: 1}
+	registerJobProgress(1, first)
+	assert.Equal(t, first, getJobProgress(1))
+	assert.Nil(t, getJobProgress(2))
+
+	second := &jobProgress{jobID: 2}
+	registered := make(chan struct{})
+	go func() {
+		registerJobProgress(1, second)
+		close(registered)
+	}()
+
+	select {
+	case <-registered:
+		t.Fatal("the second job of the user was registered while the first one is still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unregisterJobProgress(1)
+	<-registered
+	assert.Equal(t, second, getJobProgress(1))
+	unregisterJobProgress(1)
+	assert.Nil(t, getJobProgress(1))
+}
+
+func TestResumeJobs(t *testing.T) {
+	u := &user.User{ID: 1}
+
+	db.LoadAndAssertFixtures(t)
+	s := db.NewSession()
+	defer s.Close()
+
+	running, err := CreateJob(s, &testMigrator{}, u, `{"title":"Interrupted import"}`, 0)
+	require.NoError(t, err)
+	_, err = s.Where("id = ?", running.ID).Cols("status").Update(&Job{Status: JobStatusRunning})
+	require.NoError(t, err)
+	queued, err := CreateJob(s, &testMigrator{}, u, `{"title":"Queued import"}`, 0)
+	require.NoError(t, err)
+
+	events.Fake()
+	notifications.Fake()
+	err = ResumeJobs()
+	require.NoError(t, err)
+
+	job := getTestJob(t, running.ID)
+	assert.Equal(t, JobStatusFailed, job.Status)
+	assert.NotEmpty(t, job.Error)
+	assert.Empty(t, job.Payload)
+	assert.False(t, job.Finished.IsZero())
+	notifications.AssertSent(t, &MigrationFailedNotification{})
+
+	job = getTestJob(t, queued.ID)
+	assert.Equal(t, JobStatusQueued, job.Status)
+	assert.Equal(t, `{"title":"Queued import"}`, job.Payload)
+	events.AssertDispatched(t, &MigrationJobCreatedEvent{})
+}
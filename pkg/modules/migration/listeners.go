@@ -0,0 +1,50 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"encoding/json"
+
+	"code.vikunja.io/api/pkg/events"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// RegisterListeners registers all event listeners of migrations
+func RegisterListeners() {
+	events.RegisterListener((&MigrationJobCreatedEvent{}).Name(), &HandleMigrationJob{})
+}
+
+// HandleMigrationJob represents a listener
+type HandleMigrationJob struct {
+}
+
+// Name defines the name for the HandleMigrationJob listener
+func (s *HandleMigrationJob) Name() string {
+	return "handle.migration.job"
+}
+
+// Handle is executed when the event HandleMigrationJob listens on is fired
+func (s *HandleMigrationJob) Handle(msg *message.Message) (err error) {
+	event := &MigrationJobCreatedEvent{}
+	err = json.Unmarshal(msg.Payload, event)
+	if err != nil {
+		return err
+	}
+
+	return RunJob(event.Job.ID)
+}
@@ -23,8 +23,11 @@ import (
 	"code.vikunja.io/api/pkg/events"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/log"
 	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/notifications"
 	"code.vikunja.io/api/pkg/user"
 )
 
@@ -40,5 +43,15 @@ func TestMain(m *testing.M) {
 	user.InitTests()
 	models.SetupTests()
 	events.Fake()
+	notifications.Fake()
+
+	// The migration tables are not part of the models
+	s := db.NewSession()
+	err := s.Sync2(GetTables()...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.Close()
+
 	os.Exit(m.Run())
 }
@@ -20,9 +20,13 @@ import (
 	"testing"
 	"time"
 
+	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
 	"github.com/d4l3k/messagediff"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConverting(t *testing.T) {
@@ -191,3 +195,40 @@ func TestConverting(t *testing.T) {
 		t.Errorf("converted microsoft todo data = %v, want %v, diff: %v", hierachie, expectedHierachie, diff)
 	}
 }
+
+func TestInsertMicrosoftTodoStructure(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	u := &user.User{ID: 1}
+	hierachie, err := convertMicrosoftTodoData([]*project{
+		{
+			ID:          "project1",
+			DisplayName: "Project 1",
+			Tasks:       []*task{{ID: "task1", Title: "Task 1", Status: "notStarted"}},
+		},
+		{
+			ID:          "project2",
+			DisplayName: "Project 2",
+			Tasks:       []*task{{ID: "task2", Title: "Task 2", Status: "notStarted"}},
+		},
+	})
+	require.NoError(t, err)
+	err = migration.InsertFromStructure(hierachie, u)
+	require.NoError(t, err)
+
+	// The lists are created as child projects of the migration project
+	parent := hierachie[0]
+	require.NotZero(t, parent.ID)
+	require.Len(t, parent.ChildProjects, 2)
+	for _, child := range parent.ChildProjects {
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             child.Title,
+			"parent_project_id": parent.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":      child.Tasks[0].Title,
+			"project_id": child.ID,
+		}, false)
+	}
+}
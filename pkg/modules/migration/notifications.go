@@ -0,0 +1,112 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"strconv"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/i18n"
+	"code.vikunja.io/api/pkg/notifications"
+	"code.vikunja.io/api/pkg/user"
+)
+
+// MigrationDoneNotification represents a MigrationDoneNotification notification
+type MigrationDoneNotification struct {
+	User *user.User `json:"user"`
+	Job  *Job       `json:"job"`
+}
+
+// ToMail returns the mail notification for MigrationDoneNotification
+func (n *MigrationDoneNotification) ToMail(lang string) *notifications.Mail {
+	mail := notifications.NewMail().
+		Subject(i18n.T(lang, "notifications.migration.done.subject", n.Job.MigratorName)).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.migration.done.message",
+			n.Job.MigratorName,
+			strconv.FormatInt(n.Job.ProjectsProcessed, 10),
+			strconv.FormatInt(n.Job.TasksProcessed, 10),
+			strconv.FormatInt(n.Job.FilesProcessed, 10),
+		))
+
+	if len(n.Job.Warnings) > 0 {
+		mail.Line(i18n.T(lang, "notifications.migration.done.warnings", strconv.Itoa(len(n.Job.Warnings))))
+		for _, warning := range n.Job.Warnings {
+			mail.Line("* " + warning)
+		}
+	}
+
+	return mail.
+		Action(i18n.T(lang, "notifications.migration.done.action"), config.ServiceFrontendurl.GetString()).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
+}
+
+// ToDB returns the MigrationDoneNotification notification in a format which can be saved in the db
+func (n *MigrationDoneNotification) ToDB() interface{} {
+	return n
+}
+
+// ToPush returns the MigrationDoneNotification notification as web push message
+func (n *MigrationDoneNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: "Your " + n.Job.MigratorName + " migration is done",
+		Body:  "All your data was imported into Vikunja.",
+		URL:   config.ServiceFrontendurl.GetString(),
+	}
+}
+
+// Name returns the name of the notification
+func (n *MigrationDoneNotification) Name() string {
+	return "migration.done"
+}
+
+// MigrationFailedNotification represents a MigrationFailedNotification notification
+type MigrationFailedNotification struct {
+	User *user.User `json:"user"`
+	Job  *Job       `json:"job"`
+}
+
+// ToMail returns the mail notification for MigrationFailedNotification
+func (n *MigrationFailedNotification) ToMail(lang string) *notifications.Mail {
+	return notifications.NewMail().
+		Subject(i18n.T(lang, "notifications.migration.failed.subject", n.Job.MigratorName)).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.migration.failed.message", n.Job.MigratorName)).
+		Line(n.Job.Error).
+		Line(i18n.T(lang, "notifications.migration.failed.retry")).
+		Action(i18n.T(lang, "notifications.migration.failed.action"), config.ServiceFrontendurl.GetString()+"migrate/"+n.Job.MigratorName).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
+}
+
+// ToDB returns the MigrationFailedNotification notification in a format which can be saved in the db
+func (n *MigrationFailedNotification) ToDB() interface{} {
+	return n
+}
+
+// ToPush returns the MigrationFailedNotification notification as web push message
+func (n *MigrationFailedNotification) ToPush() *notifications.Push {
+	return &notifications.Push{
+		Title: "Your " + n.Job.MigratorName + " migration failed",
+		Body:  n.Job.Error,
+		URL:   config.ServiceFrontendurl.GetString() + "migrate/" + n.Job.MigratorName,
+	}
+}
+
+// Name returns the name of the notification
+func (n *MigrationFailedNotification) Name() string {
+	return "migration.failed"
+}
@@ -0,0 +1,176 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"bytes"
+
+	"xorm.io/xorm"
+
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+)
+
+type projectImport struct {
+	files            map[int64][]byte
+	labels           map[string]*models.Label
+	archivedProjects []int64
+	tasksByOldID     map[int64]int64 // old id is the key, new id the value
+	relations        []*models.TaskRelation
+}
+
+// ImportProjectExport creates the project of a project export with all of its child projects, tasks, buckets,
+// comments, attachments, labels and relations below an existing parent project.
+// Assignees are not imported since the users of the export might not exist or have access to the new project.
+func ImportProjectExport(s *xorm.Session, export *models.ProjectExport, parentProjectID int64, user *user.User) (project *models.Project, err error) {
+	pi := &projectImport{
+		files:        export.Files,
+		labels:       make(map[string]*models.Label),
+		tasksByOldID: make(map[int64]int64),
+	}
+
+	err = pi.createProject(s, &export.ProjectWithTasksAndBuckets, parentProjectID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relations are only created once all projects exist because they might reference tasks in other projects
+	// of the export. Relations to tasks which were not part of the export are dropped.
+	for _, rel := range pi.relations {
+		taskID, has := pi.tasksByOldID[rel.TaskID]
+		if !has {
+			continue
+		}
+		otherTaskID, has := pi.tasksByOldID[rel.OtherTaskID]
+		if !has {
+			log.Debugf("[creating structure] Could not find related task with old id %d, skipping relation", rel.OtherTaskID)
+			continue
+		}
+
+		taskRel := &models.TaskRelation{
+			TaskID:       taskID,
+			OtherTaskID:  otherTaskID,
+			RelationKind: rel.RelationKind,
+		}
+		err = taskRel.Create(s, user)
+		if err != nil && !models.IsErrRelationAlreadyExists(err) {
+			return nil, err
+		}
+	}
+
+	if len(pi.archivedProjects) > 0 {
+		_, err = s.
+			Cols("is_archived").
+			In("id", pi.archivedProjects).
+			Update(&models.Project{IsArchived: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &export.Project, nil
+}
+
+func (pi *projectImport) createProject(s *xorm.Session, project *models.ProjectWithTasksAndBuckets, parentProjectID int64, user *user.User) (err error) {
+	project.ParentProjectID = parentProjectID
+
+	// The bucket ids only refer to the buckets of the export, we set them again once the buckets were created.
+	oldDoneBucketID := project.DoneBucketID
+	oldDefaultBucketID := project.DefaultBucketID
+	project.DoneBucketID = 0
+	project.DefaultBucketID = 0
+	oldBucketIDs := make([]int64, 0, len(project.Buckets))
+	for _, b := range project.Buckets {
+		oldBucketIDs = append(oldBucketIDs, b.ID)
+	}
+
+	project.BackgroundInformation = nil
+	if content, has := pi.files[project.BackgroundFileID]; has && project.BackgroundFileID != 0 {
+		project.BackgroundInformation = bytes.NewBuffer(content)
+	}
+
+	oldTaskIDs := make([]int64, 0, len(project.Tasks))
+	for _, t := range project.Tasks {
+		oldTaskIDs = append(oldTaskIDs, t.ID)
+
+		for kind, related := range t.RelatedTasks {
+			for _, rt := range related {
+				pi.relations = append(pi.relations, &models.TaskRelation{
+					TaskID:       t.ID,
+					OtherTaskID:  rt.ID,
+					RelationKind: kind,
+				})
+			}
+		}
+		t.RelatedTasks = nil
+		t.Assignees = nil
+		// The uid is used by caldav clients to identify a task, it needs to be unique.
+		t.UID = ""
+
+		for _, l := range t.Labels {
+			if l != nil {
+				l.ID = 0
+			}
+		}
+
+		attachments := make([]*models.TaskAttachment, 0, len(t.Attachments))
+		for _, a := range t.Attachments {
+			if a.File == nil {
+				continue
+			}
+			a.ID = 0
+			a.File.FileContent = pi.files[a.File.ID]
+			attachments = append(attachments, a)
+		}
+		t.Attachments = attachments
+	}
+
+	err = createProject(s, project, &pi.archivedProjects, pi.labels, user, nil)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range project.Tasks {
+		pi.tasksByOldID[oldTaskIDs[i]] = t.ID
+	}
+
+	newBucketIDs := make(map[int64]int64, len(oldBucketIDs))
+	for i, b := range project.Buckets {
+		newBucketIDs[oldBucketIDs[i]] = b.ID
+	}
+	project.DoneBucketID = newBucketIDs[oldDoneBucketID]
+	project.DefaultBucketID = newBucketIDs[oldDefaultBucketID]
+	if project.DoneBucketID != 0 || project.DefaultBucketID != 0 {
+		_, err = s.
+			Where("id = ?", project.ID).
+			Cols("done_bucket_id", "default_bucket_id").
+			Update(&project.Project)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, child := range project.ChildProjects {
+		err = pi.createProject(s, child, project.ID, user)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
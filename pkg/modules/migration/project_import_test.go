@@ -0,0 +1,205 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/files"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportProjectExport(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		u := &user.User{ID: 1}
+		export := &models.ProjectExport{
+			ProjectWithTasksAndBuckets: models.ProjectWithTasksAndBuckets{
+				Project: models.Project{
+					ID:           100,
+					Title:        "Imported",
+					DoneBucketID: 2000,
+				},
+				Buckets: []*models.Bucket{
+					{ID: 1000, Title: "Todo"},
+					{ID: 2000, Title: "Done"},
+				},
+				Tasks: []*models.TaskWithComments{
+					{
+						Task: models.Task{
+							ID:       500,
+							UID:      "exported-uid",
+							Title:    "First imported task",
+							BucketID: 1000,
+							RelatedTasks: map[models.RelationKind][]*models.Task{
+								models.RelationKindRelated: {{ID: 501}, {ID: 9999}},
+							},
+							Assignees: []*user.User{{ID: 2}},
+						},
+					},
+					{
+						Task: models.Task{
+							ID:       501,
+							Title:    "Second imported task",
+							BucketID: 2000,
+							Done:     true,
+						},
+					},
+				},
+				ChildProjects: []*models.ProjectWithTasksAndBuckets{
+					{
+						Project: models.Project{
+							ID:    101,
+							Title: "Imported child",
+						},
+						Tasks: []*models.TaskWithComments{
+							{
+								Task: models.Task{
+									ID:    502,
+									Title: "Imported child task",
+									Attachments: []*models.TaskAttachment{
+										{
+											File: &files.File{
+												ID:   7,
+												Name: "testfile",
+												Size: 4,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Files: map[int64][]byte{
+				7: {1, 2, 3, 4},
+			},
+		}
+
+		project, err := ImportProjectExport(s, export, 10, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, int64(100), project.ID)
+		assert.Equal(t, int64(10), project.ParentProjectID)
+		assert.Equal(t, export.Buckets[1].ID, project.DoneBucketID)
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                project.ID,
+			"parent_project_id": 10,
+			"done_bucket_id":    export.Buckets[1].ID,
+		}, false)
+
+		first := export.Tasks[0]
+		second := export.Tasks[1]
+		assert.NotEqual(t, "exported-uid", first.UID)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"id":         first.ID,
+			"project_id": project.ID,
+			"bucket_id":  export.Buckets[0].ID,
+		}, false)
+		db.AssertExists(t, "task_relations", map[string]interface{}{
+			"task_id":       first.ID,
+			"other_task_id": second.ID,
+			"relation_kind": models.RelationKindRelated,
+		}, false)
+		db.AssertMissing(t, "task_assignees", map[string]interface{}{
+			"task_id": first.ID,
+		})
+
+		child := export.ChildProjects[0]
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             "Imported child",
+			"parent_project_id": project.ID,
+		}, false)
+		db.AssertExists(t, "task_attachments", map[string]interface{}{
+			"task_id": child.Tasks[0].ID,
+		}, false)
+	})
+	t.Run("nothing is saved when a child project fails", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		err := s.Begin()
+		assert.NoError(t, err)
+
+		u := &user.User{ID: 1}
+		export := &models.ProjectExport{
+			ProjectWithTasksAndBuckets: models.ProjectWithTasksAndBuckets{
+				Project: models.Project{Title: "Imported"},
+				Tasks: []*models.TaskWithComments{
+					{Task: models.Task{Title: "Imported task"}},
+				},
+				ChildProjects: []*models.ProjectWithTasksAndBuckets{
+					{
+						Project: models.Project{Title: "Imported child"},
+						// Tasks need a title
+						Tasks: []*models.TaskWithComments{{}},
+					},
+				},
+			},
+		}
+		_, err = ImportProjectExport(s, export, 10, u)
+		assert.Error(t, err)
+		err = s.Rollback()
+		assert.NoError(t, err)
+
+		db.AssertMissing(t, "projects", map[string]interface{}{
+			"title": "Imported",
+		})
+		db.AssertMissing(t, "tasks", map[string]interface{}{
+			"title": "Imported task",
+		})
+	})
+	t.Run("roundtrip", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		u := &user.User{ID: 6}
+		export, err := models.GetProjectExport(s, u, 27)
+		assert.NoError(t, err)
+
+		project, err := ImportProjectExport(s, export, 27, u)
+		assert.NoError(t, err)
+		err = s.Commit()
+		assert.NoError(t, err)
+
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                project.ID,
+			"title":             "Test27",
+			"parent_project_id": 27,
+		}, false)
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"title":             "Test12",
+			"parent_project_id": project.ID,
+		}, false)
+		db.AssertExists(t, "buckets", map[string]interface{}{
+			"title":      "testbucket13",
+			"project_id": export.ChildProjects[0].ID,
+		}, false)
+	})
+}
@@ -20,7 +20,10 @@ import (
 	"testing"
 	"time"
 
+	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -139,3 +142,31 @@ func TestConvertTicktickTasksToVikunja(t *testing.T) {
 	assert.Equal(t, vikunjaTasks[0].ChildProjects[1].Tasks[0].Title, tickTickTasks[3].Title)
 	assert.Equal(t, vikunjaTasks[0].ChildProjects[1].Tasks[0].Position, tickTickTasks[3].Order)
 }
+
+func TestInsertTicktickStructure(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	u := &user.User{ID: 1}
+	vikunjaTasks := convertTickTickToVikunja([]*tickTickTask{
+		{TaskID: 1, ProjectName: "Project 1", Title: "Test task 1"},
+		{TaskID: 2, ProjectName: "Project 2", Title: "Test task 2"},
+	})
+	err := migration.InsertFromStructure(vikunjaTasks, u)
+	require.NoError(t, err)
+
+	// The projects are created as child projects of the migration project
+	parent := vikunjaTasks[0]
+	require.NotZero(t, parent.ID)
+	require.Len(t, parent.ChildProjects, 2)
+	for _, child := range parent.ChildProjects {
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             child.Title,
+			"parent_project_id": parent.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":      child.Tasks[0].Title,
+			"project_id": child.ID,
+		}, false)
+	}
+}
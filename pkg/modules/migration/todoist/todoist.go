@@ -120,6 +120,8 @@ type fileAttachment struct {
 	FileSize    int64  `json:"file_size"`
 	FileURL     string `json:"file_url"`
 	UploadState string `json:"upload_state"`
+	// content holds the file of an attachment from a backup, those are not downloaded
+	content []byte
 }
 
 type note struct {
@@ -294,6 +296,7 @@ func convertTodoistToVikunja(sync *sync, doneItems map[string]*doneItem) (fullVi
 			Created: section.DateAdded,
 		})
 		sections[section.ID] = fabricatedSectionID
+		fabricatedSectionID++
 	}
 
 	for _, label := range sync.Labels {
@@ -358,8 +361,6 @@ func convertTodoistToVikunja(sync *sync, doneItems map[string]*doneItem) (fullVi
 		}
 
 		lists[i.ProjectID].Tasks = append(lists[i.ProjectID].Tasks, task)
-
-		fabricatedSectionID++
 	}
 
 	// If the parenId of a task is not 0, create a task relation
@@ -416,14 +417,18 @@ func convertTodoistToVikunja(sync *sync, doneItems map[string]*doneItem) (fullVi
 			continue
 		}
 
+		content := n.FileAttachment.content
 		// Only add the attachment if there's something to download
-		if len(n.FileAttachment.FileURL) > 0 {
+		if content == nil && len(n.FileAttachment.FileURL) > 0 {
 			// Download the attachment and put it in the file
 			buf, err := migration.DownloadFile(n.FileAttachment.FileURL)
 			if err != nil {
 				return nil, err
 			}
+			content = buf.Bytes()
+		}
 
+		if content != nil {
 			tasks[n.ItemID].Attachments = append(tasks[n.ItemID].Attachments, &models.TaskAttachment{
 				File: &files.File{
 					Name:    n.FileAttachment.FileName,
@@ -433,7 +438,7 @@ func convertTodoistToVikunja(sync *sync, doneItems map[string]*doneItem) (fullVi
 					// We directly pass the file contents here to have a way to link the attachment to the file later.
 					// Because we don't have an ID for our task at this point of the migration, we cannot just throw all
 					// attachments in a slice and do the work of downloading and properly storing them later.
-					FileContent: buf.Bytes(),
+					FileContent: content,
 				},
 				Created: n.Posted,
 			})
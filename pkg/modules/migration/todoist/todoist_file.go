@@ -0,0 +1,345 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package todoist
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+)
+
+// FileMigrator imports todoist backups, without using the todoist api.
+// A backup is a zip file with one csv file per project, a single csv file contains one project.
+type FileMigrator struct {
+}
+
+// Backup files are named after their project, like "Inbox [2203306141].csv"
+var backupFileNameRegex = regexp.MustCompile(`\s*\[\d+\]$`)
+
+// Attachments are stored in notes like [[file {"file_name": "...", "file_url": "..."}]]
+var backupFileAttachmentRegex = regexp.MustCompile(`\[\[file (\{.*?\})\]\]`)
+
+// Labels are part of the task content, like "Buy milk @shopping"
+var backupLabelRegex = regexp.MustCompile(`(?:^|\s)@([^\s@]+)`)
+
+// backupDateFormats are the formats of due dates in a backup in addition to the ones parseDate understands.
+// Due dates in natural language like "every day" can't be imported.
+var backupDateFormats = []string{
+	"2 Jan 2006",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"02/01/2006",
+}
+
+// backupConverter converts the rows of the csv files of a backup to the sync structure of the todoist api so that
+// they can be converted with convertTodoistToVikunja.
+type backupConverter struct {
+	sync    *sync
+	labels  map[string]bool
+	lastID  int64
+	archive *zip.Reader
+}
+
+func (b *backupConverter) nextID() string {
+	b.lastID++
+	return strconv.FormatInt(b.lastID, 10)
+}
+
+func parseBackupDate(value string) (string, bool) {
+	if _, err := parseDate(value); err == nil {
+		return value, true
+	}
+	for _, format := range backupDateFormats {
+		if date, err := time.Parse(format, value); err == nil {
+			return date.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+// addNote adds a note of a backup. Attachments are stored in the note text and are imported from the files of the
+// backup zip file with the same name, since they can't be downloaded without the todoist api.
+func (b *backupConverter) addNote(itemID, content string) error {
+	n := &note{
+		ID:     b.nextID(),
+		ItemID: itemID,
+	}
+
+	// A note has at most one attachment
+	if match := backupFileAttachmentRegex.FindStringSubmatch(content); match != nil {
+		attachment := &fileAttachment{}
+		err := json.Unmarshal([]byte(match[1]), attachment)
+		if err == nil && attachment.FileName != "" {
+			attachment.content, err = b.readAttachment(attachment.FileName)
+			if err != nil {
+				return err
+			}
+			if attachment.content == nil {
+				log.Debugf("[Todoist Migration] Could not find attachment %s in the backup, not importing it", attachment.FileName)
+			} else {
+				attachment.FileSize = int64(len(attachment.content))
+				n.FileAttachment = attachment
+			}
+		}
+	}
+
+	n.Content = strings.TrimSpace(backupFileAttachmentRegex.ReplaceAllString(content, ""))
+	b.sync.Notes = append(b.sync.Notes, n)
+	return nil
+}
+
+// readAttachment returns the content of the first file in the backup zip file with the given name or nil if there is none.
+func (b *backupConverter) readAttachment(name string) ([]byte, error) {
+	if b.archive == nil {
+		return nil, nil
+	}
+	for _, f := range b.archive.File {
+		if f.FileInfo().IsDir() || path.Base(f.Name) != name {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	return nil, nil
+}
+
+// addProject adds all sections, tasks and notes of one csv file of a backup as a project.
+func (b *backupConverter) addProject(title string, content []byte) error {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\uFEFF"))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToUpper(strings.TrimSpace(name))] = i
+	}
+	if _, has := columns["TYPE"]; !has {
+		return &models.ErrInvalidMigrationFile{Migrator: "todoist-file", Reason: "The csv file is not a todoist backup."}
+	}
+	if _, has := columns["CONTENT"]; !has {
+		return &models.ErrInvalidMigrationFile{Migrator: "todoist-file", Reason: "The csv file is not a todoist backup."}
+	}
+	value := func(record []string, column string) string {
+		i, has := columns[column]
+		if !has || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	p := &project{
+		ID:   b.nextID(),
+		Name: title,
+	}
+	b.sync.Projects = append(b.sync.Projects, p)
+
+	var sectionID string
+	var lastItem *item
+	// The last task on each indentation level, to find the parent of subtasks
+	parents := []*item{}
+
+	for _, record := range records[1:] {
+		switch strings.ToLower(value(record, "TYPE")) {
+		case "section":
+			sectionID = b.nextID()
+			b.sync.Sections = append(b.sync.Sections, &section{
+				ID:           sectionID,
+				Name:         value(record, "CONTENT"),
+				ProjectID:    p.ID,
+				SectionOrder: int64(len(b.sync.Sections)),
+			})
+		case "task":
+			i := &item{
+				ID:        b.nextID(),
+				ProjectID: p.ID,
+				SectionID: sectionID,
+			}
+
+			content := value(record, "CONTENT")
+			for _, match := range backupLabelRegex.FindAllStringSubmatch(content, -1) {
+				i.Labels = append(i.Labels, match[1])
+				if !b.labels[match[1]] {
+					b.labels[match[1]] = true
+					b.sync.Labels = append(b.sync.Labels, &label{Name: match[1]})
+				}
+			}
+			i.Content = strings.TrimSpace(backupLabelRegex.ReplaceAllString(content, ""))
+			if i.Content == "" {
+				i.Content = content
+			}
+
+			// Priorities in backups are the other way around than in the api: 1 is the highest
+			priority, err := strconv.ParseInt(value(record, "PRIORITY"), 10, 64)
+			if err == nil && priority >= 1 && priority <= 4 {
+				i.Priority = 5 - priority
+			}
+
+			if date := value(record, "DATE"); date != "" {
+				if due, ok := parseBackupDate(date); ok {
+					i.Due = &dueDate{Date: due}
+				} else {
+					log.Debugf("[Todoist Migration] Could not parse due date %s of task %s, not importing it", date, i.Content)
+				}
+			}
+
+			indent, err := strconv.Atoi(value(record, "INDENT"))
+			if err != nil || indent < 1 {
+				indent = 1
+			}
+			if indent > len(parents)+1 {
+				indent = len(parents) + 1
+			}
+			parents = append(parents[:indent-1], i)
+			if indent > 1 {
+				i.ParentID = parents[indent-2].ID
+			}
+
+			// The description is added as the first note, notes end up in the description anyway
+			if description := value(record, "DESCRIPTION"); description != "" {
+				b.sync.Notes = append(b.sync.Notes, &note{
+					ID:      b.nextID(),
+					ItemID:  i.ID,
+					Content: description,
+				})
+			}
+
+			b.sync.Items = append(b.sync.Items, i)
+			lastItem = i
+		case "note":
+			if lastItem == nil {
+				continue
+			}
+			if err := b.addNote(lastItem.ID, value(record, "CONTENT")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readBackup reads a zip file with one csv file per project or a single csv file.
+func readBackup(file io.ReaderAt, size int64) (*sync, error) {
+	b := &backupConverter{
+		sync:   &sync{},
+		labels: make(map[string]bool),
+	}
+
+	zr, err := zip.NewReader(file, size)
+	if err != nil {
+		content, err := io.ReadAll(io.NewSectionReader(file, 0, size))
+		if err != nil {
+			return nil, err
+		}
+		return b.sync, b.addProject("Todoist project", content)
+	}
+	b.archive = zr
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".csv") {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		title := backupFileNameRegex.ReplaceAllString(strings.TrimSuffix(path.Base(f.Name), path.Ext(f.Name)), "")
+		if err := b.addProject(title, content); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(b.sync.Projects) == 0 {
+		return nil, &models.ErrInvalidMigrationFile{Migrator: "todoist-file", Reason: "The zip file does not contain any todoist projects."}
+	}
+
+	return b.sync, nil
+}
+
+// Name is used to get the name of the todoist file migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/todoist-file/status [get]
+func (m *FileMigrator) Name() string {
+	return "todoist-file"
+}
+
+// Migrate takes a todoist backup, parses it and imports everything in it into Vikunja.
+// @Summary Import all projects and tasks from a todoist backup
+// @Description Imports a todoist backup zip file or the csv file of a single project, without the need for api credentials. Projects, sections, tasks, subtasks, labels and notes are converted the same way as with the todoist migration. Since files can't be downloaded without the todoist api, attachments are imported from the files in the backup zip file with the same name as the attachment.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The todoist backup zip file, optionally with the attachment files added to it, or a csv file."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 400 {object} web.HTTPError "The file is not a todoist backup."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/todoist-file/migrate [put]
+func (m *FileMigrator) Migrate(u *user.User, file io.ReaderAt, size int64) error {
+	syncData, err := readBackup(file, size)
+	if err != nil {
+		if models.IsErrInvalidMigrationFile(err) {
+			return err
+		}
+		return &models.ErrInvalidMigrationFile{Migrator: m.Name(), Reason: err.Error()}
+	}
+
+	log.Debugf("[Todoist Migration] Importing %d projects from a backup for user %d", len(syncData.Projects), u.ID)
+
+	fullVikunjaHierachie, err := convertTodoistToVikunja(syncData, nil)
+	if err != nil {
+		return err
+	}
+
+	return migration.InsertFromStructure(fullVikunjaHierachie, u)
+}
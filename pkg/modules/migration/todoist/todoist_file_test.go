@@ -0,0 +1,142 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package todoist
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"code.vikunja.io/api/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBackupCSV = `TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE
+task,Buy milk @shopping @errands,Whole milk,1,1,Jane (1),,2026-10-20,en,Europe/Berlin
+note,"Here's the receipt [[file {""file_name"": ""receipt.pdf"", ""file_type"": ""application/pdf"", ""file_url"": ""https://files.todoist.com/receipt.pdf""}]]",,,,,,,,
+task,Get the oat milk too,,4,2,Jane (1),,every day,en,Europe/Berlin
+,,,,,,,,,
+section,Later,,,,,,,,
+task,Clean the house,,2,1,Jane (1),,,en,Europe/Berlin
+`
+
+func TestReadBackup(t *testing.T) {
+	t.Run("zip", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		w, err := zw.Create("Errands [2203306141].csv")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(testBackupCSV))
+		require.NoError(t, err)
+		w, err = zw.Create("attachments/receipt.pdf")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("receipt"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		file := bytes.NewReader(buf.Bytes())
+		data, err := readBackup(file, file.Size())
+		require.NoError(t, err)
+
+		require.Len(t, data.Projects, 1)
+		assert.Equal(t, "Errands", data.Projects[0].Name)
+		require.Len(t, data.Items, 3)
+
+		milk := data.Items[0]
+		assert.Equal(t, "Buy milk", milk.Content)
+		assert.Equal(t, []string{"shopping", "errands"}, milk.Labels)
+		assert.Equal(t, int64(4), milk.Priority)
+		assert.Equal(t, "2026-10-20", milk.Due.Date)
+		assert.Equal(t, "", milk.SectionID)
+
+		oatMilk := data.Items[1]
+		assert.Equal(t, milk.ID, oatMilk.ParentID)
+		assert.Equal(t, int64(1), oatMilk.Priority)
+		assert.Nil(t, oatMilk.Due)
+
+		house := data.Items[2]
+		require.Len(t, data.Sections, 1)
+		assert.Equal(t, data.Sections[0].ID, house.SectionID)
+		assert.Equal(t, "", house.ParentID)
+
+		require.Len(t, data.Labels, 2)
+		require.Len(t, data.Notes, 2)
+		assert.Equal(t, "Whole milk", data.Notes[0].Content)
+		assert.Equal(t, "Here's the receipt", data.Notes[1].Content)
+		require.NotNil(t, data.Notes[1].FileAttachment)
+		assert.Equal(t, "receipt.pdf", data.Notes[1].FileAttachment.FileName)
+		assert.Equal(t, []byte("receipt"), data.Notes[1].FileAttachment.content)
+		assert.Equal(t, int64(7), data.Notes[1].FileAttachment.FileSize)
+	})
+	t.Run("csv", func(t *testing.T) {
+		file := bytes.NewReader([]byte(testBackupCSV))
+		data, err := readBackup(file, file.Size())
+		require.NoError(t, err)
+		require.Len(t, data.Projects, 1)
+		assert.Len(t, data.Items, 3)
+		require.Len(t, data.Notes, 2)
+		assert.Equal(t, "Here's the receipt", data.Notes[1].Content)
+		assert.Nil(t, data.Notes[1].FileAttachment)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		file := bytes.NewReader([]byte("Title,Due\nSomething,tomorrow\n"))
+		_, err := readBackup(file, file.Size())
+		assert.True(t, models.IsErrInvalidMigrationFile(err))
+	})
+}
+
+func TestConvertTodoistBackupToVikunja(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("Errands [2203306141].csv")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(testBackupCSV))
+	require.NoError(t, err)
+	w, err = zw.Create("receipt.pdf")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("receipt"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	file := bytes.NewReader(buf.Bytes())
+	data, err := readBackup(file, file.Size())
+	require.NoError(t, err)
+
+	hierarchy, err := convertTodoistToVikunja(data, nil)
+	require.NoError(t, err)
+	require.Len(t, hierarchy[0].ChildProjects, 1)
+
+	project := hierarchy[0].ChildProjects[0]
+	require.Len(t, project.Buckets, 1)
+	assert.Equal(t, "Later", project.Buckets[0].Title)
+	require.Len(t, project.Tasks, 2)
+
+	milk := project.Tasks[0]
+	assert.Equal(t, "Whole milk\nHere's the receipt", milk.Description)
+	require.Len(t, milk.Attachments, 1)
+	assert.Equal(t, "receipt.pdf", milk.Attachments[0].File.Name)
+	assert.Equal(t, "application/pdf", milk.Attachments[0].File.Mime)
+	assert.Equal(t, uint64(7), milk.Attachments[0].File.Size)
+	assert.Equal(t, []byte("receipt"), milk.Attachments[0].File.FileContent)
+	require.Len(t, milk.Labels, 2)
+	assert.Equal(t, "shopping", milk.Labels[0].Title)
+	require.Len(t, milk.RelatedTasks[models.RelationKindSubtask], 1)
+	assert.Equal(t, "Get the oat milk too", milk.RelatedTasks[models.RelationKindSubtask][0].Title)
+	assert.Equal(t, project.Buckets[0].ID, project.Tasks[1].BucketID)
+}
@@ -22,9 +22,13 @@ import (
 	"time"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/files"
 	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/d4l3k/messagediff.v1"
 )
 
@@ -254,31 +258,31 @@ func TestConvertTodoistToVikunja(t *testing.T) {
 		},
 		ProjectNotes: []*projectNote{
 			{
-				ID:        102000,
+				ID:        "102000",
 				Content:   "Lorem Ipsum dolor sit amet",
 				ProjectID: "396936926",
 				Posted:    time3,
 			},
 			{
-				ID:        102001,
+				ID:        "102001",
 				Content:   "Lorem Ipsum dolor sit amet 2",
 				ProjectID: "396936926",
 				Posted:    time3,
 			},
 			{
-				ID:        102002,
+				ID:        "102002",
 				Content:   "Lorem Ipsum dolor sit amet 3",
 				ProjectID: "396936926",
 				Posted:    time3,
 			},
 			{
-				ID:        102003,
+				ID:        "102003",
 				Content:   "Lorem Ipsum dolor sit amet 4",
 				ProjectID: "396936927",
 				Posted:    time3,
 			},
 			{
-				ID:        102004,
+				ID:        "102004",
 				Content:   "Lorem Ipsum dolor sit amet 5",
 				ProjectID: "396936927",
 				Posted:    time3,
@@ -630,3 +634,66 @@ func TestConvertTodoistToVikunja(t *testing.T) {
 		t.Errorf("converted todoist data = %v, want %v, diff: %v", hierachie, expectedHierachie, diff)
 	}
 }
+
+func TestConvertTodoistSectionsToBuckets(t *testing.T) {
+	testSync := &sync{
+		Projects: []*project{
+			{ID: "1", Name: "Project1"},
+		},
+		Sections: []*section{
+			{ID: "10", Name: "Section1", ProjectID: "1", SectionOrder: 1},
+			{ID: "11", Name: "Section2", ProjectID: "1", SectionOrder: 2},
+		},
+		Items: []*item{
+			{ID: "100", ProjectID: "1", SectionID: "10", Content: "Task1"},
+			{ID: "101", ProjectID: "1", SectionID: "11", Content: "Task2"},
+			{ID: "102", ProjectID: "1", SectionID: "11", Content: "Task3"},
+		},
+	}
+
+	hierachie, err := convertTodoistToVikunja(testSync, map[string]*doneItem{})
+	assert.NoError(t, err)
+
+	// Every section needs its own bucket, no matter how many tasks there are
+	p := hierachie[0].ChildProjects[0]
+	assert.Len(t, p.Buckets, 2)
+	assert.NotEqual(t, p.Buckets[0].ID, p.Buckets[1].ID)
+	assert.Equal(t, p.Buckets[0].ID, p.Tasks[0].BucketID)
+	assert.Equal(t, p.Buckets[1].ID, p.Tasks[1].BucketID)
+	assert.Equal(t, p.Buckets[1].ID, p.Tasks[2].BucketID)
+}
+
+func TestInsertTodoistStructure(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	u := &user.User{ID: 1}
+	hierachie, err := convertTodoistToVikunja(&sync{
+		Projects: []*project{
+			{ID: "1", Name: "Project 1"},
+			{ID: "2", Name: "Project 2"},
+		},
+		Items: []*item{
+			{ID: "1", ProjectID: "1", Content: "Task 1"},
+			{ID: "2", ProjectID: "2", Content: "Task 2"},
+		},
+	}, map[string]*doneItem{})
+	require.NoError(t, err)
+	err = migration.InsertFromStructure(hierachie, u)
+	require.NoError(t, err)
+
+	// The projects are created as child projects of the migration project
+	parent := hierachie[0]
+	require.NotZero(t, parent.ID)
+	require.Len(t, parent.ChildProjects, 2)
+	for _, child := range parent.ChildProjects {
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             child.Title,
+			"parent_project_id": parent.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":      child.Tasks[0].Title,
+			"project_id": child.ID,
+		}, false)
+	}
+}
@@ -0,0 +1,97 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package todotxt
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/models"
+)
+
+// letterFromPriority converts a Vikunja priority to a todo.txt priority. Returns 0 if the task has no priority.
+func letterFromPriority(priority int64) byte {
+	switch {
+	case priority >= 5:
+		return 'A'
+	case priority == 4:
+		return 'B'
+	case priority == 3:
+		return 'C'
+	case priority == 2:
+		return 'D'
+	case priority == 1:
+		return 'E'
+	}
+	return 0
+}
+
+// tag converts a project or label title to a todo.txt +project or @context, which can't contain whitespace.
+func tag(prefix, title string) string {
+	return prefix + strings.Join(strings.Fields(title), "_")
+}
+
+// formatTask returns a task as one todo.txt line.
+func formatTask(task *models.Task, project *models.Project, location *time.Location) string {
+	parts := []string{}
+	priority := letterFromPriority(task.Priority)
+
+	if task.Done {
+		doneAt := task.DoneAt
+		if doneAt.IsZero() {
+			doneAt = task.Updated
+		}
+		parts = append(parts, "x", doneAt.In(location).Format(dateFormat))
+	} else if priority != 0 {
+		parts = append(parts, "("+string(priority)+")")
+	}
+
+	if !task.Created.IsZero() {
+		parts = append(parts, task.Created.In(location).Format(dateFormat))
+	}
+
+	parts = append(parts, strings.Join(strings.Fields(task.Title), " "))
+
+	if project != nil {
+		parts = append(parts, tag("+", project.Title))
+	}
+	for _, label := range task.Labels {
+		parts = append(parts, tag("@", label.Title))
+	}
+	if !task.DueDate.IsZero() {
+		parts = append(parts, "due:"+task.DueDate.In(location).Format(dateFormat))
+	}
+	if task.Done && priority != 0 {
+		parts = append(parts, "pri:"+string(priority))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Export writes all tasks in todo.txt format, one task per line. The project of each task is looked up in projects
+// and added as +project.
+func Export(w io.Writer, tasks []*models.Task, projects map[int64]*models.Project, location *time.Location) error {
+	bw := bufio.NewWriter(w)
+	for _, task := range tasks {
+		if _, err := bw.WriteString(formatTask(task, projects[task.ProjectID], location) + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
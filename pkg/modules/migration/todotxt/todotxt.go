@@ -0,0 +1,274 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package todotxt
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+
+	"xorm.io/xorm"
+)
+
+const dateFormat = "2006-01-02"
+
+// Migrator imports todo.txt files, see http://todotxt.org
+type Migrator struct {
+}
+
+type todoTxtTask struct {
+	Done     bool
+	Priority int64
+	// The date the task was done, only set for done tasks
+	Completed time.Time
+	Created   time.Time
+	Title     string
+	// The first +project of the task
+	Project  string
+	Contexts []string
+	Due      time.Time
+}
+
+// priorityFromLetter converts a todo.txt priority (A-Z) to a Vikunja priority, with A as the highest.
+func priorityFromLetter(letter byte) int64 {
+	switch letter {
+	case 'A':
+		return 5
+	case 'B':
+		return 4
+	case 'C':
+		return 3
+	case 'D':
+		return 2
+	}
+	return 1
+}
+
+// isPriority checks if a token is a priority like (A).
+func isPriority(token string) bool {
+	return len(token) == 3 && token[0] == '(' && token[1] >= 'A' && token[1] <= 'Z' && token[2] == ')'
+}
+
+func parseDate(token string, location *time.Location) (time.Time, bool) {
+	if len(token) != len(dateFormat) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(dateFormat, token, location)
+	return t, err == nil
+}
+
+// parseLine parses one line of a todo.txt file. Returns nil if the line does not contain a task.
+func parseLine(line string, location *time.Location) *todoTxtTask {
+	tokens := strings.Fields(line)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	task := &todoTxtTask{}
+
+	// A done task starts with "x", followed by the completion and the creation date.
+	// An open task can start with a priority, followed by the creation date.
+	if tokens[0] == "x" {
+		task.Done = true
+		tokens = tokens[1:]
+		if len(tokens) > 0 {
+			if date, ok := parseDate(tokens[0], location); ok {
+				task.Completed = date
+				tokens = tokens[1:]
+			}
+		}
+	} else if isPriority(tokens[0]) {
+		task.Priority = priorityFromLetter(tokens[0][1])
+		tokens = tokens[1:]
+	}
+	if len(tokens) > 0 {
+		if date, ok := parseDate(tokens[0], location); ok {
+			task.Created = date
+			tokens = tokens[1:]
+		}
+	}
+
+	title := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		switch {
+		case strings.HasPrefix(token, "+") && len(token) > 1 && task.Project == "":
+			task.Project = token[1:]
+		case strings.HasPrefix(token, "@") && len(token) > 1:
+			task.Contexts = append(task.Contexts, token[1:])
+		case strings.HasPrefix(token, "due:"):
+			date, ok := parseDate(strings.TrimPrefix(token, "due:"), location)
+			if !ok {
+				title = append(title, token)
+				continue
+			}
+			task.Due = date
+		// Done tasks lose their priority, most tools keep it as pri:A
+		case strings.HasPrefix(token, "pri:") && len(token) == 5 && token[4] >= 'A' && token[4] <= 'Z':
+			task.Priority = priorityFromLetter(token[4])
+		default:
+			title = append(title, token)
+		}
+	}
+	task.Title = strings.Join(title, " ")
+
+	return task
+}
+
+func parseTodoTxt(r io.Reader, location *time.Location) (tasks []*todoTxtTask, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		task := parseLine(strings.TrimPrefix(scanner.Text(), "\uFEFF"), location)
+		if task == nil || task.Title == "" {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, scanner.Err()
+}
+
+// convertTodoTxtToVikunja puts all tasks in one project, with a child project for every +project.
+// The returned map contains the creation date of every task with one, since these can only be set after the tasks are
+// created.
+func convertTodoTxtToVikunja(tasks []*todoTxtTask) (root *models.ProjectWithTasksAndBuckets, created map[*models.TaskWithComments]time.Time) {
+	root = &models.ProjectWithTasksAndBuckets{
+		Project: models.Project{
+			Title: "Imported from todo.txt",
+		},
+	}
+	created = make(map[*models.TaskWithComments]time.Time)
+
+	projects := make(map[string]*models.ProjectWithTasksAndBuckets)
+	for _, t := range tasks {
+		task := &models.TaskWithComments{
+			Task: models.Task{
+				Title:    t.Title,
+				Done:     t.Done,
+				DoneAt:   t.Completed,
+				Priority: t.Priority,
+				DueDate:  t.Due,
+			},
+		}
+		for _, context := range t.Contexts {
+			task.Labels = append(task.Labels, &models.Label{Title: context})
+		}
+		if !t.Created.IsZero() {
+			created[task] = t.Created
+		}
+
+		if t.Project == "" {
+			root.Tasks = append(root.Tasks, task)
+			continue
+		}
+
+		project, exists := projects[t.Project]
+		if !exists {
+			project = &models.ProjectWithTasksAndBuckets{
+				Project: models.Project{
+					Title: t.Project,
+				},
+			}
+			projects[t.Project] = project
+			root.ChildProjects = append(root.ChildProjects, project)
+		}
+		project.Tasks = append(project.Tasks, task)
+	}
+
+	return
+}
+
+func setCreationDates(s *xorm.Session, created map[*models.TaskWithComments]time.Time) (err error) {
+	for task, date := range created {
+		// xorm never updates created columns when updating with a struct
+		_, err = s.
+			Table("tasks").
+			Where("id = ?", task.ID).
+			NoAutoTime().
+			Update(map[string]interface{}{"created": date})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Name is used to get the name of the todo.txt migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/todotxt/status [get]
+func (m *Migrator) Name() string {
+	return "todotxt"
+}
+
+// Migrate takes a todo.txt file, parses it and imports all tasks in it into Vikunja.
+// @Summary Import all tasks from a todo.txt file
+// @Description Imports all tasks of a todo.txt file into a new project. Every +project becomes a child project of it and every @context a label. Priorities, creation and completion dates and due dates (`due:YYYY-MM-DD`) are imported as well.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The todo.txt file."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/todotxt/migrate [put]
+func (m *Migrator) Migrate(u *user.User, file io.ReaderAt, size int64) (err error) {
+	location := config.GetTimeZone()
+	if u.Timezone != "" {
+		location, err = time.LoadLocation(u.Timezone)
+		if err != nil {
+			location = config.GetTimeZone()
+		}
+	}
+
+	tasks, err := parseTodoTxt(io.NewSectionReader(file, 0, size), location)
+	if err != nil {
+		return &models.ErrInvalidMigrationFile{Migrator: m.Name(), Reason: err.Error()}
+	}
+
+	log.Debugf("[todo.txt Migration] Importing %d tasks", len(tasks))
+
+	root, created := convertTodoTxtToVikunja(tasks)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	err = migration.InsertFromStructureWithSession(s, []*models.ProjectWithTasksAndBuckets{root}, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	err = setCreationDates(s, created)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
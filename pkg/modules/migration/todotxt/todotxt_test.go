@@ -0,0 +1,168 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package todotxt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(t *testing.T, value string) time.Time {
+	d, err := time.ParseInLocation(dateFormat, value, time.UTC)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParseLine(t *testing.T) {
+	t.Run("open task", func(t *testing.T) {
+		task := parseLine("(A) 2026-10-12 Call mom +Family @phone @home due:2026-10-20 +Other", time.UTC)
+		require.NotNil(t, task)
+		assert.False(t, task.Done)
+		assert.Equal(t, int64(5), task.Priority)
+		assert.Equal(t, date(t, "2026-10-12"), task.Created)
+		assert.Equal(t, "Call mom +Other", task.Title)
+		assert.Equal(t, "Family", task.Project)
+		assert.Equal(t, []string{"phone", "home"}, task.Contexts)
+		assert.Equal(t, date(t, "2026-10-20"), task.Due)
+	})
+	t.Run("done task", func(t *testing.T) {
+		task := parseLine("x 2026-10-16 2026-10-12 Pay the bills pri:B", time.UTC)
+		require.NotNil(t, task)
+		assert.True(t, task.Done)
+		assert.Equal(t, date(t, "2026-10-16"), task.Completed)
+		assert.Equal(t, date(t, "2026-10-12"), task.Created)
+		assert.Equal(t, int64(4), task.Priority)
+		assert.Equal(t, "Pay the bills", task.Title)
+	})
+	t.Run("plain task", func(t *testing.T) {
+		task := parseLine("Buy milk due:tomorrow", time.UTC)
+		require.NotNil(t, task)
+		assert.Equal(t, "Buy milk due:tomorrow", task.Title)
+		assert.Equal(t, int64(0), task.Priority)
+		assert.True(t, task.Created.IsZero())
+		assert.True(t, task.Due.IsZero())
+	})
+	t.Run("empty line", func(t *testing.T) {
+		assert.Nil(t, parseLine("   ", time.UTC))
+	})
+}
+
+func TestFormatTask(t *testing.T) {
+	project := &models.Project{Title: "Family stuff"}
+	t.Run("open task", func(t *testing.T) {
+		line := formatTask(&models.Task{
+			Title:    "Call\nmom",
+			Priority: 5,
+			Created:  date(t, "2026-10-12"),
+			DueDate:  date(t, "2026-10-20"),
+			Labels:   []*models.Label{{Title: "phone"}},
+		}, project, time.UTC)
+		assert.Equal(t, "(A) 2026-10-12 Call mom +Family_stuff @phone due:2026-10-20", line)
+	})
+	t.Run("done task", func(t *testing.T) {
+		line := formatTask(&models.Task{
+			Title:    "Pay the bills",
+			Done:     true,
+			DoneAt:   date(t, "2026-10-16"),
+			Priority: 4,
+			Created:  date(t, "2026-10-12"),
+		}, nil, time.UTC)
+		assert.Equal(t, "x 2026-10-16 2026-10-12 Pay the bills pri:B", line)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		original := "(C) 2026-10-12 Water the plants +Home @garden due:2026-10-13"
+		task := parseLine(original, time.UTC)
+		line := formatTask(&models.Task{
+			Title:    task.Title,
+			Priority: task.Priority,
+			Created:  task.Created,
+			DueDate:  task.Due,
+			Labels:   []*models.Label{{Title: task.Contexts[0]}},
+		}, &models.Project{Title: task.Project}, time.UTC)
+		assert.Equal(t, original, line)
+	})
+}
+
+func TestExport(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Export(buf, []*models.Task{
+		{Title: "First", ProjectID: 1},
+		{Title: "Second", ProjectID: 2},
+	}, map[int64]*models.Project{1: {Title: "Inbox"}}, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, "First +Inbox\nSecond\n", buf.String())
+}
+
+func TestConvertTodoTxtToVikunja(t *testing.T) {
+	tasks, err := parseTodoTxt(strings.NewReader("2026-10-12 Unsorted\n\nFirst +Work\nSecond +Work @office\nThird +Home\n"), time.UTC)
+	require.NoError(t, err)
+
+	root, created := convertTodoTxtToVikunja(tasks)
+	assert.Equal(t, "Imported from todo.txt", root.Title)
+	require.Len(t, root.Tasks, 1)
+	assert.Equal(t, date(t, "2026-10-12"), created[root.Tasks[0]])
+	require.Len(t, root.ChildProjects, 2)
+	assert.Equal(t, "Work", root.ChildProjects[0].Title)
+	require.Len(t, root.ChildProjects[0].Tasks, 2)
+	assert.Equal(t, "office", root.ChildProjects[0].Tasks[1].Labels[0].Title)
+	assert.Equal(t, "Home", root.ChildProjects[1].Title)
+	assert.Len(t, created, 1)
+}
+
+func TestMigrate(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	file := strings.NewReader("(A) 2020-01-02 Call mom +Family @phone due:2026-10-20\nx 2026-10-16 Pay the bills\n")
+	u := &user.User{ID: 1, Username: "user1"}
+	err := (&Migrator{}).Migrate(u, file, file.Size())
+	require.NoError(t, err)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	project := &models.Project{}
+	has, err := s.Where("title = ? AND owner_id = ?", "Family", 1).Get(project)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	task := &models.Task{}
+	has, err = s.Where("project_id = ? AND title = ?", project.ID, "Call mom").Get(task)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Equal(t, int64(5), task.Priority)
+	assert.Equal(t, 2020, task.Created.Year())
+	assert.False(t, task.DueDate.IsZero())
+
+	db.AssertExists(t, "tasks", map[string]interface{}{
+		"project_id": project.ParentProjectID,
+		"title":      "Pay the bills",
+		"done":       true,
+	}, false)
+	db.AssertExists(t, "labels", map[string]interface{}{
+		"title":         "phone",
+		"created_by_id": 1,
+	}, false)
+}
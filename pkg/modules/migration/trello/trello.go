@@ -17,6 +17,10 @@
 package trello
 
 import (
+	"archive/zip"
+	"bytes"
+	"sort"
+
 	"code.vikunja.io/api/pkg/config"
 	"code.vikunja.io/api/pkg/files"
 	"code.vikunja.io/api/pkg/log"
@@ -33,6 +37,9 @@ type Migration struct {
 
 var trelloColorMap map[string]string
 
+// apiBaseURL is the url of the trello api, only changed in tests
+var apiBaseURL = trello.DefaultBaseURL
+
 func init() {
 	trelloColorMap = make(map[string]string, 10)
 	trelloColorMap = map[string]string{
@@ -87,6 +94,7 @@ func getTrelloData(token string) (trelloData []*trello.Board, err error) {
 	allArg := trello.Arguments{"fields": "all"}
 
 	client := trello.NewClient(config.MigrationTrelloKey.GetString(), token)
+	client.BaseURL = apiBaseURL
 	client.Logger = log.GetLogger()
 
 	log.Debugf("[Trello Migration] Getting boards...")
@@ -133,6 +141,15 @@ func getTrelloData(token string) (trelloData []*trello.Board, err error) {
 				return nil, err
 			}
 
+			card.Actions, err = card.GetActions(trello.Arguments{"filter": "commentCard", "limit": "1000"})
+			if err != nil {
+				return nil, err
+			}
+			// Trello returns the newest comment first
+			sort.SliceStable(card.Actions, func(i, j int) bool {
+				return card.Actions[i].Date.Before(card.Actions[j].Date)
+			})
+
 			if len(card.IDCheckLists) > 0 {
 				for _, checkListID := range card.IDCheckLists {
 					checklist, err := client.GetChecklist(checkListID, allArg)
@@ -162,7 +179,9 @@ func getTrelloData(token string) (trelloData []*trello.Board, err error) {
 
 // Converts all previously obtained data from trello into the vikunja format.
 // `trelloData` should contain all boards with their projects and cards respectively.
-func convertTrelloDataToVikunja(trelloData []*trello.Board, token string) (fullVikunjaHierachie []*models.ProjectWithTasksAndBuckets, err error) {
+// If `downloadFiles` is false, for example because the boards were imported from a file, attachments are taken from
+// `archive` instead and backgrounds are not imported.
+func convertTrelloDataToVikunja(trelloData []*trello.Board, token string, downloadFiles bool, archive *zip.Reader) (fullVikunjaHierachie []*models.ProjectWithTasksAndBuckets, err error) {
 
 	log.Debugf("[Trello Migration] ")
 
@@ -190,7 +209,12 @@ func convertTrelloDataToVikunja(trelloData []*trello.Board, token string) (fullV
 
 		// Background
 		// We're pretty much abusing the backgroundinformation field here - not sure if this is really better than adding a new property to the project
-		if board.Prefs.BackgroundImage != "" {
+		switch {
+		case board.Prefs.BackgroundImage == "":
+			log.Debugf("[Trello Migration] Board %s does not have a background image, not copying...", board.ID)
+		case !downloadFiles:
+			log.Debugf("[Trello Migration] Not downloading background %s for board %s", board.Prefs.BackgroundImage, board.ID)
+		default:
 			log.Debugf("[Trello Migration] Downloading background %s for board %s", board.Prefs.BackgroundImage, board.ID)
 			buf, err := migration.DownloadFile(board.Prefs.BackgroundImage)
 			if err != nil {
@@ -198,8 +222,6 @@ func convertTrelloDataToVikunja(trelloData []*trello.Board, token string) (fullV
 			}
 			log.Debugf("[Trello Migration] Downloaded background %s for board %s", board.Prefs.BackgroundImage, board.ID)
 			project.BackgroundInformation = buf
-		} else {
-			log.Debugf("[Trello Migration] Board %s does not have a background image, not copying...", board.ID)
 		}
 
 		for _, l := range board.Lists {
@@ -270,14 +292,23 @@ func convertTrelloDataToVikunja(trelloData []*trello.Board, token string) (fullV
 						continue
 					}
 
-					log.Debugf("[Trello Migration] Downloading card attachment %s", attachment.ID)
+					var buf *bytes.Buffer
+					if downloadFiles {
+						log.Debugf("[Trello Migration] Downloading card attachment %s", attachment.ID)
 
-					buf, err := migration.DownloadFileWithHeaders(attachment.URL, map[string][]string{
-						"Authorization": {`OAuth oauth_consumer_key="` + config.MigrationTrelloKey.GetString() + `", oauth_token="` + token + `"`},
-					})
+						buf, err = migration.DownloadFileWithHeaders(attachment.URL, map[string][]string{
+							"Authorization": {`OAuth oauth_consumer_key="` + config.MigrationTrelloKey.GetString() + `", oauth_token="` + token + `"`},
+						})
+					} else {
+						buf, err = readArchiveAttachment(archive, card, attachment)
+					}
 					if err != nil {
 						return nil, err
 					}
+					if buf == nil {
+						log.Debugf("[Trello Migration] Could not find card attachment %s in the archive, not importing it", attachment.ID)
+						continue
+					}
 
 					task.Attachments = append(task.Attachments, &models.TaskAttachment{
 						File: &files.File{
@@ -291,7 +322,21 @@ func convertTrelloDataToVikunja(trelloData []*trello.Board, token string) (fullV
 					log.Debugf("[Trello Migration] Downloaded card attachment %s", attachment.ID)
 				}
 
-				project.Tasks = append(project.Tasks, &models.TaskWithComments{Task: *task})
+				// Comments
+				var comments []*models.TaskComment
+				for _, action := range card.Actions {
+					if action.Type != "commentCard" || action.Data == nil {
+						continue
+					}
+
+					comment := action.Data.Text
+					if action.MemberCreator != nil && action.MemberCreator.FullName != "" {
+						comment = action.MemberCreator.FullName + ":\n\n" + comment
+					}
+					comments = append(comments, &models.TaskComment{Comment: comment})
+				}
+
+				project.Tasks = append(project.Tasks, &models.TaskWithComments{Task: *task, Comments: comments})
 			}
 
 			project.Buckets = append(project.Buckets, bucket)
@@ -329,7 +374,7 @@ func (m *Migration) Migrate(u *user.User) (err error) {
 	log.Debugf("[Trello Migration] Got all trello data for user %d", u.ID)
 	log.Debugf("[Trello Migration] Start converting trello data for user %d", u.ID)
 
-	fullVikunjaHierachie, err := convertTrelloDataToVikunja(trelloData, m.Token)
+	fullVikunjaHierachie, err := convertTrelloDataToVikunja(trelloData, m.Token, true, nil)
 	if err != nil {
 		return
 	}
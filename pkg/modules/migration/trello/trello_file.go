@@ -0,0 +1,248 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package trello
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/adlio/trello"
+)
+
+// FileMigrator imports trello boards from their json export, without using the trello api
+type FileMigrator struct {
+}
+
+// boardExport is the json export of a trello board. Other than the api, it contains all cards, checklists and actions
+// of the board next to each other.
+type boardExport struct {
+	trello.Board
+	Cards      []*trello.Card      `json:"cards"`
+	Checklists []*trello.Checklist `json:"checklists"`
+	// Only the comments are used from the actions, which is why they're decoded into our own struct
+	Actions []*boardAction `json:"actions"`
+}
+
+type boardAction struct {
+	Type string    `json:"type"`
+	Date time.Time `json:"date"`
+	Data struct {
+		Text string `json:"text"`
+		Card struct {
+			ID string `json:"id"`
+		} `json:"card"`
+	} `json:"data"`
+	MemberCreator *trello.Member `json:"memberCreator"`
+}
+
+// toBoard puts all open lists, cards and checklists of the export together, like getTrelloData does with the api.
+func (e *boardExport) toBoard() *trello.Board {
+	board := e.Board
+	board.Actions = nil
+
+	lists := make(map[string]*trello.List, len(e.Lists))
+	board.Lists = []*trello.List{}
+	for _, l := range e.Lists {
+		if l.Closed {
+			continue
+		}
+		l.Cards = nil
+		lists[l.ID] = l
+		board.Lists = append(board.Lists, l)
+	}
+	sort.SliceStable(board.Lists, func(i, j int) bool {
+		return board.Lists[i].Pos < board.Lists[j].Pos
+	})
+
+	sort.SliceStable(e.Checklists, func(i, j int) bool {
+		return e.Checklists[i].Pos < e.Checklists[j].Pos
+	})
+	checklists := make(map[string][]*trello.Checklist)
+	for _, c := range e.Checklists {
+		sort.SliceStable(c.CheckItems, func(i, j int) bool {
+			return c.CheckItems[i].Pos < c.CheckItems[j].Pos
+		})
+		checklists[c.IDCard] = append(checklists[c.IDCard], c)
+	}
+
+	sort.SliceStable(e.Actions, func(i, j int) bool {
+		return e.Actions[i].Date.Before(e.Actions[j].Date)
+	})
+	comments := make(map[string]trello.ActionCollection)
+	for _, a := range e.Actions {
+		if a.Type != "commentCard" {
+			continue
+		}
+		comments[a.Data.Card.ID] = append(comments[a.Data.Card.ID], &trello.Action{
+			Type:          a.Type,
+			Date:          a.Date,
+			Data:          &trello.ActionData{Text: a.Data.Text},
+			MemberCreator: a.MemberCreator,
+		})
+	}
+
+	sort.SliceStable(e.Cards, func(i, j int) bool {
+		return e.Cards[i].Pos < e.Cards[j].Pos
+	})
+	for _, card := range e.Cards {
+		list, exists := lists[card.IDList]
+		if card.Closed || !exists {
+			continue
+		}
+		card.Checklists = checklists[card.ID]
+		card.Actions = comments[card.ID]
+		list.Cards = append(list.Cards, card)
+	}
+
+	return &board
+}
+
+func parseBoardExport(content []byte) (*trello.Board, error) {
+	export := &boardExport{}
+	if err := json.Unmarshal(content, export); err != nil {
+		return nil, err
+	}
+	if export.ID == "" || export.Name == "" {
+		return nil, &models.ErrInvalidMigrationFile{Migrator: "trello-file", Reason: "The file is not a trello board export."}
+	}
+	return export.toBoard(), nil
+}
+
+// readBoardExports reads either a single board export or a zip file with multiple board exports. If the export is a
+// zip file, it is also returned to read the attachments from.
+func readBoardExports(file io.ReaderAt, size int64) (boards []*trello.Board, archive *zip.Reader, err error) {
+	archive, err = zip.NewReader(file, size)
+	if err != nil {
+		content, err := io.ReadAll(io.NewSectionReader(file, 0, size))
+		if err != nil {
+			return nil, nil, err
+		}
+		board, err := parseBoardExport(bytes.TrimPrefix(content, []byte("\uFEFF")))
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*trello.Board{board}, nil, nil
+	}
+
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		board, err := parseBoardExport(bytes.TrimPrefix(content, []byte("\uFEFF")))
+		if err != nil {
+			return nil, nil, err
+		}
+		boards = append(boards, board)
+	}
+
+	if len(boards) == 0 {
+		return nil, nil, &models.ErrInvalidMigrationFile{Migrator: "trello-file", Reason: "The zip file does not contain any trello board exports."}
+	}
+
+	return boards, archive, nil
+}
+
+// readArchiveAttachment returns the content of a card attachment from the archive or nil if it is not in there.
+// Attachments are either stored with their id as name or with their file name in a directory named after the card id.
+func readArchiveAttachment(archive *zip.Reader, card *trello.Card, attachment *trello.Attachment) (*bytes.Buffer, error) {
+	if archive == nil {
+		return nil, nil
+	}
+	for _, f := range archive.File {
+		name := path.Base(f.Name)
+		if name != attachment.ID && (name != attachment.Name || path.Base(path.Dir(f.Name)) != card.ID) {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		buf := &bytes.Buffer{}
+		if _, err := buf.ReadFrom(r); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+	return nil, nil
+}
+
+// Name is used to get the name of the trello file migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/trello-file/status [get]
+func (m *FileMigrator) Name() string {
+	return "trello-file"
+}
+
+// Migrate takes a trello board export, parses it and imports everything in it into Vikunja.
+// @Summary Import all boards from trello board exports
+// @Description Imports trello boards from their json export, without the need for api credentials. Boards, lists, cards, checklists, labels and comments are converted the same way as with the trello migration. Since files can't be downloaded without the trello api, attachments are imported from a zip file with the board exports and the attachments, either named with their attachment id or with their file name in a directory named after the card id. Backgrounds are not imported.
+// @tags migration
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param import formData string true "The json export of a trello board or a zip file with multiple board exports and their attachments."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 400 {object} web.HTTPError "The file is not a trello board export."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/trello-file/migrate [put]
+func (m *FileMigrator) Migrate(u *user.User, file io.ReaderAt, size int64) error {
+	boards, archive, err := readBoardExports(file, size)
+	if err != nil {
+		if models.IsErrInvalidMigrationFile(err) {
+			return err
+		}
+		return &models.ErrInvalidMigrationFile{Migrator: m.Name(), Reason: err.Error()}
+	}
+
+	log.Debugf("[Trello Migration] Importing %d boards from file for user %d", len(boards), u.ID)
+
+	fullVikunjaHierachie, err := convertTrelloDataToVikunja(boards, "", false, archive)
+	if err != nil {
+		return err
+	}
+
+	return migration.InsertFromStructure(fullVikunjaHierachie, u)
+}
@@ -0,0 +1,188 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package trello
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"code.vikunja.io/api/pkg/models"
+
+	"github.com/adlio/trello"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBoardExport = `{
+	"id": "board1",
+	"name": "Roadmap",
+	"desc": "What we're working on",
+	"closed": false,
+	"prefs": {"backgroundImage": "https://trello-backgrounds.s3.amazonaws.com/bg.jpg"},
+	"lists": [
+		{"id": "list2", "name": "Done", "closed": false, "pos": 2},
+		{"id": "list1", "name": "Todo", "closed": false, "pos": 1},
+		{"id": "list3", "name": "Old", "closed": true, "pos": 3}
+	],
+	"cards": [
+		{
+			"id": "card2",
+			"name": "Second card",
+			"idList": "list1",
+			"pos": 20,
+			"closed": false
+		},
+		{
+			"id": "card1",
+			"name": "First card",
+			"desc": "Description",
+			"idList": "list1",
+			"pos": 10,
+			"closed": false,
+			"due": "2026-10-20T10:00:00.000Z",
+			"labels": [{"id": "label1", "name": "Important", "color": "red"}],
+			"attachments": [{"id": "att1", "name": "plan.pdf", "mimeType": "application/pdf", "url": "https://trello.com/1/cards/card1/attachments/att1/download/plan.pdf"}]
+		},
+		{"id": "card3", "name": "Archived card", "idList": "list1", "pos": 30, "closed": true},
+		{"id": "card4", "name": "Card in closed list", "idList": "list3", "pos": 10, "closed": false}
+	],
+	"checklists": [
+		{"id": "cl1", "name": "Steps", "idCard": "card1", "pos": 1, "checkItems": [
+			{"id": "ci2", "name": "Step two", "state": "incomplete", "pos": 2},
+			{"id": "ci1", "name": "Step one", "state": "complete", "pos": 1}
+		]}
+	],
+	"actions": [
+		{"type": "commentCard", "date": "2026-10-02T10:00:00.000Z", "data": {"text": "Second comment", "card": {"id": "card1"}}, "memberCreator": {"fullName": "Jane Doe"}},
+		{"type": "commentCard", "date": "2026-10-01T10:00:00.000Z", "data": {"text": "First comment", "card": {"id": "card1"}}, "memberCreator": {"fullName": "Jane Doe"}},
+		{"type": "updateCard", "date": "2026-10-03T10:00:00.000Z", "data": {"card": {"id": "card1"}}}
+	]
+}`
+
+func TestReadBoardExports(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		file := bytes.NewReader([]byte(testBoardExport))
+		boards, archive, err := readBoardExports(file, file.Size())
+		require.NoError(t, err)
+		assert.Nil(t, archive)
+		require.Len(t, boards, 1)
+
+		board := boards[0]
+		require.Len(t, board.Lists, 2)
+		assert.Equal(t, "Todo", board.Lists[0].Name)
+		assert.Equal(t, "Done", board.Lists[1].Name)
+		require.Len(t, board.Lists[0].Cards, 2)
+		card := board.Lists[0].Cards[0]
+		assert.Equal(t, "First card", card.Name)
+		require.Len(t, card.Checklists, 1)
+		assert.Equal(t, "Step one", card.Checklists[0].CheckItems[0].Name)
+		require.Len(t, card.Actions, 2)
+		assert.Equal(t, "First comment", card.Actions[0].Data.Text)
+	})
+	t.Run("zip", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		w, err := zw.Create("boards/roadmap.json")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(testBoardExport))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		file := bytes.NewReader(buf.Bytes())
+		boards, archive, err := readBoardExports(file, file.Size())
+		require.NoError(t, err)
+		assert.NotNil(t, archive)
+		assert.Len(t, boards, 1)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		file := bytes.NewReader([]byte(`{"foo": "bar"}`))
+		_, _, err := readBoardExports(file, file.Size())
+		assert.True(t, models.IsErrInvalidMigrationFile(err))
+	})
+}
+
+func TestConvertTrelloFileToVikunja(t *testing.T) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("roadmap.json")
+	require.NoError(t, err)
+	_, err = w.Write([]byte(testBoardExport))
+	require.NoError(t, err)
+	w, err = zw.Create("card1/plan.pdf")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("plan"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	file := bytes.NewReader(buf.Bytes())
+	boards, archive, err := readBoardExports(file, file.Size())
+	require.NoError(t, err)
+
+	hierarchy, err := convertTrelloDataToVikunja(boards, "", false, archive)
+	require.NoError(t, err)
+	require.Len(t, hierarchy, 1)
+	require.Len(t, hierarchy[0].ChildProjects, 1)
+
+	project := hierarchy[0].ChildProjects[0]
+	assert.Equal(t, "Roadmap", project.Title)
+	assert.Nil(t, project.BackgroundInformation)
+	require.Len(t, project.Buckets, 2)
+	require.Len(t, project.Tasks, 2)
+
+	task := project.Tasks[0]
+	assert.Equal(t, "First card", task.Title)
+	assert.Equal(t, project.Buckets[0].ID, task.BucketID)
+	assert.Equal(t, "Description\n\n## Steps\n\n* [x] Step one\n* [ ] Step two", task.Description)
+	require.Len(t, task.Attachments, 1)
+	assert.Equal(t, "plan.pdf", task.Attachments[0].File.Name)
+	assert.Equal(t, "application/pdf", task.Attachments[0].File.Mime)
+	assert.Equal(t, []byte("plan"), task.Attachments[0].File.FileContent)
+	require.Len(t, task.Labels, 1)
+	assert.Equal(t, trelloColorMap["red"], task.Labels[0].HexColor)
+	require.Len(t, task.Comments, 2)
+	assert.Equal(t, "Jane Doe:\n\nFirst comment", task.Comments[0].Comment)
+	assert.Nil(t, project.Tasks[1].Comments)
+
+	t.Run("attachment named after its id", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		w, err := zw.Create("attachments/att1")
+		require.NoError(t, err)
+		_, err = w.Write([]byte("plan"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		content, err := readArchiveAttachment(archive, &trello.Card{ID: "card1"}, &trello.Attachment{ID: "att1", Name: "plan.pdf"})
+		require.NoError(t, err)
+		require.NotNil(t, content)
+		assert.Equal(t, "plan", content.String())
+	})
+	t.Run("missing attachment", func(t *testing.T) {
+		file := bytes.NewReader([]byte(testBoardExport))
+		boards, archive, err := readBoardExports(file, file.Size())
+		require.NoError(t, err)
+
+		hierarchy, err := convertTrelloDataToVikunja(boards, "", false, archive)
+		require.NoError(t, err)
+		task := hierarchy[0].ChildProjects[0].Tasks[0]
+		assert.Empty(t, task.Attachments)
+		assert.NotContains(t, task.Description, "plan.pdf")
+	})
+}
@@ -18,16 +18,22 @@ package trello
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
 	"code.vikunja.io/api/pkg/files"
 	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
 	"github.com/adlio/trello"
 	"github.com/d4l3k/messagediff"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvertTrelloToVikunja(t *testing.T) {
@@ -359,10 +365,105 @@ func TestConvertTrelloToVikunja(t *testing.T) {
 		},
 	}
 
-	hierachie, err := convertTrelloDataToVikunja(trelloData, "")
+	hierachie, err := convertTrelloDataToVikunja(trelloData, "", true, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, hierachie)
 	if diff, equal := messagediff.PrettyDiff(hierachie, expectedHierachie); !equal {
 		t.Errorf("converted trello data = %v, want %v, diff: %v", hierachie, expectedHierachie, diff)
 	}
 }
+
+func TestGetTrelloDataComments(t *testing.T) {
+	responses := map[string]string{
+		"/members/me/boards":       `[{"id": "board1", "name": "Board"}]`,
+		"/boards/board1/lists":     `[{"id": "list1", "name": "List", "idBoard": "board1"}]`,
+		"/boards/board1/cards":     `[{"id": "card1", "name": "Card", "idList": "list1"}]`,
+		"/cards/card1/attachments": `[]`,
+		"/cards/card1/actions":     `[{"id": "action2", "type": "commentCard", "date": "2023-01-02T00:00:00.000Z", "data": {"text": "Second"}, "memberCreator": {"fullName": "Jane"}}, {"id": "action1", "type": "commentCard", "date": "2023-01-01T00:00:00.000Z", "data": {"text": "First"}}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, has := responses[r.URL.Path]
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The client pages through cards until it gets an empty page
+		if r.URL.Query().Get("before") != "" {
+			response = `[]`
+		}
+		if r.URL.Path == "/cards/card1/actions" {
+			assert.Equal(t, "commentCard", r.URL.Query().Get("filter"))
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	oldBaseURL := apiBaseURL
+	apiBaseURL = server.URL
+	defer func() {
+		apiBaseURL = oldBaseURL
+	}()
+
+	boards, err := getTrelloData("token")
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Len(t, boards[0].Lists, 1)
+	require.Len(t, boards[0].Lists[0].Cards, 1)
+
+	hierachie, err := convertTrelloDataToVikunja(boards, "token", false, nil)
+	require.NoError(t, err)
+	task := hierachie[0].ChildProjects[0].Tasks[0]
+	require.Len(t, task.Comments, 2)
+	assert.Equal(t, "First", task.Comments[0].Comment)
+	assert.Equal(t, "Jane:\n\nSecond", task.Comments[1].Comment)
+}
+
+func TestInsertTrelloStructure(t *testing.T) {
+	db.LoadAndAssertFixtures(t)
+
+	u := &user.User{ID: 1}
+	trelloData := []*trello.Board{
+		{
+			ID:   "board1",
+			Name: "TestBoard",
+			Lists: []*trello.List{
+				{
+					ID:    "list1",
+					Name:  "Test List 1",
+					Cards: []*trello.Card{{ID: "card1", Name: "Test Card 1"}},
+				},
+			},
+		},
+		{
+			ID:   "board2",
+			Name: "TestBoard 2",
+			Lists: []*trello.List{
+				{
+					ID:    "list2",
+					Name:  "Test List 2",
+					Cards: []*trello.Card{{ID: "card2", Name: "Test Card 2"}},
+				},
+			},
+		},
+	}
+	hierachie, err := convertTrelloDataToVikunja(trelloData, "", false, nil)
+	require.NoError(t, err)
+	err = migration.InsertFromStructure(hierachie, u)
+	require.NoError(t, err)
+
+	// The boards are created as child projects of the migration project
+	parent := hierachie[0]
+	require.NotZero(t, parent.ID)
+	require.Len(t, parent.ChildProjects, 2)
+	for _, child := range parent.ChildProjects {
+		db.AssertExists(t, "projects", map[string]interface{}{
+			"id":                child.ID,
+			"title":             child.Title,
+			"parent_project_id": parent.ID,
+		}, false)
+		db.AssertExists(t, "tasks", map[string]interface{}{
+			"title":      child.Tasks[0].Title,
+			"project_id": child.ID,
+		}, false)
+	}
+}
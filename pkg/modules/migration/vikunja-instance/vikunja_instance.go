@@ -0,0 +1,614 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package vikunjainstance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/api/pkg/utils"
+
+	"xorm.io/xorm"
+)
+
+const logPrefix = "[Vikunja Instance Migration] "
+
+// How many items are requested per page, this is the default maximum of Vikunja instances.
+const perPage = 50
+
+// client is used for all requests to the other instance. The timeout includes reading the response body, which is
+// why it is long enough to download large attachments.
+// It never uses a proxy because the guarded dialer would then only check the address of the proxy.
+var client = &http.Client{
+	Timeout: 5 * time.Minute,
+	Transport: &http.Transport{
+		Proxy:       nil,
+		DialContext: utils.GuardedDialContext(config.MigrationVikunjaInstanceAllowedHosts.GetStringSlice, errAddressNotAllowed),
+	},
+}
+
+// errAddressNotAllowed is returned when the other instance is at an internal address which is not allowed through
+// the migration.vikunjainstance.allowedhosts config.
+var errAddressNotAllowed = errors.New("the address of the other Vikunja instance is not allowed")
+
+// Migration represents the migration from another Vikunja instance
+type Migration struct {
+	// The url of the other Vikunja instance, for example https://vikunja.example.com. The api path is added if it is missing.
+	URL string `json:"url"`
+	// An api token of the user on the other Vikunja instance. It needs the read_all permission for projects,
+	// projects_tasks, projects_buckets and tasks_comments and the read_one permission for tasks_attachments_download,
+	// projects_background_download and filters.
+	Token string `json:"token"`
+
+	// ctx is cancelled when the migration job is cancelled
+	ctx context.Context
+}
+
+// AuthURL returns an empty url since users create an api token on the other instance instead of authorizing Vikunja.
+// @Summary Get the auth url for another Vikunja instance
+// @Description There is no auth flow for other Vikunja instances, this always returns an empty url. Create an api token in the settings of the other instance instead and pass it to the migrate route.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} handler.AuthURL "An empty auth url."
+// @Router /migration/vikunja-instance/auth [get]
+func (m *Migration) AuthURL() string {
+	return ""
+}
+
+// Name is used to get the name of the vikunja instance migration - we're using the docs here to annotate the status route.
+// @Summary Get migration status
+// @Description Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.
+// @tags migration
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} migration.Status "The migration status"
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/vikunja-instance/status [get]
+func (m *Migration) Name() string {
+	return "vikunja-instance"
+}
+
+func (m *Migration) apiURL() string {
+	u := strings.TrimRight(m.URL, "/")
+	if !strings.HasSuffix(u, "/api/v1") {
+		u += "/api/v1"
+	}
+	return u
+}
+
+// get requests a path of the other instance's api. It returns the response body and the total number of pages the
+// endpoint has.
+func (m *Migration) get(path string, query url.Values) (body []byte, totalPages int, err error) {
+	u := m.apiURL() + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.Token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	buf := &bytes.Buffer{}
+	_, err = buf.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, 0, models.ErrInvalidData{Message: "The other Vikunja instance did not accept the api token for " + path + ". Make sure it is valid and has all permissions the migration needs."}
+	}
+	if resp.StatusCode > 399 {
+		// The response could contain anything, which is why it is only logged and not part of the error
+		log.Debugf(logPrefix+"Request to %s failed with status %d, response was: %s", path, resp.StatusCode, buf.String())
+		return nil, 0, &errRequestFailed{path: path, status: resp.StatusCode}
+	}
+
+	totalPages, _ = strconv.Atoi(resp.Header.Get("x-pagination-total-pages"))
+	return buf.Bytes(), totalPages, nil
+}
+
+type errRequestFailed struct {
+	path   string
+	status int
+}
+
+func (err *errRequestFailed) Error() string {
+	return fmt.Sprintf("Vikunja API Error: Request to %s failed with status %d", err.path, err.status)
+}
+
+func isNotFound(err error) bool {
+	e, is := err.(*errRequestFailed)
+	return is && e.status == http.StatusNotFound
+}
+
+// getAll requests all pages of a paginated endpoint and appends the items of each to result.
+func getAll[T any](m *Migration, path string, query url.Values, result *[]T) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		body, totalPages, err := m.get(path, query)
+		if err != nil {
+			return err
+		}
+
+		items := []T{}
+		err = json.Unmarshal(body, &items)
+		if err != nil {
+			return fmt.Errorf("could not parse response of %s: %w", path, err)
+		}
+		*result = append(*result, items...)
+
+		if page >= totalPages || len(items) == 0 {
+			return nil
+		}
+	}
+}
+
+// instanceData holds everything from the other instance
+type instanceData struct {
+	projects []*models.ProjectWithTasksAndBuckets
+	filters  []*models.SavedFilter
+	// The relations of all tasks with the task's id on the other instance as key
+	relations map[int64]models.RelatedTaskMap
+	// The title and color of all labels with their id on the other instance as key. Labels are created by their title
+	// and color, which makes it possible to find their new id.
+	labels map[int64]string
+	// The owner of the api token on the other instance
+	owner *user.User
+}
+
+func (m *Migration) getData() (data *instanceData, err error) {
+	data = &instanceData{
+		relations: make(map[int64]models.RelatedTaskMap),
+		labels:    make(map[int64]string),
+	}
+
+	projects := []*models.Project{}
+	err = getAll(m, "/projects", url.Values{"is_archived": []string{"true"}}, &projects)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf(logPrefix+"Got %d projects", len(projects))
+
+	for _, p := range projects {
+		if p.ID == models.FavoritesPseudoProject.ID {
+			continue
+		}
+
+		if p.ID < 0 {
+			// Saved filters are returned as pseudo projects with a negative id
+			filterID := p.ID*-1 - 1
+			body, _, err := m.get("/filters/"+strconv.FormatInt(filterID, 10), nil)
+			if err != nil {
+				return nil, err
+			}
+			filter := &models.SavedFilter{}
+			err = json.Unmarshal(body, filter)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse saved filter %d: %w", filterID, err)
+			}
+			if filter.Owner != nil {
+				data.owner = filter.Owner
+			}
+			data.filters = append(data.filters, filter)
+			continue
+		}
+
+		project, err := m.getProject(p, data)
+		if err != nil {
+			return nil, err
+		}
+		data.projects = append(data.projects, project)
+	}
+
+	return data, nil
+}
+
+func (m *Migration) getProject(p *models.Project, data *instanceData) (project *models.ProjectWithTasksAndBuckets, err error) {
+	project = &models.ProjectWithTasksAndBuckets{Project: *p}
+	projectPath := "/projects/" + strconv.FormatInt(p.ID, 10)
+
+	if p.BackgroundBlurHash != "" || p.BackgroundInformation != nil {
+		background, _, err := m.get(projectPath+"/background", nil)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			project.BackgroundInformation = bytes.NewBuffer(background)
+		}
+	}
+
+	// The pagination of buckets applies to the tasks in them, the buckets themselves are always returned all at once.
+	body, _, err := m.get(projectPath+"/buckets", nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(body, &project.Buckets)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse buckets of project %d: %w", p.ID, err)
+	}
+	for _, b := range project.Buckets {
+		b.Tasks = nil
+	}
+
+	tasks := []*models.Task{}
+	err = getAll(m, projectPath+"/tasks", url.Values{"sort_by": []string{"id"}, "order_by": []string{"asc"}}, &tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf(logPrefix+"Got %d tasks for project %d", len(tasks), p.ID)
+
+	for _, t := range tasks {
+		task := &models.TaskWithComments{Task: *t}
+		taskPath := "/tasks/" + strconv.FormatInt(t.ID, 10)
+
+		// Relations are created once all tasks exist
+		if len(t.RelatedTasks) > 0 {
+			data.relations[t.ID] = t.RelatedTasks
+		}
+		task.RelatedTasks = nil
+		task.Assignees = nil
+		task.CoverImageAttachmentID = 0
+		for _, l := range task.Labels {
+			data.labels[l.ID] = l.Title + l.HexColor
+			l.ID = 0
+		}
+
+		err = getAll(m, taskPath+"/comments", nil, &task.Comments)
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+
+		attachments := make([]*models.TaskAttachment, 0, len(task.Attachments))
+		for _, a := range task.Attachments {
+			if a.File == nil {
+				// The file of the attachment does not exist anymore on the other instance
+				continue
+			}
+			content, _, err := m.get(taskPath+"/attachments/"+strconv.FormatInt(a.ID, 10), nil)
+			if err != nil {
+				return nil, err
+			}
+			a.ID = 0
+			a.File.ID = 0
+			a.File.FileContent = content
+			a.File.Size = uint64(len(content))
+			attachments = append(attachments, a)
+		}
+		task.Attachments = attachments
+
+		project.Tasks = append(project.Tasks, task)
+	}
+
+	return project, nil
+}
+
+// buildProjectTree puts all projects below their parent project. Projects with a parent which is not part of the
+// migration become top level projects.
+func buildProjectTree(projects []*models.ProjectWithTasksAndBuckets) (tree []*models.ProjectWithTasksAndBuckets) {
+	byID := make(map[int64]*models.ProjectWithTasksAndBuckets, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+
+	for _, p := range projects {
+		parent, has := byID[p.ParentProjectID]
+		p.ParentProjectID = 0
+		if has && parent != p {
+			parent.ChildProjects = append(parent.ChildProjects, p)
+			continue
+		}
+		tree = append(tree, p)
+	}
+
+	return
+}
+
+// idMaps holds the ids of everything which was created, with the id on the other instance as key.
+type idMaps struct {
+	projects map[int64]int64
+	tasks    map[int64]int64
+	buckets  map[int64]int64
+	labels   map[int64]int64
+	users    map[int64]int64
+	// Assignees are filtered by their username
+	usernames map[string]string
+}
+
+// remapFilter changes the ids in the conditions of a saved filter to the ids of what was created from them. It
+// returns false if a condition references something which was not migrated, like a project the user could not access
+// or another user.
+func remapFilter(f *models.SavedFilter, ids *idMaps) bool {
+	if f.Filters == nil {
+		return true
+	}
+
+	for i, field := range f.Filters.FilterBy {
+		if i >= len(f.Filters.FilterValue) {
+			break
+		}
+
+		var newIDs map[int64]int64
+		switch field {
+		case "id":
+			newIDs = ids.tasks
+		case "project_id":
+			newIDs = ids.projects
+		case "bucket_id":
+			newIDs = ids.buckets
+		case "labels":
+			newIDs = ids.labels
+		case "created_by_id":
+			newIDs = ids.users
+		case "assignees":
+		default:
+			continue
+		}
+
+		values := strings.Split(f.Filters.FilterValue[i], ",")
+		for j, value := range values {
+			value = strings.TrimSpace(value)
+
+			if field == "assignees" {
+				username, has := ids.usernames[value]
+				if !has {
+					return false
+				}
+				values[j] = username
+				continue
+			}
+
+			oldID, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return false
+			}
+			newID, has := newIDs[oldID]
+			if !has {
+				return false
+			}
+			values[j] = strconv.FormatInt(newID, 10)
+		}
+		f.Filters.FilterValue[i] = strings.Join(values, ",")
+	}
+
+	return true
+}
+
+// createRelations creates the relations between all tasks once they exist with their new ids.
+func createRelations(s *xorm.Session, data *instanceData, tasksByOldID map[int64]*models.TaskWithComments, u *user.User) (err error) {
+	for oldID, relations := range data.relations {
+		task, has := tasksByOldID[oldID]
+		if !has {
+			continue
+		}
+		for kind, others := range relations {
+			for _, other := range others {
+				otherTask, has := tasksByOldID[other.ID]
+				if !has {
+					// The other task is in a project the user can't access on the other instance
+					continue
+				}
+
+				rel := &models.TaskRelation{
+					TaskID:       task.ID,
+					OtherTaskID:  otherTask.ID,
+					RelationKind: kind,
+				}
+				err = rel.Create(s, u)
+				if err != nil && !models.IsErrRelationAlreadyExists(err) {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// getIDMaps puts together the new ids of everything which was created. It needs the ids of the projects and buckets
+// on the other instance in the order of data.projects, those are replaced with the new ones during the migration.
+func getIDMaps(data *instanceData, oldProjectIDs []int64, oldBucketIDs map[int64][]int64, tasksByOldID map[int64]*models.TaskWithComments, u *user.User) *idMaps {
+	ids := &idMaps{
+		projects:  make(map[int64]int64, len(data.projects)),
+		tasks:     make(map[int64]int64, len(tasksByOldID)),
+		buckets:   make(map[int64]int64),
+		labels:    make(map[int64]int64, len(data.labels)),
+		users:     make(map[int64]int64),
+		usernames: make(map[string]string),
+	}
+
+	labelsByTitle := make(map[string]int64)
+	for i, p := range data.projects {
+		ids.projects[oldProjectIDs[i]] = p.ID
+		for j, oldID := range oldBucketIDs[oldProjectIDs[i]] {
+			if j < len(p.Buckets) {
+				ids.buckets[oldID] = p.Buckets[j].ID
+			}
+		}
+		for _, t := range p.Tasks {
+			for _, l := range t.Labels {
+				// Labels which already existed keep the id 0 in the task
+				if l != nil && l.ID != 0 {
+					labelsByTitle[l.Title+l.HexColor] = l.ID
+				}
+			}
+		}
+	}
+	for oldID, t := range tasksByOldID {
+		ids.tasks[oldID] = t.ID
+	}
+	for oldID, title := range data.labels {
+		if newID, has := labelsByTitle[title]; has {
+			ids.labels[oldID] = newID
+		}
+	}
+	if data.owner != nil {
+		ids.users[data.owner.ID] = u.ID
+		ids.usernames[data.owner.Username] = u.Username
+	}
+
+	return ids
+}
+
+// Migrate gets all projects, tasks and everything else from another Vikunja instance and creates them for the user.
+// @Summary Migrate all projects, tasks etc. from another Vikunja instance
+// @Description Copies all projects, tasks, kanban buckets, labels, comments, attachments, reminders, task relations and saved filters of the owner of the api token from another Vikunja instance. Saved filters which reference projects, tasks, labels or users which were not migrated are skipped and reported as warnings of the migration job.
+// @tags migration
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param migrationCode body vikunjainstance.Migration true "The url of the other Vikunja instance and an api token of the user there."
+// @Success 200 {object} models.Message "A message telling you everything was migrated successfully."
+// @Failure 400 {object} web.HTTPError "The url or api token is missing or the api token was not accepted."
+// @Failure 500 {object} models.Message "Internal server error"
+// @Router /migration/vikunja-instance/migrate [post]
+func (m *Migration) Migrate(u *user.User) (err error) {
+	if m.URL == "" || m.Token == "" {
+		return models.ErrInvalidData{Message: "You need to provide the url of the other Vikunja instance and an api token."}
+	}
+
+	err = utils.CheckURLAddress(m.URL, config.MigrationVikunjaInstanceAllowedHosts.GetStringSlice(), errAddressNotAllowed)
+	if errors.Is(err, errAddressNotAllowed) {
+		return models.ErrInvalidData{Message: "The other Vikunja instance is at an internal address, which is not allowed."}
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(logPrefix+"Starting migration from %s for user %d", m.URL, u.ID)
+
+	m.ctx = migration.JobContext(u)
+
+	data, err := m.getData()
+	if err != nil {
+		return err
+	}
+
+	// Keep the tasks of every project to get their new ids after they were created
+	tasksByOldID := make(map[int64]*models.TaskWithComments)
+	oldProjectIDs := make([]int64, 0, len(data.projects))
+	oldBucketIDs := make(map[int64][]int64, len(data.projects))
+	// The done and default bucket ids refer to the buckets on the other instance, they are set again once the
+	// buckets were created.
+	oldDoneBucketIDs := make([]int64, 0, len(data.projects))
+	oldDefaultBucketIDs := make([]int64, 0, len(data.projects))
+	for _, p := range data.projects {
+		for _, t := range p.Tasks {
+			tasksByOldID[t.ID] = t
+		}
+		oldProjectIDs = append(oldProjectIDs, p.ID)
+		for _, b := range p.Buckets {
+			oldBucketIDs[p.ID] = append(oldBucketIDs[p.ID], b.ID)
+		}
+		oldDoneBucketIDs = append(oldDoneBucketIDs, p.DoneBucketID)
+		oldDefaultBucketIDs = append(oldDefaultBucketIDs, p.DefaultBucketID)
+		p.DoneBucketID = 0
+		p.DefaultBucketID = 0
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	err = migration.InsertFromStructureWithSession(s, buildProjectTree(data.projects), u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	ids := getIDMaps(data, oldProjectIDs, oldBucketIDs, tasksByOldID, u)
+
+	for i, p := range data.projects {
+		p.DoneBucketID = ids.buckets[oldDoneBucketIDs[i]]
+		p.DefaultBucketID = ids.buckets[oldDefaultBucketIDs[i]]
+		if p.DoneBucketID == 0 && p.DefaultBucketID == 0 {
+			continue
+		}
+		_, err = s.
+			Where("id = ?", p.ID).
+			Cols("done_bucket_id", "default_bucket_id").
+			Update(&p.Project)
+		if err != nil {
+			_ = s.Rollback()
+			return err
+		}
+	}
+
+	err = createRelations(s, data, tasksByOldID, u)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	log.Debugf(logPrefix+"Importing %d saved filters", len(data.filters))
+
+	for _, f := range data.filters {
+		if !remapFilter(f, ids) {
+			log.Debugf(logPrefix+"Saved filter %d references something which was not migrated, not migrating it", f.ID)
+			migration.Warn(u, "The saved filter \"%s\" references projects, tasks, labels or users which were not migrated, it was not migrated.", f.Title)
+			continue
+		}
+
+		f.ID = 0
+		err = f.Create(s, u)
+		if err != nil {
+			_ = s.Rollback()
+			return err
+		}
+	}
+
+	err = s.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(logPrefix+"Migration from %s for user %d done", m.URL, u.ID)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package vikunjainstance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/projects" {
+			w.Header().Set("x-pagination-total-pages", "2")
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"database password is hunter2"}`))
+	}))
+	defer server.Close()
+
+	m := &Migration{URL: server.URL, Token: "tk_test"}
+
+	t.Run("internal address", func(t *testing.T) {
+		_, _, err := m.get("/projects", nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errAddressNotAllowed)
+
+		err = m.Migrate(&user.User{ID: 1})
+		require.Error(t, err)
+		assert.True(t, models.IsErrInvalidData(err))
+	})
+
+	config.MigrationVikunjaInstanceAllowedHosts.Set([]string{"127.0.0.1"})
+	defer config.MigrationVikunjaInstanceAllowedHosts.Set([]string{})
+
+	t.Run("normal", func(t *testing.T) {
+		body, totalPages, err := m.get("/projects", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "[]", string(body))
+		assert.Equal(t, 2, totalPages)
+	})
+	t.Run("failed request", func(t *testing.T) {
+		_, _, err := m.get("/tasks/1", nil)
+		require.Error(t, err)
+		assert.Equal(t, "Vikunja API Error: Request to /tasks/1 failed with status 500", err.Error())
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+}
+
+func TestRemapFilter(t *testing.T) {
+	ids := &idMaps{
+		projects:  map[int64]int64{1: 101, 2: 102},
+		tasks:     map[int64]int64{1: 201},
+		buckets:   map[int64]int64{1: 301},
+		labels:    map[int64]int64{1: 401},
+		users:     map[int64]int64{1: 501},
+		usernames: map[string]string{"user1": "newuser"},
+	}
+
+	t.Run("all ids", func(t *testing.T) {
+		f := &models.SavedFilter{Filters: &models.TaskCollection{
+			FilterBy:    []string{"project_id", "id", "bucket_id", "labels", "created_by_id", "assignees", "done"},
+			FilterValue: []string{"1, 2", "1", "1", "1", "1", "user1", "false"},
+		}}
+		assert.True(t, remapFilter(f, ids))
+		assert.Equal(t, []string{"101,102", "201", "301", "401", "501", "newuser", "false"}, f.Filters.FilterValue)
+	})
+	t.Run("project which was not migrated", func(t *testing.T) {
+		f := &models.SavedFilter{Filters: &models.TaskCollection{
+			FilterBy:    []string{"project_id"},
+			FilterValue: []string{"1,3"},
+		}}
+		assert.False(t, remapFilter(f, ids))
+	})
+	t.Run("other user", func(t *testing.T) {
+		f := &models.SavedFilter{Filters: &models.TaskCollection{
+			FilterBy:    []string{"assignees"},
+			FilterValue: []string{"user2"},
+		}}
+		assert.False(t, remapFilter(f, ids))
+	})
+}
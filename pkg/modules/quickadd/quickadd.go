@@ -0,0 +1,556 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package quickadd
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jszwedko/go-datemath"
+)
+
+// TokenType is the kind of task property a token sets
+type TokenType string
+
+// All token types
+const (
+	TokenTypeLabel    TokenType = "label"
+	TokenTypeAssignee TokenType = "assignee"
+	TokenTypeProject  TokenType = "project"
+	TokenTypePriority TokenType = "priority"
+	TokenTypeDate     TokenType = "date"
+	TokenTypeRepeat   TokenType = "repeat"
+)
+
+// Token is a part of the text which was recognized as a task property.
+type Token struct {
+	// The kind of task property this token sets.
+	Type TokenType `json:"type"`
+	// The part of the text this token was parsed from, exactly as it was written.
+	Text string `json:"text"`
+	// The position of the first character of the token in the text.
+	Start int `json:"start"`
+	// The position after the last character of the token in the text.
+	End int `json:"end"`
+	// The title of a label or project or the username of an assignee.
+	Value string `json:"value,omitempty"`
+	// The priority, between 1 and 5.
+	Priority int64 `json:"priority,omitempty"`
+	// The due date.
+	Date *time.Time `json:"date,omitempty"`
+	// The repeat interval in seconds.
+	RepeatAfter int64 `json:"repeat_after,omitempty"`
+	// Whether the task repeats every month, in which case RepeatAfter is not set.
+	RepeatMonthly bool `json:"repeat_monthly,omitempty"`
+
+	// Byte offsets of the token in the text
+	start int
+	end   int
+}
+
+const (
+	prefixLabel    = "*"
+	prefixAssignee = "@"
+	prefixProject  = "+"
+	prefixPriority = "!"
+
+	maxPriority = 5
+
+	// Dates without a time are due at noon.
+	defaultHour = 12
+)
+
+const (
+	hour  = int64(time.Hour / time.Second)
+	day   = 24 * hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+var (
+	prefixRegex = regexp.MustCompile(`(?:^|\s)([*@+!])(?:"([^"]+)"|'([^']+)'|(\S+))`)
+
+	repeatRegex = regexp.MustCompile(`(?i)\b(?:every\s+(?:(other)\s+|(\d+)\s+)?(hour|day|week|month|year)s?|(hourly|daily|weekly|monthly|yearly|annually))\b`)
+
+	monthNames = `(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept|sep|oct|nov|dec)`
+
+	weekdays = map[string]time.Weekday{
+		"sunday":    time.Sunday,
+		"monday":    time.Monday,
+		"tuesday":   time.Tuesday,
+		"wednesday": time.Wednesday,
+		"thursday":  time.Thursday,
+		"friday":    time.Friday,
+		"saturday":  time.Saturday,
+	}
+
+	numberWords = map[string]int{
+		"a":     1,
+		"an":    1,
+		"one":   1,
+		"two":   2,
+		"three": 3,
+		"four":  4,
+		"five":  5,
+		"six":   6,
+		"seven": 7,
+		"eight": 8,
+		"nine":  9,
+		"ten":   10,
+	}
+)
+
+// A dateMatcher finds one way to write a date. If hasTime is true, the parsed date already contains a time.
+type dateMatcher struct {
+	regex   *regexp.Regexp
+	hasTime bool
+	parse   func(m []string, now time.Time) (date time.Time, ok bool)
+}
+
+var dateMatchers = []*dateMatcher{
+	{
+		// Date math like now+1d or now/w, see https://github.com/jszwedko/go-datemath
+		regex:   regexp.MustCompile(`\bnow(?:[+-]\d+[yMwdhHms]|/[yMwdhHms])+`),
+		hasTime: true,
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			t, err := datemath.ParseAndEvaluate(m[0], datemath.WithNow(now), datemath.WithLocation(now.Location()))
+			return t, err == nil
+		},
+	},
+	{
+		// 2021-02-17
+		regex: regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{4})-(\d{1,2})-(\d{1,2})\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return makeDate(atoi(m[1]), atoi(m[2]), atoi(m[3]), now.Location())
+		},
+	},
+	{
+		// 17.02.2021
+		regex: regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{1,2})\.(\d{1,2})\.(\d{4})\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return makeDate(atoi(m[3]), atoi(m[2]), atoi(m[1]), now.Location())
+		},
+	},
+	{
+		// 02/17/2021
+		regex: regexp.MustCompile(`(?i)\b(?:on\s+)?(\d{1,2})/(\d{1,2})/(\d{4})\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return makeDate(atoi(m[3]), atoi(m[1]), atoi(m[2]), now.Location())
+		},
+	},
+	{
+		// Feb 17, February 17th 2021
+		regex: regexp.MustCompile(`(?i)\b(?:on\s+)?` + monthNames + `\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s+(\d{4})\b)?`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return makeDateWithMonthName(m[3], m[1], m[2], now)
+		},
+	},
+	{
+		// 17 Feb, 17th of February 2021
+		regex: regexp.MustCompile(`(?i)\b(?:on\s+)?(?:the\s+)?(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?` + monthNames + `\b(?:\s+(\d{4})\b)?`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return makeDateWithMonthName(m[3], m[2], m[1], now)
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\b(?:the\s+)?day\s+after\s+tomorrow\b`),
+		parse: func(_ []string, now time.Time) (time.Time, bool) {
+			return startOfDay(now).AddDate(0, 0, 2), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\btoday\b`),
+		parse: func(_ []string, now time.Time) (time.Time, bool) {
+			return startOfDay(now), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\btomorrow\b`),
+		parse: func(_ []string, now time.Time) (time.Time, bool) {
+			return startOfDay(now).AddDate(0, 0, 1), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\bnext\s+(week|month|year)\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			switch strings.ToLower(m[1]) {
+			case "week":
+				return startOfDay(now).AddDate(0, 0, 7), true
+			case "month":
+				return startOfDay(now).AddDate(0, 1, 0), true
+			default:
+				return startOfDay(now).AddDate(1, 0, 0), true
+			}
+		},
+	},
+	{
+		// in 3 days, in a week
+		regex: regexp.MustCompile(`(?i)\bin\s+(\d+|a|an|one|two|three|four|five|six|seven|eight|nine|ten)\s+(hour|day|week|month|year)s?\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			amount, has := numberWords[strings.ToLower(m[1])]
+			if !has {
+				amount = atoi(m[1])
+			}
+			switch strings.ToLower(m[2]) {
+			case "hour":
+				// Handled by the matcher below, because it includes a time
+				return time.Time{}, false
+			case "day":
+				return startOfDay(now).AddDate(0, 0, amount), true
+			case "week":
+				return startOfDay(now).AddDate(0, 0, 7*amount), true
+			case "month":
+				return startOfDay(now).AddDate(0, amount, 0), true
+			default:
+				return startOfDay(now).AddDate(amount, 0, 0), true
+			}
+		},
+	},
+	{
+		regex:   regexp.MustCompile(`(?i)\bin\s+(\d+|an|a|one|two|three|four|five|six|seven|eight|nine|ten)\s+hours?\b`),
+		hasTime: true,
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			amount, has := numberWords[strings.ToLower(m[1])]
+			if !has {
+				amount = atoi(m[1])
+			}
+			return now.Add(time.Duration(amount) * time.Hour).Truncate(time.Minute), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\bthis\s+weekend\b`),
+		parse: func(_ []string, now time.Time) (time.Time, bool) {
+			return nextWeekday(now, time.Saturday, true), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\bend\s+of\s+(?:the\s+)?month\b`),
+		parse: func(_ []string, now time.Time) (time.Time, bool) {
+			firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+			return firstOfMonth.AddDate(0, 1, -1), true
+		},
+	},
+	{
+		regex: regexp.MustCompile(`(?i)\b(?:(?:next|on)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`),
+		parse: func(m []string, now time.Time) (time.Time, bool) {
+			return nextWeekday(now, weekdays[strings.ToLower(m[1])], false), true
+		},
+	},
+}
+
+var timeRegexes = []*regexp.Regexp{
+	// 5pm, at 5:30 am
+	regexp.MustCompile(`(?i)\b(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)\b`),
+	// 17:30, at 17:30
+	regexp.MustCompile(`(?i)\b(?:at\s+)?([01]?\d|2[0-3]):([0-5]\d)\b()`),
+	// at 17
+	regexp.MustCompile(`(?i)\bat\s+([01]?\d|2[0-3])\b()()`),
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// makeDate returns the date if it exists, time.Date would silently normalize the 31st of February.
+func makeDate(y, m, d int, loc *time.Location) (time.Time, bool) {
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, loc)
+	if t.Year() != y || t.Month() != time.Month(m) || t.Day() != d {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// makeDateWithMonthName parses a date with a month name. Without a year, the next date in the future is used.
+func makeDateWithMonthName(rawYear, monthName, rawDay string, now time.Time) (time.Time, bool) {
+	monthName = strings.ToLower(monthName)
+	var m time.Month
+	for i := time.January; i <= time.December; i++ {
+		if strings.HasPrefix(strings.ToLower(i.String()), monthName[:3]) {
+			m = i
+			break
+		}
+	}
+
+	if rawYear != "" {
+		return makeDate(atoi(rawYear), int(m), atoi(rawDay), now.Location())
+	}
+
+	t, ok := makeDate(now.Year(), int(m), atoi(rawDay), now.Location())
+	if ok && t.Before(startOfDay(now)) {
+		return makeDate(now.Year()+1, int(m), atoi(rawDay), now.Location())
+	}
+	return t, ok
+}
+
+// nextWeekday returns the next day with that weekday. If includeToday is true and today is that weekday, today is returned.
+func nextWeekday(now time.Time, weekday time.Weekday, includeToday bool) time.Time {
+	days := (int(weekday) - int(now.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return startOfDay(now).AddDate(0, 0, days)
+}
+
+type parser struct {
+	text   string
+	tokens []*Token
+}
+
+func (p *parser) isFree(start, end int) bool {
+	for _, t := range p.tokens {
+		if start < t.end && end > t.start {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) add(t *Token, start, end int) {
+	t.start = start
+	t.end = end
+	t.Text = p.text[start:end]
+	t.Start = utf8.RuneCountInString(p.text[:start])
+	t.End = t.Start + utf8.RuneCountInString(t.Text)
+	p.tokens = append(p.tokens, t)
+}
+
+// findFree returns the first match of the regex which does not overlap with an existing token.
+func (p *parser) findFree(regex *regexp.Regexp) (match []string, start, end int) {
+	for _, loc := range regex.FindAllStringSubmatchIndex(p.text, -1) {
+		if !p.isFree(loc[0], loc[1]) {
+			continue
+		}
+		match = make([]string, len(loc)/2)
+		for i := range match {
+			if loc[2*i] >= 0 {
+				match[i] = p.text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		return match, loc[0], loc[1]
+	}
+	return nil, -1, -1
+}
+
+func (p *parser) parsePrefixes() {
+	var hasProject, hasPriority bool
+	for _, loc := range prefixRegex.FindAllStringSubmatchIndex(p.text, -1) {
+		start := loc[2]
+		prefix := p.text[loc[2]:loc[3]]
+		var value string
+		for i := 4; i < len(loc); i += 2 {
+			if loc[i] >= 0 {
+				value = p.text[loc[i]:loc[i+1]]
+				break
+			}
+		}
+
+		switch prefix {
+		case prefixLabel:
+			p.add(&Token{Type: TokenTypeLabel, Value: value}, start, loc[1])
+		case prefixAssignee:
+			p.add(&Token{Type: TokenTypeAssignee, Value: value}, start, loc[1])
+		case prefixProject:
+			// Only the first project is used, the task can only be in one
+			if hasProject {
+				continue
+			}
+			hasProject = true
+			p.add(&Token{Type: TokenTypeProject, Value: value}, start, loc[1])
+		case prefixPriority:
+			priority, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || priority < 1 || priority > maxPriority || hasPriority {
+				continue
+			}
+			hasPriority = true
+			p.add(&Token{Type: TokenTypePriority, Priority: priority}, start, loc[1])
+		}
+	}
+}
+
+func (p *parser) parseRepeat() {
+	m, start, end := p.findFree(repeatRegex)
+	if m == nil {
+		return
+	}
+
+	unit := strings.ToLower(m[3])
+	amount := int64(1)
+	switch {
+	case m[1] != "":
+		amount = 2
+	case m[2] != "":
+		amount = int64(atoi(m[2]))
+	}
+
+	switch strings.ToLower(m[4]) {
+	case "hourly":
+		unit = "hour"
+	case "daily":
+		unit = "day"
+	case "weekly":
+		unit = "week"
+	case "monthly":
+		unit = "month"
+	case "yearly", "annually":
+		unit = "year"
+	}
+
+	if amount < 1 {
+		return
+	}
+
+	token := &Token{Type: TokenTypeRepeat}
+	switch unit {
+	case "hour":
+		token.RepeatAfter = amount * hour
+	case "day":
+		token.RepeatAfter = amount * day
+	case "week":
+		token.RepeatAfter = amount * week
+	case "month":
+		if amount == 1 {
+			token.RepeatMonthly = true
+		} else {
+			token.RepeatAfter = amount * month
+		}
+	case "year":
+		token.RepeatAfter = amount * year
+	}
+
+	p.add(token, start, end)
+}
+
+func (p *parser) parseDate(now time.Time) {
+	var date time.Time
+	var hasDate, hasTime bool
+	var dateToken *Token
+
+	for _, matcher := range dateMatchers {
+		m, start, end := p.findFree(matcher.regex)
+		if m == nil {
+			continue
+		}
+		parsed, ok := matcher.parse(m, now)
+		if !ok {
+			continue
+		}
+
+		date = parsed
+		hasDate = true
+		hasTime = matcher.hasTime
+		dateToken = &Token{Type: TokenTypeDate}
+		p.add(dateToken, start, end)
+		break
+	}
+
+	var timeToken *Token
+	if !hasTime {
+		for _, regex := range timeRegexes {
+			m, start, end := p.findFree(regex)
+			if m == nil {
+				continue
+			}
+
+			h := atoi(m[1])
+			minute := atoi(m[2])
+			switch strings.ToLower(m[3]) {
+			case "am":
+				if h == 12 {
+					h = 0
+				}
+			case "pm":
+				if h < 12 {
+					h += 12
+				}
+			}
+			if h > 23 || minute > 59 {
+				continue
+			}
+
+			if !hasDate {
+				date = startOfDay(now)
+				hasDate = true
+			}
+			date = time.Date(date.Year(), date.Month(), date.Day(), h, minute, 0, 0, date.Location())
+			hasTime = true
+			timeToken = &Token{Type: TokenTypeDate}
+			p.add(timeToken, start, end)
+			break
+		}
+	}
+
+	if !hasDate {
+		return
+	}
+	if !hasTime {
+		date = date.Add(defaultHour * time.Hour)
+	}
+
+	for _, t := range []*Token{dateToken, timeToken} {
+		if t != nil {
+			d := date
+			t.Date = &d
+		}
+	}
+}
+
+// Parse finds all task properties in a quick add text. Dates are relative to now and in its location.
+// The tokens are sorted by their position in the text.
+func Parse(text string, now time.Time) []*Token {
+	p := &parser{text: text}
+	p.parsePrefixes()
+	p.parseRepeat()
+	p.parseDate(now)
+
+	sort.Slice(p.tokens, func(i, j int) bool {
+		return p.tokens[i].start < p.tokens[j].start
+	})
+	return p.tokens
+}
+
+// Title returns the text without the tokens, which is what remains as the title of the task.
+func Title(text string, tokens []*Token) string {
+	sorted := make([]*Token, len(tokens))
+	copy(sorted, tokens)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start < sorted[j].start
+	})
+
+	var b strings.Builder
+	last := 0
+	for _, t := range sorted {
+		if t.start < last {
+			continue
+		}
+		b.WriteString(text[last:t.start])
+		b.WriteString(" ")
+		last = t.end
+	}
+	b.WriteString(text[last:])
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,180 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Wednesday
+var now = time.Date(2021, 2, 17, 9, 30, 0, 0, time.UTC)
+
+func date(y int, m time.Month, d, h, min int) *time.Time {
+	t := time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	return &t
+}
+
+func getDate(t *testing.T, tokens []*Token) *time.Time {
+	for _, token := range tokens {
+		if token.Type == TokenTypeDate {
+			return token.Date
+		}
+	}
+	t.Fatal("no date token")
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	t.Run("everything", func(t *testing.T) {
+		text := "Buy milk tomorrow 5pm *errands @alice +Home !3 every week"
+		tokens := Parse(text, now)
+
+		assert.Equal(t, "Buy milk", Title(text, tokens))
+		require.Len(t, tokens, 7)
+
+		assert.Equal(t, TokenTypeDate, tokens[0].Type)
+		assert.Equal(t, "tomorrow", tokens[0].Text)
+		assert.Equal(t, date(2021, 2, 18, 17, 0), tokens[0].Date)
+		assert.Equal(t, 9, tokens[0].Start)
+		assert.Equal(t, 17, tokens[0].End)
+		assert.Equal(t, TokenTypeDate, tokens[1].Type)
+		assert.Equal(t, "5pm", tokens[1].Text)
+
+		assert.Equal(t, TokenTypeLabel, tokens[2].Type)
+		assert.Equal(t, "errands", tokens[2].Value)
+		assert.Equal(t, "*errands", tokens[2].Text)
+		assert.Equal(t, TokenTypeAssignee, tokens[3].Type)
+		assert.Equal(t, "alice", tokens[3].Value)
+		assert.Equal(t, TokenTypeProject, tokens[4].Type)
+		assert.Equal(t, "Home", tokens[4].Value)
+		assert.Equal(t, TokenTypePriority, tokens[5].Type)
+		assert.Equal(t, int64(3), tokens[5].Priority)
+		assert.Equal(t, TokenTypeRepeat, tokens[6].Type)
+		assert.Equal(t, week, tokens[6].RepeatAfter)
+	})
+	t.Run("nothing", func(t *testing.T) {
+		tokens := Parse("Lorem Ipsum", now)
+		assert.Empty(t, tokens)
+		assert.Equal(t, "Lorem Ipsum", Title("Lorem Ipsum", tokens))
+	})
+	t.Run("quoted label", func(t *testing.T) {
+		text := `Lorem *"my label" Ipsum *'other label'`
+		tokens := Parse(text, now)
+		require.Len(t, tokens, 2)
+		assert.Equal(t, "my label", tokens[0].Value)
+		assert.Equal(t, "other label", tokens[1].Value)
+		assert.Equal(t, "Lorem Ipsum", Title(text, tokens))
+	})
+	t.Run("label containing a date", func(t *testing.T) {
+		tokens := Parse("Lorem *tomorrow", now)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, TokenTypeLabel, tokens[0].Type)
+	})
+	t.Run("only the first project", func(t *testing.T) {
+		text := "Lorem +one +two"
+		tokens := Parse(text, now)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, "one", tokens[0].Value)
+		assert.Equal(t, "Lorem +two", Title(text, tokens))
+	})
+	t.Run("invalid priority", func(t *testing.T) {
+		tokens := Parse("Lorem !9 !foo", now)
+		assert.Empty(t, tokens)
+	})
+	t.Run("no prefix inside words", func(t *testing.T) {
+		tokens := Parse("mail@example.com 3*4", now)
+		assert.Empty(t, tokens)
+	})
+	t.Run("multibyte positions", func(t *testing.T) {
+		tokens := Parse("Füße *label", now)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, 5, tokens[0].Start)
+		assert.Equal(t, 11, tokens[0].End)
+	})
+}
+
+func TestParseDates(t *testing.T) {
+	tests := map[string]*time.Time{
+		"Lorem today":                  date(2021, 2, 17, 12, 0),
+		"Lorem tomorrow":               date(2021, 2, 18, 12, 0),
+		"Lorem day after tomorrow":     date(2021, 2, 19, 12, 0),
+		"Lorem next week":              date(2021, 2, 24, 12, 0),
+		"Lorem next month":             date(2021, 3, 17, 12, 0),
+		"Lorem next year":              date(2022, 2, 17, 12, 0),
+		"Lorem in 3 days":              date(2021, 2, 20, 12, 0),
+		"Lorem in a week":              date(2021, 2, 24, 12, 0),
+		"Lorem in 2 hours":             date(2021, 2, 17, 11, 30),
+		"Lorem this weekend":           date(2021, 2, 20, 12, 0),
+		"Lorem end of month":           date(2021, 2, 28, 12, 0),
+		"Lorem monday":                 date(2021, 2, 22, 12, 0),
+		"Lorem next wednesday":         date(2021, 2, 24, 12, 0),
+		"Lorem on friday at 14:30":     date(2021, 2, 19, 14, 30),
+		"Lorem 2021-03-05":             date(2021, 3, 5, 12, 0),
+		"Lorem 05.03.2021":             date(2021, 3, 5, 12, 0),
+		"Lorem 03/05/2021":             date(2021, 3, 5, 12, 0),
+		"Lorem Mar 5":                  date(2021, 3, 5, 12, 0),
+		"Lorem February 1st":           date(2022, 2, 1, 12, 0),
+		"Lorem 5th of March 2023":      date(2023, 3, 5, 12, 0),
+		"Lorem at 5pm":                 date(2021, 2, 17, 17, 0),
+		"Lorem 12am":                   date(2021, 2, 17, 0, 0),
+		"Lorem 9:15 am tomorrow":       date(2021, 2, 18, 9, 15),
+		"Lorem at 18":                  date(2021, 2, 17, 18, 0),
+		"Lorem now+1d":                 date(2021, 2, 18, 9, 30),
+		"Lorem now/d":                  date(2021, 2, 17, 0, 0),
+		"Lorem tomorrow at 8:00":       date(2021, 2, 18, 8, 0),
+		"Lorem dec 24 at 7pm":          date(2021, 12, 24, 19, 0),
+		"Lorem 2021-02-30 tomorrow":    date(2021, 2, 18, 12, 0),
+		"Lorem ON 17.03.2021 AT 10 PM": date(2021, 3, 17, 22, 0),
+	}
+
+	for text, expected := range tests {
+		t.Run(text, func(t *testing.T) {
+			tokens := Parse(text, now)
+			assert.Equal(t, expected, getDate(t, tokens))
+			assert.Equal(t, "Lorem", Title(text, tokens)[:5])
+		})
+	}
+}
+
+func TestParseRepeat(t *testing.T) {
+	tests := map[string]*Token{
+		"Lorem every day":        {RepeatAfter: day},
+		"Lorem every 3 days":     {RepeatAfter: 3 * day},
+		"Lorem every other week": {RepeatAfter: 2 * week},
+		"Lorem daily":            {RepeatAfter: day},
+		"Lorem hourly":           {RepeatAfter: hour},
+		"Lorem every month":      {RepeatMonthly: true},
+		"Lorem monthly":          {RepeatMonthly: true},
+		"Lorem every 2 months":   {RepeatAfter: 2 * month},
+		"Lorem annually":         {RepeatAfter: year},
+	}
+
+	for text, expected := range tests {
+		t.Run(text, func(t *testing.T) {
+			tokens := Parse(text, now)
+			require.Len(t, tokens, 1)
+			assert.Equal(t, TokenTypeRepeat, tokens[0].Type)
+			assert.Equal(t, expected.RepeatAfter, tokens[0].RepeatAfter)
+			assert.Equal(t, expected.RepeatMonthly, tokens[0].RepeatMonthly)
+			assert.Equal(t, "Lorem", Title(text, tokens))
+		})
+	}
+}
@@ -0,0 +1,550 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package searchindex
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"code.vikunja.io/api/pkg/log"
+)
+
+const (
+	snapshotFile       = "index.json"
+	journalFilePattern = "journal-%d.json"
+	lockFile           = "index.lock"
+	rebuildFile        = "rebuild"
+
+	// The journal is merged into the snapshot once it has more entries than this
+	// or than documents in the index, whichever is larger.
+	minJournalEntriesBeforeCompaction = 1000
+
+	// How many runes of a term are used to find terms with the same prefix.
+	termPrefixLength = 2
+)
+
+// ErrLocked is returned by Open if another process has the index open already.
+var ErrLocked = errors.New("the search index is opened by another process")
+
+// Document is a single entry in the index.
+type Document struct {
+	ID int64 `json:"id"`
+	// Group is used to restrict searches to a subset of all documents, for example a project.
+	Group  int64             `json:"group"`
+	Fields map[string]string `json:"fields"`
+}
+
+type indexedDocument struct {
+	group   int64
+	terms   map[string]map[string]int // field -> term -> frequency
+	lengths map[string]int
+}
+
+type journalEntry struct {
+	Document *Document `json:"document,omitempty"`
+	DeleteID int64     `json:"delete_id,omitempty"`
+}
+
+type snapshot struct {
+	// Journal is the number of the first journal with changes which are not part of the snapshot.
+	Journal   int         `json:"journal"`
+	Documents []*Document `json:"documents"`
+}
+
+// Index is an embedded full text index. It lives in memory and is persisted to a directory
+// on disk, as a snapshot of all documents and journals of all changes since the snapshot.
+// Only one process at a time can have an index open.
+type Index struct {
+	mu sync.RWMutex
+	// Makes sure only one snapshot is written at a time
+	compactMu sync.Mutex
+
+	path   string
+	stem   func(string) string
+	boosts map[string]float64
+
+	documents map[int64]*Document
+	indexed   map[int64]*indexedDocument
+	// term -> ids of all documents containing the term
+	postings map[string]map[int64]struct{}
+	// Used to only compare search terms with terms which can match them
+	termsByPrefix map[string]map[string]struct{}
+	termsByLength map[int]map[string]struct{}
+	// field -> sum of the lengths of that field in all documents
+	fieldLengths map[string]int
+	// field -> number of documents which have the field
+	fieldDocuments map[string]int
+
+	lock           *os.File
+	journal        *os.File
+	journalNumber  int
+	journalEntries int
+}
+
+// Open opens the index in a directory, creating it if it does not exist.
+// Texts are stemmed using the rules of the given language. Matches in fields
+// with a higher boost are ranked higher, fields without a boost count with 1.
+func Open(path, language string, boosts map[string]float64) (idx *Index, err error) {
+	err = os.MkdirAll(path, 0o750)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := os.OpenFile(filepath.Join(path, lockFile), os.O_CREATE|os.O_RDWR, 0o640)
+	if err != nil {
+		return nil, err
+	}
+	err = lockFileExclusive(lock)
+	if err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	idx = &Index{
+		path:   path,
+		stem:   getStemmer(language),
+		boosts: boosts,
+		lock:   lock,
+	}
+	idx.clear()
+
+	err = idx.load()
+	if err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	idx.journal, err = idx.openJournal(idx.journalNumber)
+	if err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// RequestRebuild asks the process which has the index in a directory open to rebuild it.
+func RequestRebuild(path string) error {
+	return os.WriteFile(filepath.Join(path, rebuildFile), []byte{}, 0o640)
+}
+
+// RebuildRequested returns whether another process asked for a rebuild of the index.
+// The request is removed once the index is rebuilt through Replace.
+func (idx *Index) RebuildRequested() bool {
+	_, err := os.Stat(filepath.Join(idx.path, rebuildFile))
+	return err == nil
+}
+
+func (idx *Index) clear() {
+	idx.documents = make(map[int64]*Document)
+	idx.indexed = make(map[int64]*indexedDocument)
+	idx.postings = make(map[string]map[int64]struct{})
+	idx.termsByPrefix = make(map[string]map[string]struct{})
+	idx.termsByLength = make(map[int]map[string]struct{})
+	idx.fieldLengths = make(map[string]int)
+	idx.fieldDocuments = make(map[string]int)
+}
+
+func (idx *Index) openJournal(number int) (*os.File, error) {
+	return os.OpenFile(filepath.Join(idx.path, fmt.Sprintf(journalFilePattern, number)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640)
+}
+
+// journalNumbers returns the numbers of all journals in the index directory, in ascending order.
+func (idx *Index) journalNumbers() ([]int, error) {
+	entries, err := os.ReadDir(idx.path)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := []int{}
+	for _, e := range entries {
+		var number int
+		_, err = fmt.Sscanf(e.Name(), journalFilePattern, &number)
+		if err != nil || fmt.Sprintf(journalFilePattern, number) != e.Name() {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// removeJournalsBefore removes all journals which are part of a snapshot.
+func (idx *Index) removeJournalsBefore(number int) error {
+	numbers, err := idx.journalNumbers()
+	if err != nil {
+		return err
+	}
+	for _, n := range numbers {
+		if n >= number {
+			break
+		}
+		err = os.Remove(filepath.Join(idx.path, fmt.Sprintf(journalFilePattern, n)))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (idx *Index) load() error {
+	f, err := os.Open(filepath.Join(idx.path, snapshotFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		snap := &snapshot{}
+		err = json.NewDecoder(f).Decode(snap)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		for _, doc := range snap.Documents {
+			idx.put(doc)
+		}
+		idx.journalNumber = snap.Journal
+	}
+
+	// Journals from before the snapshot are left behind if Vikunja was stopped while compacting the index
+	err = idx.removeJournalsBefore(idx.journalNumber)
+	if err != nil {
+		return err
+	}
+
+	numbers, err := idx.journalNumbers()
+	if err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		complete, err := idx.loadJournal(number)
+		if err != nil {
+			return err
+		}
+		idx.journalNumber = number
+		if !complete {
+			// Don't append new changes after an incomplete entry
+			idx.journalNumber++
+		}
+	}
+
+	return nil
+}
+
+func (idx *Index) loadJournal(number int) (complete bool, err error) {
+	j, err := os.Open(filepath.Join(idx.path, fmt.Sprintf(journalFilePattern, number)))
+	if err != nil {
+		return false, err
+	}
+	defer j.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(j))
+	for {
+		entry := &journalEntry{}
+		err = decoder.Decode(entry)
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			// The last entry might be incomplete if Vikunja was stopped while writing it
+			log.Warningf("Could not read the search index journal, ignoring all following changes in it: %s", err)
+			return false, nil
+		}
+		idx.apply(entry)
+		idx.journalEntries++
+	}
+}
+
+func (idx *Index) apply(entry *journalEntry) {
+	if entry.Document != nil {
+		idx.put(entry.Document)
+		return
+	}
+	idx.remove(entry.DeleteID)
+}
+
+func termPrefix(term string) string {
+	end := 0
+	for i := 0; i < termPrefixLength && end < len(term); i++ {
+		_, size := utf8.DecodeRuneInString(term[end:])
+		end += size
+	}
+	return term[:end]
+}
+
+func addToTermSet[K comparable](sets map[K]map[string]struct{}, key K, term string) {
+	if sets[key] == nil {
+		sets[key] = make(map[string]struct{})
+	}
+	sets[key][term] = struct{}{}
+}
+
+func removeFromTermSet[K comparable](sets map[K]map[string]struct{}, key K, term string) {
+	delete(sets[key], term)
+	if len(sets[key]) == 0 {
+		delete(sets, key)
+	}
+}
+
+func (idx *Index) put(doc *Document) {
+	idx.remove(doc.ID)
+
+	indexed := &indexedDocument{
+		group:   doc.Group,
+		terms:   make(map[string]map[string]int, len(doc.Fields)),
+		lengths: make(map[string]int, len(doc.Fields)),
+	}
+	for field, text := range doc.Fields {
+		terms, length := analyze(text, idx.stem)
+		if length == 0 {
+			continue
+		}
+		indexed.terms[field] = terms
+		indexed.lengths[field] = length
+		idx.fieldLengths[field] += length
+		idx.fieldDocuments[field]++
+
+		for term := range terms {
+			if idx.postings[term] == nil {
+				idx.postings[term] = make(map[int64]struct{})
+				addToTermSet(idx.termsByPrefix, termPrefix(term), term)
+				addToTermSet(idx.termsByLength, utf8.RuneCountInString(term), term)
+			}
+			idx.postings[term][doc.ID] = struct{}{}
+		}
+	}
+
+	idx.documents[doc.ID] = doc
+	idx.indexed[doc.ID] = indexed
+}
+
+func (idx *Index) remove(id int64) {
+	indexed, has := idx.indexed[id]
+	if !has {
+		return
+	}
+
+	for field, terms := range indexed.terms {
+		idx.fieldLengths[field] -= indexed.lengths[field]
+		idx.fieldDocuments[field]--
+		for term := range terms {
+			delete(idx.postings[term], id)
+			if len(idx.postings[term]) == 0 {
+				delete(idx.postings, term)
+				removeFromTermSet(idx.termsByPrefix, termPrefix(term), term)
+				removeFromTermSet(idx.termsByLength, utf8.RuneCountInString(term), term)
+			}
+		}
+	}
+
+	delete(idx.documents, id)
+	delete(idx.indexed, id)
+}
+
+// writeJournal appends an entry to the journal and returns whether the index should be compacted.
+// Must be called with mu held.
+func (idx *Index) writeJournal(entry *journalEntry) (compact bool, err error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = idx.journal.Write(append(line, '\n'))
+	if err != nil {
+		return false, err
+	}
+	idx.journalEntries++
+
+	return idx.journalEntries > minJournalEntriesBeforeCompaction && idx.journalEntries > len(idx.documents), nil
+}
+
+// rotateJournal starts a new journal and returns all current documents, which are the state of
+// the index before the new journal. Must be called with mu held.
+func (idx *Index) rotateJournal() (docs []*Document, journalNumber int, err error) {
+	journal, err := idx.openJournal(idx.journalNumber + 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = idx.journal.Close()
+	if err != nil {
+		_ = journal.Close()
+		return nil, 0, err
+	}
+
+	idx.journal = journal
+	idx.journalNumber++
+	idx.journalEntries = 0
+
+	docs = make([]*Document, 0, len(idx.documents))
+	for _, doc := range idx.documents {
+		docs = append(docs, doc)
+	}
+	return docs, idx.journalNumber, nil
+}
+
+// writeSnapshot writes all documents into a new snapshot and removes the journals which are part of it.
+// Must be called with compactMu held.
+func (idx *Index) writeSnapshot(docs []*Document, journalNumber int) error {
+	snap := &snapshot{
+		Journal:   journalNumber,
+		Documents: docs,
+	}
+
+	tmp := filepath.Join(idx.path, snapshotFile+".tmp")
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = json.NewEncoder(w).Encode(snap)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	err = os.Rename(tmp, filepath.Join(idx.path, snapshotFile))
+	if err != nil {
+		return err
+	}
+
+	return idx.removeJournalsBefore(journalNumber)
+}
+
+// compact merges the journal into the snapshot. Searches and changes are only blocked
+// while a new journal is started, not while the snapshot is written.
+// Must be called with compactMu held.
+func (idx *Index) compact() error {
+	idx.mu.Lock()
+	docs, journalNumber, err := idx.rotateJournal()
+	idx.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return idx.writeSnapshot(docs, journalNumber)
+}
+
+func (idx *Index) compactUnlessRunning() error {
+	if !idx.compactMu.TryLock() {
+		return nil
+	}
+	defer idx.compactMu.Unlock()
+	return idx.compact()
+}
+
+// Put adds a document to the index or replaces it if a document with the same id already exists.
+func (idx *Index) Put(doc *Document) error {
+	idx.mu.Lock()
+	idx.put(doc)
+	compact, err := idx.writeJournal(&journalEntry{Document: doc})
+	idx.mu.Unlock()
+	if err != nil || !compact {
+		return err
+	}
+
+	return idx.compactUnlessRunning()
+}
+
+// Delete removes a document from the index.
+func (idx *Index) Delete(id int64) error {
+	idx.mu.Lock()
+	if _, has := idx.documents[id]; !has {
+		idx.mu.Unlock()
+		return nil
+	}
+
+	idx.remove(id)
+	compact, err := idx.writeJournal(&journalEntry{DeleteID: id})
+	idx.mu.Unlock()
+	if err != nil || !compact {
+		return err
+	}
+
+	return idx.compactUnlessRunning()
+}
+
+// Replace removes all documents from the index and adds the given ones instead.
+func (idx *Index) Replace(docs []*Document) error {
+	idx.compactMu.Lock()
+	defer idx.compactMu.Unlock()
+
+	idx.mu.Lock()
+	idx.clear()
+	for _, doc := range docs {
+		idx.put(doc)
+	}
+	snapshotDocs, journalNumber, err := idx.rotateJournal()
+	idx.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = idx.writeSnapshot(snapshotDocs, journalNumber)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(idx.path, rebuildFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Count returns the number of documents in the index.
+func (idx *Index) Count() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.documents)
+}
+
+// Close writes all pending changes into the snapshot and closes the index,
+// so that other processes can open it.
+func (idx *Index) Close() error {
+	idx.compactMu.Lock()
+	defer idx.compactMu.Unlock()
+
+	err := idx.compact()
+	if err != nil {
+		return err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	err = idx.journal.Close()
+	if err != nil {
+		return err
+	}
+	return idx.lock.Close()
+}
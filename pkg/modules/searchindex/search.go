@@ -0,0 +1,198 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package searchindex
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Weights of terms which do not match a search term exactly, relative to exact matches.
+const (
+	prefixMatchWeight = 0.75
+	fuzzyMatchWeight  = 0.5
+)
+
+// Result is a document found by a search.
+type Result struct {
+	ID    int64
+	Score float64
+}
+
+// Filter restricts a search to documents which are in one of the groups or have one of the ids.
+type Filter struct {
+	Groups []int64
+	IDs    []int64
+}
+
+func (f *Filter) matcher() func(id int64, group int64) bool {
+	if f == nil {
+		return func(int64, int64) bool { return true }
+	}
+
+	groups := make(map[int64]bool, len(f.Groups))
+	for _, g := range f.Groups {
+		groups[g] = true
+	}
+	ids := make(map[int64]bool, len(f.IDs))
+	for _, id := range f.IDs {
+		ids[id] = true
+	}
+
+	return func(id int64, group int64) bool {
+		return groups[group] || ids[id]
+	}
+}
+
+// expand returns all terms in the index a search term matches, with a weight for
+// how good the match is: the term itself, all terms it is a prefix of and all terms
+// which are only a few typos away.
+func (idx *Index) expand(word string) map[string]float64 {
+	stemmed := idx.stem(word)
+	terms := make(map[string]float64)
+	if _, has := idx.postings[stemmed]; has {
+		terms[stemmed] = 1
+	}
+
+	// Only terms which start with the same letters can have the search term as prefix
+	if utf8.RuneCountInString(word) >= 3 {
+		candidates := []map[string]struct{}{idx.termsByPrefix[termPrefix(word)]}
+		if utf8.RuneCountInString(stemmed) >= termPrefixLength && termPrefix(stemmed) != termPrefix(word) {
+			candidates = append(candidates, idx.termsByPrefix[termPrefix(stemmed)])
+		}
+		for _, c := range candidates {
+			for term := range c {
+				if term != stemmed && (strings.HasPrefix(term, word) || strings.HasPrefix(term, stemmed)) {
+					terms[term] = prefixMatchWeight
+				}
+			}
+		}
+	}
+
+	// Only terms with about the same length can be a few typos away
+	maxDistance := maxEditDistance(stemmed)
+	if maxDistance == 0 {
+		return terms
+	}
+	length := utf8.RuneCountInString(stemmed)
+	for l := length - maxDistance; l <= length+maxDistance; l++ {
+		for term := range idx.termsByLength[l] {
+			if _, has := terms[term]; has {
+				continue
+			}
+			distance := editDistance(term, stemmed, maxDistance)
+			if distance <= maxDistance {
+				terms[term] = fuzzyMatchWeight / float64(distance)
+			}
+		}
+	}
+
+	return terms
+}
+
+// Search returns all documents which match every word of the query and the filter, sorted by relevance.
+// All documents are searched if the filter is nil.
+func (idx *Index) Search(query string, filter *Filter) []*Result {
+	words := tokenize(query)
+	if len(words) == 0 {
+		return []*Result{}
+	}
+
+	matches := filter.matcher()
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	documentCount := float64(len(idx.documents))
+
+	var scores map[int64]float64
+	for _, word := range words {
+		wordScores := make(map[int64]float64)
+
+		for term, weight := range idx.expand(word) {
+			postings := idx.postings[term]
+			df := float64(len(postings))
+			idf := math.Log(1 + (documentCount-df+0.5)/(df+0.5))
+
+			for id := range postings {
+				indexed := idx.indexed[id]
+				if !matches(id, indexed.group) {
+					continue
+				}
+
+				var score float64
+				for field, terms := range indexed.terms {
+					tf := float64(terms[term])
+					if tf == 0 {
+						continue
+					}
+
+					boost, has := idx.boosts[field]
+					if !has {
+						boost = 1
+					}
+
+					avgLength := float64(idx.fieldLengths[field]) / float64(idx.fieldDocuments[field])
+					length := float64(indexed.lengths[field])
+					score += boost * idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avgLength))
+				}
+
+				// Only the best matching variant of a word counts
+				score *= weight
+				if score > wordScores[id] {
+					wordScores[id] = score
+				}
+			}
+		}
+
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+
+		for id, score := range scores {
+			wordScore, has := wordScores[id]
+			if !has {
+				delete(scores, id)
+				continue
+			}
+			scores[id] = score + wordScore
+		}
+	}
+
+	results := make([]*Result, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, &Result{ID: id, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	return results
+}
@@ -0,0 +1,187 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package searchindex
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBoosts = map[string]float64{
+	"title": 3,
+}
+
+func resultIDs(results []*Result) []int64 {
+	ids := make([]int64, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// simulateCrash closes the index without merging the journal into the snapshot.
+func simulateCrash(t *testing.T, idx *Index) {
+	require.NoError(t, idx.journal.Close())
+	require.NoError(t, idx.lock.Close())
+}
+
+func openTestIndex(t *testing.T) *Index {
+	idx, err := Open(t.TempDir(), "english", testBoosts)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		simulateCrash(t, idx)
+	})
+
+	docs := []*Document{
+		{ID: 1, Group: 1, Fields: map[string]string{"title": "Buy groceries", "description": "Milk, eggs and bread"}},
+		{ID: 2, Group: 1, Fields: map[string]string{"title": "Write the release notes", "description": "Mention the new grocery list feature"}},
+		{ID: 3, Group: 2, Fields: map[string]string{"title": "Plan running routes", "comments": "I ran the route along the river"}},
+		{ID: 4, Group: 2, Fields: map[string]string{"title": "Organize the kitchen", "attachments": "groceries.pdf"}},
+	}
+	for _, doc := range docs {
+		require.NoError(t, idx.Put(doc))
+	}
+	return idx
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := openTestIndex(t)
+
+	t.Run("relevance", func(t *testing.T) {
+		// A match in the title is ranked higher than one in other fields
+		assert.Equal(t, []int64{1, 4, 2}, resultIDs(idx.Search("groceries", nil)))
+	})
+	t.Run("stemming", func(t *testing.T) {
+		assert.Equal(t, []int64{3}, resultIDs(idx.Search("run", nil)))
+		assert.Equal(t, []int64{3}, resultIDs(idx.Search("routing", nil)))
+	})
+	t.Run("fuzzy", func(t *testing.T) {
+		assert.Equal(t, []int64{4}, resultIDs(idx.Search("kitchn", nil)))
+	})
+	t.Run("prefix", func(t *testing.T) {
+		assert.Equal(t, []int64{4}, resultIDs(idx.Search("organ", nil)))
+	})
+	t.Run("all words must match", func(t *testing.T) {
+		assert.Equal(t, []int64{1}, resultIDs(idx.Search("milk groceries", nil)))
+		assert.Empty(t, idx.Search("milk river", nil))
+	})
+	t.Run("groups", func(t *testing.T) {
+		assert.Equal(t, []int64{4}, resultIDs(idx.Search("groceries", &Filter{Groups: []int64{2}})))
+	})
+	t.Run("groups or ids", func(t *testing.T) {
+		assert.Equal(t, []int64{1, 4}, resultIDs(idx.Search("groceries", &Filter{Groups: []int64{2}, IDs: []int64{1}})))
+	})
+	t.Run("no match", func(t *testing.T) {
+		assert.Empty(t, idx.Search("unicorn", nil))
+		assert.Empty(t, idx.Search("  ", nil))
+	})
+}
+
+func TestIndex_Persistence(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+
+	require.NoError(t, idx.Put(&Document{ID: 1, Fields: map[string]string{"title": "Buy groceries"}}))
+	require.NoError(t, idx.Put(&Document{ID: 2, Fields: map[string]string{"title": "Clean the kitchen"}}))
+	require.NoError(t, idx.Put(&Document{ID: 2, Fields: map[string]string{"title": "Clean the garage"}}))
+	require.NoError(t, idx.Delete(1))
+	simulateCrash(t, idx)
+
+	idx, err = Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx.Count())
+	assert.Empty(t, idx.Search("groceries", nil))
+	assert.Empty(t, idx.Search("kitchen", nil))
+	assert.Equal(t, []int64{2}, resultIDs(idx.Search("garage", nil)))
+
+	require.NoError(t, idx.Close())
+	// All changes are in the snapshot, only the new empty journal is left
+	numbers, err := idx.journalNumbers()
+	require.NoError(t, err)
+	require.Len(t, numbers, 1)
+	journal, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf(journalFilePattern, numbers[0])))
+	require.NoError(t, err)
+	assert.Empty(t, journal)
+
+	idx, err = Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	defer idx.Close()
+	assert.Equal(t, []int64{2}, resultIDs(idx.Search("garage", nil)))
+
+	require.NoError(t, idx.Replace([]*Document{{ID: 5, Fields: map[string]string{"title": "Water the plants"}}}))
+	assert.Equal(t, 1, idx.Count())
+	assert.Empty(t, idx.Search("garage", nil))
+	assert.Equal(t, []int64{5}, resultIDs(idx.Search("plant", nil)))
+}
+
+func TestIndex_IncompleteJournal(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	require.NoError(t, idx.Put(&Document{ID: 1, Fields: map[string]string{"title": "Buy groceries"}}))
+	// Simulate a crash while writing an entry
+	_, err = idx.journal.WriteString(`{"document":{"id":2,"fie`)
+	require.NoError(t, err)
+	simulateCrash(t, idx)
+
+	idx, err = Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx.Count())
+	require.NoError(t, idx.Put(&Document{ID: 3, Fields: map[string]string{"title": "Clean the garage"}}))
+	simulateCrash(t, idx)
+
+	// The change after the incomplete entry must not get lost
+	idx, err = Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	defer idx.Close()
+	assert.Equal(t, 2, idx.Count())
+	assert.Equal(t, []int64{3}, resultIDs(idx.Search("garage", nil)))
+}
+
+func TestIndex_Lock(t *testing.T) {
+	dir := t.TempDir()
+	idx, err := Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+
+	_, err = Open(dir, "english", testBoosts)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	assert.False(t, idx.RebuildRequested())
+	require.NoError(t, RequestRebuild(dir))
+	assert.True(t, idx.RebuildRequested())
+	require.NoError(t, idx.Replace([]*Document{{ID: 1, Fields: map[string]string{"title": "Buy groceries"}}}))
+	assert.False(t, idx.RebuildRequested())
+
+	require.NoError(t, idx.Close())
+	idx, err = Open(dir, "english", testBoosts)
+	require.NoError(t, err)
+	defer idx.Close()
+	assert.Equal(t, 1, idx.Count())
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("kitchen", "kitchen", 2))
+	assert.Equal(t, 1, editDistance("kitchen", "kitchn", 2))
+	assert.Equal(t, 2, editDistance("kitchen", "kichn", 2))
+	assert.Equal(t, 3, editDistance("kitchen", "garage", 2))
+}
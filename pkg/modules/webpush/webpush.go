@@ -0,0 +1,288 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/version"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+// This implements the message encryption for web push (RFC 8291) and the VAPID authentication
+// (RFC 8292) needed to send a message to a push service.
+
+const (
+	recordSize    = 4096
+	defaultTTL    = 24 * time.Hour
+	jwtExpiration = 12 * time.Hour
+)
+
+// Subscription holds everything needed to send a message to a push service.
+// The values are the ones returned by the PushSubscription in the browser.
+type Subscription struct {
+	Endpoint string
+	// The base64url encoded P-256 public key of the user agent.
+	P256dh string
+	// The base64url encoded authentication secret of the user agent.
+	Auth string
+}
+
+// ErrSubscriptionGone is returned when the push service responded that the subscription
+// has expired or does not exist anymore. It should not be used again.
+var ErrSubscriptionGone = errors.New("push subscription is gone")
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// GenerateVAPIDKeys creates a new base64url encoded VAPID key pair.
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return
+	}
+
+	publicKey = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	privateKey = base64.RawURLEncoding.EncodeToString(key.Bytes())
+	return
+}
+
+// InitVAPIDKeys makes sure there is a VAPID key pair to use when web push is enabled. If none is
+// configured, it will generate one which is only valid until the next restart.
+func InitVAPIDKeys() {
+	if !config.WebPushEnabled.GetBool() {
+		return
+	}
+
+	if config.WebPushVAPIDPublicKey.GetString() != "" && config.WebPushVAPIDPrivateKey.GetString() != "" {
+		return
+	}
+
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		log.Fatalf("Could not generate VAPID keys for web push: %s", err)
+	}
+
+	config.WebPushVAPIDPublicKey.Set(publicKey)
+	config.WebPushVAPIDPrivateKey.Set(privateKey)
+
+	log.Warningf("No VAPID keys configured for web push, generated a new pair. All push subscriptions will stop working when Vikunja is restarted. Please add these keys to your config: webpush.vapidpublickey: %s, webpush.vapidprivatekey: %s", publicKey, privateKey)
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func getVAPIDKey() (*ecdsa.PrivateKey, error) {
+	raw, err := decodeBase64(config.WebPushVAPIDPrivateKey.GetString())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// The public key is in uncompressed form: 0x04 || x || y
+	pub := key.PublicKey().Bytes()
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}, nil
+}
+
+func getVAPIDAuthorization(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := getVAPIDKey()
+	if err != nil {
+		return "", err
+	}
+
+	subject := config.WebPushSubject.GetString()
+	if subject == "" {
+		subject = "mailto:" + config.MailerFromEmail.GetString()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(jwtExpiration).Unix(),
+		"sub": subject,
+	})
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + signed + ", k=" + config.WebPushVAPIDPublicKey.GetString(), nil
+}
+
+// Encrypt encrypts a payload for a subscription using the aes128gcm content encoding.
+func Encrypt(sub *Subscription, payload []byte) ([]byte, error) {
+	uaPublicRaw, err := decodeBase64(sub.P256dh)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := decodeBase64(sub.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	return encrypt(uaPublicRaw, authSecret, asPrivate, salt, payload)
+}
+
+// encrypt does the actual encryption with the given application server key and salt, which are only
+// random for real messages.
+func encrypt(uaPublicRaw, authSecret []byte, asPrivate *ecdh.PrivateKey, salt, payload []byte) ([]byte, error) {
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicRaw...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := readHKDF(ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	cek, err := readHKDF(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := readHKDF(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everything is sent in a single record, 0x02 is the delimiter of the last record.
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	if len(plaintext)+gcm.Overhead() > recordSize {
+		return nil, errors.New("push payload is too large")
+	}
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func readHKDF(secret, salt, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	_, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out)
+	return out, err
+}
+
+// Send encrypts the payload and sends it to the push service of the subscription.
+// It returns ErrSubscriptionGone if the subscription should be removed.
+func Send(sub *Subscription, payload []byte) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := getVAPIDAuthorization(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(defaultTTL.Seconds())))
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("User-Agent", "Vikunja/"+version.Version)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return ErrSubscriptionGone
+	}
+
+	if res.StatusCode > 399 {
+		resBody, _ := io.ReadAll(res.Body)
+		return errors.New("push service responded with status " + strconv.Itoa(res.StatusCode) + ": " + string(resBody))
+	}
+
+	return nil
+}
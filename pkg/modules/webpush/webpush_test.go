@@ -0,0 +1,186 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decrypt does what a browser does when it receives a push message.
+func decrypt(t *testing.T, uaPrivate *ecdh.PrivateKey, authSecret, body []byte) []byte {
+	salt := body[:16]
+	rs := binary.BigEndian.Uint32(body[16:20])
+	assert.Equal(t, uint32(recordSize), rs)
+	idLen := int(body[20])
+	asPublicRaw := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicRaw)
+	require.NoError(t, err)
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	require.NoError(t, err)
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicRaw...)
+	ikm, err := readHKDF(ecdhSecret, authSecret, keyInfo, 32)
+	require.NoError(t, err)
+	cek, err := readHKDF(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	require.NoError(t, err)
+	nonce, err := readHKDF(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	require.NoError(t, err)
+
+	// Strip the padding delimiter
+	assert.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func newTestSubscription(t *testing.T, endpoint string) (*Subscription, *ecdh.PrivateKey, []byte) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	return &Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+	}, uaPrivate, authSecret
+}
+
+func setTestVAPIDKeys(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	config.WebPushVAPIDPublicKey.Set(publicKey)
+	config.WebPushVAPIDPrivateKey.Set(privateKey)
+	config.WebPushSubject.Set("mailto:admin@example.com")
+}
+
+func TestSend(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		setTestVAPIDKeys(t)
+
+		var received []byte
+		var sub *Subscription
+		var uaPrivate *ecdh.PrivateKey
+		var authSecret []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+			assert.NotEmpty(t, r.Header.Get("TTL"))
+
+			authorization := r.Header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(authorization, "vapid t="))
+			parts := strings.Split(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+			require.Len(t, parts, 2)
+			assert.Equal(t, config.WebPushVAPIDPublicKey.GetString(), parts[1])
+
+			token, err := jwt.Parse(parts[0], func(token *jwt.Token) (interface{}, error) {
+				key, err := getVAPIDKey()
+				if err != nil {
+					return nil, err
+				}
+				return &key.PublicKey, nil
+			}, jwt.WithValidMethods([]string{"ES256"}))
+			require.NoError(t, err)
+			claims := token.Claims.(jwt.MapClaims)
+			assert.Equal(t, "http://"+r.Host, claims["aud"])
+			assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			received = decrypt(t, uaPrivate, authSecret, body)
+
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer server.Close()
+
+		sub, uaPrivate, authSecret = newTestSubscription(t, server.URL+"/push/abc")
+
+		err := Send(sub, []byte(`{"title":"Test"}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"Test"}`, string(received))
+	})
+	t.Run("gone", func(t *testing.T) {
+		setTestVAPIDKeys(t)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		sub, _, _ := newTestSubscription(t, server.URL)
+		err := Send(sub, []byte(`{}`))
+		assert.ErrorIs(t, err, ErrSubscriptionGone)
+	})
+	t.Run("server error", func(t *testing.T) {
+		setTestVAPIDKeys(t)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		sub, _, _ := newTestSubscription(t, server.URL)
+		err := Send(sub, []byte(`{}`))
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrSubscriptionGone)
+	})
+}
+
+// The example from RFC 8291, section 5 and appendix A.
+func TestEncryptRFC8291(t *testing.T) {
+	decode := func(s string) []byte {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	asPrivate, err := ecdh.P256().NewPrivateKey(decode("yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	require.NoError(t, err)
+	assert.Equal(t, decode("BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"), asPrivate.PublicKey().Bytes())
+
+	uaPublic := decode("BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4")
+	authSecret := decode("BTBZMqHH6r4Tts7J_aSIgg")
+	salt := decode("DGv6ra1nlYgDCS1FRnbzlw")
+
+	body, err := encrypt(uaPublic, authSecret, asPrivate, salt, []byte("When I grow up, I want to be a watermelon"))
+	require.NoError(t, err)
+	assert.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN", base64.RawURLEncoding.EncodeToString(body))
+}
@@ -0,0 +1,273 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/utils"
+	"code.vikunja.io/api/pkg/version"
+
+	"xorm.io/xorm"
+)
+
+// These are all chat services a notification can be sent to.
+const (
+	ChatTypeMatrix = `matrix`
+	ChatTypeNtfy   = `ntfy`
+	ChatTypeGotify = `gotify`
+	ChatTypeSlack  = `slack`
+)
+
+// ChatTypes returns all supported chat services.
+func ChatTypes() []string {
+	return []string{
+		ChatTypeMatrix,
+		ChatTypeNtfy,
+		ChatTypeGotify,
+		ChatTypeSlack,
+	}
+}
+
+// ChatDestination is a chat room or topic notifications are sent to. It belongs either to a user or a project.
+type ChatDestination struct {
+	// The unique, numeric id of this chat destination.
+	ID int64 `xorm:"bigint autoincr not null unique pk" json:"id" param:"destination"`
+
+	// The user this destination belongs to. 0 if it is a project destination.
+	UserID int64 `xorm:"bigint not null default 0 index" json:"-"`
+	// The project this destination belongs to. 0 if it is a user destination.
+	ProjectID int64 `xorm:"bigint not null default 0 index" json:"project_id" param:"project"`
+
+	// The chat service. Can be one of `matrix`, `ntfy`, `gotify` or `slack`. Use `slack` for all services which
+	// accept slack-compatible incoming webhooks, like Mattermost or Rocket.Chat.
+	Type string `xorm:"varchar(20) not null" json:"type" valid:"required,in(matrix|ntfy|gotify|slack)"`
+	// For matrix the url of the homeserver, for ntfy and gotify the url of the server and for slack the incoming webhook url.
+	URL string `xorm:"text not null" json:"url" valid:"required,url"`
+	// The access token for matrix, the application token for gotify or an optional access token for ntfy.
+	// This will never be returned by the api.
+	Token string `xorm:"text null" json:"token,omitempty"`
+	// The room id for matrix or the topic for ntfy.
+	Room string `xorm:"varchar(250) null" json:"room"`
+
+	// A timestamp when this destination was created. You cannot change this value.
+	Created time.Time `xorm:"created not null" json:"created"`
+	// A timestamp when this destination was last updated. You cannot change this value.
+	Updated time.Time `xorm:"updated not null" json:"updated"`
+}
+
+// TableName returns the table name for chat destinations
+func (c *ChatDestination) TableName() string {
+	return "chat_destinations"
+}
+
+// GetChatDestinationsForUser returns all chat destinations a user configured for themselves.
+func GetChatDestinationsForUser(s *xorm.Session, userID int64) (destinations []*ChatDestination, err error) {
+	destinations = []*ChatDestination{}
+	err = s.
+		Where("user_id = ? AND project_id = 0", userID).
+		OrderBy("id ASC").
+		Find(&destinations)
+	return
+}
+
+// GetChatDestinationsForProject returns all chat destinations of a project.
+func GetChatDestinationsForProject(s *xorm.Session, projectID int64) (destinations []*ChatDestination, err error) {
+	destinations = []*ChatDestination{}
+	err = s.
+		Where("project_id = ?", projectID).
+		OrderBy("id ASC").
+		Find(&destinations)
+	return
+}
+
+// The chat client never uses a proxy because the guarded dialer would then only check the address of the proxy
+// and not the one of the chat server.
+var chatClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		Proxy:       nil,
+		DialContext: utils.GuardedDialContext(config.ChatAllowedHosts.GetStringSlice, ErrChatAddressNotAllowed),
+	},
+}
+
+// ErrChatAddressNotAllowed is returned when a chat destination points to an internal address which is not allowed
+// through the chat.allowedhosts config.
+var ErrChatAddressNotAllowed = errors.New("the address of the chat destination is not allowed")
+
+// CheckChatURL returns an error if the url of a chat destination obviously points to an internal address. Host names
+// are only resolved when sending a message.
+func CheckChatURL(rawURL string) error {
+	return utils.CheckURLAddress(rawURL, config.ChatAllowedHosts.GetStringSlice(), ErrChatAddressNotAllowed)
+}
+
+func notifyChat(notifiable Notifiable, notification Notification) error {
+	n, is := notifiable.(NotifiableWithChat)
+	if !is {
+		return nil
+	}
+
+	destinations, err := n.RouteForChat()
+	if err != nil {
+		return err
+	}
+
+	SendChat(destinations, notification)
+	return nil
+}
+
+// SendChat sends a notification to chat destinations. The message is rendered from the web push payload
+// of the notification, notifications without one are not sent.
+// Errors are only logged so that one broken chat service does not prevent the others from getting the message.
+func SendChat(destinations []*ChatDestination, notification Notification) {
+	if isUnderTest || len(destinations) == 0 {
+		return
+	}
+
+	message := notification.ToPush()
+	if message == nil {
+		return
+	}
+
+	for _, destination := range destinations {
+		err := sendChatMessage(destination, message)
+		if err != nil {
+			log.Errorf("Could not send notification %s to %s chat destination %d: %s", notification.Name(), destination.Type, destination.ID, err)
+		}
+	}
+}
+
+func sendChatMessage(destination *ChatDestination, message *Push) error {
+	switch destination.Type {
+	case ChatTypeMatrix:
+		return sendMatrixMessage(destination, message)
+	case ChatTypeNtfy:
+		return sendNtfyMessage(destination, message)
+	case ChatTypeGotify:
+		return sendGotifyMessage(destination, message)
+	case ChatTypeSlack:
+		return sendSlackMessage(destination, message)
+	}
+
+	return errors.New("unknown chat type " + destination.Type)
+}
+
+func doChatRequest(method, target, token string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Vikunja/"+version.Version)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := chatClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 399 {
+		return errors.New("chat service responded with status " + strconv.Itoa(res.StatusCode))
+	}
+
+	return nil
+}
+
+func sendMatrixMessage(destination *ChatDestination, message *Push) error {
+	plain := message.Title + "\n" + message.Body
+	formatted := "<strong>" + html.EscapeString(message.Title) + "</strong><br>" +
+		strings.ReplaceAll(html.EscapeString(message.Body), "\n", "<br>")
+	if message.URL != "" {
+		plain += "\n" + message.URL
+		formatted += `<br><a href="` + html.EscapeString(message.URL) + `">` + html.EscapeString(message.URL) + `</a>`
+	}
+
+	transactionID := "vikunja-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	target := strings.TrimSuffix(destination.URL, "/") +
+		"/_matrix/client/v3/rooms/" + url.PathEscape(destination.Room) +
+		"/send/m.room.message/" + transactionID
+
+	return doChatRequest(http.MethodPut, target, destination.Token, map[string]string{
+		"msgtype":        "m.text",
+		"body":           plain,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": formatted,
+	})
+}
+
+func sendNtfyMessage(destination *ChatDestination, message *Push) error {
+	payload := map[string]string{
+		"topic":   destination.Room,
+		"title":   message.Title,
+		"message": message.Body,
+	}
+	if message.URL != "" {
+		payload["click"] = message.URL
+	}
+
+	return doChatRequest(http.MethodPost, destination.URL, destination.Token, payload)
+}
+
+func sendGotifyMessage(destination *ChatDestination, message *Push) error {
+	payload := map[string]interface{}{
+		"title":    message.Title,
+		"message":  message.Body,
+		"priority": 5,
+	}
+	if message.URL != "" {
+		payload["extras"] = map[string]interface{}{
+			"client::notification": map[string]interface{}{
+				"click": map[string]string{"url": message.URL},
+			},
+		}
+	}
+
+	return doChatRequest(http.MethodPost, strings.TrimSuffix(destination.URL, "/")+"/message", destination.Token, payload)
+}
+
+func escapeSlack(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+func sendSlackMessage(destination *ChatDestination, message *Push) error {
+	text := "*" + escapeSlack(message.Title) + "*\n" + escapeSlack(message.Body)
+	if message.URL != "" {
+		text += "\n<" + message.URL + "|Open in Vikunja>"
+	}
+
+	return doChatRequest(http.MethodPost, destination.URL, "", map[string]string{
+		"text": text,
+	})
+}
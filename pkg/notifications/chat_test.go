@@ -0,0 +1,217 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testNotifiableWithChat struct {
+	testNotifiable
+	Destinations []*ChatDestination
+}
+
+func (t *testNotifiableWithChat) RouteForChat() ([]*ChatDestination, error) {
+	return t.Destinations, nil
+}
+
+type chatRequest struct {
+	Method        string
+	Path          string
+	Authorization string
+	Body          map[string]interface{}
+}
+
+func newChatServer(t *testing.T, requests *[]*chatRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := &chatRequest{
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Authorization: r.Header.Get("Authorization"),
+		}
+		err = json.Unmarshal(body, &req.Body)
+		assert.NoError(t, err)
+		*requests = append(*requests, req)
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestSendChat(t *testing.T) {
+	n := &pushTestNotification{}
+
+	// The test servers listen on localhost
+	config.ChatAllowedHosts.Set([]string{"127.0.0.1/32"})
+	defer config.ChatAllowedHosts.Set([]string{})
+
+	t.Run("matrix", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		SendChat([]*ChatDestination{{Type: ChatTypeMatrix, URL: server.URL, Token: "token", Room: "!room:example.com"}}, n)
+
+		assert.Len(t, requests, 1)
+		assert.Equal(t, http.MethodPut, requests[0].Method)
+		assert.Contains(t, requests[0].Path, "/_matrix/client/v3/rooms/!room:example.com/send/m.room.message/")
+		assert.Equal(t, "Bearer token", requests[0].Authorization)
+		assert.Equal(t, "m.text", requests[0].Body["msgtype"])
+		assert.Equal(t, "Task <1> done\nSome text\nhttps://example.com/tasks/1", requests[0].Body["body"])
+		assert.Equal(t, `<strong>Task &lt;1&gt; done</strong><br>Some text<br><a href="https://example.com/tasks/1">https://example.com/tasks/1</a>`, requests[0].Body["formatted_body"])
+	})
+	t.Run("ntfy", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		SendChat([]*ChatDestination{{Type: ChatTypeNtfy, URL: server.URL, Room: "vikunja"}}, n)
+
+		assert.Len(t, requests, 1)
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Empty(t, requests[0].Authorization)
+		assert.Equal(t, "vikunja", requests[0].Body["topic"])
+		assert.Equal(t, "Task <1> done", requests[0].Body["title"])
+		assert.Equal(t, "https://example.com/tasks/1", requests[0].Body["click"])
+	})
+	t.Run("gotify", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		SendChat([]*ChatDestination{{Type: ChatTypeGotify, URL: server.URL + "/", Token: "apptoken"}}, n)
+
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "/message", requests[0].Path)
+		assert.Equal(t, "Bearer apptoken", requests[0].Authorization)
+		assert.Equal(t, "Some text", requests[0].Body["message"])
+	})
+	t.Run("slack", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		SendChat([]*ChatDestination{{Type: ChatTypeSlack, URL: server.URL + "/hooks/abc"}}, n)
+
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "/hooks/abc", requests[0].Path)
+		assert.Equal(t, "*Task &lt;1&gt; done*\nSome text\n<https://example.com/tasks/1|Open in Vikunja>", requests[0].Body["text"])
+	})
+	t.Run("via notifiable", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		tnf := &testNotifiableWithChat{
+			testNotifiable: testNotifiable{ShouldSendNotification: true},
+			Destinations: []*ChatDestination{
+				{Type: ChatTypeSlack, URL: server.URL},
+				{Type: ChatTypeNtfy, URL: server.URL, Room: "vikunja"},
+			},
+		}
+		err := Notify(tnf, n)
+		assert.NoError(t, err)
+		assert.Len(t, requests, 2)
+	})
+}
+
+func TestChatInternalAddresses(t *testing.T) {
+	n := &pushTestNotification{}
+
+	t.Run("internal address is blocked", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		err := sendChatMessage(&ChatDestination{Type: ChatTypeSlack, URL: server.URL}, n.ToPush())
+		assert.ErrorIs(t, err, ErrChatAddressNotAllowed)
+		assert.Empty(t, requests)
+	})
+	t.Run("host name of an internal address is blocked", func(t *testing.T) {
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		err := sendChatMessage(&ChatDestination{Type: ChatTypeSlack, URL: strings.Replace(server.URL, "127.0.0.1", "localhost", 1)}, n.ToPush())
+		assert.ErrorIs(t, err, ErrChatAddressNotAllowed)
+		assert.Empty(t, requests)
+	})
+	t.Run("allowed host", func(t *testing.T) {
+		config.ChatAllowedHosts.Set([]string{"localhost"})
+		defer config.ChatAllowedHosts.Set([]string{})
+
+		requests := []*chatRequest{}
+		server := newChatServer(t, &requests)
+		defer server.Close()
+
+		err := sendChatMessage(&ChatDestination{Type: ChatTypeSlack, URL: strings.Replace(server.URL, "127.0.0.1", "localhost", 1)}, n.ToPush())
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+	})
+	t.Run("check url", func(t *testing.T) {
+		assert.NoError(t, CheckChatURL("https://hooks.example.com/services/abc"))
+		assert.NoError(t, CheckChatURL("https://1.1.1.1/hook"))
+		assert.ErrorIs(t, CheckChatURL("http://localhost:8080"), ErrChatAddressNotAllowed)
+		assert.ErrorIs(t, CheckChatURL("http://127.0.0.1:8080"), ErrChatAddressNotAllowed)
+		assert.ErrorIs(t, CheckChatURL("http://10.1.2.3"), ErrChatAddressNotAllowed)
+		assert.ErrorIs(t, CheckChatURL("http://169.254.169.254/latest/meta-data"), ErrChatAddressNotAllowed)
+		assert.ErrorIs(t, CheckChatURL("http://[::1]/"), ErrChatAddressNotAllowed)
+		assert.ErrorIs(t, CheckChatURL("http://[::ffff:127.0.0.1]/"), ErrChatAddressNotAllowed)
+
+		config.ChatAllowedHosts.Set([]string{"10.0.0.0/8"})
+		defer config.ChatAllowedHosts.Set([]string{})
+		assert.NoError(t, CheckChatURL("http://10.1.2.3"))
+	})
+	t.Run("no proxy", func(t *testing.T) {
+		// A proxy would make the dialer only check the address of the proxy
+		transport, is := chatClient.Transport.(*http.Transport)
+		assert.True(t, is)
+		assert.Nil(t, transport.Proxy)
+	})
+}
+
+type pushTestNotification struct {
+}
+
+func (n *pushTestNotification) ToMail(_ string) *Mail {
+	return nil
+}
+
+func (n *pushTestNotification) ToDB() interface{} {
+	return nil
+}
+
+func (n *pushTestNotification) ToPush() *Push {
+	return &Push{
+		Title: "Task <1> done",
+		Body:  "Some text",
+		URL:   "https://example.com/tasks/1",
+	}
+}
+
+func (n *pushTestNotification) Name() string {
+	return "test.push"
+}
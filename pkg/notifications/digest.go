@@ -0,0 +1,315 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/i18n"
+	"code.vikunja.io/api/pkg/log"
+)
+
+// These are all intervals in which a notifiable can receive a digest of their mail notifications.
+// An empty interval means all mail notifications are sent right away.
+const (
+	DigestIntervalNone   = ``
+	DigestIntervalHourly = `hourly`
+	DigestIntervalDaily  = `daily`
+	DigestIntervalWeekly = `weekly`
+)
+
+// DigestIntervalDuration returns the time between two digests for an interval.
+func DigestIntervalDuration(interval string) time.Duration {
+	switch interval {
+	case DigestIntervalHourly:
+		return time.Hour
+	case DigestIntervalDaily:
+		return 24 * time.Hour
+	case DigestIntervalWeekly:
+		return 7 * 24 * time.Hour
+	}
+
+	return 0
+}
+
+// NotifiableWithDigest is a notifiable which can receive its mail notifications batched as a digest.
+type NotifiableWithDigest interface {
+	Notifiable
+	// DigestInterval returns how often the notifiable wants to receive a digest of their mail notifications.
+	// If it returns DigestIntervalNone, mail notifications are sent right away.
+	DigestInterval() (interval string, err error)
+}
+
+// DigestGroup holds the project and task a notification is grouped under in a digest mail.
+type DigestGroup struct {
+	ProjectID    int64
+	ProjectTitle string
+	TaskID       int64
+	TaskTitle    string
+	TaskURL      string
+}
+
+// DigestableNotification is a notification which can be batched into a digest mail instead of being sent right away.
+type DigestableNotification interface {
+	Notification
+	DigestGroup() *DigestGroup
+}
+
+// DigestNotification is a mail notification queued to be sent as part of the next digest.
+type DigestNotification struct {
+	ID int64 `xorm:"bigint autoincr not null unique pk"`
+
+	// The ID of the notifiable this notification will be sent to.
+	NotifiableID int64 `xorm:"bigint not null index"`
+	// The name of the notification
+	Name string `xorm:"varchar(250) not null"`
+	// The id of the database notification which was created along with this one. If that one was read in the meantime,
+	// this notification won't be part of the digest.
+	DatabaseNotificationID int64 `xorm:"bigint null"`
+
+	ProjectID    int64  `xorm:"bigint not null default 0"`
+	ProjectTitle string `xorm:"text null"`
+	TaskID       int64  `xorm:"bigint not null default 0"`
+	TaskTitle    string `xorm:"text null"`
+	TaskURL      string `xorm:"text null"`
+
+	// The subject and all lines of the original mail.
+	Subject string   `xorm:"text not null"`
+	Lines   []string `xorm:"json null"`
+
+	// A timestamp when this notification was created.
+	Created time.Time `xorm:"created not null"`
+}
+
+// TableName resolves to a better table name for digest notifications
+func (d *DigestNotification) TableName() string {
+	return "notification_digest_queue"
+}
+
+func shouldDigest(notifiable Notifiable, notification Notification) (digest bool, err error) {
+	n, is := notifiable.(NotifiableWithDigest)
+	if !is {
+		return false, nil
+	}
+
+	if _, is := notification.(DigestableNotification); !is {
+		return false, nil
+	}
+
+	interval, err := n.DigestInterval()
+	if err != nil {
+		return false, err
+	}
+
+	return interval != DigestIntervalNone, nil
+}
+
+func queueForDigest(notifiable Notifiable, notification Notification, databaseNotificationID int64) (err error) {
+	mail := notification.ToMail(getLanguage(notifiable))
+	if mail == nil {
+		return nil
+	}
+
+	group := notification.(DigestableNotification).DigestGroup()
+
+	lines := make([]string, 0, len(mail.introLines)+len(mail.outroLines))
+	lines = append(lines, mail.introLines...)
+	lines = append(lines, mail.outroLines...)
+
+	s := db.NewSession()
+	defer s.Close()
+
+	_, err = s.Insert(&DigestNotification{
+		NotifiableID:           notifiable.RouteForDB(),
+		Name:                   notification.Name(),
+		DatabaseNotificationID: databaseNotificationID,
+		ProjectID:              group.ProjectID,
+		ProjectTitle:           group.ProjectTitle,
+		TaskID:                 group.TaskID,
+		TaskTitle:              group.TaskTitle,
+		TaskURL:                group.TaskURL,
+		Subject:                mail.subject,
+		Lines:                  lines,
+	})
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
+
+// SendDigest sends all queued digest notifications of a notifiable as one mail, grouped by project and task.
+// Notifications which were already read in the database are not part of the digest.
+func SendDigest(notifiable Notifiable, greeting string) (err error) {
+	s := db.NewSession()
+	defer s.Close()
+
+	// The queued notifications are only deleted once the digest was sent
+	err = s.Begin()
+	if err != nil {
+		return err
+	}
+
+	queued := []*DigestNotification{}
+	err = s.
+		Where("notifiable_id = ?", notifiable.RouteForDB()).
+		OrderBy("id ASC").
+		Find(&queued)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	if len(queued) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(queued))
+	dbNotificationIDs := make([]int64, 0, len(queued))
+	for _, q := range queued {
+		ids = append(ids, q.ID)
+		if q.DatabaseNotificationID != 0 {
+			dbNotificationIDs = append(dbNotificationIDs, q.DatabaseNotificationID)
+		}
+	}
+
+	read := make(map[int64]bool)
+	if len(dbNotificationIDs) > 0 {
+		dbNotifications := []*DatabaseNotification{}
+		err = s.In("id", dbNotificationIDs).Find(&dbNotifications)
+		if err != nil {
+			_ = s.Rollback()
+			return err
+		}
+		for _, n := range dbNotifications {
+			if !n.ReadAt.IsZero() {
+				read[n.ID] = true
+			}
+		}
+	}
+
+	unread := make([]*DigestNotification, 0, len(queued))
+	for _, q := range queued {
+		if q.DatabaseNotificationID != 0 && read[q.DatabaseNotificationID] {
+			continue
+		}
+		unread = append(unread, q)
+	}
+
+	_, err = s.In("id", ids).Delete(&DigestNotification{})
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	if len(unread) == 0 {
+		log.Debugf("Not sending digest to notifiable %d because all notifications were already read", notifiable.RouteForDB())
+		return s.Commit()
+	}
+
+	lang := getLanguage(notifiable)
+	mail := newDigestMail(lang, unread).
+		Greeting(greeting).
+		Language(lang)
+	to, err := notifiable.RouteForMail()
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+	mail.To(to)
+
+	err = SendMail(mail)
+	if err != nil {
+		_ = s.Rollback()
+		return err
+	}
+
+	return s.Commit()
+}
+
+func newDigestMail(lang string, queued []*DigestNotification) *Mail {
+	type taskGroup struct {
+		title         string
+		url           string
+		notifications []*DigestNotification
+	}
+	type projectGroup struct {
+		title string
+		tasks map[int64]*taskGroup
+		order []int64
+	}
+
+	projects := make(map[int64]*projectGroup)
+	projectOrder := []int64{}
+	for _, q := range queued {
+		p, has := projects[q.ProjectID]
+		if !has {
+			p = &projectGroup{title: q.ProjectTitle, tasks: make(map[int64]*taskGroup)}
+			projects[q.ProjectID] = p
+			projectOrder = append(projectOrder, q.ProjectID)
+		}
+
+		t, has := p.tasks[q.TaskID]
+		if !has {
+			t = &taskGroup{title: q.TaskTitle, url: q.TaskURL}
+			p.tasks[q.TaskID] = t
+			p.order = append(p.order, q.TaskID)
+		}
+
+		t.notifications = append(t.notifications, q)
+	}
+
+	sort.SliceStable(projectOrder, func(i, j int) bool {
+		return projects[projectOrder[i]].title < projects[projectOrder[j]].title
+	})
+
+	mail := NewMail().
+		Template("digest").
+		Subject(i18n.T(lang, "notifications.digest.subject")).
+		Line(i18n.T(lang, "notifications.digest.message", strconv.Itoa(len(queued))))
+
+	for _, projectID := range projectOrder {
+		p := projects[projectID]
+		if p.title != "" {
+			mail.Line("## " + p.title)
+		}
+
+		for _, taskID := range p.order {
+			t := p.tasks[taskID]
+			if t.url != "" {
+				mail.Line("### [" + t.title + "](" + t.url + ")")
+			} else if t.title != "" {
+				mail.Line("### " + t.title)
+			}
+
+			for _, n := range t.notifications {
+				mail.Line("**" + n.Subject + "**")
+				for _, line := range n.Lines {
+					mail.Line(line)
+				}
+			}
+		}
+	}
+
+	return mail.
+		Action(i18n.T(lang, "notifications.common.actions.open_vikunja"), config.ServiceFrontendurl.GetString())
+}
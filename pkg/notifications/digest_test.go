@@ -0,0 +1,199 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"errors"
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDigestNotification struct {
+	testNotification
+	group *DigestGroup
+}
+
+func (n *testDigestNotification) DigestGroup() *DigestGroup {
+	return n.group
+}
+
+type testDigestNotifiable struct {
+	testNotifiable
+	Interval string
+}
+
+func (t *testDigestNotifiable) DigestInterval() (string, error) {
+	return t.Interval, nil
+}
+
+type testDigestNotifiableWithoutMail struct {
+	testDigestNotifiable
+}
+
+func (t *testDigestNotifiableWithoutMail) RouteForMail() (string, error) {
+	return "", errors.New("no email address")
+}
+
+func TestDigest(t *testing.T) {
+	cleanup := func(t *testing.T) {
+		s := db.NewSession()
+		defer s.Close()
+		_, err := s.Exec("delete from notifications")
+		assert.NoError(t, err)
+		_, err = s.Exec("delete from notification_digest_queue")
+		assert.NoError(t, err)
+	}
+
+	tn := &testDigestNotification{
+		testNotification: testNotification{
+			Test:       "somethingsomething",
+			OtherValue: 42,
+		},
+		group: &DigestGroup{
+			ProjectID:    1,
+			ProjectTitle: "Project",
+			TaskID:       2,
+			TaskTitle:    "Task",
+			TaskURL:      "http://example.com/tasks/2",
+		},
+	}
+
+	t.Run("queues mail when digest is enabled", func(t *testing.T) {
+		cleanup(t)
+
+		tnf := &testDigestNotifiable{
+			testNotifiable: testNotifiable{ShouldSendNotification: true},
+			Interval:       DigestIntervalDaily,
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+		db.AssertExists(t, "notification_digest_queue", map[string]interface{}{
+			"notifiable_id": 42,
+			"name":          "test.notification",
+			"task_id":       2,
+			"subject":       "Test Notification",
+		}, false)
+		db.AssertExists(t, "notifications", map[string]interface{}{
+			"notifiable_id": 42,
+		}, false)
+	})
+	t.Run("does not queue when digest is disabled", func(t *testing.T) {
+		cleanup(t)
+
+		tnf := &testDigestNotifiable{
+			testNotifiable: testNotifiable{ShouldSendNotification: true},
+			Interval:       DigestIntervalNone,
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+		db.AssertMissing(t, "notification_digest_queue", map[string]interface{}{
+			"notifiable_id": 42,
+		})
+	})
+	t.Run("send digest", func(t *testing.T) {
+		cleanup(t)
+
+		tnf := &testDigestNotifiable{
+			testNotifiable: testNotifiable{ShouldSendNotification: true},
+			Interval:       DigestIntervalHourly,
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+
+		err = SendDigest(tnf, "Hi,")
+		assert.NoError(t, err)
+		db.AssertMissing(t, "notification_digest_queue", map[string]interface{}{
+			"notifiable_id": 42,
+		})
+	})
+	t.Run("keeps the queue when the digest could not be sent", func(t *testing.T) {
+		cleanup(t)
+
+		tnf := &testDigestNotifiableWithoutMail{
+			testDigestNotifiable: testDigestNotifiable{
+				testNotifiable: testNotifiable{ShouldSendNotification: true},
+				Interval:       DigestIntervalHourly,
+			},
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+
+		err = SendDigest(tnf, "Hi,")
+		assert.Error(t, err)
+		db.AssertExists(t, "notification_digest_queue", map[string]interface{}{
+			"notifiable_id": 42,
+		}, false)
+	})
+	t.Run("drops notifications read in the meantime", func(t *testing.T) {
+		cleanup(t)
+
+		tnf := &testDigestNotifiable{
+			testNotifiable: testNotifiable{ShouldSendNotification: true},
+			Interval:       DigestIntervalHourly,
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+
+		s := db.NewSession()
+		defer s.Close()
+		err = MarkAllNotificationsAsRead(s, 42)
+		assert.NoError(t, err)
+		assert.NoError(t, s.Commit())
+
+		queued := []*DigestNotification{}
+		err = s.Where("notifiable_id = ?", 42).Find(&queued)
+		assert.NoError(t, err)
+		assert.Len(t, queued, 1)
+		assert.NotZero(t, queued[0].DatabaseNotificationID)
+
+		err = SendDigest(tnf, "Hi,")
+		assert.NoError(t, err)
+		db.AssertMissing(t, "notification_digest_queue", map[string]interface{}{
+			"notifiable_id": 42,
+		})
+	})
+}
+
+func TestNewDigestMail(t *testing.T) {
+	mail := newDigestMail("en", []*DigestNotification{
+		{ProjectID: 2, ProjectTitle: "Second", TaskID: 3, TaskTitle: "Other task", TaskURL: "http://example.com/tasks/3", Subject: "Comment 3", Lines: []string{"Line 3"}},
+		{ProjectID: 1, ProjectTitle: "First", TaskID: 1, TaskTitle: "Task", TaskURL: "http://example.com/tasks/1", Subject: "Comment 1", Lines: []string{"Line 1"}},
+		{ProjectID: 1, ProjectTitle: "First", TaskID: 1, TaskTitle: "Task", TaskURL: "http://example.com/tasks/1", Subject: "Comment 2", Lines: []string{"Line 2"}},
+	})
+
+	assert.Equal(t, []string{
+		"You have 3 new notifications since your last digest:",
+		"## First",
+		"### [Task](http://example.com/tasks/1)",
+		"**Comment 1**",
+		"Line 1",
+		"**Comment 2**",
+		"Line 2",
+		"## Second",
+		"### [Other task](http://example.com/tasks/3)",
+		"**Comment 3**",
+		"Line 3",
+	}, mail.introLines)
+}
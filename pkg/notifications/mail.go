@@ -28,6 +28,8 @@ type Mail struct {
 	greeting   string
 	introLines []string
 	outroLines []string
+	language   string
+	template   string
 }
 
 // NewMail creates a new mail object with a default greeting
@@ -59,6 +61,19 @@ func (m *Mail) Greeting(greeting string) *Mail {
 	return m
 }
 
+// Language sets the language of the parts of the mail which are not composed by the notification itself.
+func (m *Mail) Language(language string) *Mail {
+	m.language = language
+	return m
+}
+
+// Template sets the name of the template used to render the mail. Operators can override the
+// blocks of a template by putting a file with the same name into the configured templates directory.
+func (m *Mail) Template(name string) *Mail {
+	m.template = name
+	return m
+}
+
 // Action sets any action a mail might have
 func (m *Mail) Action(text, url string) *Mail {
 	m.actionText = text
@@ -21,25 +21,32 @@ import (
 	"embed"
 	_ "embed"
 	templatehtml "html/template"
+	"io"
+	"os"
+	"path/filepath"
 	templatetext "text/template"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/i18n"
 	"code.vikunja.io/api/pkg/mail"
 	"code.vikunja.io/api/pkg/utils"
 
 	"github.com/yuin/goldmark"
 )
 
+// The default templates are split into named blocks. Operators can override single blocks per
+// notification by putting a "<template name>.html" or "<template name>.txt" file into the templates
+// directory, or replace the whole layout with a "mail.html" or "mail.txt" file.
 const mailTemplatePlain = `
-{{ .Greeting }}
-{{ range $line := .IntroLines}}
+{{ block "greeting" . }}{{ .Greeting }}{{ end }}
+{{ block "intro" . }}{{ range $line := .IntroLines}}
 {{ $line }}
-{{ end }}
-{{ if .ActionURL }}{{ .ActionText }}:
-{{ .ActionURL }}{{end}}
-{{ range $line := .OutroLines}}
+{{ end }}{{ end }}
+{{ block "action" . }}{{ if .ActionURL }}{{ .ActionText }}:
+{{ .ActionURL }}{{end}}{{ end }}
+{{ block "outro" . }}{{ range $line := .OutroLines}}
 {{ $line }}
-{{ end }}`
+{{ end }}{{ end }}`
 
 const mailTemplateHTML = `
 <!doctype html>
@@ -47,38 +54,38 @@ const mailTemplateHTML = `
 <head>
     <meta name="viewport" content="width: display-width;">
 </head>
-<body style="width: 100%; padding: 0; margin: 0; background: #f3f4f6">
+<body style="width: 100%; padding: 0; margin: 0; background: {{ .Brand.BackgroundColor }}">
 <div style="width: 100%; font-family: 'Open Sans', sans-serif; text-rendering: optimizeLegibility">
     <div style="width: 600px; margin: 0 auto; text-align: justify;">
-        <h1 style="font-size: 30px; text-align: center;">
-            <img src="cid:logo.png" style="height: 75px;" alt="Vikunja"/>
-        </h1>
+        {{ block "header" . }}<h1 style="font-size: 30px; text-align: center;">
+            <img src="{{ .LogoURL }}" style="height: 75px;" alt="{{ .Brand.Name }}"/>
+        </h1>{{ end }}
         <div style="border: 1px solid #dbdbdb; -webkit-box-shadow: 0.3em 0.3em 0.8em #e6e6e6; box-shadow: 0.3em 0.3em 0.8em #e6e6e6; color: #4a4a4a; padding: 5px 25px; border-radius: 3px; background: #fff;">
-<p>
+{{ block "greeting" . }}<p>
 	{{ .Greeting }}
-</p>
+</p>{{ end }}
 
-{{ range $line := .IntroLinesHTML}}
+{{ block "intro" . }}{{ range $line := .IntroLinesHTML}}
 	{{ $line }}
-{{ end }}
+{{ end }}{{ end }}
 
-{{ if .ActionURL }}
+{{ block "action" . }}{{ if .ActionURL }}
 	<a href="{{ .ActionURL }}" title="{{ .ActionText }}"
-		style="position: relative;text-decoration:none;display: block;border-radius: 4px;cursor: pointer;padding-bottom: 8px;padding-left: 14px;padding-right: 14px;padding-top: 8px;width:280px;margin:10px auto;text-align: center;white-space: nowrap;border: 0;text-transform: uppercase;font-size: 14px;font-weight: 700;-webkit-box-shadow: 0 3px 6px rgba(107,114,128,.12),0 2px 4px rgba(107,114,128,.1);box-shadow: 0 3px 6px rgba(107,114,128,.12),0 2px 4px rgba(107,114,128,.1);background-color: #1973ff;border-color: transparent;color: #fff;">
+		style="position: relative;text-decoration:none;display: block;border-radius: 4px;cursor: pointer;padding-bottom: 8px;padding-left: 14px;padding-right: 14px;padding-top: 8px;width:280px;margin:10px auto;text-align: center;white-space: nowrap;border: 0;text-transform: uppercase;font-size: 14px;font-weight: 700;-webkit-box-shadow: 0 3px 6px rgba(107,114,128,.12),0 2px 4px rgba(107,114,128,.1);box-shadow: 0 3px 6px rgba(107,114,128,.12),0 2px 4px rgba(107,114,128,.1);background-color: {{ .Brand.Color }};border-color: transparent;color: #fff;">
 		{{ .ActionText }}
 	</a>
-{{end}}
+{{end}}{{ end }}
 
-{{ range $line := .OutroLinesHTML}}
+{{ block "outro" . }}{{ range $line := .OutroLinesHTML}}
 	{{ $line }}
-{{ end }}
+{{ end }}{{ end }}
 
-{{ if .ActionURL }}
+{{ block "footer" . }}{{ if .ActionURL }}
 	<p style="color: #9CA3AF;font-size:12px;border-top: 1px solid #dbdbdb;margin-top:20px;padding-top:20px;">
-		If the button above doesn't work, copy the url below and paste it in your browser's address bar:<br/>
+		{{ .CopyURLHint }}<br/>
 		{{ .ActionURL }}
 	</p>
-{{ end }}
+{{ end }}{{ end }}
 </div>
 </div>
 </div>
@@ -86,29 +93,144 @@ const mailTemplateHTML = `
 </html>
 `
 
+// MailBrand holds everything about the look of a mail which can be configured without
+// writing templates.
+type MailBrand struct {
+	Name            string
+	Color           string
+	BackgroundColor string
+}
+
 //go:embed logo.png
 var logo embed.FS
 
+// readMailTemplate returns the content of a template file from the configured templates
+// directory. If the directory is not configured or the file does not exist, it returns false.
+func readMailTemplate(filename string) (content string, exists bool, err error) {
+	dir := config.MailerTemplatesDir.GetString()
+	if dir == "" {
+		return "", false, nil
+	}
+
+	c, err := os.ReadFile(filepath.Join(dir, filepath.Base(filename)))
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(c), true, nil
+}
+
+// parseMailTemplate parses the layout for a mail with all overrides from the templates directory.
+// parse is called with the default layout first and then with each override so that overrides
+// can redefine single blocks of the layout.
+func parseMailTemplate(name, extension, defaultLayout string, parse func(content string) error) error {
+	layout, exists, err := readMailTemplate("mail" + extension)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		layout = defaultLayout
+	}
+
+	err = parse(layout)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	override, exists, err := readMailTemplate(name + extension)
+	if err != nil || !exists {
+		return err
+	}
+
+	return parse(override)
+}
+
+func getMailTemplates(name string) (plain *templatetext.Template, html *templatehtml.Template, err error) {
+	plain = templatetext.New("mail-plain")
+	err = parseMailTemplate(name, ".txt", mailTemplatePlain, func(content string) (err error) {
+		plain, err = plain.Parse(content)
+		return
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	html = templatehtml.New("mail-html")
+	err = parseMailTemplate(name, ".html", mailTemplateHTML, func(content string) (err error) {
+		html, err = html.Parse(content)
+		return
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return
+}
+
+// GetMailLogo returns the file name and content of the logo shown in all mails.
+func GetMailLogo() (name string, content []byte, err error) {
+	logoPath := config.MailerLogo.GetString()
+	if logoPath == "" {
+		content, err = logo.ReadFile("logo.png")
+		return "logo.png", content, err
+	}
+
+	content, err = os.ReadFile(logoPath)
+	return filepath.Base(logoPath), content, err
+}
+
 // RenderMail takes a precomposed mail message and renders it into a ready to send mail.Opts object
 func RenderMail(m *Mail) (mailOpts *mail.Opts, err error) {
+	return renderMail(m, false)
+}
+
+// RenderMailPreview renders a mail like RenderMail, but references the logo by its file name
+// instead of as an attachment so that the html can be opened in a browser.
+func RenderMailPreview(m *Mail) (mailOpts *mail.Opts, err error) {
+	return renderMail(m, true)
+}
+
+func renderMail(m *Mail, preview bool) (mailOpts *mail.Opts, err error) {
 
 	var htmlContent bytes.Buffer
 	var plainContent bytes.Buffer
 
-	plain, err := templatetext.New("mail-plain").Parse(mailTemplatePlain)
+	plain, html, err := getMailTemplates(m.template)
 	if err != nil {
 		return nil, err
 	}
 
-	html, err := templatehtml.New("mail-plain").Parse(mailTemplateHTML)
+	logoName, logoContent, err := GetMailLogo()
 	if err != nil {
 		return nil, err
 	}
 
+	//#nosec - the logo name comes from the config
+	logoURL := templatehtml.URL("cid:" + logoName)
+	if preview {
+		//#nosec - the logo name comes from the config
+		logoURL = templatehtml.URL(logoName)
+	}
+
 	boundary := "np" + utils.MakeRandomString(13)
 
 	data := make(map[string]interface{})
 
+	data["Template"] = m.template
+	data["Subject"] = m.subject
+	data["Brand"] = &MailBrand{
+		Name:            config.MailerBrandName.GetString(),
+		Color:           config.MailerBrandColor.GetString(),
+		BackgroundColor: config.MailerBackgroundColor.GetString(),
+	}
+	data["LogoURL"] = logoURL
 	data["Greeting"] = m.greeting
 	data["IntroLines"] = m.introLines
 	data["OutroLines"] = m.outroLines
@@ -116,6 +238,8 @@ func RenderMail(m *Mail) (mailOpts *mail.Opts, err error) {
 	data["ActionURL"] = m.actionURL
 	data["Boundary"] = boundary
 	data["FrontendURL"] = config.ServiceFrontendurl.GetString()
+	//#nosec - the translations are embedded in the binary
+	data["CopyURLHint"] = templatehtml.HTML(i18n.T(m.language, "notifications.common.copy_url"))
 
 	var introLinesHTML []templatehtml.HTML
 	for _, line := range m.introLines {
@@ -160,8 +284,8 @@ func RenderMail(m *Mail) (mailOpts *mail.Opts, err error) {
 		Message:     plainContent.String(),
 		HTMLMessage: htmlContent.String(),
 		Boundary:    boundary,
-		EmbedFS: map[string]*embed.FS{
-			"logo.png": &logo,
+		Embeds: map[string]io.Reader{
+			logoName: bytes.NewReader(logoContent),
 		},
 	}
 
@@ -17,9 +17,14 @@
 package notifications
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"code.vikunja.io/api/pkg/config"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewMail(t *testing.T) {
@@ -173,3 +178,76 @@ And one more, because why not?
 </html>
 `, mailopts.HTMLMessage)
 }
+
+func TestRenderMailLanguage(t *testing.T) {
+	mail := NewMail().
+		Subject("Testmail").
+		Line("This is a line").
+		Action("The action", "https://example.com").
+		Language("de-DE")
+
+	mailopts, err := RenderMail(mail)
+	assert.NoError(t, err)
+	assert.Contains(t, mailopts.HTMLMessage, "Falls der Button oben nicht funktioniert")
+}
+
+func TestRenderMailTemplates(t *testing.T) {
+	dir := t.TempDir()
+	config.MailerTemplatesDir.Set(dir)
+	defer config.MailerTemplatesDir.Set("")
+
+	err := os.WriteFile(filepath.Join(dir, "task.comment.html"), []byte(`{{ define "greeting" }}<h2>{{ .Subject }}</h2>{{ end }}`), 0o600)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "mail.txt"), []byte(`{{ block "greeting" . }}{{ .Greeting }}{{ end }} - {{ .Brand.Name }}`), 0o600)
+	require.NoError(t, err)
+
+	t.Run("override block per template", func(t *testing.T) {
+		mail := NewMail().
+			Template("task.comment").
+			Subject("Testmail").
+			Greeting("Hi there,").
+			Line("This is a line")
+
+		mailopts, err := RenderMail(mail)
+		require.NoError(t, err)
+		assert.Contains(t, mailopts.HTMLMessage, "<h2>Testmail</h2>")
+		assert.NotContains(t, mailopts.HTMLMessage, "Hi there,")
+		assert.Contains(t, mailopts.HTMLMessage, "<p>This is a line</p>")
+		assert.Equal(t, "Hi there, - Vikunja", mailopts.Message)
+	})
+	t.Run("other templates use the default blocks", func(t *testing.T) {
+		mail := NewMail().
+			Template("task.assigned").
+			Subject("Testmail").
+			Greeting("Hi there,")
+
+		mailopts, err := RenderMail(mail)
+		require.NoError(t, err)
+		assert.NotContains(t, mailopts.HTMLMessage, "<h2>Testmail</h2>")
+		assert.Contains(t, mailopts.HTMLMessage, "Hi there,")
+	})
+	t.Run("brand", func(t *testing.T) {
+		config.MailerBrandName.Set("ACME Tasks")
+		config.MailerBrandColor.Set("#ff0000")
+		defer config.MailerBrandName.Set("Vikunja")
+		defer config.MailerBrandColor.Set("#1973ff")
+
+		mail := NewMail().
+			Subject("Testmail").
+			Greeting("Hi there,").
+			Action("The action", "https://example.com")
+
+		mailopts, err := RenderMail(mail)
+		require.NoError(t, err)
+		assert.Contains(t, mailopts.HTMLMessage, `alt="ACME Tasks"`)
+		assert.Contains(t, mailopts.HTMLMessage, "background-color: #ff0000;")
+		assert.Equal(t, "Hi there, - ACME Tasks", mailopts.Message)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		err := os.WriteFile(filepath.Join(dir, "broken.html"), []byte(`{{ define "greeting" }}`), 0o600)
+		require.NoError(t, err)
+
+		_, err = RenderMail(NewMail().Template("broken"))
+		assert.Error(t, err)
+	})
+}
@@ -20,13 +20,16 @@ import (
 	"encoding/json"
 
 	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/i18n"
 	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/mail"
 )
 
-// Notification is a notification which can be sent via mail or db.
+// Notification is a notification which can be sent via mail, db or web push.
 type Notification interface {
-	ToMail() *Mail
+	ToMail(lang string) *Mail
 	ToDB() interface{}
+	ToPush() *Push
 	Name() string
 }
 
@@ -39,6 +42,30 @@ type NotificationWithSubject interface {
 	SubjectID
 }
 
+// ProjectID is implemented by notifications which are about something in a project.
+// It is used to look up project-level notification preferences.
+type ProjectID interface {
+	ProjectID() int64
+}
+
+// These are all channels a notification can be sent through.
+const (
+	ChannelMail = `email`
+	ChannelDB   = `in_app`
+	ChannelPush = `push`
+	ChannelChat = `chat`
+)
+
+// Channels returns all available notification channels.
+func Channels() []string {
+	return []string{
+		ChannelMail,
+		ChannelDB,
+		ChannelPush,
+		ChannelChat,
+	}
+}
+
 // Notifiable is an entity which can be notified. Usually a user.
 type Notifiable interface {
 	// RouteForMail should return the email address this notifiable has.
@@ -50,6 +77,35 @@ type Notifiable interface {
 	ShouldNotify() (should bool, err error)
 }
 
+// NotifiableWithPreferences is a notifiable which can opt out of single notifications per channel.
+type NotifiableWithPreferences interface {
+	Notifiable
+	// ShouldNotifyVia will be called before a notification is sent through a channel. If it returns false,
+	// the notification is not sent through that channel.
+	ShouldNotifyVia(notification Notification, channel string) (should bool, err error)
+}
+
+// NotifiableWithLanguage is a notifiable which receives mail notifications in their own language.
+type NotifiableWithLanguage interface {
+	Notifiable
+	// Lang should return the language code of the notifiable, for example "de-DE".
+	Lang() string
+}
+
+func getLanguage(notifiable Notifiable) string {
+	if n, is := notifiable.(NotifiableWithLanguage); is {
+		return n.Lang()
+	}
+	return i18n.DefaultLanguage
+}
+
+// NotifiableWithChat is a notifiable which can receive notifications in chat services.
+type NotifiableWithChat interface {
+	Notifiable
+	// RouteForChat should return all chat destinations the notifiable configured.
+	RouteForChat() (destinations []*ChatDestination, err error)
+}
+
 // Notify notifies a notifiable of a notification
 func Notify(notifiable Notifiable, notification Notification) (err error) {
 	if isUnderTest {
@@ -63,16 +119,105 @@ func Notify(notifiable Notifiable, notification Notification) (err error) {
 		return err
 	}
 
-	err = notifyMail(notifiable, notification)
+	shouldMail, err := shouldNotifyVia(notifiable, notification, ChannelMail)
+	if err != nil {
+		return err
+	}
+
+	var digest bool
+	if shouldMail {
+		digest, err = shouldDigest(notifiable, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shouldMail && !digest {
+		err = notifyMail(notifiable, notification)
+		if err != nil {
+			return
+		}
+	}
+
+	var dbNotificationID int64
+	should, err = shouldNotifyVia(notifiable, notification, ChannelDB)
+	if err != nil {
+		return err
+	}
+	if should {
+		dbNotificationID, err = notifyDB(notifiable, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	if shouldMail && digest {
+		err = queueForDigest(notifiable, notification, dbNotificationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	should, err = shouldNotifyVia(notifiable, notification, ChannelPush)
 	if err != nil {
-		return
+		return err
+	}
+	if should {
+		err = notifyPush(notifiable, notification)
+		if err != nil {
+			return err
+		}
+	}
+
+	should, err = shouldNotifyVia(notifiable, notification, ChannelChat)
+	if err != nil || !should {
+		return err
 	}
 
-	return notifyDB(notifiable, notification)
+	return notifyChat(notifiable, notification)
+}
+
+func shouldNotifyVia(notifiable Notifiable, notification Notification, channel string) (bool, error) {
+	n, has := notifiable.(NotifiableWithPreferences)
+	if !has {
+		return true, nil
+	}
+
+	should, err := n.ShouldNotifyVia(notification, channel)
+	if err == nil && !should {
+		log.Debugf("Not notifying user %d of %s via %s because they disabled it", notifiable.RouteForDB(), notification.Name(), channel)
+	}
+	return should, err
+}
+
+// getMail returns the mail of a notification in the given language. Mails use the notification
+// name as their template, unless the notification sets a template itself.
+func getMail(notification Notification, lang string) *Mail {
+	mail := notification.ToMail(lang)
+	if mail == nil {
+		return nil
+	}
+	mail.Language(lang)
+	if mail.template == "" {
+		mail.Template(notification.Name())
+	}
+	return mail
+}
+
+// RenderNotificationPreview renders the mail of a notification without sending it and returns
+// it together with the name of its template. It returns nil if the notification has no mail.
+func RenderNotificationPreview(notification Notification, lang string) (template string, opts *mail.Opts, err error) {
+	m := getMail(notification, lang)
+	if m == nil {
+		return "", nil, nil
+	}
+	opts, err = RenderMailPreview(m)
+	return m.template, opts, err
 }
 
 func notifyMail(notifiable Notifiable, notification Notification) error {
-	mail := notification.ToMail()
+	lang := getLanguage(notifiable)
+	mail := getMail(notification, lang)
 	if mail == nil {
 		return nil
 	}
@@ -86,16 +231,16 @@ func notifyMail(notifiable Notifiable, notification Notification) error {
 	return SendMail(mail)
 }
 
-func notifyDB(notifiable Notifiable, notification Notification) (err error) {
+func notifyDB(notifiable Notifiable, notification Notification) (id int64, err error) {
 
 	dbContent := notification.ToDB()
 	if dbContent == nil {
-		return nil
+		return 0, nil
 	}
 
 	content, err := json.Marshal(dbContent)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	s := db.NewSession()
@@ -112,8 +257,8 @@ func notifyDB(notifiable Notifiable, notification Notification) (err error) {
 	_, err = s.Insert(dbNotification)
 	if err != nil {
 		_ = s.Rollback()
-		return err
+		return 0, err
 	}
 
-	return s.Commit()
+	return dbNotification.ID, s.Commit()
 }
@@ -30,7 +30,7 @@ type testNotification struct {
 }
 
 // ToMail returns the mail notification for testNotification
-func (n *testNotification) ToMail() *Mail {
+func (n *testNotification) ToMail(_ string) *Mail {
 	return NewMail().
 		Subject("Test Notification").
 		Line(n.Test)
@@ -44,6 +44,14 @@ func (n *testNotification) ToDB() interface{} {
 	return data
 }
 
+// ToPush returns the testNotification notification as web push message
+func (n *testNotification) ToPush() *Push {
+	return &Push{
+		Title: "Test Notification",
+		Body:  n.Test,
+	}
+}
+
 // Name returns the name of the notification
 func (n *testNotification) Name() string {
 	return "test.notification"
@@ -67,6 +75,34 @@ func (t *testNotifiable) ShouldNotify() (should bool, err error) {
 	return t.ShouldSendNotification, nil
 }
 
+type testNotifiableWithPreferences struct {
+	testNotifiable
+	DisabledChannels map[string]bool
+}
+
+func (t *testNotifiableWithPreferences) ShouldNotifyVia(_ Notification, channel string) (should bool, err error) {
+	return !t.DisabledChannels[channel], nil
+}
+
+type testNotifiableWithLanguage struct {
+	testNotifiable
+	Language string
+}
+
+func (t *testNotifiableWithLanguage) Lang() string {
+	return t.Language
+}
+
+type languageTestNotification struct {
+	testNotification
+	receivedLanguage string
+}
+
+func (n *languageTestNotification) ToMail(lang string) *Mail {
+	n.receivedLanguage = lang
+	return n.testNotification.ToMail(lang)
+}
+
 func TestNotify(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 
@@ -123,4 +159,43 @@ func TestNotify(t *testing.T) {
 			"notifiable_id": 42,
 		})
 	})
+	t.Run("disabled db channel", func(t *testing.T) {
+
+		s := db.NewSession()
+		defer s.Close()
+		_, err := s.Exec("delete from notifications")
+		assert.NoError(t, err)
+
+		tn := &testNotification{
+			Test:       "somethingsomething",
+			OtherValue: 42,
+		}
+		tnf := &testNotifiableWithPreferences{
+			testNotifiable: testNotifiable{
+				ShouldSendNotification: true,
+			},
+			DisabledChannels: map[string]bool{
+				ChannelDB: true,
+			},
+		}
+
+		err = Notify(tnf, tn)
+		assert.NoError(t, err)
+		db.AssertMissing(t, "notifications", map[string]interface{}{
+			"notifiable_id": 42,
+		})
+	})
+	t.Run("language of the notifiable", func(t *testing.T) {
+		tn := &languageTestNotification{}
+		tnf := &testNotifiableWithLanguage{
+			testNotifiable: testNotifiable{
+				ShouldSendNotification: true,
+			},
+			Language: "de-DE",
+		}
+
+		err := Notify(tnf, tn)
+		assert.NoError(t, err)
+		assert.Equal(t, "de-DE", tn.receivedLanguage)
+	})
 }
@@ -0,0 +1,155 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/modules/webpush"
+
+	"xorm.io/xorm"
+)
+
+// Push is the payload of a notification sent as web push message. The service worker of the
+// frontend receives it and shows it to the user.
+type Push struct {
+	// The name of the notification.
+	Name string `json:"name"`
+	// The title of the push message.
+	Title string `json:"title"`
+	// The text of the push message.
+	Body string `json:"body"`
+	// The url to open when the user clicks on the push message.
+	URL string `json:"url,omitempty"`
+}
+
+// PushSubscription is a web push subscription of a browser or device.
+type PushSubscription struct {
+	// The unique, numeric id of this push subscription.
+	ID int64 `xorm:"bigint autoincr not null unique pk" json:"id" param:"subscription"`
+
+	// The ID of the notifiable this subscription belongs to.
+	NotifiableID int64 `xorm:"bigint not null index" json:"-"`
+	// The push service url to which messages for this subscription are sent.
+	Endpoint string `xorm:"text not null" json:"endpoint" valid:"required,url"`
+	// The base64url encoded P-256 public key of the browser.
+	P256dh string `xorm:"varchar(250) not null" json:"p256dh" valid:"required"`
+	// The base64url encoded authentication secret of the browser.
+	Auth string `xorm:"varchar(250) not null" json:"auth" valid:"required"`
+
+	// A timestamp when this subscription was created. You cannot change this value.
+	Created time.Time `xorm:"created not null" json:"created"`
+}
+
+// TableName returns the table name for push subscriptions
+func (p *PushSubscription) TableName() string {
+	return "push_subscriptions"
+}
+
+// GetPushSubscriptions returns all push subscriptions of a notifiable.
+func GetPushSubscriptions(s *xorm.Session, notifiableID int64) (subscriptions []*PushSubscription, err error) {
+	subscriptions = []*PushSubscription{}
+	err = s.
+		Where("notifiable_id = ?", notifiableID).
+		OrderBy("id ASC").
+		Find(&subscriptions)
+	return
+}
+
+// AddPushSubscription saves a new push subscription. If the endpoint was already subscribed before
+// by the same notifiable, the existing subscription is replaced. Subscriptions of other notifiables are never touched.
+func AddPushSubscription(s *xorm.Session, subscription *PushSubscription) (err error) {
+	_, err = s.
+		Where("endpoint = ? AND notifiable_id = ?", subscription.Endpoint, subscription.NotifiableID).
+		Delete(&PushSubscription{})
+	if err != nil {
+		return err
+	}
+
+	subscription.ID = 0
+	_, err = s.Insert(subscription)
+	return
+}
+
+// DeletePushSubscription removes a push subscription of a notifiable.
+func DeletePushSubscription(s *xorm.Session, id, notifiableID int64) (err error) {
+	_, err = s.
+		Where("id = ? AND notifiable_id = ?", id, notifiableID).
+		Delete(&PushSubscription{})
+	return
+}
+
+// DeleteAllPushSubscriptions removes all push subscriptions of a notifiable.
+func DeleteAllPushSubscriptions(s *xorm.Session, notifiableID int64) (err error) {
+	_, err = s.
+		Where("notifiable_id = ?", notifiableID).
+		Delete(&PushSubscription{})
+	return
+}
+
+func notifyPush(notifiable Notifiable, notification Notification) error {
+	if !config.WebPushEnabled.GetBool() {
+		return nil
+	}
+
+	push := notification.ToPush()
+	if push == nil {
+		return nil
+	}
+	push.Name = notification.Name()
+
+	payload, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	subscriptions, err := GetPushSubscriptions(s, notifiable.RouteForDB())
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		err = webpush.Send(&webpush.Subscription{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.P256dh,
+			Auth:     subscription.Auth,
+		}, payload)
+		if errors.Is(err, webpush.ErrSubscriptionGone) {
+			log.Debugf("Push subscription %d of notifiable %d is gone, removing it", subscription.ID, notifiable.RouteForDB())
+			err = DeletePushSubscription(s, subscription.ID, subscription.NotifiableID)
+			if err != nil {
+				_ = s.Rollback()
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			// A single broken push service should not prevent the other subscriptions from getting the message
+			log.Errorf("Could not send push notification %s to subscription %d: %s", notification.Name(), subscription.ID, err)
+		}
+	}
+
+	return s.Commit()
+}
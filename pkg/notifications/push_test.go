@@ -0,0 +1,138 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package notifications
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/modules/webpush"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPushSubscription(t *testing.T, endpoint string) *PushSubscription {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	assert.NoError(t, err)
+
+	return &PushSubscription{
+		NotifiableID: 42,
+		Endpoint:     endpoint,
+		P256dh:       base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:         base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+func TestNotifyPush(t *testing.T) {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	assert.NoError(t, err)
+	config.WebPushEnabled.Set(true)
+	config.WebPushVAPIDPublicKey.Set(publicKey)
+	config.WebPushVAPIDPrivateKey.Set(privateKey)
+	defer config.WebPushEnabled.Set(false)
+
+	var receivedMessages int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		receivedMessages++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	s := db.NewSession()
+	_, err = s.Exec("delete from push_subscriptions")
+	assert.NoError(t, err)
+	active := newTestPushSubscription(t, server.URL+"/active")
+	err = AddPushSubscription(s, active)
+	assert.NoError(t, err)
+	gone := newTestPushSubscription(t, server.URL+"/gone")
+	err = AddPushSubscription(s, gone)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Commit())
+	s.Close()
+
+	t.Run("normal", func(t *testing.T) {
+		err := Notify(&testNotifiable{ShouldSendNotification: true}, &testNotification{Test: "push"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, receivedMessages)
+		db.AssertExists(t, "push_subscriptions", map[string]interface{}{
+			"id": active.ID,
+		}, false)
+		db.AssertMissing(t, "push_subscriptions", map[string]interface{}{
+			"id": gone.ID,
+		})
+	})
+	t.Run("disabled push channel", func(t *testing.T) {
+		receivedMessages = 0
+		tnf := &testNotifiableWithPreferences{
+			testNotifiable: testNotifiable{
+				ShouldSendNotification: true,
+			},
+			DisabledChannels: map[string]bool{
+				ChannelPush: true,
+			},
+		}
+
+		err := Notify(tnf, &testNotification{Test: "push"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, receivedMessages)
+	})
+}
+
+func TestAddPushSubscription(t *testing.T) {
+	s := db.NewSession()
+	defer s.Close()
+	_, err := s.Exec("delete from push_subscriptions")
+	assert.NoError(t, err)
+
+	first := newTestPushSubscription(t, "https://push.example.com/endpoint")
+	err = AddPushSubscription(s, first)
+	assert.NoError(t, err)
+
+	t.Run("same notifiable replaces the subscription", func(t *testing.T) {
+		again := newTestPushSubscription(t, first.Endpoint)
+		err := AddPushSubscription(s, again)
+		assert.NoError(t, err)
+
+		subscriptions, err := GetPushSubscriptions(s, 42)
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+		assert.Equal(t, again.ID, subscriptions[0].ID)
+	})
+	t.Run("other notifiable does not remove the subscription", func(t *testing.T) {
+		other := newTestPushSubscription(t, first.Endpoint)
+		other.NotifiableID = 43
+		err := AddPushSubscription(s, other)
+		assert.NoError(t, err)
+
+		subscriptions, err := GetPushSubscriptions(s, 42)
+		assert.NoError(t, err)
+		assert.Len(t, subscriptions, 1)
+	})
+}
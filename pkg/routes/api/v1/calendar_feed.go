@@ -0,0 +1,76 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	"code.vikunja.io/api/pkg/caldav"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/utils"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCalendarFeed returns the tasks of a calendar feed as iCalendar file
+// @Summary Get a calendar feed
+// @Description Returns the tasks of the project or saved filter of a calendar feed as iCalendar file, to subscribe to it with calendar apps which don't support CalDAV. This route does not need any authentication, the secret token in the url is enough. Depending on the settings of the feed, tasks are included as VTODO and VEVENT at their due date or from their start until their end date. Clients should send the ETag of the last response as `If-None-Match` header to get a 304 response if nothing changed.
+// @tags user
+// @Produce text/calendar
+// @Param token path string true "The secret token of the feed, optionally followed by `.ics`."
+// @Param If-None-Match header string false "The ETag of the last response."
+// @Success 200 {string} string "The iCalendar file."
+// @Success 304 "The feed did not change since the last request."
+// @Failure 404 {object} web.HTTPError "The calendar feed does not exist or its owner can't access the project or filter anymore."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /feeds/{token} [get]
+func GetCalendarFeed(c echo.Context) error {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	s := db.NewSession()
+	defer s.Close()
+
+	feed, err := models.GetCalendarFeedByToken(s, token)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	title, tasks, err := feed.GetTasks(s)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	calendar := caldav.GetCalendarFeedForTasks(feed, title, tasks)
+
+	// The url is a secret, shared caches must not store it.
+	etag := `"` + utils.Sha256(calendar) + `"`
+	c.Response().Header().Set("Cache-Control", "private, max-age=900")
+	c.Response().Header().Set("ETag", etag)
+
+	if ifNoneMatch := c.Request().Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, match := range strings.Split(ifNoneMatch, ",") {
+			if strings.TrimPrefix(strings.TrimSpace(match), "W/") == etag {
+				return c.NoContent(http.StatusNotModified)
+			}
+		}
+	}
+
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
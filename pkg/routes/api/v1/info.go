@@ -22,11 +22,16 @@ import (
 	"code.vikunja.io/api/pkg/config"
 	"code.vikunja.io/api/pkg/log"
 	"code.vikunja.io/api/pkg/modules/auth/openid"
+	"code.vikunja.io/api/pkg/modules/migration/csv"
+	gitissues "code.vikunja.io/api/pkg/modules/migration/git-issues"
+	"code.vikunja.io/api/pkg/modules/migration/jira"
 	microsofttodo "code.vikunja.io/api/pkg/modules/migration/microsoft-todo"
 	"code.vikunja.io/api/pkg/modules/migration/ticktick"
 	"code.vikunja.io/api/pkg/modules/migration/todoist"
+	"code.vikunja.io/api/pkg/modules/migration/todotxt"
 	"code.vikunja.io/api/pkg/modules/migration/trello"
 	vikunja_file "code.vikunja.io/api/pkg/modules/migration/vikunja-file"
+	vikunjainstance "code.vikunja.io/api/pkg/modules/migration/vikunja-instance"
 	"code.vikunja.io/api/pkg/version"
 
 	"github.com/labstack/echo/v4"
@@ -51,6 +56,12 @@ type vikunjaInfos struct {
 	TaskCommentsEnabled        bool      `json:"task_comments_enabled"`
 	DemoModeEnabled            bool      `json:"demo_mode_enabled"`
 	WebhooksEnabled            bool      `json:"webhooks_enabled"`
+	WebPush                    webPush   `json:"web_push"`
+}
+
+type webPush struct {
+	Enabled        bool   `json:"enabled"`
+	VAPIDPublicKey string `json:"vapid_public_key"`
 }
 
 type authInfo struct {
@@ -96,9 +107,18 @@ func Info(c echo.Context) error {
 		TaskCommentsEnabled:    config.ServiceEnableTaskComments.GetBool(),
 		DemoModeEnabled:        config.ServiceDemoMode.GetBool(),
 		WebhooksEnabled:        config.WebhooksEnabled.GetBool(),
+		WebPush: webPush{
+			Enabled: config.WebPushEnabled.GetBool(),
+		},
 		AvailableMigrators: []string{
 			(&vikunja_file.FileMigrator{}).Name(),
 			(&ticktick.Migrator{}).Name(),
+			(&csv.Migrator{}).Name(),
+			(&jira.Migrator{}).Name(),
+			(&gitissues.Migrator{}).Name(),
+			(&todotxt.Migrator{}).Name(),
+			(&trello.FileMigrator{}).Name(),
+			(&todoist.FileMigrator{}).Name(),
 		},
 		Legal: legalInfo{
 			ImprintURL:       config.LegalImprintURL.GetString(),
@@ -123,6 +143,10 @@ func Info(c echo.Context) error {
 
 	info.AuthInfo.OpenIDConnect.Providers = providers
 
+	if info.WebPush.Enabled {
+		info.WebPush.VAPIDPublicKey = config.WebPushVAPIDPublicKey.GetString()
+	}
+
 	// Migrators
 	if config.MigrationTodoistEnable.GetBool() {
 		m := &todoist.Migration{}
@@ -136,6 +160,10 @@ func Info(c echo.Context) error {
 		m := &microsofttodo.Migration{}
 		info.AvailableMigrators = append(info.AvailableMigrators, m.Name())
 	}
+	if config.MigrationVikunjaInstanceEnable.GetBool() {
+		m := &vikunjainstance.Migration{}
+		info.AvailableMigrators = append(info.AvailableMigrators, m.Name())
+	}
 
 	if config.BackgroundsEnabled.GetBool() {
 		if config.BackgroundsUploadEnabled.GetBool() {
@@ -0,0 +1,251 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/auth"
+	"code.vikunja.io/api/pkg/modules/migration"
+	"code.vikunja.io/api/pkg/modules/migration/todotxt"
+	"code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExportProject returns a project with everything in it as json or csv
+// @Summary Export a project
+// @Description Exports a project with all of its child projects, tasks, comments, attachments, labels and buckets. As json, the export contains the contents of all attachments and backgrounds and can be imported again with the project import endpoint. As csv, it contains one row per task with the most important task properties.
+// @tags project
+// @Produce json
+// @Produce text/csv
+// @Security JWTKeyAuth
+// @Param id path int true "Project ID"
+// @Param format query string false "The format of the export, either `json` (the default) or `csv`."
+// @Success 200 {object} models.ProjectExport "The exported project."
+// @Failure 400 {object} web.HTTPError "Invalid export format."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 404 {object} web.HTTPError "The project does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /projects/{id}/export [get]
+func ExportProject(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("project"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project id")
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid export format, must be json or csv")
+	}
+
+	a, err := auth.GetAuthFromClaims(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	export, err := models.GetProjectExport(s, a, projectID)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="project-`+strconv.FormatInt(projectID, 10)+`.`+format+`"`)
+
+	if format == "csv" {
+		buf := &bytes.Buffer{}
+		if err := export.WriteCSV(buf); err != nil {
+			return handler.HandleHTTPError(err, c)
+		}
+		return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	}
+
+	return c.JSON(http.StatusOK, export)
+}
+
+// ExportProjectTodoTxt returns all tasks of a project or saved filter in todo.txt format
+// @Summary Export the tasks of a project as todo.txt
+// @Description Exports all tasks of a project or saved filter in todo.txt format, one task per line. The project of a task becomes its +project, labels become @contexts and the due date is exported as `due:YYYY-MM-DD`. Child projects are not included. The file can be imported again with the todo.txt migrator.
+// @tags project
+// @Produce plain
+// @Security JWTKeyAuth
+// @Param id path int true "Project ID, or the ID of the pseudo project of a saved filter"
+// @Success 200 {string} string "The tasks in todo.txt format."
+// @Failure 403 {object} web.HTTPError "The user does not have access to the project."
+// @Failure 404 {object} web.HTTPError "The project does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /projects/{id}/export/todotxt [get]
+func ExportProjectTodoTxt(c echo.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("project"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project id")
+	}
+
+	a, err := auth.GetAuthFromClaims(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	// Saved filters are checked through their pseudo project as well
+	can, _, err := (&models.Project{ID: projectID}).CanRead(s, a)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+	if !can {
+		return echo.ErrForbidden
+	}
+
+	location := config.GetTimeZone()
+	if _, is := a.(*models.LinkSharing); !is {
+		u, err := user.GetUserByID(s, a.GetID())
+		if err != nil {
+			return handler.HandleHTTPError(err, c)
+		}
+		if u.Timezone != "" {
+			if loc, err := time.LoadLocation(u.Timezone); err == nil {
+				location = loc
+			}
+		}
+	}
+
+	result, _, _, err := (&models.TaskCollection{ProjectID: projectID}).ReadAll(s, a, "", -1, 0)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+	tasks := result.([]*models.Task)
+
+	projectIDs := make([]int64, 0, len(tasks))
+	for _, t := range tasks {
+		projectIDs = append(projectIDs, t.ProjectID)
+	}
+	projects, err := models.GetProjectsByIDs(s, projectIDs)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := todotxt.Export(buf, tasks, projects, location); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="project-`+strconv.FormatInt(projectID, 10)+`-todo.txt"`)
+	return c.Blob(http.StatusOK, "text/plain; charset=utf-8", buf.Bytes())
+}
+
+// ImportProject creates a project from a project export below an existing project
+// @Summary Import a project
+// @Description Imports a json project export, created with the project export endpoint, as a new child project of the given project. All child projects, tasks, comments, attachments, labels, buckets and relations between the imported tasks are created as well. Assignees are not imported. The user needs write access to the project.
+// @tags project
+// @Accept mpfd
+// @Produce json
+// @Security JWTKeyAuth
+// @Param id path int true "The id of the project which will be the parent of the imported project"
+// @Param import formData string true "The json project export, as multipart form file."
+// @Success 201 {object} models.Project "The imported project."
+// @Failure 400 {object} web.HTTPError "Invalid project export provided."
+// @Failure 403 {object} web.HTTPError "The user does not have write access to the project."
+// @Failure 404 {object} web.HTTPError "The project does not exist."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /projects/{id}/import [put]
+func ImportProject(c echo.Context) error {
+	parentProjectID, err := strconv.ParseInt(c.Param("project"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project id")
+	}
+
+	file, err := c.FormFile("import")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No project export provided: "+err.Error())
+	}
+	src, err := file.Open()
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+	defer src.Close()
+
+	export := &models.ProjectExport{}
+	if err := json.NewDecoder(src).Decode(export); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project export provided: "+err.Error())
+	}
+
+	a, err := auth.GetAuthFromClaims(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+	if _, is := a.(*models.LinkSharing); is {
+		return echo.ErrForbidden
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+	defer events.CleanupPending(s)
+
+	// Nothing of the export is saved if any part of it could not be imported
+	if err := s.Begin(); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	can, err := (&models.Project{ParentProjectID: parentProjectID}).CanCreate(s, a)
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+	if !can {
+		_ = s.Rollback()
+		return echo.ErrForbidden
+	}
+
+	u, err := user.GetUserByID(s, a.GetID())
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	project, err := migration.ImportProjectExport(s, export, parentProjectID, u)
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	if err := s.Commit(); err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	if err := events.DispatchPending(s); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusCreated, project)
+}
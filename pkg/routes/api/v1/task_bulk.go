@@ -0,0 +1,128 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/events"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/auth"
+	"code.vikunja.io/web"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+	"xorm.io/xorm"
+)
+
+// handleBulkTaskOperation runs a bulk operation in one transaction. If the operation failed for any of the tasks,
+// nothing is saved and the results with the errors of the failed tasks are returned with a 400 status code.
+func handleBulkTaskOperation(c echo.Context, operation interface{}, run func(s *xorm.Session, a web.Auth) ([]*models.BulkTaskResult, bool, error)) error {
+	if err := c.Bind(operation); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No or invalid model provided: "+err.Error())
+	}
+	if err := c.Validate(operation); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	a, err := auth.GetAuthFromClaims(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+	defer events.CleanupPending(s)
+
+	if err := s.Begin(); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	results, failed, err := run(s, a)
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+	if failed {
+		_ = s.Rollback()
+		return c.JSON(http.StatusBadRequest, results)
+	}
+
+	if err := s.Commit(); err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	// Events are only dispatched once everything was saved
+	if err := events.DispatchPending(s); err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// BulkCreateTasks creates multiple tasks at once
+// @Summary Create multiple tasks at once
+// @Description Creates multiple tasks, possibly in different projects, in one transaction. Labels, assignees and reminders are created with each task. The user needs write access to the project of every task. If any task could not be created, no task is created and the response contains the errors of the failed tasks.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param tasks body models.BulkTaskCreate true "The tasks to create."
+// @Success 200 {array} models.BulkTaskResult "The created tasks."
+// @Failure 400 {array} models.BulkTaskResult "At least one task could not be created. Nothing was saved."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/bulk [put]
+func BulkCreateTasks(c echo.Context) error {
+	bt := &models.BulkTaskCreate{}
+	return handleBulkTaskOperation(c, bt, bt.Create)
+}
+
+// BulkDeleteTasks deletes multiple tasks at once
+// @Summary Delete multiple tasks at once
+// @Description Deletes multiple tasks in one transaction. The user needs the right to delete every task. If any task could not be deleted, no task is deleted and the response contains the errors of the failed tasks.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param tasks body models.BulkTaskDelete true "The ids of the tasks to delete."
+// @Success 200 {array} models.BulkTaskResult "The deleted tasks."
+// @Failure 400 {array} models.BulkTaskResult "At least one task could not be deleted. Nothing was deleted."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/bulk [delete]
+func BulkDeleteTasks(c echo.Context) error {
+	bt := &models.BulkTaskDelete{}
+	return handleBulkTaskOperation(c, bt, bt.Delete)
+}
+
+// BulkMoveTasks moves multiple tasks into another project or bucket at once
+// @Summary Move multiple tasks at once
+// @Description Moves multiple tasks into another project and/or kanban bucket in one transaction. The user needs write access to the projects of the tasks and the target project. Tasks moved into another project get a new index in that project. If any task could not be moved, no task is moved and the response contains the errors of the failed tasks.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param tasks body models.BulkTaskMove true "The ids of the tasks to move and where to move them."
+// @Success 200 {array} models.BulkTaskResult "The moved tasks."
+// @Failure 400 {array} models.BulkTaskResult "At least one task could not be moved. Nothing was saved."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/bulk/move [post]
+func BulkMoveTasks(c echo.Context) error {
+	bt := &models.BulkTaskMove{}
+	return handleBulkTaskOperation(c, bt, bt.Move)
+}
@@ -0,0 +1,71 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/auth"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TaskCursorPagination paginates task collections with a cursor instead of page numbers when the `cursor` query
+// parameter is present, even if it's empty. The cursor for the next page is returned in the
+// `x-pagination-next-cursor` header, which is empty on the last page.
+func TaskCursorPagination(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !c.QueryParams().Has("cursor") {
+			return next(c)
+		}
+
+		tc := &models.TaskCollection{}
+		if err := c.Bind(tc); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "No or invalid model provided: "+err.Error())
+		}
+
+		var perPage int
+		if c.QueryParam("per_page") != "" {
+			var err error
+			perPage, err = strconv.Atoi(c.QueryParam("per_page"))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid per_page: "+err.Error())
+			}
+		}
+
+		a, err := auth.GetAuthFromClaims(c)
+		if err != nil {
+			return handler.HandleHTTPError(err, c)
+		}
+
+		s := db.NewSession()
+		defer s.Close()
+
+		tasks, nextCursor, err := tc.ReadAllByCursor(s, a, c.QueryParam("s"), c.QueryParam("cursor"), perPage)
+		if err != nil {
+			return handler.HandleHTTPError(err, c)
+		}
+
+		c.Response().Header().Set("x-pagination-next-cursor", nextCursor)
+		c.Response().Header().Set("x-pagination-result-count", strconv.Itoa(len(tasks)))
+		return c.JSON(http.StatusOK, tasks)
+	}
+}
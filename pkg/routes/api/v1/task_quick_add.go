@@ -0,0 +1,94 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/modules/auth"
+	"code.vikunja.io/web/handler"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ParseTask parses a quick add text into a task
+// @Summary Parse a quick add text
+// @Description Parses a text like "Buy milk tomorrow 5pm *errands @alice +Home !3 every week" into a task without creating it. Recognizes dates and times, labels (`*`), assignees (`@`), a project (`+`), a priority (`!`) and repeating intervals. Dates are in the timezone of the user. To create the task right away, pass `quick_add=true` when creating it.
+// @tags task
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param text body models.QuickAdd true "The text to parse. Only `text` and `project_id` are used."
+// @Success 200 {object} models.QuickAdd "The parsed task and all parts of the text which were recognized."
+// @Failure 400 {object} web.HTTPError "Invalid text provided."
+// @Failure 500 {object} models.Message "Internal error"
+// @Router /tasks/parse [post]
+func ParseTask(c echo.Context) error {
+	qa := &models.QuickAdd{}
+	if err := c.Bind(qa); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "No or invalid model provided: "+err.Error())
+	}
+
+	a, err := auth.GetAuthFromClaims(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	err = models.ParseQuickAdd(s, a, qa)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, qa)
+}
+
+// QuickAddQueryParam lets clients enable the quick add mode with a `quick_add=true` query parameter when creating
+// a task. Echo only binds query parameters for GET and DELETE requests, so this moves it into the request body.
+func QuickAddQueryParam(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		quickAdd, _ := strconv.ParseBool(c.QueryParam("quick_add"))
+		if !quickAdd {
+			return next(c)
+		}
+
+		body := map[string]interface{}{}
+		decoder := json.NewDecoder(c.Request().Body)
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "No or invalid model provided: "+err.Error())
+		}
+		body["quick_add"] = true
+
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(raw))
+		c.Request().ContentLength = int64(len(raw))
+
+		return next(c)
+	}
+}
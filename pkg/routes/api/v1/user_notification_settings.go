@@ -0,0 +1,158 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/api/pkg/notifications"
+	user2 "code.vikunja.io/api/pkg/user"
+	"code.vikunja.io/web/handler"
+)
+
+// UserNotificationSettings holds the notification preferences of a user
+type UserNotificationSettings struct {
+	// All notification preferences of the user. A preference with a project id overrides the general preference
+	// for the same notification and channel for that project. Every notification without a preference is sent
+	// through all channels.
+	Preferences []*user2.NotificationPreference `json:"preferences"`
+	// The names of all notifications which can be configured. Read only.
+	AvailableNotifications []string `json:"available_notifications"`
+	// All channels notifications can be sent through. Read only.
+	AvailableChannels []string `json:"available_channels"`
+}
+
+// GetUserNotificationSettings returns the notification preferences of the current user
+// @Summary Get the notification preferences of the current user.
+// @Description Returns all notification preferences of the current user, together with all notifications and channels which can be configured.
+// @tags user
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Success 200 {object} v1.UserNotificationSettings
+// @Failure 400 {object} web.HTTPError "Something's invalid."
+// @Failure 500 {object} models.Message "Internal server error."
+// @Router /user/settings/notifications [get]
+func GetUserNotificationSettings(c echo.Context) error {
+	u, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	preferences, err := user2.GetNotificationPreferences(s, u)
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	if err := s.Commit(); err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, &UserNotificationSettings{
+		Preferences:            preferences,
+		AvailableNotifications: models.ConfigurableNotificationNames(),
+		AvailableChannels:      notifications.Channels(),
+	})
+}
+
+// UpdateUserNotificationSettings is the handler to change the notification preferences of the current user
+// @Summary Change the notification preferences of the current user.
+// @Description Replaces all notification preferences of the current user with the ones provided.
+// @tags user
+// @Accept json
+// @Produce json
+// @Security JWTKeyAuth
+// @Param settings body v1.UserNotificationSettings true "The updated notification preferences"
+// @Success 200 {object} models.Message
+// @Failure 400 {object} web.HTTPError "Something's invalid."
+// @Failure 403 {object} web.HTTPError "The user does not have access to a project of a preference."
+// @Failure 412 {object} web.HTTPError "A notification or channel is invalid."
+// @Failure 500 {object} models.Message "Internal server error."
+// @Router /user/settings/notifications [post]
+func UpdateUserNotificationSettings(c echo.Context) error {
+	us := &UserNotificationSettings{}
+	err := c.Bind(us)
+	if err != nil {
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid model provided. Error was: %s", he.Message))
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model provided.")
+	}
+
+	u, err := user2.GetCurrentUser(c)
+	if err != nil {
+		return handler.HandleHTTPError(err, c)
+	}
+
+	configurable := make(map[string]bool)
+	for _, name := range models.ConfigurableNotificationNames() {
+		configurable[name] = true
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	for _, p := range us.Preferences {
+		if !configurable[p.NotificationName] {
+			_ = s.Rollback()
+			return handler.HandleHTTPError(&user2.ErrInvalidNotificationPreference{
+				NotificationName: p.NotificationName,
+				Channel:          p.Channel,
+			}, c)
+		}
+
+		if p.ProjectID == 0 {
+			continue
+		}
+
+		project := &models.Project{ID: p.ProjectID}
+		can, _, err := project.CanRead(s, u)
+		if err != nil {
+			_ = s.Rollback()
+			return handler.HandleHTTPError(err, c)
+		}
+		if !can {
+			_ = s.Rollback()
+			return handler.HandleHTTPError(models.ErrNeedToHaveProjectReadAccess{ProjectID: p.ProjectID, UserID: u.ID}, c)
+		}
+	}
+
+	err = user2.SetNotificationPreferences(s, u, us.Preferences)
+	if err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	if err := s.Commit(); err != nil {
+		_ = s.Rollback()
+		return handler.HandleHTTPError(err, c)
+	}
+
+	return c.JSON(http.StatusOK, &models.Message{Message: "The notification settings were updated successfully."})
+}
@@ -59,6 +59,9 @@ type UserSettings struct {
 	Language string `json:"language"`
 	// The user's time zone. Used to send task reminders in the time zone of the user.
 	Timezone string `json:"timezone"`
+	// If set, mail notifications about task comments and assignments are not sent right away but collected
+	// and sent as one digest mail. Can be `hourly`, `daily` or `weekly`. Leave empty to get all mails right away.
+	NotificationDigestInterval string `json:"notification_digest_interval" valid:"in(hourly|daily|weekly)"`
 	// Additional settings only used by the frontend
 	FrontendSettings interface{} `json:"frontend_settings"`
 }
@@ -201,6 +204,7 @@ func UpdateGeneralUserSettings(c echo.Context) error {
 	user.Timezone = us.Timezone
 	user.OverdueTasksRemindersTime = us.OverdueTasksRemindersTime
 	user.FrontendSettings = us.FrontendSettings
+	user.NotificationDigestInterval = us.NotificationDigestInterval
 
 	_, err = user2.UpdateUser(s, user, true)
 	if err != nil {
@@ -77,6 +77,7 @@ func UserShow(c echo.Context) error {
 			Timezone:                     u.Timezone,
 			OverdueTasksRemindersTime:    u.OverdueTasksRemindersTime,
 			FrontendSettings:             u.FrontendSettings,
+			NotificationDigestInterval:   u.NotificationDigestInterval,
 		},
 		DeletionScheduledAt: u.DeletionScheduledAt,
 		IsLocalUser:         u.Issuer == user.IssuerLocal,
@@ -0,0 +1,56 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"code.vikunja.io/api/pkg/models"
+	"code.vikunja.io/web"
+
+	"github.com/labstack/echo/v4"
+)
+
+// VersionConflict responds with the current state of an entity if an update failed because the entity was
+// changed since the version the client sent in the If-Match header. The current state is read with the passed
+// handler, but returned with a 412 status code so clients can show both versions to the user.
+func VersionConflict(read echo.HandlerFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			var herr *echo.HTTPError
+			if !errors.As(err, &herr) {
+				return err
+			}
+			details, is := herr.Message.(web.HTTPError)
+			if !is || details.Code != models.ErrCodeVersionConflict {
+				return err
+			}
+
+			// The body was already used for the update, reading only needs the path params.
+			c.Request().Body = http.NoBody
+			c.Request().ContentLength = 0
+			c.Response().Before(func() {
+				c.Response().Status = http.StatusPreconditionFailed
+			})
+
+			return read(c)
+		}
+	}
+}
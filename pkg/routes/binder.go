@@ -0,0 +1,43 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package routes
+
+import (
+	"reflect"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CustomBinder binds requests like echo's default binder and additionally binds headers into struct fields with
+// a `header` tag, for example the If-Match header used to update tasks and projects.
+type CustomBinder struct {
+	echo.DefaultBinder
+}
+
+// Bind binds path params, query params, the request body and headers
+func (cb *CustomBinder) Bind(i interface{}, c echo.Context) error {
+	if err := cb.DefaultBinder.Bind(i, c); err != nil {
+		return err
+	}
+
+	typ := reflect.TypeOf(i)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	return cb.BindHeaders(c, i)
+}
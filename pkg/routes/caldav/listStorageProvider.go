@@ -457,9 +457,9 @@ func (vlra *VikunjaProjectResourceAdapter) CalculateEtag() string {
 	//	 return `"` + strconv.FormatInt(vlra.project.ID, 10) + `-` + strconv.FormatInt(vlra.project.Updated, 10) + `"`
 	// }
 
-	// Return the etag of a task if we have one
+	// Return the etag of a task if we have one. This is the same etag the api uses for the If-Match header.
 	if vlra.task != nil {
-		return `"` + strconv.FormatInt(vlra.task.ID, 10) + `-` + strconv.FormatInt(vlra.task.Updated.Unix(), 10) + `"`
+		return models.GetEtag(vlra.task.ID, vlra.task.Version)
 	}
 
 	if vlra.project == nil {
@@ -469,6 +469,8 @@ func (vlra *VikunjaProjectResourceAdapter) CalculateEtag() string {
 	// This also returns the etag of the project, and not of the task,
 	// which becomes problematic because the client uses this etag (= the one from the project) to make
 	// Requests to update a task. These do not match and thus updating a task fails.
+	// Unlike the version, the updated timestamp of a project also changes when one of its tasks changes, which
+	// is what clients rely on to detect changes in the collection.
 	return `"` + strconv.FormatInt(vlra.project.ID, 10) + `-` + strconv.FormatInt(vlra.project.Updated.Unix(), 10) + `"`
 }
 
@@ -21,9 +21,13 @@
 // @description Every endpoint capable of pagination will return two headers:
 // @description * `x-pagination-total-pages`: The total number of available pages for this request
 // @description * `x-pagination-result-count`: The number of items returned for this request.
+// @description
+// @description Task collections can also be paginated with a cursor by passing a `cursor` query parameter instead of `page`, empty for the first page. Cursor pagination does not skip or duplicate tasks when tasks change between requests. Instead of the total pages, these requests return the cursor for the next page in the `x-pagination-next-cursor` header, which is empty on the last page.
 // @description # Rights
 // @description All endpoints which return a single item (project, task, etc.) - no array - will also return a `x-max-right` header with the max right the user has on this item as an int where `0` is `Read Only`, `1` is `Read & Write` and `2` is `Admin`.
 // @description This can be used to show or hide ui elements based on the rights the user has.
+// @description # Concurrent changes
+// @description Tasks, projects, buckets and comments have a `version` which is increased with every change. To make sure an update does not overwrite changes someone else made in the meantime, send the version (or the ETag `"<id>-<version>"`) you based your changes on in an `If-Match` header. Every response containing a single task, project, bucket or comment returns its current ETag in the `ETag` header. If the entity was changed since then, the update is rejected with a `412` status code and the error message contains the current ETag.
 // @description # Errors
 // @description All errors have an error code and a human-readable error message in addition to the http status code. You should always check for the status code in the response, not only the http status code.
 // @description Due to limitations in the swagger library we're using for this document, only one error per http status code is documented here. Make sure to check the [error docs](https://vikunja.io/docs/errors/) in Vikunja's documentation for a full list of available error codes.
@@ -68,12 +72,16 @@ import (
 	"code.vikunja.io/api/pkg/modules/background/unsplash"
 	"code.vikunja.io/api/pkg/modules/background/upload"
 	"code.vikunja.io/api/pkg/modules/migration"
+	gitissues "code.vikunja.io/api/pkg/modules/migration/git-issues"
 	migrationHandler "code.vikunja.io/api/pkg/modules/migration/handler"
+	"code.vikunja.io/api/pkg/modules/migration/jira"
 	microsofttodo "code.vikunja.io/api/pkg/modules/migration/microsoft-todo"
 	"code.vikunja.io/api/pkg/modules/migration/ticktick"
 	"code.vikunja.io/api/pkg/modules/migration/todoist"
+	"code.vikunja.io/api/pkg/modules/migration/todotxt"
 	"code.vikunja.io/api/pkg/modules/migration/trello"
 	vikunja_file "code.vikunja.io/api/pkg/modules/migration/vikunja-file"
+	vikunjainstance "code.vikunja.io/api/pkg/modules/migration/vikunja-instance"
 	apiv1 "code.vikunja.io/api/pkg/routes/api/v1"
 	"code.vikunja.io/api/pkg/routes/caldav"
 	"code.vikunja.io/api/pkg/version"
@@ -151,6 +159,12 @@ func NewEcho() *echo.Echo {
 	// Validation
 	e.Validator = &CustomValidator{}
 
+	// Binding, including headers
+	e.Binder = &CustomBinder{}
+
+	// Serialization, including the ETag header
+	e.JSONSerializer = &CustomJSONSerializer{}
+
 	// Handler config
 	handler.SetAuthProvider(&web.Auths{
 		AuthObject: auth.GetAuthFromClaims,
@@ -275,6 +289,9 @@ func registerAPIRoutes(a *echo.Group) {
 	// Avatar endpoint
 	n.GET("/avatar/:username", apiv1.GetAvatar)
 
+	// Calendar feeds are authenticated by the secret token in their url
+	n.GET("/feeds/:token", apiv1.GetCalendarFeed)
+
 	// Link share auth
 	if config.ServiceEnableLinkSharing.GetBool() {
 		ur.POST("/shares/:share/auth", apiv1.AuthenticateLinkShare)
@@ -304,6 +321,8 @@ func registerAPIRoutes(a *echo.Group) {
 	u.POST("/settings/avatar", apiv1.ChangeUserAvatarProvider)
 	u.PUT("/settings/avatar/upload", apiv1.UploadAvatar)
 	u.POST("/settings/general", apiv1.UpdateGeneralUserSettings)
+	u.GET("/settings/notifications", apiv1.GetUserNotificationSettings)
+	u.POST("/settings/notifications", apiv1.UpdateUserNotificationSettings)
 	u.POST("/export/request", apiv1.RequestUserDataExport)
 	u.POST("/export/download", apiv1.DownloadUserDataExport)
 	u.GET("/timezones", apiv1.GetAvailableTimezones)
@@ -311,6 +330,16 @@ func registerAPIRoutes(a *echo.Group) {
 	u.GET("/settings/token/caldav", apiv1.GetCaldavTokens)
 	u.DELETE("/settings/token/caldav/:id", apiv1.DeleteCaldavToken)
 
+	calendarFeedHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.CalendarFeed{}
+		},
+	}
+	u.GET("/settings/calendar-feeds", calendarFeedHandler.ReadAllWeb)
+	u.PUT("/settings/calendar-feeds", calendarFeedHandler.CreateWeb)
+	u.POST("/settings/calendar-feeds/:feed", calendarFeedHandler.UpdateWeb)
+	u.DELETE("/settings/calendar-feeds/:feed", calendarFeedHandler.DeleteWeb)
+
 	if config.ServiceEnableTotp.GetBool() {
 		u.GET("/settings/totp", apiv1.UserTOTP)
 		u.POST("/settings/totp/enroll", apiv1.UserTOTPEnroll)
@@ -333,7 +362,7 @@ func registerAPIRoutes(a *echo.Group) {
 	}
 	a.GET("/projects", projectHandler.ReadAllWeb)
 	a.GET("/projects/:project", projectHandler.ReadOneWeb)
-	a.POST("/projects/:project", projectHandler.UpdateWeb)
+	a.POST("/projects/:project", projectHandler.UpdateWeb, apiv1.VersionConflict(projectHandler.ReadOneWeb))
 	a.DELETE("/projects/:project", projectHandler.DeleteWeb)
 	a.PUT("/projects", projectHandler.CreateWeb)
 	a.GET("/projects/:project/projectusers", apiv1.ListUsersForProject)
@@ -355,7 +384,15 @@ func registerAPIRoutes(a *echo.Group) {
 			return &models.TaskCollection{}
 		},
 	}
-	a.GET("/projects/:project/tasks", taskCollectionHandler.ReadAllWeb)
+	a.GET("/projects/:project/tasks", taskCollectionHandler.ReadAllWeb, apiv1.TaskCursorPagination)
+
+	taskAggregateHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.TaskAggregate{}
+		},
+	}
+	a.GET("/projects/:project/tasks/aggregate", taskAggregateHandler.ReadAllWeb)
+	a.GET("/tasks/aggregate", taskAggregateHandler.ReadAllWeb)
 
 	kanbanBucketHandler := &handler.WebHandler{
 		EmptyStruct: func() handler.CObject {
@@ -364,7 +401,8 @@ func registerAPIRoutes(a *echo.Group) {
 	}
 	a.GET("/projects/:project/buckets", kanbanBucketHandler.ReadAllWeb)
 	a.PUT("/projects/:project/buckets", kanbanBucketHandler.CreateWeb)
-	a.POST("/projects/:project/buckets/:bucket", kanbanBucketHandler.UpdateWeb)
+	a.GET("/projects/:project/buckets/:bucket", kanbanBucketHandler.ReadOneWeb)
+	a.POST("/projects/:project/buckets/:bucket", kanbanBucketHandler.UpdateWeb, apiv1.VersionConflict(kanbanBucketHandler.ReadOneWeb))
 	a.DELETE("/projects/:project/buckets/:bucket", kanbanBucketHandler.DeleteWeb)
 
 	projectDuplicateHandler := &handler.WebHandler{
@@ -373,17 +411,21 @@ func registerAPIRoutes(a *echo.Group) {
 		},
 	}
 	a.PUT("/projects/:projectid/duplicate", projectDuplicateHandler.CreateWeb)
+	a.GET("/projects/:project/export", apiv1.ExportProject)
+	a.GET("/projects/:project/export/todotxt", apiv1.ExportProjectTodoTxt)
+	a.PUT("/projects/:project/import", apiv1.ImportProject)
 
 	taskHandler := &handler.WebHandler{
 		EmptyStruct: func() handler.CObject {
 			return &models.Task{}
 		},
 	}
-	a.PUT("/projects/:project/tasks", taskHandler.CreateWeb)
+	a.PUT("/projects/:project/tasks", taskHandler.CreateWeb, apiv1.QuickAddQueryParam)
 	a.GET("/tasks/:projecttask", taskHandler.ReadOneWeb)
-	a.GET("/tasks/all", taskCollectionHandler.ReadAllWeb)
+	a.GET("/tasks/all", taskCollectionHandler.ReadAllWeb, apiv1.TaskCursorPagination)
+	a.POST("/tasks/parse", apiv1.ParseTask)
 	a.DELETE("/tasks/:projecttask", taskHandler.DeleteWeb)
-	a.POST("/tasks/:projecttask", taskHandler.UpdateWeb)
+	a.POST("/tasks/:projecttask", taskHandler.UpdateWeb, apiv1.VersionConflict(taskHandler.ReadOneWeb))
 
 	bulkTaskHandler := &handler.WebHandler{
 		EmptyStruct: func() handler.CObject {
@@ -391,6 +433,23 @@ func registerAPIRoutes(a *echo.Group) {
 		},
 	}
 	a.POST("/tasks/bulk", bulkTaskHandler.UpdateWeb)
+	a.PUT("/tasks/bulk", apiv1.BulkCreateTasks)
+	a.DELETE("/tasks/bulk", apiv1.BulkDeleteTasks)
+	a.POST("/tasks/bulk/move", apiv1.BulkMoveTasks)
+
+	taskMoveHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.TaskMove{}
+		},
+	}
+	a.POST("/tasks/:projecttask/move", taskMoveHandler.UpdateWeb)
+
+	taskByIdentifierHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.TaskByIdentifier{}
+		},
+	}
+	a.GET("/tasks/by-identifier/:identifier", taskByIdentifierHandler.ReadOneWeb)
 
 	assigneeTaskHandler := &handler.WebHandler{
 		EmptyStruct: func() handler.CObject {
@@ -453,7 +512,7 @@ func registerAPIRoutes(a *echo.Group) {
 		a.GET("/tasks/:task/comments", taskCommentHandler.ReadAllWeb)
 		a.PUT("/tasks/:task/comments", taskCommentHandler.CreateWeb)
 		a.DELETE("/tasks/:task/comments/:commentid", taskCommentHandler.DeleteWeb)
-		a.POST("/tasks/:task/comments/:commentid", taskCommentHandler.UpdateWeb)
+		a.POST("/tasks/:task/comments/:commentid", taskCommentHandler.UpdateWeb, apiv1.VersionConflict(taskCommentHandler.ReadOneWeb))
 		a.GET("/tasks/:task/comments/:commentid", taskCommentHandler.ReadOneWeb)
 	}
 
@@ -527,6 +586,22 @@ func registerAPIRoutes(a *echo.Group) {
 	a.PUT("/subscriptions/:entity/:entityID", subscriptionHandler.CreateWeb)
 	a.DELETE("/subscriptions/:entity/:entityID", subscriptionHandler.DeleteWeb)
 
+	// Search
+	searchHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.Search{}
+		},
+	}
+	a.GET("/search", searchHandler.ReadAllWeb)
+
+	// Sync
+	syncHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.Sync{}
+		},
+	}
+	a.GET("/sync", syncHandler.ReadOneWeb)
+
 	// Notifications
 	notificationHandler := &handler.WebHandler{
 		EmptyStruct: func() handler.CObject {
@@ -537,6 +612,31 @@ func registerAPIRoutes(a *echo.Group) {
 	a.POST("/notifications/:notificationid", notificationHandler.UpdateWeb)
 	a.POST("/notifications", apiv1.MarkAllNotificationsAsRead)
 
+	if config.WebPushEnabled.GetBool() {
+		pushSubscriptionHandler := &handler.WebHandler{
+			EmptyStruct: func() handler.CObject {
+				return &models.PushSubscriptions{}
+			},
+		}
+		a.GET("/notifications/push/subscriptions", pushSubscriptionHandler.ReadAllWeb)
+		a.PUT("/notifications/push/subscriptions", pushSubscriptionHandler.CreateWeb)
+		a.DELETE("/notifications/push/subscriptions/:subscription", pushSubscriptionHandler.DeleteWeb)
+	}
+
+	chatDestinationHandler := &handler.WebHandler{
+		EmptyStruct: func() handler.CObject {
+			return &models.ChatDestinations{}
+		},
+	}
+	a.GET("/user/settings/chat-destinations", chatDestinationHandler.ReadAllWeb)
+	a.PUT("/user/settings/chat-destinations", chatDestinationHandler.CreateWeb)
+	a.POST("/user/settings/chat-destinations/:destination", chatDestinationHandler.UpdateWeb)
+	a.DELETE("/user/settings/chat-destinations/:destination", chatDestinationHandler.DeleteWeb)
+	a.GET("/projects/:project/chat-destinations", chatDestinationHandler.ReadAllWeb)
+	a.PUT("/projects/:project/chat-destinations", chatDestinationHandler.CreateWeb)
+	a.POST("/projects/:project/chat-destinations/:destination", chatDestinationHandler.UpdateWeb)
+	a.DELETE("/projects/:project/chat-destinations/:destination", chatDestinationHandler.DeleteWeb)
+
 	// Migrations
 	m := a.Group("/migration")
 	registerMigrations(m)
@@ -622,6 +722,16 @@ func registerMigrations(m *echo.Group) {
 		microsoftTodoMigrationHandler.RegisterRoutes(m)
 	}
 
+	// Other Vikunja instances
+	if config.MigrationVikunjaInstanceEnable.GetBool() {
+		vikunjaInstanceMigrationHandler := &migrationHandler.MigrationWeb{
+			MigrationStruct: func() migration.Migrator {
+				return &vikunjainstance.Migration{}
+			},
+		}
+		vikunjaInstanceMigrationHandler.RegisterRoutes(m)
+	}
+
 	// Vikunja File Migrator
 	vikunjaFileMigrationHandler := &migrationHandler.FileMigratorWeb{
 		MigrationStruct: func() migration.FileMigrator {
@@ -637,6 +747,50 @@ func registerMigrations(m *echo.Group) {
 		},
 	}
 	tickTickFileMigrator.RegisterRoutes(m)
+
+	// Jira File Migrator
+	jiraFileMigrator := migrationHandler.FileMigratorWeb{
+		MigrationStruct: func() migration.FileMigrator {
+			return &jira.Migrator{}
+		},
+	}
+	jiraFileMigrator.RegisterRoutes(m)
+
+	// Git Issues File Migrator
+	gitIssuesFileMigrator := migrationHandler.FileMigratorWeb{
+		MigrationStruct: func() migration.FileMigrator {
+			return &gitissues.Migrator{}
+		},
+	}
+	gitIssuesFileMigrator.RegisterRoutes(m)
+
+	// Trello File Migrator
+	trelloFileMigrator := migrationHandler.FileMigratorWeb{
+		MigrationStruct: func() migration.FileMigrator {
+			return &trello.FileMigrator{}
+		},
+	}
+	trelloFileMigrator.RegisterRoutes(m)
+
+	// Todoist File Migrator
+	todoistFileMigrator := migrationHandler.FileMigratorWeb{
+		MigrationStruct: func() migration.FileMigrator {
+			return &todoist.FileMigrator{}
+		},
+	}
+	todoistFileMigrator.RegisterRoutes(m)
+
+	// todo.txt File Migrator
+	todoTxtFileMigrator := migrationHandler.FileMigratorWeb{
+		MigrationStruct: func() migration.FileMigrator {
+			return &todotxt.Migrator{}
+		},
+	}
+	todoTxtFileMigrator.RegisterRoutes(m)
+
+	// CSV Migrator
+	csvMigrator := &migrationHandler.CSVMigratorWeb{}
+	csvMigrator.RegisterRoutes(m)
 }
 
 func registerCalDavRoutes(c *echo.Group) {
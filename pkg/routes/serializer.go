@@ -0,0 +1,40 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package routes
+
+import (
+	"code.vikunja.io/api/pkg/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CustomJSONSerializer serializes responses like echo's default serializer and additionally sets the ETag header
+// when the response is a single task, project, bucket or comment.
+type CustomJSONSerializer struct {
+	echo.DefaultJSONSerializer
+}
+
+// Serialize sets the ETag header and writes the json response
+func (cs *CustomJSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	if e, is := i.(models.Etagger); is {
+		if etag := e.Etag(); etag != "" {
+			c.Response().Header().Set("ETag", etag)
+		}
+	}
+
+	return cs.DefaultJSONSerializer.Serialize(c, i, indent)
+}
@@ -216,6 +216,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/feeds/{token}": {
+            "get": {
+                "description": "Returns the tasks of the project or saved filter of a calendar feed as iCalendar file, to subscribe to it with calendar apps which don't support CalDAV. This route does not need any authentication, the secret token in the url is enough. Depending on the settings of the feed, tasks are included as VTODO and VEVENT at their due date or from their start until their end date. Clients should send the ETag of the last response as ` + "`" + `If-None-Match` + "`" + ` header to get a 304 response if nothing changed.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get a calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The secret token of the feed, optionally followed by ` + "`" + `.ics` + "`" + `.",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ETag of the last response.",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The iCalendar file.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "The feed did not change since the last request."
+                    },
+                    "404": {
+                        "description": "The calendar feed does not exist or its owner can't access the project or filter anymore.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
         "/filters": {
             "put": {
                 "security": [
@@ -765,26 +815,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/microsoft-todo/auth": {
-            "get": {
+        "/migration/csv/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from Microsoft Todo to Vikunja.",
+                "description": "Imports all rows of a csv or tsv file as tasks into a new project. Use the preview endpoint to get the columns of the file and pass a mapping from the columns to task fields. Rows which can't be imported are skipped and returned with the reason in the report.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "migration"
                 ],
-                "summary": "Get the auth url from Microsoft Todo",
+                "summary": "Import tasks from a csv file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The csv or tsv file.",
+                        "name": "import",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The mapping of the columns to task fields as json, see csv.Mapping.",
+                        "name": "mapping",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "The auth url.",
+                        "description": "The number of imported tasks and the rows which were not imported.",
                         "schema": {
-                            "$ref": "#/definitions/handler.AuthURL"
+                            "$ref": "#/definitions/csv.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "The file is not a valid csv file or the mapping is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -796,16 +871,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/microsoft-todo/migrate": {
-            "post": {
+        "/migration/csv/preview": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Migrates all tasklinsts, tasks, notes and reminders from Microsoft Todo to Vikunja.",
+                "description": "Returns the detected delimiter, the columns and the first rows of a csv or tsv file. Use the columns to build the mapping for the import.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -813,23 +888,27 @@ const docTemplate = `{
                 "tags": [
                     "migration"
                 ],
-                "summary": "Migrate all projects, tasks etc. from Microsoft Todo",
+                "summary": "Preview a csv file",
                 "parameters": [
                     {
-                        "description": "The auth token previously obtained from the auth url. See the docs for /migration/microsoft-todo/auth.",
-                        "name": "migrationCode",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/microsofttodo.Migration"
-                        }
+                        "type": "string",
+                        "description": "The csv or tsv file.",
+                        "name": "import",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "A message telling you everything was migrated successfully.",
+                        "description": "The columns and first rows of the file.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/csv.Preview"
+                        }
+                    },
+                    "400": {
+                        "description": "The file is not a valid csv file.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -841,7 +920,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/microsoft-todo/status": {
+        "/migration/csv/status": {
             "get": {
                 "security": [
                     {
@@ -872,16 +951,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/ticktick/migrate": {
-            "post": {
+        "/migration/git-issues/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Imports all projects, tasks, notes, reminders, subtasks and files from a TickTick backup export into Vikunja.",
+                "description": "Imports all issues of a GitHub migration archive, a GitLab project export or a Gitea repository dump (created with ` + "`" + `gitea dump-repo` + "`" + `) into Vikunja, with one project per repository. Issues become tasks which keep their number as identifier, milestones become kanban buckets and comments are imported as task comments. Closed issues are marked as done and issues which reference each other are related.",
                 "consumes": [
-                    "application/x-www-form-urlencoded"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -889,11 +968,11 @@ const docTemplate = `{
                 "tags": [
                     "migration"
                 ],
-                "summary": "Import all projects, tasks etc. from a TickTick backup export",
+                "summary": "Import all issues from a GitHub, GitLab or Gitea repository export",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The TickTick backup csv file.",
+                        "description": "The export archive, as zip or (gzip compressed) tar file.",
                         "name": "import",
                         "in": "formData",
                         "required": true
@@ -906,6 +985,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Message"
                         }
                     },
+                    "400": {
+                        "description": "The file is not a supported repository export.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -915,7 +1000,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/ticktick/status": {
+        "/migration/git-issues/status": {
             "get": {
                 "security": [
                     {
@@ -946,47 +1031,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/todoist/auth": {
-            "get": {
-                "security": [
-                    {
-                        "JWTKeyAuth": []
-                    }
-                ],
-                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from todoist to Vikunja.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "migration"
-                ],
-                "summary": "Get the auth url from todoist",
-                "responses": {
-                    "200": {
-                        "description": "The auth url.",
-                        "schema": {
-                            "$ref": "#/definitions/handler.AuthURL"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    }
-                }
-            }
-        },
-        "/migration/todoist/migrate": {
-            "post": {
+        "/migration/jira/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Migrates all projects, tasks, notes, reminders, subtasks and files from todoist to vikunja.",
+                "description": "Imports all issues of a Jira xml or json issue export into Vikunja, with one project per Jira project. Statuses become kanban buckets, issue keys are kept as task identifiers and subtasks and issue links become task relations. Assignees and comment authors are matched to Vikunja users by their email address if they already share a team or project with the importing user, matched assignees get write access to the imported projects. To import attachments, upload a zip archive with the export and the attachments, either named with their attachment id or with their file name in a directory named after the issue key.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -994,16 +1048,14 @@ const docTemplate = `{
                 "tags": [
                     "migration"
                 ],
-                "summary": "Migrate all lists, tasks etc. from todoist",
+                "summary": "Import all projects and issues from a Jira export",
                 "parameters": [
                     {
-                        "description": "The auth code previously obtained from the auth url. See the docs for /migration/todoist/auth.",
-                        "name": "migrationCode",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/todoist.Migration"
-                        }
+                        "type": "string",
+                        "description": "The Jira xml or json export or a zip archive containing it.",
+                        "name": "import",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1013,6 +1065,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Message"
                         }
                     },
+                    "400": {
+                        "description": "The file is not a valid Jira export.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1022,7 +1080,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/todoist/status": {
+        "/migration/jira/status": {
             "get": {
                 "security": [
                     {
@@ -1053,21 +1111,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/trello/auth": {
+        "/migration/microsoft-todo/auth": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from trello to Vikunja.",
+                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from Microsoft Todo to Vikunja.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "migration"
                 ],
-                "summary": "Get the auth url from trello",
+                "summary": "Get the auth url from Microsoft Todo",
                 "responses": {
                     "200": {
                         "description": "The auth url.",
@@ -1084,14 +1142,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/trello/migrate": {
+        "/migration/microsoft-todo/migrate": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Migrates all projects, tasks, notes, reminders, subtasks and files from trello to vikunja.",
+                "description": "Migrates all tasklinsts, tasks, notes and reminders from Microsoft Todo to Vikunja.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1101,15 +1159,15 @@ const docTemplate = `{
                 "tags": [
                     "migration"
                 ],
-                "summary": "Migrate all projects, tasks etc. from trello",
+                "summary": "Migrate all projects, tasks etc. from Microsoft Todo",
                 "parameters": [
                     {
-                        "description": "The auth token previously obtained from the auth url. See the docs for /migration/trello/auth.",
+                        "description": "The auth token previously obtained from the auth url. See the docs for /migration/microsoft-todo/auth.",
                         "name": "migrationCode",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/trello.Migration"
+                            "$ref": "#/definitions/microsofttodo.Migration"
                         }
                     }
                 ],
@@ -1129,7 +1187,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/trello/status": {
+        "/migration/microsoft-todo/status": {
             "get": {
                 "security": [
                     {
@@ -1160,14 +1218,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/vikunja-file/migrate": {
+        "/migration/ticktick/migrate": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Imports all projects, tasks, notes, reminders, subtasks and files from a Vikunjda data export into Vikunja.",
+                "description": "Imports all projects, tasks, notes, reminders, subtasks and files from a TickTick backup export into Vikunja.",
                 "consumes": [
                     "application/x-www-form-urlencoded"
                 ],
@@ -1177,11 +1235,11 @@ const docTemplate = `{
                 "tags": [
                     "migration"
                 ],
-                "summary": "Import all projects, tasks etc. from a Vikunja data export",
+                "summary": "Import all projects, tasks etc. from a TickTick backup export",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The Vikunja export zip file.",
+                        "description": "The TickTick backup csv file.",
                         "name": "import",
                         "in": "formData",
                         "required": true
@@ -1203,7 +1261,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/migration/vikunja-file/status": {
+        "/migration/ticktick/status": {
             "get": {
                 "security": [
                     {
@@ -1234,82 +1292,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications": {
-            "get": {
+        "/migration/todoist-file/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns an array with all notifications for the current user.",
+                "description": "Imports a todoist backup zip file or the csv file of a single project, without the need for api credentials. Projects, sections, tasks, subtasks, labels and notes are converted the same way as with the todoist migration. Since files can't be downloaded without the todoist api, attachments are imported from the files in the backup zip file with the same name as the attachment.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "migration"
                 ],
-                "summary": "Get all notifications for the current user",
+                "summary": "Import all projects and tasks from a todoist backup",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "The todoist backup zip file, optionally with the attachment files added to it, or a csv file.",
+                        "name": "import",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The notifications",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/notifications.DatabaseNotification"
-                            }
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "403": {
-                        "description": "Link shares cannot have notifications.",
+                    "400": {
+                        "description": "The file is not a todoist backup.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/migration/todoist-file/status": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
                 ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "migration"
                 ],
-                "summary": "Mark all notifications of a user as read",
+                "summary": "Get migration status",
                 "responses": {
                     "200": {
-                        "description": "All notifications marked as read.",
+                        "description": "The migration status",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1317,54 +1372,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}": {
-            "post": {
+        "/migration/todoist/auth": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Marks a notification as either read or unread. A user can only mark their own notifications as read.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from todoist to Vikunja.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
-                ],
-                "summary": "Mark a notification as (un-)read",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "migration"
                 ],
+                "summary": "Get the auth url from todoist",
                 "responses": {
                     "200": {
-                        "description": "The notification to mark as read.",
-                        "schema": {
-                            "$ref": "#/definitions/models.DatabaseNotifications"
-                        }
-                    },
-                    "403": {
-                        "description": "Link shares cannot have notifications.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "The notification does not exist.",
+                        "description": "The auth url.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/handler.AuthURL"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1372,14 +1403,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects": {
-            "get": {
+        "/migration/todoist/migrate": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all projects a user has access to.",
+                "description": "Migrates all projects, tasks, notes, reminders, subtasks and files from todoist to vikunja.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1387,108 +1418,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Get all projects a user has access to",
+                "summary": "Migrate all lists, tasks etc. from todoist",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search projects by title.",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "If true, also returns all archived projects.",
-                        "name": "is_archived",
-                        "in": "query"
+                        "description": "The auth code previously obtained from the auth url. See the docs for /migration/todoist/auth.",
+                        "name": "migrationCode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/todoist.Migration"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The projects",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Project"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/migration/todoist/status": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Creates a new project. If a parent project is provided the user needs to have write access to that project.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
-                ],
-                "summary": "Creates a new project",
-                "parameters": [
-                    {
-                        "description": "The project you want to create.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    }
+                    "migration"
                 ],
+                "summary": "Get migration status",
                 "responses": {
-                    "201": {
-                        "description": "The created project.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid project object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                    "200": {
+                        "description": "The migration status",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1496,160 +1479,122 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}": {
-            "get": {
+        "/migration/todotxt/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a project by its ID.",
+                "description": "Imports all tasks of a todo.txt file into a new project. Every +project becomes a child project of it and every @context a label. Priorities, creation and completion dates and due dates (` + "`" + `due:YYYY-MM-DD` + "`" + `) are imported as well.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Gets one project",
+                "summary": "Import all tasks from a todo.txt file",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "The todo.txt file.",
+                        "name": "import",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The project",
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/migration/todotxt/status": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates a project. This does not include adding a task (see below).",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
-                ],
-                "summary": "Updates a project",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The project with updated values you want to update.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    }
+                    "migration"
                 ],
+                "summary": "Get migration status",
                 "responses": {
                     "200": {
-                        "description": "The updated project.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid project object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "The migration status",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/migration/trello-file/migrate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delets a project",
+                "description": "Imports trello boards from their json export, without the need for api credentials. Boards, lists, cards, checklists, labels and comments are converted the same way as with the trello migration. Since files can't be downloaded without the trello api, attachments are imported from a zip file with the board exports and the attachments, either named with their attachment id or with their file name in a directory named after the card id. Backgrounds are not imported.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Deletes a project",
+                "summary": "Import all boards from trello board exports",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "The json export of a trello board or a zip file with multiple board exports and their attachments.",
+                        "name": "import",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The project was successfully deleted.",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "400": {
-                        "description": "Invalid project object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "The file is not a trello board export.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1657,101 +1602,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/background": {
+        "/migration/trello-file/status": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Get the project background of a specific project. **Returns json on error.**",
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "project"
-                ],
-                "summary": "Get the project background",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "migration"
                 ],
+                "summary": "Get migration status",
                 "responses": {
                     "200": {
-                        "description": "The project background file.",
-                        "schema": {
-                            "type": "file"
-                        }
-                    },
-                    "403": {
-                        "description": "No access to this project.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "404": {
-                        "description": "The project does not exist.",
+                        "description": "The migration status",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/migration/trello/auth": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Removes a previously set project background, regardless of the project provider used to set the background. It does not throw an error if the project does not have a background.",
+                "description": "Returns the auth url where the user needs to get its auth code. This code can then be used to migrate everything from trello to Vikunja.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
-                ],
-                "summary": "Remove a project background",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "migration"
                 ],
+                "summary": "Get the auth url from trello",
                 "responses": {
                     "200": {
-                        "description": "The project",
-                        "schema": {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    },
-                    "403": {
-                        "description": "No access to this project.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "404": {
-                        "description": "The project does not exist.",
+                        "description": "The auth url.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/handler.AuthURL"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1759,14 +1664,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/backgrounds/unsplash": {
+        "/migration/trello/migrate": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Sets a photo from unsplash as project background.",
+                "description": "Migrates all projects, tasks, notes, reminders, subtasks and files from trello to vikunja.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1774,48 +1679,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Set an unsplash photo as project background",
+                "summary": "Migrate all projects, tasks etc. from trello",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The image you want to set as background",
-                        "name": "project",
+                        "description": "The auth token previously obtained from the auth url. See the docs for /migration/trello/auth.",
+                        "name": "migrationCode",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/background.Image"
+                            "$ref": "#/definitions/trello.Migration"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The background has been successfully set.",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
-                            "$ref": "#/definitions/models.Project"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Invalid image object provided.",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                    }
+                }
+            }
+        },
+        "/migration/trello/status": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "migration"
+                ],
+                "summary": "Get migration status",
+                "responses": {
+                    "200": {
+                        "description": "The migration status",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1823,67 +1740,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/backgrounds/upload": {
-            "put": {
+        "/migration/vikunja-file/migrate": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Upload a project background.",
+                "description": "Imports all projects, tasks, notes, reminders, subtasks and files from a Vikunjda data export into Vikunja.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/x-www-form-urlencoded"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Upload a project background",
+                "summary": "Import all projects, tasks etc. from a Vikunja data export",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "The file as single file.",
-                        "name": "background",
+                        "description": "The Vikunja export zip file.",
+                        "name": "import",
                         "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The background was set successfully.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "400": {
-                        "description": "File is no image.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "403": {
-                        "description": "File too large.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "404": {
-                        "description": "The project does not exist.",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -1891,89 +1783,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/buckets": {
+        "/migration/vikunja-file/status": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all kanban buckets with belong to a project including their tasks. Buckets are always sorted by their ` + "`" + `position` + "`" + ` in ascending order. Tasks are sorted by their ` + "`" + `kanban_position` + "`" + ` in ascending order.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
-                ],
-                "summary": "Get all kanban buckets of a project",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The page number for tasks. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of tasks per bucket per page. This parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search tasks by task text.",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
-                        "name": "filter_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The value to filter for.",
-                        "name": "filter_value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
-                        "name": "filter_comparator",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
-                        "name": "filter_concat",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
-                        "name": "filter_include_nulls",
-                        "in": "query"
-                    }
+                    "migration"
                 ],
+                "summary": "Get migration status",
                 "responses": {
                     "200": {
-                        "description": "The buckets with their tasks",
+                        "description": "The migration status",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Bucket"
-                            }
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
@@ -1983,63 +1812,108 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/migration/vikunja-instance/auth": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Creates a new kanban bucket on a project.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "There is no auth flow for other Vikunja instances, this always returns an empty url. Create an api token in the settings of the other instance instead and pass it to the migrate route.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Create a new bucket",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
+                "summary": "Get the auth url for another Vikunja instance",
+                "responses": {
+                    "200": {
+                        "description": "An empty auth url.",
+                        "schema": {
+                            "$ref": "#/definitions/handler.AuthURL"
+                        }
+                    }
+                }
+            }
+        },
+        "/migration/vikunja-instance/migrate": {
+            "post": {
+                "security": [
                     {
-                        "description": "The bucket object",
-                        "name": "bucket",
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Copies all projects, tasks, kanban buckets, labels, comments, attachments, reminders, task relations and saved filters of the owner of the api token from another Vikunja instance. Saved filters which reference projects, tasks, labels or users which were not migrated are skipped and reported as warnings of the migration job.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "migration"
+                ],
+                "summary": "Migrate all projects, tasks etc. from another Vikunja instance",
+                "parameters": [
+                    {
+                        "description": "The url of the other Vikunja instance and an api token of the user there.",
+                        "name": "migrationCode",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Bucket"
+                            "$ref": "#/definitions/vikunjainstance.Migration"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The created bucket object.",
+                        "description": "A message telling you everything was migrated successfully.",
                         "schema": {
-                            "$ref": "#/definitions/models.Bucket"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "400": {
-                        "description": "Invalid bucket object provided.",
+                        "description": "The url or api token is missing or the api token was not accepted.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "The project does not exist.",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/migration/vikunja-instance/status": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns if the current user already did the migation or not. This is useful to show a confirmation message in the frontend if the user is trying to do the same migration again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "migration"
+                ],
+                "summary": "Get migration status",
+                "responses": {
+                    "200": {
+                        "description": "The migration status",
+                        "schema": {
+                            "$ref": "#/definitions/migration.Status"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -2047,127 +1921,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/projectusers": {
+        "/migration/{service}/jobs": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Lists all users (without emailadresses). Also possible to search for a specific user.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns all migration jobs of the current user for this migration service, the latest first. Running jobs contain their current progress.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "project"
+                    "migration"
                 ],
-                "summary": "Get users",
+                "summary": "Get all migration jobs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search for a user by its name.",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
+                        "description": "The name of the migration service, for example trello or vikunja-file.",
+                        "name": "service",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "All (found) users.",
+                        "description": "The migration jobs",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/user.User"
+                                "$ref": "#/definitions/migration.Job"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "401": {
-                        "description": "The user does not have the right to see the project.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
-        },
-        "/projects/{id}/tasks": {
+            },
             "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Inserts a task into a project.",
+                "description": "Queues a migration as a background job and returns the job right away instead of waiting until everything is migrated. File migrators take the file as multipart form file ` + "`" + `import` + "`" + `, auth based migrators like todoist or trello take the same json body as their migrate route. Poll the job to get its progress, a notification is sent once it is done or failed.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "migration"
                 ],
-                "summary": "Create a task",
+                "summary": "Start a migration in the background",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "The name of the migration service, for example trello or vikunja-file.",
+                        "name": "service",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "The task object",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
+                        "type": "string",
+                        "description": "The file to import, only for file migrators.",
+                        "name": "import",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "The created task object.",
+                        "description": "The queued migration job.",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/migration.Job"
                         }
                     },
                     "400": {
-                        "description": "Invalid task object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "No file or an invalid request body provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -2175,137 +2017,121 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/teams": {
+        "/migration/{service}/jobs/{job}": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a project with all teams which have access on a given project.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a migration job with how many projects, tasks and files it created so far and everything which could not be migrated as it was. Poll this endpoint to show the progress of a running migration.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "migration"
                 ],
-                "summary": "Get teams on a project",
+                "summary": "Get a migration job",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "The name of the migration service, for example trello or vikunja-file.",
+                        "name": "service",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search teams by its name.",
-                        "name": "s",
-                        "in": "query"
+                        "description": "The id of the migration job",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The teams with their right.",
+                        "description": "The migration job",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TeamWithRight"
-                            }
+                            "$ref": "#/definitions/migration.Job"
                         }
                     },
-                    "403": {
-                        "description": "No right to see the project.",
+                    "400": {
+                        "description": "Invalid job id.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The migration job does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Gives a team access to a project.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancels a migration job. A queued job won't be started, a running job stops at the next project, task or file it creates. Everything it imported until then is kept. The job will have the status ` + "`" + `cancelled` + "`" + ` once it stopped.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "migration"
                 ],
-                "summary": "Add a team to a project",
+                "summary": "Cancel a migration job",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "The name of the migration service, for example trello or vikunja-file.",
+                        "name": "service",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "The team you want to add to the project.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TeamProject"
-                        }
+                        "type": "integer",
+                        "description": "The id of the migration job",
+                        "name": "job",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created team\u003c-\u003eproject relation.",
+                    "200": {
+                        "description": "The migration job",
                         "schema": {
-                            "$ref": "#/definitions/models.TeamProject"
+                            "$ref": "#/definitions/migration.Job"
                         }
                     },
                     "400": {
-                        "description": "Invalid team project object provided.",
+                        "description": "Invalid job id.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                    "404": {
+                        "description": "The migration job does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "The team does not exist.",
+                    "412": {
+                        "description": "The migration job is already finished.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -2313,14 +2139,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/users": {
+        "/notifications": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a project with all users which have access on a given project.",
+                "description": "Returns an array with all notifications for the current user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2328,17 +2154,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "subscriptions"
                 ],
-                "summary": "Get users on a project",
+                "summary": "Get all notifications for the current user",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
@@ -2350,26 +2169,20 @@ const docTemplate = `{
                         "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
                         "name": "per_page",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search users by its name.",
-                        "name": "s",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The users with the right they have.",
+                        "description": "The notifications",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.UserWithRight"
+                                "$ref": "#/definitions/notifications.DatabaseNotification"
                             }
                         }
                     },
                     "403": {
-                        "description": "No right to see the project.",
+                        "description": "Link shares cannot have notifications.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2382,13 +2195,7 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "JWTKeyAuth": []
-                    }
-                ],
-                "description": "Gives a user access to a project.",
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -2398,48 +2205,12 @@ const docTemplate = `{
                 "tags": [
                     "sharing"
                 ],
-                "summary": "Add a user to a project",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The user you want to add to the project.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProjectUser"
-                        }
-                    }
-                ],
+                "summary": "Mark all notifications of a user as read",
                 "responses": {
-                    "201": {
-                        "description": "The created user\u003c-\u003eproject relation.",
-                        "schema": {
-                            "$ref": "#/definitions/models.ProjectUser"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid user project object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "The user does not exist.",
+                    "200": {
+                        "description": "All notifications marked as read.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -2451,57 +2222,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/webhooks": {
+        "/notifications/push/subscriptions": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Get all api webhook targets for the specified project.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns all web push subscriptions of the current user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "webhooks"
-                ],
-                "summary": "Get all api webhook targets for the specified project",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per bucket per page. This parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "subscriptions"
                 ],
+                "summary": "Get all push subscriptions of the current user",
                 "responses": {
                     "200": {
-                        "description": "The list of all webhook targets",
+                        "description": "The push subscriptions.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Webhook"
+                                "$ref": "#/definitions/notifications.PushSubscription"
                             }
                         }
                     },
+                    "403": {
+                        "description": "Link shares cannot have push subscriptions.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -2514,7 +2267,7 @@ const docTemplate = `{
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Create a webhook target which receives POST requests about specified events from a project.",
+                "description": "Registers the push subscription of a browser or device. All notifications the user did not disable for the push channel will be sent to it. If the endpoint was already registered, the subscription is replaced.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2522,36 +2275,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "webhooks"
+                    "subscriptions"
                 ],
-                "summary": "Create a webhook target",
+                "summary": "Subscribe to web push notifications",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The webhook target object with required fields",
-                        "name": "webhook",
+                        "description": "The push subscription as returned by the browser.",
+                        "name": "subscription",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Webhook"
+                            "$ref": "#/definitions/notifications.PushSubscription"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "The created webhook target.",
+                    "201": {
+                        "description": "The created push subscription.",
                         "schema": {
-                            "$ref": "#/definitions/models.Webhook"
+                            "$ref": "#/definitions/notifications.PushSubscription"
                         }
                     },
                     "400": {
-                        "description": "Invalid webhook object provided.",
+                        "description": "Invalid push subscription object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Link shares cannot subscribe to push notifications.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2565,49 +2317,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{id}/webhooks/{webhookID}": {
-            "post": {
+        "/notifications/push/subscriptions/{subscription}": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Change a webhook target's events. You cannot change other values of a webhook.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Removes a web push subscription of the current user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "webhooks"
+                    "subscriptions"
                 ],
-                "summary": "Change a webhook target's events.",
+                "summary": "Unsubscribe from web push notifications",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Webhook ID",
-                        "name": "webhookID",
+                        "description": "Push subscription ID",
+                        "name": "subscription",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated webhook target",
+                        "description": "The push subscription was deleted successfully.",
                         "schema": {
-                            "$ref": "#/definitions/models.Webhook"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "404": {
-                        "description": "The webhok target does not exist",
+                    "403": {
+                        "description": "The user does not have access to the push subscription.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2619,14 +2361,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/notifications/{id}": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delete any of the project's webhook targets.",
+                "description": "Marks a notification as either read or unread. A user can only mark their own notifications as read.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2634,34 +2378,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "webhooks"
+                    "subscriptions"
                 ],
-                "summary": "Deletes an existing webhook target",
+                "summary": "Mark a notification as (un-)read",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
+                        "description": "Notification ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Webhook ID",
-                        "name": "webhookID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted.",
+                        "description": "The notification to mark as read.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.DatabaseNotifications"
+                        }
+                    },
+                    "403": {
+                        "description": "Link shares cannot have notifications.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The webhok target does not exist.",
+                        "description": "The notification does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2675,14 +2418,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{projectID}/buckets/{bucketID}": {
-            "post": {
+        "/projects": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates an existing kanban bucket.",
+                "description": "Returns all projects a user has access to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2692,47 +2435,45 @@ const docTemplate = `{
                 "tags": [
                     "project"
                 ],
-                "summary": "Update an existing bucket",
+                "summary": "Get all projects a user has access to",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project Id",
-                        "name": "projectID",
-                        "in": "path",
-                        "required": true
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Bucket Id",
-                        "name": "bucketID",
-                        "in": "path",
-                        "required": true
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
                     },
                     {
-                        "description": "The bucket object",
-                        "name": "bucket",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Bucket"
-                        }
+                        "type": "string",
+                        "description": "Search projects by title.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "If true, also returns all archived projects.",
+                        "name": "is_archived",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The created bucket object.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Bucket"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid bucket object provided.",
+                        "description": "The projects",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Project"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "The bucket does not exist.",
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2745,13 +2486,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Deletes an existing kanban bucket and dissociates all of its task. It does not delete any tasks. You cannot delete the last bucket on a project.",
+                "description": "Creates a new project. If a parent project is provided the user needs to have write access to that project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2761,32 +2502,33 @@ const docTemplate = `{
                 "tags": [
                     "project"
                 ],
-                "summary": "Deletes an existing bucket",
+                "summary": "Creates a new project",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Project Id",
-                        "name": "projectID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Bucket Id",
-                        "name": "bucketID",
-                        "in": "path",
-                        "required": true
+                        "description": "The project you want to create.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully deleted.",
+                    "201": {
+                        "description": "The created project.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Project"
                         }
                     },
-                    "404": {
-                        "description": "The bucket does not exist.",
+                    "400": {
+                        "description": "Invalid project object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2800,14 +2542,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{projectID}/duplicate": {
-            "put": {
+        "/projects/{id}": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Copies the project, tasks, files, kanban data, assignees, comments, attachments, lables, relations, backgrounds, user/team rights and link shares from one project to a new one. The user needs read access in the project and write access in the parent of the new project.",
+                "description": "Returns a project by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2817,40 +2559,25 @@ const docTemplate = `{
                 "tags": [
                     "project"
                 ],
-                "summary": "Duplicate an existing project",
+                "summary": "Gets one project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The project ID to duplicate",
-                        "name": "projectID",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "The target parent project which should hold the copied project.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ProjectDuplicate"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created project.",
-                        "schema": {
-                            "$ref": "#/definitions/models.ProjectDuplicate"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid project duplicate object provided.",
+                    "200": {
+                        "description": "The project",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Project"
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to the project or its parent.",
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -2862,16 +2589,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/projects/{projectID}/tasks": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all tasks for the current project.",
+                "description": "Updates a project. This does not include adding a task (see below).",
                 "consumes": [
                     "application/json"
                 ],
@@ -2879,86 +2604,106 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "project"
                 ],
-                "summary": "Get tasks in a project",
+                "summary": "Updates a project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The project ID.",
-                        "name": "projectID",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search tasks by task text.",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parametes, along with ` + "`" + `order_by` + "`" + `. Possible values to sort by are ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `due_date` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `project_id` + "`" + `, ` + "`" + `repeat_after` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `hex_color` + "`" + `, ` + "`" + `percent_done` + "`" + `, ` + "`" + `uid` + "`" + `, ` + "`" + `created` + "`" + `, ` + "`" + `updated` + "`" + `. Default is ` + "`" + `id` + "`" + `.",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "The project with updated values you want to update.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "The ordering parameter. Possible values to order by are ` + "`" + `asc` + "`" + ` or ` + "`" + `desc` + "`" + `. Default is ` + "`" + `asc` + "`" + `.",
-                        "name": "order_by",
-                        "in": "query"
+                        "description": "The ETag or version of the project the changes are based on. If the project was changed since then, it is not updated.",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated project.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
-                        "name": "filter_by",
-                        "in": "query"
+                    "400": {
+                        "description": "Invalid project object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "The value to filter for. You can use [grafana](https://grafana.com/docs/grafana/latest/dashboards/time-range-controls)- or [elasticsearch](https://www.elastic.co/guide/en/elasticsearch/reference/7.3/common-options.html#date-math)-style relative dates for all date fields like ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, etc.",
-                        "name": "filter_value",
-                        "in": "query"
+                    "403": {
+                        "description": "The user does not have access to the project",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
-                        "name": "filter_comparator",
-                        "in": "query"
+                    "412": {
+                        "description": "The project was changed in the meantime. Returns the current project.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Project"
+                        }
                     },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
-                        "name": "filter_concat",
-                        "in": "query"
-                    },
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Delets a project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "project"
+                ],
+                "summary": "Deletes a project",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
-                        "name": "filter_include_nulls",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The tasks",
+                        "description": "The project was successfully deleted.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Task"
-                            }
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -2970,66 +2715,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{projectID}/teams/{teamID}": {
-            "post": {
+        "/projects/{id}/background": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Update a team \u003c-\u003e project relation. Mostly used to update the right that team has.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the project background of a specific project. **Returns json on error.**",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Update a team \u003c-\u003e project relation",
+                "summary": "Get the project background",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Project ID",
-                        "name": "projectID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "The team you want to update.",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TeamProject"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated team \u003c-\u003e project relation.",
+                        "description": "The project background file.",
                         "schema": {
-                            "$ref": "#/definitions/models.TeamProject"
+                            "type": "file"
                         }
                     },
                     "403": {
-                        "description": "The user does not have admin-access to the project",
+                        "description": "No access to this project.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "Team or project does not exist.",
+                        "description": "The project does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -3046,47 +2772,40 @@ const docTemplate = `{
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delets a team from a project. The team won't have access to the project anymore.",
+                "description": "Removes a previously set project background, regardless of the project provider used to set the background. It does not throw an error if the project does not have a background.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Delete a team from a project",
+                "summary": "Remove a project background",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Project ID",
-                        "name": "projectID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "teamID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The team was successfully deleted.",
+                        "description": "The project",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Project"
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "No access to this project.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "Team or project does not exist.",
+                        "description": "The project does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -3098,14 +2817,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{projectID}/users/{userID}": {
+        "/projects/{id}/backgrounds/unsplash": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Update a user \u003c-\u003e project relation. Mostly used to update the right that user has.",
+                "description": "Sets a photo from unsplash as project background.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3113,49 +2832,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Update a user \u003c-\u003e project relation",
+                "summary": "Set an unsplash photo as project background",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Project ID",
-                        "name": "projectID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "The user you want to update.",
+                        "description": "The image you want to set as background",
                         "name": "project",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProjectUser"
+                            "$ref": "#/definitions/background.Image"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated user \u003c-\u003e project relation.",
+                        "description": "The background has been successfully set.",
                         "schema": {
-                            "$ref": "#/definitions/models.ProjectUser"
+                            "$ref": "#/definitions/models.Project"
                         }
                     },
-                    "403": {
-                        "description": "The user does not have admin-access to the project",
+                    "400": {
+                        "description": "Invalid image object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "User or project does not exist.",
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3167,54 +2879,65 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/projects/{id}/backgrounds/upload": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delets a user from a project. The user won't have access to the project anymore.",
+                "description": "Upload a project background.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Delete a user from a project",
+                "summary": "Upload a project background",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Project ID",
-                        "name": "projectID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
+                        "type": "string",
+                        "description": "The file as single file.",
+                        "name": "background",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The user was successfully removed from the project.",
+                        "description": "The background was set successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "File is no image.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "File too large.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "user or project does not exist.",
+                        "description": "The project does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -3226,14 +2949,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{project}/shares": {
+        "/projects/{id}/buckets": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all link shares which exist for a given project",
+                "description": "Returns all kanban buckets with belong to a project including their tasks. Buckets are always sorted by their ` + "`" + `position` + "`" + ` in ascending order. Tasks are sorted by their ` + "`" + `kanban_position` + "`" + ` in ascending order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3241,48 +2964,78 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Get all link shares for a project",
+                "summary": "Get all kanban buckets of a project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "project",
+                        "description": "Project Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "description": "The page number for tasks. Used for pagination. If not provided, the first page of results is returned.",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "description": "The maximum number of tasks per bucket per page. This parameter is limited by the configured maximum of items per page.",
                         "name": "per_page",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search shares by hash.",
+                        "description": "Search tasks by task text.",
                         "name": "s",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
+                        "name": "filter_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The value to filter for.",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
+                        "name": "filter_comparator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
+                        "name": "filter_concat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
+                        "name": "filter_include_nulls",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The share links",
+                        "description": "The buckets with their tasks",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.LinkSharing"
+                                "$ref": "#/definitions/models.Bucket"
                             }
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -3295,7 +3048,7 @@ const docTemplate = `{
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Share a project via link. The user needs to have write-access to the project to be able do this.",
+                "description": "Creates a new kanban bucket on a project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3303,42 +3056,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Share a project via link",
+                "summary": "Create a new bucket",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "project",
+                        "description": "Project Id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "The new link share object",
-                        "name": "label",
+                        "description": "The bucket object",
+                        "name": "bucket",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LinkSharing"
+                            "$ref": "#/definitions/models.Bucket"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created link share object.",
+                    "200": {
+                        "description": "The created bucket object.",
                         "schema": {
-                            "$ref": "#/definitions/models.LinkSharing"
+                            "$ref": "#/definitions/models.Bucket"
                         }
                     },
                     "400": {
-                        "description": "Invalid link share object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "Not allowed to add the project share.",
+                        "description": "Invalid bucket object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3358,55 +3105,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects/{project}/shares/{share}": {
+        "/projects/{id}/export": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns one link share by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Exports a project with all of its child projects, tasks, comments, attachments, labels and buckets. As json, the export contains the contents of all attachments and backgrounds and can be imported again with the project import endpoint. As csv, it contains one row per task with the most important task properties.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Get one link shares for a project",
+                "summary": "Export a project",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Project ID",
-                        "name": "project",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Share ID",
-                        "name": "share",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "The format of the export, either ` + "`" + `json` + "`" + ` (the default) or ` + "`" + `csv` + "`" + `.",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The share links",
+                        "description": "The exported project.",
                         "schema": {
-                            "$ref": "#/definitions/models.LinkSharing"
+                            "$ref": "#/definitions/models.ProjectExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid export format.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "403": {
-                        "description": "No access to the project",
+                        "description": "The user does not have access to the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Share Link not found.",
+                        "description": "The project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3418,55 +3168,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/projects/{id}/export/todotxt": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Remove a link share. The user needs to have write-access to the project to be able do this.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Exports all tasks of a project or saved filter in todo.txt format, one task per line. The project of a task becomes its +project, labels become @contexts and the due date is exported as ` + "`" + `due:YYYY-MM-DD` + "`" + `. Child projects are not included. The file can be imported again with the todo.txt migrator.",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "sharing"
+                    "project"
                 ],
-                "summary": "Remove a link share",
+                "summary": "Export the tasks of a project as todo.txt",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Project ID",
-                        "name": "project",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Share Link ID",
-                        "name": "share",
+                        "description": "Project ID, or the ID of the pseudo project of a saved filter",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The link was successfully removed.",
+                        "description": "The tasks in todo.txt format.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Not allowed to remove the link.",
+                        "description": "The user does not have access to the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Share Link not found.",
+                        "description": "The project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3480,39 +3222,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Creates a new user account.",
+        "/projects/{id}/import": {
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Imports a json project export, created with the project export endpoint, as a new child project of the given project. All child projects, tasks, comments, attachments, labels, buckets and relations between the imported tasks are created as well. Assignees are not imported. The user needs write access to the project.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "project"
                 ],
-                "summary": "Register",
+                "summary": "Import a project",
                 "parameters": [
                     {
-                        "description": "The user credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.APIUserPassword"
-                        }
+                        "type": "integer",
+                        "description": "The id of the project which will be the parent of the imported project",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The json project export, as multipart form file.",
+                        "name": "import",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "The imported project.",
                         "schema": {
-                            "$ref": "#/definitions/user.User"
+                            "$ref": "#/definitions/models.Project"
                         }
                     },
                     "400": {
-                        "description": "No or invalid user register object provided / User already exists.",
+                        "description": "Invalid project export provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have write access to the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3526,37 +3290,78 @@ const docTemplate = `{
                 }
             }
         },
-        "/routes": {
+        "/projects/{id}/projectusers": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a list of all API routes which are available to use with an api token, not a user login.",
+                "description": "Lists all users (without emailadresses). Also possible to search for a specific user.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api"
+                    "project"
+                ],
+                "summary": "Get users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search for a user by its name.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get a list of all token api routes",
                 "responses": {
                     "200": {
-                        "description": "The list of all routes.",
+                        "description": "All (found) users.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.APITokenRoute"
+                                "$ref": "#/definitions/user.User"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "The user does not have the right to see the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
                     }
                 }
             }
         },
-        "/shares/{share}/auth": {
-            "post": {
-                "description": "Get a jwt auth token for a shared project from a share hash.",
+        "/projects/{id}/tasks": {
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Inserts a task into a project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3564,36 +3369,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sharing"
+                    "task"
                 ],
-                "summary": "Get an auth token for a share",
+                "summary": "Create a task",
                 "parameters": [
                     {
-                        "description": "The password for link shares which require one.",
-                        "name": "password",
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The task object",
+                        "name": "task",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.LinkShareAuth"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
                     {
-                        "type": "string",
-                        "description": "The share hash",
-                        "name": "share",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "If true, the title is parsed for task properties. See the ` + "`" + `quick_add` + "`" + ` property of the task.",
+                        "name": "quick_add",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "The valid jwt auth token.",
+                    "201": {
+                        "description": "The created task object.",
                         "schema": {
-                            "$ref": "#/definitions/auth.Token"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
                     "400": {
-                        "description": "Invalid link share object provided.",
+                        "description": "Invalid task object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3607,14 +3424,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/{entity}/{entityID}": {
-            "put": {
+        "/projects/{id}/teams": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Subscribes the current user to an entity.",
+                "description": "Returns a project with all teams which have access on a given project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3622,40 +3439,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "sharing"
                 ],
-                "summary": "Subscribes the current user to an entity.",
+                "summary": "Get teams on a project",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The entity the user subscribes to. Can be either ` + "`" + `project` + "`" + ` or ` + "`" + `task` + "`" + `.",
-                        "name": "entity",
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "The numeric id of the entity to subscribe to.",
-                        "name": "entityID",
-                        "in": "path",
-                        "required": true
+                        "description": "Search teams by its name.",
+                        "name": "s",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The subscription",
+                    "200": {
+                        "description": "The teams with their right.",
                         "schema": {
-                            "$ref": "#/definitions/models.Subscription"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TeamWithRight"
+                            }
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to subscribe to this entity.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "412": {
-                        "description": "The subscription entity is invalid.",
+                        "description": "No right to see the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3668,13 +3493,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Unsubscribes the current user to an entity.",
+                "description": "Gives a team access to a project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3682,40 +3507,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subscriptions"
+                    "sharing"
                 ],
-                "summary": "Unsubscribe the current user from an entity.",
+                "summary": "Add a team to a project",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The entity the user subscribed to. Can be either ` + "`" + `project` + "`" + ` or ` + "`" + `task` + "`" + `.",
-                        "name": "entity",
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "The numeric id of the subscribed entity to.",
-                        "name": "entityID",
-                        "in": "path",
-                        "required": true
+                        "description": "The team you want to add to the project.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamProject"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "The subscription",
+                    "201": {
+                        "description": "The created team\u003c-\u003eproject relation.",
                         "schema": {
-                            "$ref": "#/definitions/models.Subscription"
+                            "$ref": "#/definitions/models.TeamProject"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team project object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to subscribe to this entity.",
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The subscription does not exist.",
+                        "description": "The team does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3729,14 +3562,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/all": {
+        "/projects/{id}/users": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all tasks on any project the user has access to.",
+                "description": "Returns a project with all users which have access on a given project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3744,10 +3577,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Get tasks",
+                "summary": "Get users on a project",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
@@ -3762,63 +3602,27 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search tasks by task text.",
+                        "description": "Search users by its name.",
                         "name": "s",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parameters, along with ` + "`" + `order_by` + "`" + `. Possible values to sort by are ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `due_date` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `project_id` + "`" + `, ` + "`" + `repeat_after` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `hex_color` + "`" + `, ` + "`" + `percent_done` + "`" + `, ` + "`" + `uid` + "`" + `, ` + "`" + `created` + "`" + `, ` + "`" + `updated` + "`" + `. Default is ` + "`" + `id` + "`" + `.",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The ordering parameter. Possible values to order by are ` + "`" + `asc` + "`" + ` or ` + "`" + `desc` + "`" + `. Default is ` + "`" + `asc` + "`" + `.",
-                        "name": "order_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
-                        "name": "filter_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The value to filter for.",
-                        "name": "filter_value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
-                        "name": "filter_comparator",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
-                        "name": "filter_concat",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
-                        "name": "filter_include_nulls",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The tasks",
+                        "description": "The users with the right they have.",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Task"
+                                "$ref": "#/definitions/models.UserWithRight"
                             }
                         }
                     },
+                    "403": {
+                        "description": "No right to see the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -3826,16 +3630,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/bulk": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates a bunch of tasks at once. This includes marking them as done. Note: although you could supply another ID, it will be ignored. Use task_ids instead.",
+                "description": "Gives a user access to a project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3843,35 +3645,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Update a bunch of tasks at once",
+                "summary": "Add a user to a project",
                 "parameters": [
                     {
-                        "description": "The task object. Looks like a normal task, the only difference is it uses an array of project_ids to update.",
-                        "name": "task",
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The user you want to add to the project.",
+                        "name": "project",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.BulkTask"
+                            "$ref": "#/definitions/models.ProjectUser"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "The updated task object.",
+                    "201": {
+                        "description": "The created user\u003c-\u003eproject relation.",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/models.ProjectUser"
                         }
                     },
                     "400": {
-                        "description": "Invalid task object provided.",
+                        "description": "Invalid user project object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "403": {
-                        "description": "The user does not have access to the task (aka its project)",
+                        "description": "The user does not have access to the project",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3885,14 +3700,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{ID}": {
-            "post": {
+        "/projects/{id}/webhooks": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates a task. This includes marking it as done. Assignees you pass will be updated, see their individual endpoints for more details on how this is done. To update labels, see the description of the endpoint.",
+                "description": "Get all api webhook targets for the specified project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3900,92 +3715,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "webhooks"
                 ],
-                "summary": "Update a task",
+                "summary": "Get all api webhook targets for the specified project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The Task ID",
-                        "name": "ID",
-                        "in": "path",
-                        "required": true
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "The task object",
-                        "name": "task",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
+                        "type": "integer",
+                        "description": "The maximum number of items per bucket per page. This parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated task object.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Task"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid task object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the task (aka its project)",
+                        "description": "The list of all webhook targets",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Deletes a task from a project. This does not mean \"mark it done\".",
+                "description": "Create a webhook target which receives POST requests about specified events from a project.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "webhooks"
                 ],
-                "summary": "Delete a task",
+                "summary": "Create a webhook target",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "ID",
+                        "description": "Project ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The webhook target object with required fields",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The created task object.",
+                        "description": "The created webhook target.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "400": {
-                        "description": "Invalid task ID provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the project",
+                        "description": "Invalid webhook object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -3999,14 +3814,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{id}": {
-            "get": {
+        "/projects/{id}/webhooks/{webhookID}": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns one task by its ID",
+                "description": "Change a webhook target's events. You cannot change other values of a webhook.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4014,29 +3829,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "webhooks"
                 ],
-                "summary": "Get one task",
+                "summary": "Change a webhook target's events.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The task ID",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The task",
+                        "description": "Updated webhook target",
                         "schema": {
-                            "$ref": "#/definitions/models.Task"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
                     "404": {
-                        "description": "Task not found",
+                        "description": "The webhok target does not exist",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4046,16 +3868,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/{id}/attachments": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Get all task attachments for one task.",
+                "description": "Delete any of the project's webhook targets.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4063,50 +3883,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "webhooks"
                 ],
-                "summary": "Get  all attachments for one task.",
+                "summary": "Deletes an existing webhook target",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
+                        "description": "Project ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
+                        "description": "Webhook ID",
+                        "name": "webhookID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "All attachments for this task",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TaskAttachment"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "No access to this task.",
+                        "description": "Successfully deleted.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "The task does not exist.",
+                        "description": "The webhok target does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4116,57 +3922,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/projects/{projectID}/buckets/{bucketID}": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Upload a task attachment. You can pass multiple files with the files form param.",
+                "description": "Returns a single kanban bucket of a project without its tasks.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "project"
                 ],
-                "summary": "Upload a task attachment",
+                "summary": "Get one kanban bucket",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
+                        "description": "Project Id",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "The file, as multipart form file. You can pass multiple.",
-                        "name": "files",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Bucket Id",
+                        "name": "bucketID",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Attachments were uploaded successfully.",
+                        "description": "The bucket",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Bucket"
                         }
                     },
                     "403": {
-                        "description": "No access to the task.",
+                        "description": "The user does not have access to the project.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The task does not exist.",
+                        "description": "The bucket does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4176,56 +3984,78 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/{id}/attachments/{attachmentID}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Get one attachment for download. **Returns json on error.**",
+                "description": "Updates an existing kanban bucket.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "project"
                 ],
-                "summary": "Get one attachment.",
+                "summary": "Update an existing bucket",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
+                        "description": "Project Id",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Attachment ID",
-                        "name": "attachmentID",
+                        "description": "Bucket Id",
+                        "name": "bucketID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The bucket object",
+                        "name": "bucket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Bucket"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ETag or version of the bucket the changes are based on. If the bucket was changed since then, it is not updated.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The attachment file.",
+                        "description": "The created bucket object.",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.Bucket"
                         }
                     },
-                    "403": {
-                        "description": "No access to this task.",
+                    "400": {
+                        "description": "Invalid bucket object provided.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The task does not exist.",
+                        "description": "The bucket does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "The bucket was changed in the meantime. Returns the current bucket.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Bucket"
                         }
                     },
                     "500": {
@@ -4242,7 +4072,7 @@ const docTemplate = `{
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delete an attachment.",
+                "description": "Deletes an existing kanban bucket and dissociates all of its task. It does not delete any tasks. You cannot delete the last bucket on a project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4250,42 +4080,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "project"
                 ],
-                "summary": "Delete an attachment",
+                "summary": "Deletes an existing bucket",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "id",
+                        "description": "Project Id",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Attachment ID",
-                        "name": "attachmentID",
+                        "description": "Bucket Id",
+                        "name": "bucketID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The attachment was deleted successfully.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "403": {
-                        "description": "No access to this task.",
+                        "description": "Successfully deleted.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "The task does not exist.",
+                        "description": "The bucket does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4297,14 +4121,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{taskID}/assignees": {
-            "get": {
+        "/projects/{projectID}/duplicate": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns an array with all assignees for this task.",
+                "description": "Copies the project, tasks, files, kanban data, assignees, comments, attachments, lables, relations, backgrounds, user/team rights and link shares from one project to a new one. The user needs read access in the project and write access in the parent of the new project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4312,44 +4136,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "assignees"
+                    "project"
                 ],
-                "summary": "Get all assignees for a task",
+                "summary": "Duplicate an existing project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search assignees by their username.",
-                        "name": "s",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "The project ID to duplicate",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The target parent project which should hold the copied project.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectDuplicate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "The assignees",
+                    "201": {
+                        "description": "The created project.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/user.User"
-                            }
+                            "$ref": "#/definitions/models.ProjectDuplicate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid project duplicate object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project or its parent.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4359,14 +4183,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/projects/{projectID}/tasks": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Adds a new assignee to a task. The assignee needs to have access to the project, the doer must be able to edit this task.",
+                "description": "Returns all tasks for the current project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4374,38 +4200,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "assignees"
+                    "task"
                 ],
-                "summary": "Add a new assignee to a task",
+                "summary": "Get tasks in a project",
                 "parameters": [
-                    {
-                        "description": "The assingee object",
-                        "name": "assignee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskAssginee"
-                        }
-                    },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "The project ID.",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paginate with a cursor instead of page numbers. Pass an empty cursor for the first page and the ` + "`" + `x-pagination-next-cursor` + "`" + ` header of the last response for the following ones. Cursors only work with the same sort parameters they were created with.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search tasks by task text.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parametes, along with ` + "`" + `order_by` + "`" + `. Possible values to sort by are ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `due_date` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `project_id` + "`" + `, ` + "`" + `repeat_after` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `hex_color` + "`" + `, ` + "`" + `percent_done` + "`" + `, ` + "`" + `uid` + "`" + `, ` + "`" + `created` + "`" + `, ` + "`" + `updated` + "`" + `. Default is ` + "`" + `id` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ordering parameter. Possible values to order by are ` + "`" + `asc` + "`" + ` or ` + "`" + `desc` + "`" + `. Default is ` + "`" + `asc` + "`" + `.",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
+                        "name": "filter_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The value to filter for. You can use [grafana](https://grafana.com/docs/grafana/latest/dashboards/time-range-controls)- or [elasticsearch](https://www.elastic.co/guide/en/elasticsearch/reference/7.3/common-options.html#date-math)-style relative dates for all date fields like ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, etc.",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
+                        "name": "filter_comparator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
+                        "name": "filter_concat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
+                        "name": "filter_include_nulls",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created assingee object.",
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskAssginee"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid assignee object provided.",
+                    "200": {
+                        "description": "The tasks",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Task"
+                            }
                         }
                     },
                     "500": {
@@ -4417,14 +4297,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{taskID}/assignees/bulk": {
-            "post": {
+        "/projects/{projectID}/tasks/aggregate": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Adds multiple new assignees to a task. The assignee needs to have access to the project, the doer must be able to edit this task. Every user not in the project will be unassigned from the task, pass an empty array to unassign everyone.",
+                "description": "Groups all tasks matching the filters by the provided dimensions and calculates metrics for each group, for example the number of open tasks per assignee. Accepts all filter parameters of the task collection.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4432,92 +4312,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "assignees"
+                    "task"
                 ],
-                "summary": "Add multiple new assignees to a task",
+                "summary": "Aggregate tasks",
                 "parameters": [
-                    {
-                        "description": "The array of assignees",
-                        "name": "assignee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.BulkAssignees"
-                        }
-                    },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "The project ID. Only available on the project route.",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "The created assingees object.",
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskAssginee"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid assignee object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    }
-                }
-            }
-        },
-        "/tasks/{taskID}/assignees/{userID}": {
-            "delete": {
-                "security": [
-                    {
-                        "JWTKeyAuth": []
-                    }
-                ],
-                "description": "Un-assign a user from a task.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "assignees"
-                ],
-                "summary": "Delete an assignee",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Assignee user ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
+                        "description": "The maximum number of groups per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only aggregate tasks matching this search text.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The dimension to group tasks by. You can pass this multiple times to group by multiple dimensions. Possible values are ` + "`" + `project_id` + "`" + `, ` + "`" + `bucket_id` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `assignees` + "`" + ` and ` + "`" + `labels` + "`" + `. If not provided, all tasks are aggregated in one group.",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The metric to calculate per group. You can pass this multiple times. Possible values are ` + "`" + `count` + "`" + `, ` + "`" + `sum_percent_done` + "`" + `, ` + "`" + `avg_percent_done` + "`" + ` and ` + "`" + `min_` + "`" + ` or ` + "`" + `max_` + "`" + ` of ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `created` + "`" + ` and ` + "`" + `updated` + "`" + `, for example ` + "`" + `max_due_date` + "`" + `. Defaults to ` + "`" + `count` + "`" + `.",
+                        "name": "metrics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
+                        "name": "filter_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The value to filter for. You can use [grafana](https://grafana.com/docs/grafana/latest/dashboards/time-range-controls)- or [elasticsearch](https://www.elastic.co/guide/en/elasticsearch/reference/7.3/common-options.html#date-math)-style relative dates for all date fields like ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, etc.",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
+                        "name": "filter_comparator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
+                        "name": "filter_concat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
+                        "name": "filter_include_nulls",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The assignee was successfully deleted.",
+                        "description": "The groups of tasks with their metrics",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TaskAggregateGroup"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid group by dimension or metric.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "403": {
-                        "description": "Not allowed to delete the assignee.",
+                        "description": "The user does not have access to the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4531,14 +4415,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{taskID}/comments": {
-            "get": {
+        "/projects/{projectID}/teams/{teamID}": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Get all task comments. The user doing this need to have at least read access to the task.",
+                "description": "Update a team \u003c-\u003e project relation. Mostly used to update the right that team has.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4546,26 +4430,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Get all task comments",
+                "summary": "Update a team \u003c-\u003e project relation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "teamID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The team you want to update.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamProject"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The array with all task comments",
+                        "description": "The updated team \u003c-\u003e project relation.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TaskComment"
-                            }
+                            "$ref": "#/definitions/models.TeamProject"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have admin-access to the project",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or project does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -4576,50 +4485,51 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Create a new task comment. The user doing this need to have at least write access to the task this comment should belong to.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delets a team from a project. The team won't have access to the project anymore.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Create a new task comment",
+                "summary": "Delete a team from a project",
                 "parameters": [
                     {
-                        "description": "The task comment object",
-                        "name": "relation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskComment"
-                        }
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "projectID",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "Team ID",
+                        "name": "teamID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created task comment object.",
+                    "200": {
+                        "description": "The team was successfully deleted.",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskComment"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Invalid task comment object provided.",
+                    "403": {
+                        "description": "The user does not have access to the project",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Team or project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4633,14 +4543,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{taskID}/comments/{commentID}": {
-            "get": {
+        "/projects/{projectID}/users/{userID}": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Remove a task comment. The user doing this need to have at least read access to the task this comment belongs to.",
+                "description": "Update a user \u003c-\u003e project relation. Mostly used to update the right that user has.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4648,40 +4558,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Remove a task comment",
+                "summary": "Update a user \u003c-\u003e project relation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "Project ID",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "commentID",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The user you want to update.",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProjectUser"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The task comment object.",
+                        "description": "The updated user \u003c-\u003e project relation.",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskComment"
+                            "$ref": "#/definitions/models.ProjectUser"
                         }
                     },
-                    "400": {
-                        "description": "Invalid task comment object provided.",
+                    "403": {
+                        "description": "The user does not have admin-access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The task comment was not found.",
+                        "description": "User or project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4694,54 +4613,51 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Update an existing task comment. The user doing this need to have at least write access to the task this comment belongs to.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delets a user from a project. The user won't have access to the project anymore.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "sharing"
                 ],
-                "summary": "Update an existing task comment",
+                "summary": "Delete a user from a project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "Project ID",
+                        "name": "projectID",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Comment ID",
-                        "name": "commentID",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated task comment object.",
+                        "description": "The user was successfully removed from the project.",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskComment"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Invalid task comment object provided.",
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The task comment was not found.",
+                        "description": "user or project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4753,55 +4669,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/projects/{project}/chat-destinations": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Remove a task comment. The user doing this need to have at least write access to the task this comment belongs to.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns all chat destinations of the current user or a project. Tokens are never returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "subscriptions"
                 ],
-                "summary": "Remove a task comment",
+                "summary": "Get all chat destinations",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Comment ID",
-                        "name": "commentID",
-                        "in": "path",
-                        "required": true
+                        "description": "Project ID",
+                        "name": "project",
+                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The task comment was successfully deleted.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid task comment object provided.",
+                        "description": "The chat destinations.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notifications.ChatDestination"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "The task comment was not found.",
+                    "403": {
+                        "description": "The user does not have access to the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4813,16 +4717,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/{taskID}/labels/bulk": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates all labels on a task. Every label which is not passed but exists on the task will be deleted. Every label which does not exist on the task will be added. All labels which are passed and already exist on the task won't be touched.",
+                "description": "Adds a chat room or topic where notifications are sent to. Destinations created at the user settings route receive all notifications of the current user, destinations of a project receive notifications about everything happening in that project.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4830,36 +4732,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "subscriptions"
                 ],
-                "summary": "Update all labels on a task.",
+                "summary": "Create a chat destination",
                 "parameters": [
                     {
-                        "description": "The array of labels",
-                        "name": "label",
+                        "description": "The chat destination.",
+                        "name": "destination",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LabelTaskBulk"
+                            "$ref": "#/definitions/notifications.ChatDestination"
                         }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "The updated labels object.",
+                        "description": "The created chat destination.",
                         "schema": {
-                            "$ref": "#/definitions/models.LabelTaskBulk"
+                            "$ref": "#/definitions/notifications.ChatDestination"
                         }
                     },
                     "400": {
-                        "description": "Invalid label object provided.",
+                        "description": "Invalid chat destination object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4873,14 +4774,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{taskID}/relations": {
-            "put": {
+        "/projects/{project}/chat-destinations/{destination}": {
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Creates a new relation between two tasks. The user needs to have update rights on the base task and at least read rights on the other task. Both tasks do not need to be on the same project. Take a look at the docs for available task relation kinds.",
+                "description": "Updates a chat destination. If no token is provided, the existing one is kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4888,36 +4789,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "subscriptions"
                 ],
-                "summary": "Create a new relation between two tasks",
+                "summary": "Update a chat destination",
                 "parameters": [
                     {
-                        "description": "The relation object",
-                        "name": "relation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskRelation"
-                        }
+                        "type": "integer",
+                        "description": "Project ID",
+                        "name": "project",
+                        "in": "path"
                     },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
+                        "description": "Chat destination ID",
+                        "name": "destination",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The chat destination.",
+                        "name": "destinationObject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ChatDestination"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created task relation object.",
+                    "200": {
+                        "description": "The updated chat destination.",
                         "schema": {
-                            "$ref": "#/definitions/models.TaskRelation"
+                            "$ref": "#/definitions/notifications.ChatDestination"
                         }
                     },
                     "400": {
-                        "description": "Invalid task relation object provided.",
+                        "description": "Invalid chat destination object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the chat destination.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -4929,72 +4842,45 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tasks/{taskID}/relations/{relationKind}/{otherTaskID}": {
+            },
             "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Removes a chat destination of the current user or a project.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "subscriptions"
                 ],
-                "summary": "Remove a task relation",
+                "summary": "Delete a chat destination",
                 "parameters": [
-                    {
-                        "description": "The relation object",
-                        "name": "relation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TaskRelation"
-                        }
-                    },
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "The kind of the relation. See the TaskRelation type for more info.",
-                        "name": "relationKind",
-                        "in": "path",
-                        "required": true
+                        "description": "Project ID",
+                        "name": "project",
+                        "in": "path"
                     },
                     {
                         "type": "integer",
-                        "description": "The id of the other task.",
-                        "name": "otherTaskID",
+                        "description": "Chat destination ID",
+                        "name": "destination",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The task relation was successfully deleted.",
+                        "description": "The chat destination was deleted successfully.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Invalid task relation object provided.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "The task relation was not found.",
+                    "403": {
+                        "description": "The user does not have access to the chat destination.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5008,14 +4894,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{task}/labels": {
+        "/projects/{project}/shares": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all labels which are assicociated with a given task.",
+                "description": "Returns all link shares which exist for a given project",
                 "consumes": [
                     "application/json"
                 ],
@@ -5023,14 +4909,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "sharing"
                 ],
-                "summary": "Get all labels on a task",
+                "summary": "Get all link shares for a project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "task",
+                        "description": "Project ID",
+                        "name": "project",
                         "in": "path",
                         "required": true
                     },
@@ -5048,18 +4934,18 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search labels by label text.",
+                        "description": "Search shares by hash.",
                         "name": "s",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The labels",
+                        "description": "The share links",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Label"
+                                "$ref": "#/definitions/models.LinkSharing"
                             }
                         }
                     },
@@ -5077,7 +4963,7 @@ const docTemplate = `{
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Add a label to a task. The user needs to have write-access to the project to be able do this.",
+                "description": "Share a project via link. The user needs to have write-access to the project to be able do this.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5085,48 +4971,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "sharing"
                 ],
-                "summary": "Add a label to a task",
+                "summary": "Share a project via link",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "task",
+                        "description": "Project ID",
+                        "name": "project",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "The label object",
+                        "description": "The new link share object",
                         "name": "label",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LabelTask"
+                            "$ref": "#/definitions/models.LinkSharing"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "The created label relation object.",
+                        "description": "The created link share object.",
                         "schema": {
-                            "$ref": "#/definitions/models.LabelTask"
+                            "$ref": "#/definitions/models.LinkSharing"
                         }
                     },
                     "400": {
-                        "description": "Invalid label object provided.",
+                        "description": "Invalid link share object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "403": {
-                        "description": "Not allowed to add the label.",
+                        "description": "Not allowed to add the project share.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "The label does not exist.",
+                        "description": "The project does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5140,14 +5026,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/{task}/labels/{label}": {
-            "delete": {
+        "/projects/{project}/shares/{share}": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Remove a label from a task. The user needs to have write-access to the project to be able do this.",
+                "description": "Returns one link share by its ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5155,40 +5041,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "labels"
+                    "sharing"
                 ],
-                "summary": "Remove a label from a task",
+                "summary": "Get one link shares for a project",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Task ID",
-                        "name": "task",
+                        "description": "Project ID",
+                        "name": "project",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Label ID",
-                        "name": "label",
+                        "description": "Share ID",
+                        "name": "share",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The label was successfully removed.",
+                        "description": "The share links",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.LinkSharing"
                         }
                     },
                     "403": {
-                        "description": "Not allowed to remove the label.",
+                        "description": "No access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "Label not found.",
+                        "description": "Share Link not found.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5200,16 +5086,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teams": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all teams the current user is part of.",
+                "description": "Remove a link share. The user needs to have write-access to the project to be able do this.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5217,54 +5101,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "sharing"
                 ],
-                "summary": "Get teams",
+                "summary": "Remove a link share",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Project ID",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
-                        "name": "per_page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search teams by its name.",
-                        "name": "s",
-                        "in": "query"
+                        "description": "Share Link ID",
+                        "name": "share",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The teams.",
+                        "description": "The link was successfully removed.",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Team"
-                            }
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "500": {
-                        "description": "Internal error",
+                    "403": {
+                        "description": "Not allowed to remove the link.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Share Link not found.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "JWTKeyAuth": []
-                    }
-                ],
-                "description": "Creates a new team.",
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Creates a new user account.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5272,29 +5158,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "auth"
                 ],
-                "summary": "Creates a new team",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "description": "The team you want to create.",
-                        "name": "team",
+                        "description": "The user credentials",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "$ref": "#/definitions/user.APIUserPassword"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "The created team.",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "$ref": "#/definitions/user.User"
                         }
                     },
                     "400": {
-                        "description": "Invalid team object provided.",
+                        "description": "No or invalid user register object provided / User already exists.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5308,61 +5194,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{id}": {
+        "/routes": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a team by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a list of all API routes which are available to use with an api token, not a user login.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team"
-                ],
-                "summary": "Gets one team",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "api"
                 ],
+                "summary": "Get a list of all token api routes",
                 "responses": {
                     "200": {
-                        "description": "The team",
-                        "schema": {
-                            "$ref": "#/definitions/models.Team"
-                        }
-                    },
-                    "403": {
-                        "description": "The user does not have access to the team",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal error",
+                        "description": "The list of all routes.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APITokenRoute"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/search": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Updates a team.",
+                "description": "Searches all projects, tasks, task comments, labels, saved filters and teams the user has access to and returns the best matches first. Every type returns at most 50 results. Uses Typesense if it is enabled and the database otherwise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5370,36 +5237,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "search"
                 ],
-                "summary": "Updates a team",
+                "summary": "Search everything",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "The search string.",
+                        "name": "s",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "The team with updated values you want to update.",
-                        "name": "team",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Team"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only return results of these types. Possible values are ` + "`" + `project` + "`" + `, ` + "`" + `task` + "`" + `, ` + "`" + `task_comment` + "`" + `, ` + "`" + `label` + "`" + `, ` + "`" + `saved_filter` + "`" + ` and ` + "`" + `team` + "`" + `.",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The updated team.",
+                        "description": "The search results.",
                         "schema": {
-                            "$ref": "#/definitions/models.Team"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchResult"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid team object provided.",
+                        "description": "Invalid search type.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Link shares cannot use the search.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5411,39 +5300,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "JWTKeyAuth": []
-                    }
+            }
+        },
+        "/shares/{share}/auth": {
+            "post": {
+                "description": "Get a jwt auth token for a shared project from a share hash.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Delets a team. This will also remove the access for all users in that team.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "sharing"
                 ],
-                "summary": "Deletes a team",
+                "summary": "Get an auth token for a share",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
+                        "description": "The password for link shares which require one.",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.LinkShareAuth"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "The share hash",
+                        "name": "share",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The team was successfully deleted.",
+                        "description": "The valid jwt auth token.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/auth.Token"
                         }
                     },
                     "400": {
-                        "description": "Invalid team object provided.",
+                        "description": "Invalid link share object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5457,14 +5355,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{id}/members": {
+        "/subscriptions/{entity}/{entityID}": {
             "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Add a user to a team.",
+                "description": "Subscribes the current user to an entity.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5472,42 +5370,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "subscriptions"
                 ],
-                "summary": "Add a user to a team",
+                "summary": "Subscribes the current user to an entity.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "The entity the user subscribes to. Can be either ` + "`" + `project` + "`" + ` or ` + "`" + `task` + "`" + `.",
+                        "name": "entity",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "The user to be added to a team.",
-                        "name": "team",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.TeamMember"
-                        }
+                        "type": "string",
+                        "description": "The numeric id of the entity to subscribe to.",
+                        "name": "entityID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "The newly created member object",
+                        "description": "The subscription",
                         "schema": {
-                            "$ref": "#/definitions/models.TeamMember"
+                            "$ref": "#/definitions/models.Subscription"
                         }
                     },
-                    "400": {
-                        "description": "Invalid member object provided.",
+                    "403": {
+                        "description": "The user does not have access to subscribe to this entity.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "The user does not have access to the team",
+                    "412": {
+                        "description": "The subscription entity is invalid.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5519,44 +5415,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/teams/{id}/members/{userID}": {
+            },
             "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Remove a user from a team. This will also revoke any access this user might have via that team. A user can remove themselves from the team if they are not the last user in the team.",
+                "description": "Unsubscribes the current user to an entity.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "subscriptions"
                 ],
-                "summary": "Remove a user from a team",
+                "summary": "Unsubscribe the current user from an entity.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "The entity the user subscribed to. Can be either ` + "`" + `project` + "`" + ` or ` + "`" + `task` + "`" + `.",
+                        "name": "entity",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
+                        "type": "string",
+                        "description": "The numeric id of the subscribed entity to.",
+                        "name": "entityID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The user was successfully removed from the team.",
+                        "description": "The subscription",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to subscribe to this entity.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The subscription does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -5568,42 +5477,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/teams/{id}/members/{userID}/admin": {
-            "post": {
+        "/sync": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "If a user is team admin, this will make them member and vise-versa.",
+                "description": "Returns all projects, tasks, labels and kanban buckets the user has access to which were created or updated since the last sync, together with tombstones for everything which was deleted or the user lost access to. Offline-first clients can use this to sync incrementally. Pass the returned ` + "`" + `cursor` + "`" + ` as ` + "`" + `since` + "`" + ` to the next sync. Without ` + "`" + `since` + "`" + `, everything is returned. Changes made during a sync might be returned again with the next one. Tasks are paginated: as long as ` + "`" + `has_more` + "`" + ` is true, pass the returned ` + "`" + `cursor` + "`" + ` as ` + "`" + `since` + "`" + ` right away to get the next page. Deletions are only kept for the configured number of days, older cursors need a full sync.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "team"
+                    "sync"
                 ],
-                "summary": "Toggle a team member's admin status",
+                "summary": "Sync changes",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Team ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "The cursor returned by the last sync.",
+                        "name": "since",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userID",
-                        "in": "path",
-                        "required": true
+                        "description": "The maximum number of tasks per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The member right was successfully changed.",
+                        "description": "Everything which changed since the last sync.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Sync"
+                        }
+                    },
+                    "400": {
+                        "description": "The cursor is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "410": {
+                        "description": "The cursor is older than the deletions which are kept.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
@@ -5615,9 +5537,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/test/{table}": {
-            "patch": {
-                "description": "Fills the specified table with the content provided in the payload. You need to enable the testing endpoint before doing this and provide the ` + "`" + `Authorization: \u003ctoken\u003e` + "`" + ` secret when making requests to this endpoint. See docs for more details.",
+        "/tasks/aggregate": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Groups all tasks matching the filters by the provided dimensions and calculates metrics for each group, for example the number of open tasks per assignee. Accepts all filter parameters of the task collection.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5625,30 +5552,95 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "testing"
+                    "task"
                 ],
-                "summary": "Reset the db to a defined state",
+                "summary": "Aggregate tasks",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of groups per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "The table to reset",
-                        "name": "table",
-                        "in": "path",
-                        "required": true
+                        "description": "Only aggregate tasks matching this search text.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The dimension to group tasks by. You can pass this multiple times to group by multiple dimensions. Possible values are ` + "`" + `project_id` + "`" + `, ` + "`" + `bucket_id` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `assignees` + "`" + ` and ` + "`" + `labels` + "`" + `. If not provided, all tasks are aggregated in one group.",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The metric to calculate per group. You can pass this multiple times. Possible values are ` + "`" + `count` + "`" + `, ` + "`" + `sum_percent_done` + "`" + `, ` + "`" + `avg_percent_done` + "`" + ` and ` + "`" + `min_` + "`" + ` or ` + "`" + `max_` + "`" + ` of ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `created` + "`" + ` and ` + "`" + `updated` + "`" + `, for example ` + "`" + `max_due_date` + "`" + `. Defaults to ` + "`" + `count` + "`" + `.",
+                        "name": "metrics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
+                        "name": "filter_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The value to filter for. You can use [grafana](https://grafana.com/docs/grafana/latest/dashboards/time-range-controls)- or [elasticsearch](https://www.elastic.co/guide/en/elasticsearch/reference/7.3/common-options.html#date-math)-style relative dates for all date fields like ` + "`" + `due_date` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, etc.",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
+                        "name": "filter_comparator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
+                        "name": "filter_concat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
+                        "name": "filter_include_nulls",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Everything has been imported successfully.",
+                    "200": {
+                        "description": "The groups of tasks with their metrics",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/user.User"
+                                "$ref": "#/definitions/models.TaskAggregateGroup"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid group by dimension or metric.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -5656,14 +5648,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tokens": {
+        "/tasks/all": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns all api tokens the current user has created.",
+                "description": "Returns all tasks on any project the user has access to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5671,54 +5663,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "api"
+                    "task"
                 ],
-                "summary": "Get all api tokens of the current user",
+                "summary": "Get tasks",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "The page number, used for pagination. If not provided, the first page of results is returned.",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
                         "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "The maximum number of tokens per page. This parameter is limited by the configured maximum of items per page.",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
                         "name": "per_page",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search tokens by their title.",
+                        "description": "Paginate with a cursor instead of page numbers. Pass an empty cursor for the first page and the ` + "`" + `x-pagination-next-cursor` + "`" + ` header of the last response for the following ones. Cursors only work with the same sort parameters they were created with.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search tasks by task text.",
                         "name": "s",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The sorting parameter. You can pass this multiple times to get the tasks ordered by multiple different parameters, along with ` + "`" + `order_by` + "`" + `. Possible values to sort by are ` + "`" + `id` + "`" + `, ` + "`" + `title` + "`" + `, ` + "`" + `description` + "`" + `, ` + "`" + `done` + "`" + `, ` + "`" + `done_at` + "`" + `, ` + "`" + `due_date` + "`" + `, ` + "`" + `created_by_id` + "`" + `, ` + "`" + `project_id` + "`" + `, ` + "`" + `repeat_after` + "`" + `, ` + "`" + `priority` + "`" + `, ` + "`" + `start_date` + "`" + `, ` + "`" + `end_date` + "`" + `, ` + "`" + `hex_color` + "`" + `, ` + "`" + `percent_done` + "`" + `, ` + "`" + `uid` + "`" + `, ` + "`" + `created` + "`" + `, ` + "`" + `updated` + "`" + `. Default is ` + "`" + `id` + "`" + `.",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ordering parameter. Possible values to order by are ` + "`" + `asc` + "`" + ` or ` + "`" + `desc` + "`" + `. Default is ` + "`" + `asc` + "`" + `.",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the field to filter by. Allowed values are all task properties. Task properties which are their own object require passing in the id of that entity. Accepts an array for multiple filters which will be chanied together, all supplied filter must match.",
+                        "name": "filter_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The value to filter for.",
+                        "name": "filter_value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The comparator to use for a filter. Available values are ` + "`" + `equals` + "`" + `, ` + "`" + `greater` + "`" + `, ` + "`" + `greater_equals` + "`" + `, ` + "`" + `less` + "`" + `, ` + "`" + `less_equals` + "`" + `, ` + "`" + `like` + "`" + ` and ` + "`" + `in` + "`" + `. ` + "`" + `in` + "`" + ` expects comma-separated values in ` + "`" + `filter_value` + "`" + `. Defaults to ` + "`" + `equals` + "`" + `",
+                        "name": "filter_comparator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The concatinator to use for filters. Available values are ` + "`" + `and` + "`" + ` or ` + "`" + `or` + "`" + `. Defaults to ` + "`" + `or` + "`" + `.",
+                        "name": "filter_concat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set to true the result will include filtered fields whose value is set to ` + "`" + `null` + "`" + `. Available values are ` + "`" + `true` + "`" + ` or ` + "`" + `false` + "`" + `. Defaults to ` + "`" + `false` + "`" + `.",
+                        "name": "filter_include_nulls",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The list of all tokens",
+                        "description": "The tasks",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.APIToken"
+                                "$ref": "#/definitions/models.Task"
                             }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/tasks/bulk": {
             "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Create a new api token to use on behalf of the user creating it.",
+                "description": "Creates multiple tasks, possibly in different projects, in one transaction. Labels, assignees and reminders are created with each task. The user needs write access to the project of every task. If any task could not be created, no task is created and the response contains the errors of the failed tasks.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5726,31 +5768,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "api"
+                    "task"
                 ],
-                "summary": "Create a new api token",
+                "summary": "Create multiple tasks at once",
                 "parameters": [
                     {
-                        "description": "The token object with required fields",
-                        "name": "token",
+                        "description": "The tasks to create.",
+                        "name": "tasks",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.APIToken"
+                            "$ref": "#/definitions/models.BulkTaskCreate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The created token.",
+                        "description": "The created tasks.",
                         "schema": {
-                            "$ref": "#/definitions/models.APIToken"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid token object provided.",
+                        "description": "At least one task could not be created. Nothing was saved.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
                     "500": {
@@ -5760,16 +5808,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/tokens/{tokenID}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Delete any of the user's api tokens.",
+                "description": "Updates a bunch of tasks at once. This includes marking them as done. Note: although you could supply another ID, it will be ignored. Use task_ids instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5777,27 +5823,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "api"
+                    "task"
                 ],
-                "summary": "Deletes an existing api token",
+                "summary": "Update a bunch of tasks at once",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Token ID",
-                        "name": "tokenID",
-                        "in": "path",
-                        "required": true
+                        "description": "The task object. Looks like a normal task, the only difference is it uses an array of project_ids to update.",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkTask"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted.",
+                        "description": "The updated task object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
-                    "404": {
-                        "description": "The token does not exist.",
+                    "400": {
+                        "description": "Invalid task object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the task (aka its project)",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5809,16 +5863,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns the current user object.",
+                "description": "Deletes multiple tasks in one transaction. The user needs the right to delete every task. If any task could not be deleted, no task is deleted and the response contains the errors of the failed tasks.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5826,24 +5878,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
+                ],
+                "summary": "Delete multiple tasks at once",
+                "parameters": [
+                    {
+                        "description": "The ids of the tasks to delete.",
+                        "name": "tasks",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkTaskDelete"
+                        }
+                    }
                 ],
-                "summary": "Get user information",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The deleted tasks.",
                         "schema": {
-                            "$ref": "#/definitions/user.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "User does not exist.",
+                    "400": {
+                        "description": "At least one task could not be deleted. Nothing was deleted.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -5851,9 +5920,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/confirm": {
+        "/tasks/bulk/move": {
             "post": {
-                "description": "Confirms the email of a newly registered user.",
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Moves multiple tasks into another project and/or kanban bucket in one transaction. The user needs write access to the projects of the tasks and the target project. Tasks moved into another project get a new index in that project. If any task could not be moved, no task is moved and the response contains the errors of the failed tasks.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5861,31 +5935,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Confirm the email of a new user",
+                "summary": "Move multiple tasks at once",
                 "parameters": [
                     {
-                        "description": "The token.",
-                        "name": "credentials",
+                        "description": "The ids of the tasks to move and where to move them.",
+                        "name": "tasks",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.EmailConfirm"
+                            "$ref": "#/definitions/models.BulkTaskMove"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The moved tasks.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
-                    "412": {
-                        "description": "Bad token provided.",
+                    "400": {
+                        "description": "At least one task could not be moved. Nothing was saved.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkTaskResult"
+                            }
                         }
                     },
                     "500": {
@@ -5897,9 +5977,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/deletion/cancel": {
-            "post": {
-                "description": "Aborts an in-progress user deletion.",
+        "/tasks/by-identifier/{identifier}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns a task by its identifier, for example ` + "`" + `OPS-42` + "`" + `. If the task was moved into another project, its old identifiers still resolve to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5907,29 +5992,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Abort a user deletion request",
+                "summary": "Get one task by its identifier",
                 "parameters": [
                     {
-                        "description": "The user password to confirm.",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
-                        }
+                        "type": "string",
+                        "description": "The task identifier",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The task",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
-                    "412": {
-                        "description": "Bad password provided.",
+                    "404": {
+                        "description": "There is no task with this identifier or the user does not have access to it.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5943,9 +6026,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/deletion/confirm": {
+        "/tasks/parse": {
             "post": {
-                "description": "Confirms the deletion request of a user sent via email.",
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Parses a text like \"Buy milk tomorrow 5pm *errands @alice +Home !3 every week\" into a task without creating it. Recognizes dates and times, labels (` + "`" + `*` + "`" + `), assignees (` + "`" + `@` + "`" + `), a project (` + "`" + `+` + "`" + `), a priority (` + "`" + `!` + "`" + `) and repeating intervals. Dates are in the timezone of the user. To create the task right away, pass ` + "`" + `quick_add=true` + "`" + ` when creating it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5953,29 +6041,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Confirm a user deletion request",
+                "summary": "Parse a quick add text",
                 "parameters": [
                     {
-                        "description": "The token.",
-                        "name": "credentials",
+                        "description": "The text to parse. Only ` + "`" + `text` + "`" + ` and ` + "`" + `project_id` + "`" + ` are used.",
+                        "name": "text",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UserDeletionRequestConfirm"
+                            "$ref": "#/definitions/models.QuickAdd"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The parsed task and all parts of the text which were recognized.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.QuickAdd"
                         }
                     },
-                    "412": {
-                        "description": "Bad token provided.",
+                    "400": {
+                        "description": "Invalid text provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
@@ -5989,9 +6077,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/deletion/request": {
+        "/tasks/{ID}": {
             "post": {
-                "description": "Requests the deletion of the current user. It will trigger an email which has to be confirmed to start the deletion.",
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Updates a task. This includes marking it as done. Assignees you pass will be updated, see their individual endpoints for more details on how this is done. To update labels, see the description of the endpoint.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5999,33 +6092,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Request the deletion of the user",
+                "summary": "Update a task",
                 "parameters": [
                     {
-                        "description": "The user password.",
-                        "name": "credentials",
+                        "type": "integer",
+                        "description": "The Task ID",
+                        "name": "ID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The task object",
+                        "name": "task",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
+                            "$ref": "#/definitions/models.Task"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ETag or version of the task the changes are based on. If the task was changed since then, it is not updated.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The updated task object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
-                    "412": {
-                        "description": "Bad password provided.",
+                    "400": {
+                        "description": "Invalid task object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the task (aka its project)",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
+                    "412": {
+                        "description": "The task was changed in the meantime. Returns the current task.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -6033,51 +6151,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/export/download": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a task from a project. This does not mean \"mark it done\".",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Download a user data export.",
+                "summary": "Delete a task",
                 "parameters": [
                     {
-                        "description": "User password to confirm the download.",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "ID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The created task object.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
+                        "description": "Invalid task ID provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6085,13 +6203,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/export/request": {
-            "post": {
+        "/tasks/{id}": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
+                "description": "Returns one task by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -6099,35 +6218,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Request a user data export.",
+                "summary": "Get one task",
                 "parameters": [
                     {
-                        "description": "User password to confirm the data export request.",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
-                        }
+                        "type": "integer",
+                        "description": "The task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The task",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.Task"
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
+                    "404": {
+                        "description": "Task not found",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6135,14 +6252,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password": {
-            "post": {
+        "/tasks/{id}/attachments": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Lets the current user change its password.",
+                "description": "Get all task attachments for one task.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6150,83 +6267,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Change password",
+                "summary": "Get  all attachments for one task.",
                 "parameters": [
                     {
-                        "description": "The current and new password.",
-                        "name": "userPassword",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.UserPassword"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "All attachments for this task",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TaskAttachment"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
+                    "403": {
+                        "description": "No access to this task.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "User does not exist.",
+                        "description": "The task does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
-        },
-        "/user/password/reset": {
-            "post": {
-                "description": "Resets a user email with a previously reset token.",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Upload a task attachment. You can pass multiple files with the files form param.",
+                "consumes": [
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Resets a password",
+                "summary": "Upload a task attachment",
                 "parameters": [
                     {
-                        "description": "The token with the new password.",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.PasswordReset"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The file, as multipart form file. You can pass multiple.",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Attachments were uploaded successfully.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Bad token provided.",
+                    "403": {
+                        "description": "No access to the task.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "The task does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -6238,41 +6382,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/password/token": {
-            "post": {
-                "description": "Requests a token to reset a users password. The token is sent via email.",
-                "consumes": [
-                    "application/json"
+        "/tasks/{id}/attachments/{attachmentID}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
                 ],
+                "description": "Get one attachment for download. **Returns json on error.**",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Request password reset token",
+                "summary": "Get one attachment.",
                 "parameters": [
                     {
-                        "description": "The username of the user to request a token for.",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.PasswordTokenRequest"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The attachment file.",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "No access to this task.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "404": {
-                        "description": "The user does not exist.",
+                        "description": "The task does not exist.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
@@ -6282,16 +6439,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/settings/avatar": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns the current user's avatar setting.",
+                "description": "Delete an attachment.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6299,37 +6454,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Return user avatar setting",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The attachment was deleted successfully.",
                         "schema": {
-                            "$ref": "#/definitions/v1.UserAvatarProvider"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
+                    "403": {
+                        "description": "No access to this task.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "The task does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/tasks/{id}/move": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Changes the user avatar. Valid types are gravatar (uses the user email), upload, initials, default.",
+                "description": "Moves a task into another project and/or kanban bucket. A task moved into another project gets a new index in that project, its old identifier keeps working with the ` + "`" + `/tasks/by-identifier/{identifier}` + "`" + ` endpoint.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6337,35 +6516,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Set the user's avatar",
+                "summary": "Move a task",
                 "parameters": [
                     {
-                        "description": "The user's avatar setting",
-                        "name": "avatar",
+                        "type": "integer",
+                        "description": "The task id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Where to move the task",
+                        "name": "move",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UserAvatarProvider"
+                            "$ref": "#/definitions/models.TaskMove"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The moved task.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.TaskMove"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
+                        "description": "Invalid move provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have write access to the task or the project it should be moved into.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The task, project or bucket does not exist.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6373,50 +6571,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/avatar/upload": {
-            "put": {
+        "/tasks/{taskID}/assignees": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Upload a user avatar. This will also set the user's avatar provider to \"upload\"",
+                "description": "Returns an array with all assignees for this task.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "assignees"
                 ],
-                "summary": "Upload a user avatar",
+                "summary": "Get all assignees for a task",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "The avatar as single file.",
-                        "name": "avatar",
-                        "in": "formData",
+                        "description": "Search assignees by their username.",
+                        "name": "s",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "The avatar was set successfully.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "400": {
-                        "description": "File is no image.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
-                    },
-                    "403": {
-                        "description": "File too large.",
+                        "description": "The assignees",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.User"
+                            }
                         }
                     },
                     "500": {
@@ -6426,16 +6633,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/user/settings/email": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Lets the current user change their email address.",
+                "description": "Adds a new assignee to a task. The assignee needs to have access to the project, the doer must be able to edit this task.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6443,41 +6648,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "assignees"
                 ],
-                "summary": "Update email address",
+                "summary": "Add a new assignee to a task",
                 "parameters": [
                     {
-                        "description": "The new email address and current password.",
-                        "name": "userEmailUpdate",
+                        "description": "The assingee object",
+                        "name": "assignee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.EmailUpdate"
+                            "$ref": "#/definitions/models.TaskAssginee"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "The created assingee object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.TaskAssginee"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "User does not exist.",
+                        "description": "Invalid assignee object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6485,13 +6691,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/general": {
+        "/tasks/{taskID}/assignees/bulk": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
+                "description": "Adds multiple new assignees to a task. The assignee needs to have access to the project, the doer must be able to edit this task. Every user not in the project will be unassigned from the task, pass an empty array to unassign everyone.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6499,35 +6706,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "assignees"
                 ],
-                "summary": "Change general user settings of the current user.",
+                "summary": "Add multiple new assignees to a task",
                 "parameters": [
                     {
-                        "description": "The updated user settings",
-                        "name": "avatar",
+                        "description": "The array of assignees",
+                        "name": "assignee",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UserSettings"
+                            "$ref": "#/definitions/models.BulkAssignees"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "The created assingees object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.TaskAssginee"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
+                        "description": "Invalid assignee object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6535,14 +6749,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/token/caldav": {
-            "get": {
+        "/tasks/{taskID}/assignees/{userID}": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Return the IDs and created dates of all caldav tokens for the current user.",
+                "description": "Un-assign a user from a task.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6550,46 +6764,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "assignees"
+                ],
+                "summary": "Delete an assignee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Assignee user ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Returns the caldav tokens for the current user",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/user.Token"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Something's invalid.",
+                        "description": "The assignee was successfully deleted.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
-                    "404": {
-                        "description": "User does not exist.",
+                    "403": {
+                        "description": "Not allowed to delete the assignee.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/tasks/{taskID}/comments": {
+            "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Generates a caldav token which can be used for the caldav api. It is not possible to see the token again after it was generated.",
+                "description": "Get all task comments. The user doing this need to have at least read access to the task.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6597,44 +6820,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
+                ],
+                "summary": "Get all task comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Generate a caldav token",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/user.Token"
-                        }
-                    },
-                    "400": {
-                        "description": "Something's invalid.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "User does not exist.",
+                        "description": "The array with all task comments",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TaskComment"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
-        },
-        "/user/settings/token/caldav/{id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
+                "description": "Create a new task comment. The user doing this need to have at least write access to the task this comment should belong to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6642,39 +6864,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Delete a caldav token by id",
+                "summary": "Create a new task comment",
                 "parameters": [
+                    {
+                        "description": "The task comment object",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskComment"
+                        }
+                    },
                     {
                         "type": "integer",
-                        "description": "Token ID",
-                        "name": "id",
+                        "description": "Task ID",
+                        "name": "taskID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "The created task comment object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.TaskComment"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "User does not exist.",
+                        "description": "Invalid task comment object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6682,14 +6907,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/totp": {
+        "/tasks/{taskID}/comments/{commentID}": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns the current user totp setting or an error if it is not enabled.",
+                "description": "Remove a task comment. The user doing this need to have at least read access to the task this comment belongs to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6697,33 +6922,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
+                ],
+                "summary": "Remove a task comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Totp setting for the current user",
                 "responses": {
                     "200": {
-                        "description": "The totp settings.",
+                        "description": "The task comment object.",
                         "schema": {
-                            "$ref": "#/definitions/user.TOTP"
+                            "$ref": "#/definitions/models.TaskComment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid task comment object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The task comment was not found.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
-        },
-        "/user/settings/totp/disable": {
+            },
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Disables any totp settings for the current user.",
+                "description": "Update an existing task comment. The user doing this need to have at least write access to the task this comment belongs to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6731,56 +6982,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Disable totp settings",
+                "summary": "Update an existing task comment",
                 "parameters": [
                     {
-                        "description": "The current user's password (only password is enough).",
-                        "name": "totp",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.Login"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ETag or version of the comment the changes are based on. If the comment was changed since then, it is not updated.",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully disabled",
+                        "description": "The updated task comment object.",
                         "schema": {
-                            "$ref": "#/definitions/models.Message"
+                            "$ref": "#/definitions/models.TaskComment"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
+                        "description": "Invalid task comment object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "User does not exist.",
+                        "description": "The task comment was not found.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error.",
+                    "412": {
+                        "description": "The comment was changed in the meantime. Returns the current comment.",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskComment"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
-        },
-        "/user/settings/totp/enable": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Enables a previously enrolled totp setting by providing a totp passcode.",
+                "description": "Remove a task comment. The user doing this need to have at least write access to the task this comment belongs to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6788,47 +7054,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Enable a previously enrolled totp setting.",
+                "summary": "Remove a task comment",
                 "parameters": [
                     {
-                        "description": "The totp passcode.",
-                        "name": "totp",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.TOTPPasscode"
-                        }
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "commentID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully enabled",
+                        "description": "The task comment was successfully deleted.",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     },
                     "400": {
-                        "description": "Something's invalid.",
+                        "description": "Invalid task comment object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "404": {
-                        "description": "User does not exist.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
-                    "412": {
-                        "description": "TOTP is not enrolled.",
+                        "description": "The task comment was not found.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6836,14 +7101,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/totp/enroll": {
+        "/tasks/{taskID}/labels/bulk": {
             "post": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Creates an initial setup for the user in the db. After this step, the user needs to verify they have a working totp setup with the \"enable totp\" endpoint.",
+                "description": "Updates all labels on a task. Every label which is not passed but exists on the task will be deleted. Every label which does not exist on the task will be added. All labels which are passed and already exist on the task won't be touched.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6851,30 +7116,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "labels"
                 ],
-                "summary": "Enroll a user into totp",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Update all labels on a task.",
+                "parameters": [
+                    {
+                        "description": "The array of labels",
+                        "name": "label",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.TOTP"
+                            "$ref": "#/definitions/models.LabelTaskBulk"
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The updated labels object.",
                         "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
+                            "$ref": "#/definitions/models.LabelTaskBulk"
                         }
                     },
-                    "404": {
-                        "description": "User does not exist.",
+                    "400": {
+                        "description": "Invalid label object provided.",
                         "schema": {
                             "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6882,14 +7159,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/settings/totp/qrcode": {
-            "get": {
+        "/tasks/{taskID}/relations": {
+            "put": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Returns a qr code for easier setup at end user's devices.",
+                "description": "Creates a new relation between two tasks. The user needs to have update rights on the base task and at least read rights on the other task. Both tasks do not need to be on the same project. Take a look at the docs for available task relation kinds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6897,18 +7174,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
+                ],
+                "summary": "Create a new relation between two tasks",
+                "parameters": [
+                    {
+                        "description": "The relation object",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TaskRelation"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Totp QR Code",
                 "responses": {
-                    "200": {
-                        "description": "The qr code as jpeg image",
+                    "201": {
+                        "description": "The created task relation object.",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.TaskRelation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid task relation object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6916,14 +7217,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/timezones": {
-            "get": {
+        "/tasks/{taskID}/relations/{relationKind}/{otherTaskID}": {
+            "delete": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Because available time zones depend on the system Vikunja is running on, this endpoint returns a project of all valid time zones this particular Vikunja instance can handle. The project of time zones is not sorted, you should sort it on the client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6931,50 +7231,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "task"
                 ],
-                "summary": "Get all available time zones on this vikunja instance",
-                "responses": {
-                    "200": {
-                        "description": "All available time zones.",
+                "summary": "Remove a task relation",
+                "parameters": [
+                    {
+                        "description": "The relation object",
+                        "name": "relation",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.TaskRelation"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error.",
-                        "schema": {
-                            "$ref": "#/definitions/models.Message"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "taskID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The kind of the relation. See the TaskRelation type for more info.",
+                        "name": "relationKind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the other task.",
+                        "name": "otherTaskID",
+                        "in": "path",
+                        "required": true
                     }
-                }
-            }
-        },
-        "/user/token": {
-            "post": {
-                "description": "Returns a new valid jwt user token with an extended length.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Renew user token",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "The task relation was successfully deleted.",
                         "schema": {
-                            "$ref": "#/definitions/auth.Token"
+                            "$ref": "#/definitions/models.Message"
                         }
                     },
                     "400": {
-                        "description": "Only user token are available for renew.",
+                        "description": "Invalid task relation object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The task relation was not found.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
@@ -6982,14 +7294,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/tasks/{task}/labels": {
             "get": {
                 "security": [
                     {
                         "JWTKeyAuth": []
                     }
                 ],
-                "description": "Search for a user by its username, name or full email. Name (not username) or email require that the user has enabled this in their settings.",
+                "description": "Returns all labels which are assicociated with a given task.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6997,41 +7309,2516 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "labels"
                 ],
-                "summary": "Get users",
+                "summary": "Get all labels on a task",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "The search criteria.",
+                        "description": "Search labels by label text.",
                         "name": "s",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "All (found) users.",
+                        "description": "The labels",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/user.User"
+                                "$ref": "#/definitions/models.Label"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Something's invalid.",
-                        "schema": {
-                            "$ref": "#/definitions/web.HTTPError"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error.",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/models.Message"
                         }
                     }
                 }
-            }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Add a label to a task. The user needs to have write-access to the project to be able do this.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Add a label to a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The label object",
+                        "name": "label",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LabelTask"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The created label relation object.",
+                        "schema": {
+                            "$ref": "#/definitions/models.LabelTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid label object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to add the label.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The label does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{task}/labels/{label}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Remove a label from a task. The user needs to have write-access to the project to be able do this.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Remove a label from a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "task",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Label ID",
+                        "name": "label",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The label was successfully removed.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to remove the label.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Label not found.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns all teams the current user is part of.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Get teams",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The page number. Used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of items per page. Note this parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search teams by its name.",
+                        "name": "s",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The teams.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Team"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Creates a new team",
+                "parameters": [
+                    {
+                        "description": "The team you want to create.",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The created team.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns a team by its ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Gets one team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The team",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the team",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Updates a team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Updates a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The team with updated values you want to update.",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated team.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Team"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Delets a team. This will also remove the access for all users in that team.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Deletes a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The team was successfully deleted.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid team object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/members": {
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Add a user to a team.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Add a user to a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The user to be added to a team.",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The newly created member object",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeamMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid member object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the team",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/members/{userID}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Remove a user from a team. This will also revoke any access this user might have via that team. A user can remove themselves from the team if they are not the last user in the team.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Remove a user from a team",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The user was successfully removed from the team.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/teams/{id}/members/{userID}/admin": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "If a user is team admin, this will make them member and vise-versa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "team"
+                ],
+                "summary": "Toggle a team member's admin status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The member right was successfully changed.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/test/{table}": {
+            "patch": {
+                "description": "Fills the specified table with the content provided in the payload. You need to enable the testing endpoint before doing this and provide the ` + "`" + `Authorization: \u003ctoken\u003e` + "`" + ` secret when making requests to this endpoint. See docs for more details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "testing"
+                ],
+                "summary": "Reset the db to a defined state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The table to reset",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Everything has been imported successfully.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns all api tokens the current user has created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api"
+                ],
+                "summary": "Get all api tokens of the current user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "The page number, used for pagination. If not provided, the first page of results is returned.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The maximum number of tokens per page. This parameter is limited by the configured maximum of items per page.",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search tokens by their title.",
+                        "name": "s",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The list of all tokens",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIToken"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Create a new api token to use on behalf of the user creating it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api"
+                ],
+                "summary": "Create a new api token",
+                "parameters": [
+                    {
+                        "description": "The token object with required fields",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The created token.",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid token object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/{tokenID}": {
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Delete any of the user's api tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api"
+                ],
+                "summary": "Deletes an existing api token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "The token does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns the current user object.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/confirm": {
+            "post": {
+                "description": "Confirms the email of a newly registered user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm the email of a new user",
+                "parameters": [
+                    {
+                        "description": "The token.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.EmailConfirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bad token provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/deletion/cancel": {
+            "post": {
+                "description": "Aborts an in-progress user deletion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Abort a user deletion request",
+                "parameters": [
+                    {
+                        "description": "The user password to confirm.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bad password provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/deletion/confirm": {
+            "post": {
+                "description": "Confirms the deletion request of a user sent via email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm a user deletion request",
+                "parameters": [
+                    {
+                        "description": "The token.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserDeletionRequestConfirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bad token provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/deletion/request": {
+            "post": {
+                "description": "Requests the deletion of the current user. It will trigger an email which has to be confirmed to start the deletion.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request the deletion of the user",
+                "parameters": [
+                    {
+                        "description": "The user password.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "412": {
+                        "description": "Bad password provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/export/download": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Download a user data export.",
+                "parameters": [
+                    {
+                        "description": "User password to confirm the download.",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/export/request": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request a user data export.",
+                "parameters": [
+                    {
+                        "description": "User password to confirm the data export request.",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserPasswordConfirmation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Lets the current user change its password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "The current and new password.",
+                        "name": "userPassword",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/reset": {
+            "post": {
+                "description": "Resets a user email with a previously reset token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Resets a password",
+                "parameters": [
+                    {
+                        "description": "The token with the new password.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.PasswordReset"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad token provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/password/token": {
+            "post": {
+                "description": "Requests a token to reset a users password. The token is sent via email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request password reset token",
+                "parameters": [
+                    {
+                        "description": "The username of the user to request a token for.",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.PasswordTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "The user does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/avatar": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns the current user's avatar setting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Return user avatar setting",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserAvatarProvider"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Changes the user avatar. Valid types are gravatar (uses the user email), upload, initials, default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set the user's avatar",
+                "parameters": [
+                    {
+                        "description": "The user's avatar setting",
+                        "name": "avatar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserAvatarProvider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/avatar/upload": {
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Upload a user avatar. This will also set the user's avatar provider to \"upload\"",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Upload a user avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The avatar as single file.",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The avatar was set successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "File is no image.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "File too large.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/calendar-feeds": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns all calendar feeds the current user has created.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get all calendar feeds",
+                "responses": {
+                    "200": {
+                        "description": "The calendar feeds.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CalendarFeed"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Creates a secret iCalendar feed url for the tasks of a project or saved filter. Calendar apps like Google Calendar or Outlook can subscribe to it without any credentials, everyone who knows the url can read the tasks in it. Delete the feed to revoke the url.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create a calendar feed",
+                "parameters": [
+                    {
+                        "description": "The calendar feed with the project or filter and the feed settings.",
+                        "name": "feed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeed"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The created calendar feed.",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid calendar feed object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project or saved filter.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/calendar-feeds/{feed}": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Updates which tasks a calendar feed contains and how. The project, filter and url of a feed can't be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update a calendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Calendar feed ID",
+                        "name": "feed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The calendar feed.",
+                        "name": "feedObject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeed"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated calendar feed.",
+                        "schema": {
+                            "$ref": "#/definitions/models.CalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid calendar feed object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "The calendar feed does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a calendar feed. Its url stops working immediately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete a calendar feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Calendar feed ID",
+                        "name": "feed",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The calendar feed was deleted successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "The calendar feed does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/chat-destinations": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns all chat destinations of the current user or a project. Tokens are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Get all chat destinations",
+                "responses": {
+                    "200": {
+                        "description": "The chat destinations.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notifications.ChatDestination"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Adds a chat room or topic where notifications are sent to. Destinations created at the user settings route receive all notifications of the current user, destinations of a project receive notifications about everything happening in that project.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Create a chat destination",
+                "parameters": [
+                    {
+                        "description": "The chat destination.",
+                        "name": "destination",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ChatDestination"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The created chat destination.",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ChatDestination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid chat destination object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the project.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/chat-destinations/{destination}": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Updates a chat destination. If no token is provided, the existing one is kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Update a chat destination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chat destination ID",
+                        "name": "destination",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The chat destination.",
+                        "name": "destinationObject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ChatDestination"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated chat destination.",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.ChatDestination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid chat destination object provided.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the chat destination.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Removes a chat destination of the current user or a project.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Delete a chat destination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chat destination ID",
+                        "name": "destination",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The chat destination was deleted successfully.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to the chat destination.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/email": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Lets the current user change their email address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update email address",
+                "parameters": [
+                    {
+                        "description": "The new email address and current password.",
+                        "name": "userEmailUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.EmailUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/general": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change general user settings of the current user.",
+                "parameters": [
+                    {
+                        "description": "The updated user settings",
+                        "name": "avatar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns all notification preferences of the current user, together with all notifications and channels which can be configured.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get the notification preferences of the current user.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserNotificationSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Replaces all notification preferences of the current user with the ones provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change the notification preferences of the current user.",
+                "parameters": [
+                    {
+                        "description": "The updated notification preferences",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UserNotificationSettings"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "The user does not have access to a project of a preference.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "A notification or channel is invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/token/caldav": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Return the IDs and created dates of all caldav tokens for the current user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Returns the caldav tokens for the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.Token"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Generates a caldav token which can be used for the caldav api. It is not possible to see the token again after it was generated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Generate a caldav token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.Token"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/token/caldav/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete a caldav token by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/totp": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns the current user totp setting or an error if it is not enabled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Totp setting for the current user",
+                "responses": {
+                    "200": {
+                        "description": "The totp settings.",
+                        "schema": {
+                            "$ref": "#/definitions/user.TOTP"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/totp/disable": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Disables any totp settings for the current user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Disable totp settings",
+                "parameters": [
+                    {
+                        "description": "The current user's password (only password is enough).",
+                        "name": "totp",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.Login"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/totp/enable": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Enables a previously enrolled totp setting by providing a totp passcode.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Enable a previously enrolled totp setting.",
+                "parameters": [
+                    {
+                        "description": "The totp passcode.",
+                        "name": "totp",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TOTPPasscode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully enabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "TOTP is not enrolled.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/totp/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Creates an initial setup for the user in the db. After this step, the user needs to verify they have a working totp setup with the \"enable totp\" endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Enroll a user into totp",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.TOTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/settings/totp/qrcode": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Returns a qr code for easier setup at end user's devices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Totp QR Code",
+                "responses": {
+                    "200": {
+                        "description": "The qr code as jpeg image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/timezones": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Because available time zones depend on the system Vikunja is running on, this endpoint returns a project of all valid time zones this particular Vikunja instance can handle. The project of time zones is not sorted, you should sort it on the client.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get all available time zones on this vikunja instance",
+                "responses": {
+                    "200": {
+                        "description": "All available time zones.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/token": {
+            "post": {
+                "description": "Returns a new valid jwt user token with an extended length.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Renew user token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.Token"
+                        }
+                    },
+                    "400": {
+                        "description": "Only user token are available for renew.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "JWTKeyAuth": []
+                    }
+                ],
+                "description": "Search for a user by its username, name or full email. Name (not username) or email require that the user has enabled this in their settings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The search criteria.",
+                        "name": "s",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "All (found) users.",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Something's invalid.",
+                        "schema": {
+                            "$ref": "#/definitions/web.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error.",
+                        "schema": {
+                            "$ref": "#/definitions/models.Message"
+                        }
+                    }
+                }
+            }
         },
         "/webhooks/events": {
             "get": {
@@ -7148,6 +9935,60 @@ const docTemplate = `{
                 }
             }
         },
+        "csv.Preview": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "The column names from the first row of the file.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "delimiter": {
+                    "description": "The detected delimiter between the columns.",
+                    "type": "string"
+                },
+                "rows": {
+                    "description": "The first rows after the column names.",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "csv.Report": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "The rows which could not be imported.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/csv.RowError"
+                    }
+                },
+                "imported": {
+                    "description": "The number of imported tasks.",
+                    "type": "integer"
+                }
+            }
+        },
+        "csv.RowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "description": "The line of the row in the file. The first row with the column names is line 1.",
+                    "type": "integer"
+                }
+            }
+        },
         "files.File": {
             "type": "object",
             "properties": {
@@ -7184,6 +10025,57 @@ const docTemplate = `{
                 }
             }
         },
+        "migration.Job": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this job was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Why the migration failed.",
+                    "type": "string"
+                },
+                "files_processed": {
+                    "description": "How many files like attachments or backgrounds were created so far.",
+                    "type": "integer"
+                },
+                "finished": {
+                    "description": "A timestamp when this job was finished, cancelled or failed.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The unique, numeric id of this migration job.",
+                    "type": "integer"
+                },
+                "migrator_name": {
+                    "type": "string"
+                },
+                "projects_processed": {
+                    "description": "How many projects were created so far.",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "The state of the job. One of queued, running, done, failed or cancelled.",
+                    "type": "string"
+                },
+                "tasks_processed": {
+                    "description": "How many tasks were created so far.",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "A timestamp when this job was last updated. You cannot change this value.",
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Everything which could not be migrated as it was in the other service.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "migration.Status": {
             "type": "object",
             "properties": {
@@ -7311,6 +10203,10 @@ const docTemplate = `{
                 "updated": {
                     "description": "A timestamp when this bucket was last updated. You cannot change this value.",
                     "type": "string"
+                },
+                "version": {
+                    "description": "The version of this bucket. It is increased with every change, the bucket's ETag is based on it.",
+                    "type": "integer"
                 }
             }
         },
@@ -7428,66 +10324,205 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "project_id": {
-                    "description": "The project this task belongs to.",
+                    "description": "The project this task belongs to.",
+                    "type": "integer"
+                },
+                "quick_add": {
+                    "description": "If true, the title is parsed for dates, labels, assignees, a project, a priority and a repeating interval,\nfor example \"Buy milk tomorrow 5pm *errands @alice +Home !3 every week\". Everything which was found is removed from the title.\nLabels which don't exist yet are created. Only used when creating a task, can also be passed as query parameter.",
+                    "type": "boolean"
+                },
+                "related_tasks": {
+                    "description": "All related tasks, grouped by their relation kind",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RelatedTaskMap"
+                        }
+                    ]
+                },
+                "reminders": {
+                    "description": "An array of reminders that are associated with this task.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskReminder"
+                    }
+                },
+                "repeat_after": {
+                    "description": "An amount in seconds this task repeats itself. If this is set, when marking the task as done, it will mark itself as \"undone\" and then increase all remindes and the due date by its amount.",
+                    "type": "integer"
+                },
+                "repeat_mode": {
+                    "description": "Can have three possible values which will trigger when the task is marked as done: 0 = repeats after the amount specified in repeat_after, 1 = repeats all dates each months (ignoring repeat_after), 3 = repeats from the current date rather than the last set date.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaskRepeatMode"
+                        }
+                    ]
+                },
+                "start_date": {
+                    "description": "When this task starts.",
+                    "type": "string"
+                },
+                "subscription": {
+                    "description": "The subscription status for the user reading this task. You can only read this property, use the subscription endpoints to modify it.\nWill only returned when retrieving one task.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    ]
+                },
+                "task_ids": {
+                    "description": "A project of task ids to update",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "title": {
+                    "description": "The task text. This is what you'll see in the project.",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "updated": {
+                    "description": "A timestamp when this task was last updated. You cannot change this value.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "The version of this task. It is increased with every change, the task's ETag is based on it.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BulkTaskCreate": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "description": "The tasks to create. Each task needs a project id, labels, assignees and reminders are created with the task.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
+        "models.BulkTaskDelete": {
+            "type": "object",
+            "properties": {
+                "task_ids": {
+                    "description": "The ids of the tasks to delete.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.BulkTaskMove": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "description": "The bucket to move the tasks to. If empty and the tasks are moved into another project, they are put in the\ndefault bucket of that project.",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "The project to move the tasks to. If empty, the tasks stay in their project.",
                     "type": "integer"
                 },
-                "related_tasks": {
-                    "description": "All related tasks, grouped by their relation kind",
+                "task_ids": {
+                    "description": "The ids of the tasks to move.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.BulkTaskResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "The reason the operation failed for this task.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.RelatedTaskMap"
+                            "$ref": "#/definitions/web.HTTPError"
                         }
                     ]
                 },
-                "reminders": {
-                    "description": "An array of reminders that are associated with this task.",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.TaskReminder"
-                    }
-                },
-                "repeat_after": {
-                    "description": "An amount in seconds this task repeats itself. If this is set, when marking the task as done, it will mark itself as \"undone\" and then increase all remindes and the due date by its amount.",
+                "index": {
+                    "description": "The position of the task in the request.",
                     "type": "integer"
                 },
-                "repeat_mode": {
-                    "description": "Can have three possible values which will trigger when the task is marked as done: 0 = repeats after the amount specified in repeat_after, 1 = repeats all dates each months (ignoring repeat_after), 3 = repeats from the current date rather than the last set date.",
+                "task": {
+                    "description": "The created or moved task. Only returned if all tasks of the operation succeeded.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.TaskRepeatMode"
+                            "$ref": "#/definitions/models.Task"
                         }
                     ]
                 },
-                "start_date": {
-                    "description": "When this task starts.",
+                "task_id": {
+                    "description": "The id of the task. Empty when creating tasks and the operation failed.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CalendarFeed": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this feed was created. You cannot change this value.",
                     "type": "string"
                 },
-                "subscription": {
-                    "description": "The subscription status for the user reading this task. You can only read this property, use the subscription endpoints to modify it.\nWill only returned when retrieving one task.",
+                "event_date": {
+                    "description": "The date the events of the feed are based on. Either ` + "`" + `due_date` + "`" + ` to create an event at the due date of a task,\n` + "`" + `start_end_date` + "`" + ` to create an event from the start until the end date of a task or empty to not create any events.\nTasks without that date are not added as events.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.Subscription"
+                            "$ref": "#/definitions/models.CalendarFeedEventDate"
                         }
                     ]
                 },
-                "task_ids": {
-                    "description": "A project of task ids to update",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "filter_id": {
+                    "description": "The saved filter this feed contains the tasks of. Either this or a project id must be provided.",
+                    "type": "integer"
                 },
-                "title": {
-                    "description": "The task text. This is what you'll see in the project.",
-                    "type": "string",
-                    "minLength": 1
+                "id": {
+                    "description": "The unique, numeric id of this calendar feed.",
+                    "type": "integer"
+                },
+                "include_done": {
+                    "description": "Whether done tasks are part of the feed.",
+                    "type": "boolean"
+                },
+                "include_todos": {
+                    "description": "Whether the feed contains all tasks as VTODO. Most calendar apps ignore those.",
+                    "type": "boolean"
+                },
+                "project_id": {
+                    "description": "The project this feed contains the tasks of. Either this or a filter id must be provided.",
+                    "type": "integer"
+                },
+                "token": {
+                    "description": "The secret token of this feed. The feed is available at ` + "`" + `/api/v1/feeds/\u003ctoken\u003e.ics` + "`" + ` for everyone who knows it.",
+                    "type": "string"
                 },
                 "updated": {
-                    "description": "A timestamp when this task was last updated. You cannot change this value.",
+                    "description": "A timestamp when this feed was last updated. You cannot change this value.",
                     "type": "string"
                 }
             }
         },
+        "models.CalendarFeedEventDate": {
+            "type": "string",
+            "enum": [
+                "",
+                "due_date",
+                "start_end_date"
+            ],
+            "x-enum-varnames": [
+                "CalendarFeedEventDateNone",
+                "CalendarFeedEventDateDueDate",
+                "CalendarFeedEventDateStartEndDate"
+            ]
+        },
         "models.DatabaseNotifications": {
             "type": "object",
             "properties": {
@@ -7551,112 +10586,385 @@ const docTemplate = `{
                     "minLength": 1
                 },
                 "updated": {
-                    "description": "A timestamp when this label was last updated. You cannot change this value.",
+                    "description": "A timestamp when this label was last updated. You cannot change this value.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.LabelTask": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this task was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "label_id": {
+                    "description": "The label id you want to associate with a task.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LabelTaskBulk": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "description": "All labels you want to update at once.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Label"
+                    }
+                }
+            }
+        },
+        "models.LinkSharing": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this project was shared. You cannot change this value.",
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "The public id to get this shared project",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The ID of the shared thing",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "The name of this link share. All actions someone takes while being authenticated with that link will appear with that name.",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "The password of this link share. You can only set it, not retrieve it after the link share has been created.",
+                    "type": "string"
+                },
+                "right": {
+                    "description": "The right this project is shared with. 0 = Read only, 1 = Read \u0026 Write, 2 = Admin. See the docs for more details.",
+                    "default": 0,
+                    "maximum": 2,
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Right"
+                        }
+                    ]
+                },
+                "shared_by": {
+                    "description": "The user who shared this project",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    ]
+                },
+                "sharing_type": {
+                    "description": "The kind of this link. 0 = undefined, 1 = without password, 2 = with password.",
+                    "default": 0,
+                    "maximum": 2,
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SharingType"
+                        }
+                    ]
+                },
+                "updated": {
+                    "description": "A timestamp when this share was last updated. You cannot change this value.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Message": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "A standard message.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Project": {
+            "type": "object",
+            "properties": {
+                "background_blur_hash": {
+                    "description": "Contains a very small version of the project background to use as a blurry preview until the actual background is loaded. Check out https://blurha.sh/ to learn how it works.",
+                    "type": "string"
+                },
+                "background_information": {
+                    "description": "Holds extra information about the background set since some background providers require attribution or similar. If not null, the background can be accessed at /projects/{projectID}/background"
+                },
+                "created": {
+                    "description": "A timestamp when this project was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "default_bucket_id": {
+                    "description": "The ID of the bucket where new tasks without a bucket are added to. By default, this is the leftmost bucket in a project.",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "The description of the project.",
+                    "type": "string"
+                },
+                "done_bucket_id": {
+                    "description": "If tasks are moved to the done bucket, they are marked as done. If they are marked as done individually, they are moved into the done bucket.",
+                    "type": "integer"
+                },
+                "hex_color": {
+                    "description": "The hex color of this project",
+                    "type": "string",
+                    "maxLength": 7
+                },
+                "id": {
+                    "description": "The unique, numeric id of this project.",
+                    "type": "integer"
+                },
+                "identifier": {
+                    "description": "The unique project short identifier. Used to build task identifiers.",
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 0
+                },
+                "is_archived": {
+                    "description": "Whether a project is archived.",
+                    "type": "boolean"
+                },
+                "is_favorite": {
+                    "description": "True if a project is a favorite. Favorite projects show up in a separate parent project. This value depends on the user making the call to the api.",
+                    "type": "boolean"
+                },
+                "owner": {
+                    "description": "The user who created this project.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    ]
+                },
+                "parent_project_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "The position this project has when querying all projects. See the tasks.position property on how to use this.",
+                    "type": "number"
+                },
+                "subscription": {
+                    "description": "The subscription status for the user reading this project. You can only read this property, use the subscription endpoints to modify it.\nWill only returned when retreiving one project.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Subscription"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "The title of the project. You'll see this in the overview.",
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                },
+                "updated": {
+                    "description": "A timestamp when this project was last updated. You cannot change this value.",
                     "type": "string"
+                },
+                "version": {
+                    "description": "The version of this project. It is increased with every change, the project's ETag is based on it.",
+                    "type": "integer"
                 }
             }
         },
-        "models.LabelTask": {
+        "models.ProjectDuplicate": {
             "type": "object",
             "properties": {
-                "created": {
-                    "description": "A timestamp when this task was created. You cannot change this value.",
-                    "type": "string"
+                "duplicated_project": {
+                    "description": "The copied project",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Project"
+                        }
+                    ]
                 },
-                "label_id": {
-                    "description": "The label id you want to associate with a task.",
+                "parent_project_id": {
+                    "description": "The target parent project",
                     "type": "integer"
                 }
             }
         },
-        "models.LabelTaskBulk": {
+        "models.ProjectExport": {
             "type": "object",
             "properties": {
-                "labels": {
-                    "description": "All labels you want to update at once.",
+                "background_blur_hash": {
+                    "description": "Contains a very small version of the project background to use as a blurry preview until the actual background is loaded. Check out https://blurha.sh/ to learn how it works.",
+                    "type": "string"
+                },
+                "background_file_id": {
+                    "type": "integer"
+                },
+                "background_information": {
+                    "description": "Holds extra information about the background set since some background providers require attribution or similar. If not null, the background can be accessed at /projects/{projectID}/background"
+                },
+                "buckets": {
+                    "description": "Only used for migration.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Label"
+                        "$ref": "#/definitions/models.Bucket"
                     }
-                }
-            }
-        },
-        "models.LinkSharing": {
-            "type": "object",
-            "properties": {
+                },
+                "child_projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectWithTasksAndBuckets"
+                    }
+                },
                 "created": {
-                    "description": "A timestamp when this project was shared. You cannot change this value.",
+                    "description": "A timestamp when this project was created. You cannot change this value.",
                     "type": "string"
                 },
-                "hash": {
-                    "description": "The public id to get this shared project",
+                "default_bucket_id": {
+                    "description": "The ID of the bucket where new tasks without a bucket are added to. By default, this is the leftmost bucket in a project.",
+                    "type": "integer"
+                },
+                "description": {
+                    "description": "The description of the project.",
                     "type": "string"
                 },
+                "done_bucket_id": {
+                    "description": "If tasks are moved to the done bucket, they are marked as done. If they are marked as done individually, they are moved into the done bucket.",
+                    "type": "integer"
+                },
+                "files": {
+                    "description": "The contents of all attachments and project backgrounds in the export, with the file id as key.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "hex_color": {
+                    "description": "The hex color of this project",
+                    "type": "string",
+                    "maxLength": 7
+                },
                 "id": {
-                    "description": "The ID of the shared thing",
+                    "description": "The unique, numeric id of this project.",
                     "type": "integer"
                 },
-                "name": {
-                    "description": "The name of this link share. All actions someone takes while being authenticated with that link will appear with that name.",
-                    "type": "string"
+                "identifier": {
+                    "description": "The unique project short identifier. Used to build task identifiers.",
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 0
                 },
-                "password": {
-                    "description": "The password of this link share. You can only set it, not retrieve it after the link share has been created.",
-                    "type": "string"
+                "is_archived": {
+                    "description": "Whether a project is archived.",
+                    "type": "boolean"
                 },
-                "right": {
-                    "description": "The right this project is shared with. 0 = Read only, 1 = Read \u0026 Write, 2 = Admin. See the docs for more details.",
-                    "default": 0,
-                    "maximum": 2,
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Right"
-                        }
-                    ]
+                "is_favorite": {
+                    "description": "True if a project is a favorite. Favorite projects show up in a separate parent project. This value depends on the user making the call to the api.",
+                    "type": "boolean"
                 },
-                "shared_by": {
-                    "description": "The user who shared this project",
+                "owner": {
+                    "description": "The user who created this project.",
                     "allOf": [
                         {
                             "$ref": "#/definitions/user.User"
                         }
                     ]
                 },
-                "sharing_type": {
-                    "description": "The kind of this link. 0 = undefined, 1 = without password, 2 = with password.",
-                    "default": 0,
-                    "maximum": 2,
+                "parent_project_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "The position this project has when querying all projects. See the tasks.position property on how to use this.",
+                    "type": "number"
+                },
+                "subscription": {
+                    "description": "The subscription status for the user reading this project. You can only read this property, use the subscription endpoints to modify it.\nWill only returned when retreiving one project.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.SharingType"
+                            "$ref": "#/definitions/models.Subscription"
                         }
                     ]
                 },
+                "tasks": {
+                    "description": "An array of tasks which belong to the project.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskWithComments"
+                    }
+                },
+                "title": {
+                    "description": "The title of the project. You'll see this in the overview.",
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                },
                 "updated": {
-                    "description": "A timestamp when this share was last updated. You cannot change this value.",
+                    "description": "A timestamp when this project was last updated. You cannot change this value.",
                     "type": "string"
+                },
+                "version": {
+                    "description": "The version of this project. It is increased with every change, the project's ETag is based on it.",
+                    "type": "integer"
                 }
             }
         },
-        "models.Message": {
+        "models.ProjectUser": {
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "A standard message.",
+                "created": {
+                    "description": "A timestamp when this relation was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The unique, numeric id of this project \u003c-\u003e user relation.",
+                    "type": "integer"
+                },
+                "right": {
+                    "description": "The right this user has. 0 = Read only, 1 = Read \u0026 Write, 2 = Admin. See the docs for more details.",
+                    "default": 0,
+                    "maximum": 2,
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Right"
+                        }
+                    ]
+                },
+                "updated": {
+                    "description": "A timestamp when this relation was last updated. You cannot change this value.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "The username.",
                     "type": "string"
                 }
             }
         },
-        "models.Project": {
+        "models.ProjectWithTasksAndBuckets": {
             "type": "object",
             "properties": {
                 "background_blur_hash": {
                     "description": "Contains a very small version of the project background to use as a blurry preview until the actual background is loaded. Check out https://blurha.sh/ to learn how it works.",
                     "type": "string"
                 },
+                "background_file_id": {
+                    "type": "integer"
+                },
                 "background_information": {
                     "description": "Holds extra information about the background set since some background providers require attribution or similar. If not null, the background can be accessed at /projects/{projectID}/background"
                 },
+                "buckets": {
+                    "description": "Only used for migration.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Bucket"
+                    }
+                },
+                "child_projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProjectWithTasksAndBuckets"
+                    }
+                },
                 "created": {
                     "description": "A timestamp when this project was created. You cannot change this value.",
                     "type": "string"
@@ -7719,6 +11027,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "tasks": {
+                    "description": "An array of tasks which belong to the project.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskWithComments"
+                    }
+                },
                 "title": {
                     "description": "The title of the project. You'll see this in the overview.",
                     "type": "string",
@@ -7728,54 +11043,45 @@ const docTemplate = `{
                 "updated": {
                     "description": "A timestamp when this project was last updated. You cannot change this value.",
                     "type": "string"
-                }
-            }
-        },
-        "models.ProjectDuplicate": {
-            "type": "object",
-            "properties": {
-                "duplicated_project": {
-                    "description": "The copied project",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Project"
-                        }
-                    ]
                 },
-                "parent_project_id": {
-                    "description": "The target parent project",
+                "version": {
+                    "description": "The version of this project. It is increased with every change, the project's ETag is based on it.",
                     "type": "integer"
                 }
             }
         },
-        "models.ProjectUser": {
+        "models.QuickAdd": {
             "type": "object",
             "properties": {
-                "created": {
-                    "description": "A timestamp when this relation was created. You cannot change this value.",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "The unique, numeric id of this project \u003c-\u003e user relation.",
+                "project_id": {
+                    "description": "The project the task would be created in if the text does not contain a project.",
                     "type": "integer"
                 },
-                "right": {
-                    "description": "The right this user has. 0 = Read only, 1 = Read \u0026 Write, 2 = Admin. See the docs for more details.",
-                    "default": 0,
-                    "maximum": 2,
+                "task": {
+                    "description": "The task as it would be created. Labels which don't exist yet don't have an id, they are created with the task.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.Right"
+                            "$ref": "#/definitions/models.Task"
                         }
                     ]
                 },
-                "updated": {
-                    "description": "A timestamp when this relation was last updated. You cannot change this value.",
+                "text": {
+                    "description": "The text to parse, for example \"Buy milk tomorrow 5pm *errands @alice +Home !3 every week\".",
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "The username.",
-                    "type": "string"
+                "tokens": {
+                    "description": "All parts of the text which were used to set a task property. They are not part of the task title.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/quickadd.Token"
+                    }
+                },
+                "unconsumed": {
+                    "description": "All parts of the text which look like a label, assignee or project but could not be used, for example\nbecause no such user has access to the project. They are left in the task title.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/quickadd.Token"
+                    }
                 }
             }
         },
@@ -7883,26 +11189,89 @@ const docTemplate = `{
                     "description": "True if the filter is a favorite. Favorite filters show up in a separate parent project together with favorite projects.",
                     "type": "boolean"
                 },
-                "owner": {
-                    "description": "The user who owns this filter",
+                "owner": {
+                    "description": "The user who owns this filter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "The title of the filter.",
+                    "type": "string",
+                    "maxLength": 250,
+                    "minLength": 1
+                },
+                "updated": {
+                    "description": "A timestamp when this filter was last updated. You cannot change this value.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "description": "The entity itself, as it would be returned from its own endpoint."
+                },
+                "field": {
+                    "description": "The field of the entity matching the search.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The id of the entity.",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "The project the task or comment belongs to.",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "How well the entity matches the search. Results are sorted by this, the best matches first.",
+                    "type": "number"
+                },
+                "snippet": {
+                    "description": "A part of the matching field. Matches are wrapped in \u003cmark\u003e tags, everything else is html escaped.",
+                    "type": "string"
+                },
+                "task_id": {
+                    "description": "The task the comment belongs to.",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "The title of the entity. For comments, this is the title of the task the comment belongs to.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "The type of the entity.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/user.User"
+                            "$ref": "#/definitions/models.SearchResultType"
                         }
                     ]
-                },
-                "title": {
-                    "description": "The title of the filter.",
-                    "type": "string",
-                    "maxLength": 250,
-                    "minLength": 1
-                },
-                "updated": {
-                    "description": "A timestamp when this filter was last updated. You cannot change this value.",
-                    "type": "string"
                 }
             }
         },
+        "models.SearchResultType": {
+            "type": "string",
+            "enum": [
+                "project",
+                "task",
+                "task_comment",
+                "label",
+                "saved_filter",
+                "team"
+            ],
+            "x-enum-varnames": [
+                "SearchResultTypeProject",
+                "SearchResultTypeTask",
+                "SearchResultTypeTaskComment",
+                "SearchResultTypeLabel",
+                "SearchResultTypeSavedFilter",
+                "SearchResultTypeTeam"
+            ]
+        },
         "models.SharingType": {
             "type": "integer",
             "enum": [
@@ -7944,6 +11313,54 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Sync": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "description": "All kanban buckets created or updated since the last sync.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Bucket"
+                    }
+                },
+                "cursor": {
+                    "description": "Pass this as ` + "`" + `since` + "`" + ` to the next sync to only get what changed after this one.",
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "All projects, tasks, labels and buckets deleted since the last sync. When a project was deleted,\nits tasks and buckets are deleted as well.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tombstone"
+                    }
+                },
+                "has_more": {
+                    "description": "Whether there are more tasks. If true, pass the cursor as ` + "`" + `since` + "`" + ` right away to get the next page of tasks.\nEverything other than tasks is only returned with the first page.",
+                    "type": "boolean"
+                },
+                "labels": {
+                    "description": "All labels created or updated since the last sync.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Label"
+                    }
+                },
+                "projects": {
+                    "description": "All projects created or updated since the last sync.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Project"
+                    }
+                },
+                "tasks": {
+                    "description": "All tasks created or updated since the last sync.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Task"
+                    }
+                }
+            }
+        },
         "models.Task": {
             "type": "object",
             "properties": {
@@ -8049,6 +11466,10 @@ const docTemplate = `{
                     "description": "The project this task belongs to.",
                     "type": "integer"
                 },
+                "quick_add": {
+                    "description": "If true, the title is parsed for dates, labels, assignees, a project, a priority and a repeating interval,\nfor example \"Buy milk tomorrow 5pm *errands @alice +Home !3 every week\". Everything which was found is removed from the title.\nLabels which don't exist yet are created. Only used when creating a task, can also be passed as query parameter.",
+                    "type": "boolean"
+                },
                 "related_tasks": {
                     "description": "All related tasks, grouped by their relation kind",
                     "allOf": [
@@ -8096,6 +11517,25 @@ const docTemplate = `{
                 "updated": {
                     "description": "A timestamp when this task was last updated. You cannot change this value.",
                     "type": "string"
+                },
+                "version": {
+                    "description": "The version of this task. It is increased with every change, the task's ETag is based on it.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskAggregateGroup": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "description": "The value of every group by dimension for this group, keyed by the dimension.\nA null value groups all tasks without one, for example all tasks without assignees.",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "metrics": {
+                    "description": "The calculated metrics of all tasks in this group, keyed by the metric.",
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
@@ -8184,87 +11624,281 @@ const docTemplate = `{
                 "author": {
                     "$ref": "#/definitions/user.User"
                 },
-                "comment": {
-                    "type": "string"
+                "comment": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "The version of this comment. It is increased with every change, the comment's ETag is based on it.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskMove": {
+            "type": "object",
+            "properties": {
+                "bucket_id": {
+                    "description": "The bucket to move the task to. If empty and the task is moved into another project, done tasks are put into\nthe done bucket of that project, other tasks into a bucket with the same title as their current bucket or the\ndefault bucket if there is none.",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "The project to move the task to. If empty, the task stays in its project.",
+                    "type": "integer"
+                },
+                "task": {
+                    "description": "The moved task. Ignored when sending.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Task"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.TaskRelation": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this label was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "The user who created this relation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    ]
+                },
+                "other_task_id": {
+                    "description": "The ID of the other task, the task which is being related.",
+                    "type": "integer"
+                },
+                "relation_kind": {
+                    "description": "The kind of the relation.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RelationKind"
+                        }
+                    ]
+                },
+                "task_id": {
+                    "description": "The ID of the \"base\" task, the task which has a relation to another.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskReminder": {
+            "type": "object",
+            "properties": {
+                "relative_period": {
+                    "description": "A period in seconds relative to another date argument. Negative values mean the reminder triggers before the date. Default: 0, tiggers when RelativeTo is due.",
+                    "type": "integer"
+                },
+                "relative_to": {
+                    "description": "The name of the date field to which the relative period refers to.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReminderRelation"
+                        }
+                    ]
+                },
+                "reminder": {
+                    "description": "The absolute time when the user wants to be reminded of the task.",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TaskRepeatMode": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "TaskRepeatModeDefault",
+                "TaskRepeatModeMonth",
+                "TaskRepeatModeFromCurrentDate"
+            ]
+        },
+        "models.TaskWithComments": {
+            "type": "object",
+            "properties": {
+                "assignees": {
+                    "description": "An array of users who are assigned to this task",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user.User"
+                    }
+                },
+                "attachments": {
+                    "description": "All attachments this task has",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskAttachment"
+                    }
+                },
+                "bucket_id": {
+                    "description": "BucketID is the ID of the kanban bucket this task belongs to.",
+                    "type": "integer"
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskComment"
+                    }
+                },
+                "cover_image_attachment_id": {
+                    "description": "If this task has a cover image, the field will return the id of the attachment that is the cover image.",
+                    "type": "integer"
+                },
+                "created": {
+                    "description": "A timestamp when this task was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "The user who initially created the task.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.User"
+                        }
+                    ]
+                },
+                "description": {
+                    "description": "The task description.",
+                    "type": "string"
+                },
+                "done": {
+                    "description": "Whether a task is done or not.",
+                    "type": "boolean"
+                },
+                "done_at": {
+                    "description": "The time when a task was marked as done.",
+                    "type": "string"
+                },
+                "due_date": {
+                    "description": "The time when the task is due.",
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "When this task ends.",
+                    "type": "string"
+                },
+                "hex_color": {
+                    "description": "The task color in hex",
+                    "type": "string",
+                    "maxLength": 7
+                },
+                "id": {
+                    "description": "The unique, numeric id of this task.",
+                    "type": "integer"
+                },
+                "identifier": {
+                    "description": "The task identifier, based on the project identifier and the task's index",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "The task index, calculated per project",
+                    "type": "integer"
+                },
+                "is_favorite": {
+                    "description": "True if a task is a favorite task. Favorite tasks show up in a separate \"Important\" project. This value depends on the user making the call to the api.",
+                    "type": "boolean"
+                },
+                "kanban_position": {
+                    "description": "The position of tasks in the kanban board. See the docs for the ` + "`" + `position` + "`" + ` property on how to use this.",
+                    "type": "number"
+                },
+                "labels": {
+                    "description": "An array of labels which are associated with this task.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Label"
+                    }
+                },
+                "percent_done": {
+                    "description": "Determines how far a task is left from being done",
+                    "type": "number"
                 },
-                "created": {
-                    "type": "string"
+                "position": {
+                    "description": "The position of the task - any task project can be sorted as usual by this parameter.\nWhen accessing tasks via kanban buckets, this is primarily used to sort them based on a range\nWe're using a float64 here to make it possible to put any task within any two other tasks (by changing the number).\nYou would calculate the new position between two tasks with something like task3.position = (task2.position - task1.position) / 2.\nA 64-Bit float leaves plenty of room to initially give tasks a position with 2^16 difference to the previous task\nwhich also leaves a lot of room for rearranging and sorting later.",
+                    "type": "number"
                 },
-                "id": {
+                "priority": {
+                    "description": "The task priority. Can be anything you want, it is possible to sort by this later.",
                     "type": "integer"
                 },
-                "updated": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.TaskRelation": {
-            "type": "object",
-            "properties": {
-                "created": {
-                    "description": "A timestamp when this label was created. You cannot change this value.",
-                    "type": "string"
+                "project_id": {
+                    "description": "The project this task belongs to.",
+                    "type": "integer"
                 },
-                "created_by": {
-                    "description": "The user who created this relation",
+                "quick_add": {
+                    "description": "If true, the title is parsed for dates, labels, assignees, a project, a priority and a repeating interval,\nfor example \"Buy milk tomorrow 5pm *errands @alice +Home !3 every week\". Everything which was found is removed from the title.\nLabels which don't exist yet are created. Only used when creating a task, can also be passed as query parameter.",
+                    "type": "boolean"
+                },
+                "related_tasks": {
+                    "description": "All related tasks, grouped by their relation kind",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/user.User"
+                            "$ref": "#/definitions/models.RelatedTaskMap"
                         }
                     ]
                 },
-                "other_task_id": {
-                    "description": "The ID of the other task, the task which is being related.",
+                "reminders": {
+                    "description": "An array of reminders that are associated with this task.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskReminder"
+                    }
+                },
+                "repeat_after": {
+                    "description": "An amount in seconds this task repeats itself. If this is set, when marking the task as done, it will mark itself as \"undone\" and then increase all remindes and the due date by its amount.",
                     "type": "integer"
                 },
-                "relation_kind": {
-                    "description": "The kind of the relation.",
+                "repeat_mode": {
+                    "description": "Can have three possible values which will trigger when the task is marked as done: 0 = repeats after the amount specified in repeat_after, 1 = repeats all dates each months (ignoring repeat_after), 3 = repeats from the current date rather than the last set date.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.RelationKind"
+                            "$ref": "#/definitions/models.TaskRepeatMode"
                         }
                     ]
                 },
-                "task_id": {
-                    "description": "The ID of the \"base\" task, the task which has a relation to another.",
-                    "type": "integer"
-                }
-            }
-        },
-        "models.TaskReminder": {
-            "type": "object",
-            "properties": {
-                "relative_period": {
-                    "description": "A period in seconds relative to another date argument. Negative values mean the reminder triggers before the date. Default: 0, tiggers when RelativeTo is due.",
-                    "type": "integer"
+                "start_date": {
+                    "description": "When this task starts.",
+                    "type": "string"
                 },
-                "relative_to": {
-                    "description": "The name of the date field to which the relative period refers to.",
+                "subscription": {
+                    "description": "The subscription status for the user reading this task. You can only read this property, use the subscription endpoints to modify it.\nWill only returned when retrieving one task.",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.ReminderRelation"
+                            "$ref": "#/definitions/models.Subscription"
                         }
                     ]
                 },
-                "reminder": {
-                    "description": "The absolute time when the user wants to be reminded of the task.",
+                "title": {
+                    "description": "The task text. This is what you'll see in the project.",
+                    "type": "string",
+                    "minLength": 1
+                },
+                "updated": {
+                    "description": "A timestamp when this task was last updated. You cannot change this value.",
                     "type": "string"
+                },
+                "version": {
+                    "description": "The version of this task. It is increased with every change, the task's ETag is based on it.",
+                    "type": "integer"
                 }
             }
         },
-        "models.TaskRepeatMode": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "TaskRepeatModeDefault",
-                "TaskRepeatModeMonth",
-                "TaskRepeatModeFromCurrentDate"
-            ]
-        },
         "models.Team": {
             "type": "object",
             "properties": {
@@ -8440,6 +12074,42 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Tombstone": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "A timestamp when the entity was deleted.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The id of the deleted entity.",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "The kind of the deleted entity. Can be ` + "`" + `task` + "`" + `, ` + "`" + `project` + "`" + `, ` + "`" + `label` + "`" + ` or ` + "`" + `bucket` + "`" + `.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TombstoneKind"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.TombstoneKind": {
+            "type": "string",
+            "enum": [
+                "task",
+                "project",
+                "label",
+                "bucket"
+            ],
+            "x-enum-varnames": [
+                "TombstoneKindTask",
+                "TombstoneKindProject",
+                "TombstoneKindLabel",
+                "TombstoneKindBucket"
+            ]
+        },
         "models.UserWithRight": {
             "type": "object",
             "properties": {
@@ -8519,6 +12189,43 @@ const docTemplate = `{
                 }
             }
         },
+        "notifications.ChatDestination": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "A timestamp when this destination was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The unique, numeric id of this chat destination.",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "The project this destination belongs to. 0 if it is a user destination.",
+                    "type": "integer"
+                },
+                "room": {
+                    "description": "The room id for matrix or the topic for ntfy.",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "The access token for matrix, the application token for gotify or an optional access token for ntfy.\nThis will never be returned by the api.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "The chat service. Can be one of ` + "`" + `matrix` + "`" + `, ` + "`" + `ntfy` + "`" + `, ` + "`" + `gotify` + "`" + ` or ` + "`" + `slack` + "`" + `. Use ` + "`" + `slack` + "`" + ` for all services which\naccept slack-compatible incoming webhooks, like Mattermost or Rocket.Chat.",
+                    "type": "string"
+                },
+                "updated": {
+                    "description": "A timestamp when this destination was last updated. You cannot change this value.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "For matrix the url of the homeserver, for ntfy and gotify the url of the server and for slack the incoming webhook url.",
+                    "type": "string"
+                }
+            }
+        },
         "notifications.DatabaseNotification": {
             "type": "object",
             "properties": {
@@ -8543,6 +12250,31 @@ const docTemplate = `{
                 }
             }
         },
+        "notifications.PushSubscription": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "description": "The base64url encoded authentication secret of the browser.",
+                    "type": "string"
+                },
+                "created": {
+                    "description": "A timestamp when this subscription was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "The push service url to which messages for this subscription are sent.",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The unique, numeric id of this push subscription.",
+                    "type": "integer"
+                },
+                "p256dh": {
+                    "description": "The base64url encoded P-256 public key of the browser.",
+                    "type": "string"
+                }
+            }
+        },
         "openid.Callback": {
             "type": "object",
             "properties": {
@@ -8574,6 +12306,70 @@ const docTemplate = `{
                 }
             }
         },
+        "quickadd.Token": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "The due date.",
+                    "type": "string"
+                },
+                "end": {
+                    "description": "The position after the last character of the token in the text.",
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "The priority, between 1 and 5.",
+                    "type": "integer"
+                },
+                "repeat_after": {
+                    "description": "The repeat interval in seconds.",
+                    "type": "integer"
+                },
+                "repeat_monthly": {
+                    "description": "Whether the task repeats every month, in which case RepeatAfter is not set.",
+                    "type": "boolean"
+                },
+                "start": {
+                    "description": "The position of the first character of the token in the text.",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "The part of the text this token was parsed from, exactly as it was written.",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "The kind of task property this token sets.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/quickadd.TokenType"
+                        }
+                    ]
+                },
+                "value": {
+                    "description": "The title of a label or project or the username of an assignee.",
+                    "type": "string"
+                }
+            }
+        },
+        "quickadd.TokenType": {
+            "type": "string",
+            "enum": [
+                "label",
+                "assignee",
+                "project",
+                "priority",
+                "date",
+                "repeat"
+            ],
+            "x-enum-varnames": [
+                "TokenTypeLabel",
+                "TokenTypeAssignee",
+                "TokenTypeProject",
+                "TokenTypePriority",
+                "TokenTypeDate",
+                "TokenTypeRepeat"
+            ]
+        },
         "todoist.Migration": {
             "type": "object",
             "properties": {
@@ -8659,6 +12455,35 @@ const docTemplate = `{
                 }
             }
         },
+        "user.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "description": "The channel this preference is for. Can be either ` + "`" + `email` + "`" + ` or ` + "`" + `in_app` + "`" + `.",
+                    "type": "string"
+                },
+                "created": {
+                    "description": "A timestamp when this preference was created. You cannot change this value.",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Whether the notification should be sent through the channel.",
+                    "type": "boolean"
+                },
+                "notification_name": {
+                    "description": "The name of the notification this preference is for, for example ` + "`" + `task.comment` + "`" + `.",
+                    "type": "string"
+                },
+                "project_id": {
+                    "description": "If set, this preference only applies to notifications about this project and overrides the general preference\nfor that notification. 0 means the preference applies to all projects.",
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "A timestamp when this preference was last updated. You cannot change this value.",
+                    "type": "string"
+                }
+            }
+        },
         "user.PasswordReset": {
             "type": "object",
             "properties": {
@@ -8776,6 +12601,32 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.UserNotificationSettings": {
+            "type": "object",
+            "properties": {
+                "available_channels": {
+                    "description": "All channels notifications can be sent through. Read only.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "available_notifications": {
+                    "description": "The names of all notifications which can be configured. Read only.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferences": {
+                    "description": "All notification preferences of the user. A preference with a project id overrides the general preference\nfor the same notification and channel for that project. Every notification without a preference is sent\nthrough all channels.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user.NotificationPreference"
+                    }
+                }
+            }
+        },
         "v1.UserPassword": {
             "type": "object",
             "properties": {
@@ -8825,6 +12676,10 @@ const docTemplate = `{
                     "description": "The new name of the current user.",
                     "type": "string"
                 },
+                "notification_digest_interval": {
+                    "description": "If set, mail notifications about task comments and assignments are not sent right away but collected\nand sent as one digest mail. Can be ` + "`" + `hourly` + "`" + `, ` + "`" + `daily` + "`" + ` or ` + "`" + `weekly` + "`" + `. Leave empty to get all mails right away.",
+                    "type": "string"
+                },
                 "overdue_tasks_reminders_enabled": {
                     "description": "If enabled, the user will get an email for their overdue tasks each morning.",
                     "type": "boolean"
@@ -8950,11 +12805,38 @@ const docTemplate = `{
                 "version": {
                     "type": "string"
                 },
+                "web_push": {
+                    "$ref": "#/definitions/v1.webPush"
+                },
                 "webhooks_enabled": {
                     "type": "boolean"
                 }
             }
         },
+        "v1.webPush": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "vapid_public_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "vikunjainstance.Migration": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "An api token of the user on the other Vikunja instance. It needs the read_all permission for projects,\nprojects_tasks, projects_buckets and tasks_comments and the read_one permission for tasks_attachments_download,\nprojects_background_download and filters.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "The url of the other Vikunja instance, for example https://vikunja.example.com. The api path is added if it is missing.",
+                    "type": "string"
+                }
+            }
+        },
         "web.HTTPError": {
             "type": "object",
             "properties": {
@@ -8986,7 +12868,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "Vikunja API",
-	Description:      "# Pagination\nEvery endpoint capable of pagination will return two headers:\n* `x-pagination-total-pages`: The total number of available pages for this request\n* `x-pagination-result-count`: The number of items returned for this request.\n# Rights\nAll endpoints which return a single item (project, task, etc.) - no array - will also return a `x-max-right` header with the max right the user has on this item as an int where `0` is `Read Only`, `1` is `Read & Write` and `2` is `Admin`.\nThis can be used to show or hide ui elements based on the rights the user has.\n# Errors\nAll errors have an error code and a human-readable error message in addition to the http status code. You should always check for the status code in the response, not only the http status code.\nDue to limitations in the swagger library we're using for this document, only one error per http status code is documented here. Make sure to check the [error docs](https://vikunja.io/docs/errors/) in Vikunja's documentation for a full list of available error codes.\n# Authorization\n**JWT-Auth:** Main authorization method, used for most of the requests. Needs `Authorization: Bearer <jwt-token>`-header to authenticate successfully.\n\n**API Token:** You can create scoped API tokens for your user and use the token to make authenticated requests in the context of that user. The token must be provided via an `Authorization: Bearer <token>` header, similar to jwt auth. See the documentation for the `api` group to manage token creation and revocation.\n\n**BasicAuth:** Only used when requesting tasks via CalDAV.\n<!-- ReDoc-Inject: <security-definitions> -->",
+	Description:      "# Pagination\nEvery endpoint capable of pagination will return two headers:\n* `x-pagination-total-pages`: The total number of available pages for this request\n* `x-pagination-result-count`: The number of items returned for this request.\n\nTask collections can also be paginated with a cursor by passing a `cursor` query parameter instead of `page`, empty for the first page. Cursor pagination does not skip or duplicate tasks when tasks change between requests. Instead of the total pages, these requests return the cursor for the next page in the `x-pagination-next-cursor` header, which is empty on the last page.\n# Rights\nAll endpoints which return a single item (project, task, etc.) - no array - will also return a `x-max-right` header with the max right the user has on this item as an int where `0` is `Read Only`, `1` is `Read & Write` and `2` is `Admin`.\nThis can be used to show or hide ui elements based on the rights the user has.\n# Concurrent changes\nTasks, projects, buckets and comments have a `version` which is increased with every change. To make sure an update does not overwrite changes someone else made in the meantime, send the version (or the ETag `\"<id>-<version>\"`) you based your changes on in an `If-Match` header. Every response containing a single task, project, bucket or comment returns its current ETag in the `ETag` header. If the entity was changed since then, the update is rejected with a `412` status code and the error message contains the current ETag.\n# Errors\nAll errors have an error code and a human-readable error message in addition to the http status code. You should always check for the status code in the response, not only the http status code.\nDue to limitations in the swagger library we're using for this document, only one error per http status code is documented here. Make sure to check the [error docs](https://vikunja.io/docs/errors/) in Vikunja's documentation for a full list of available error codes.\n# Authorization\n**JWT-Auth:** Main authorization method, used for most of the requests. Needs `Authorization: Bearer <jwt-token>`-header to authenticate successfully.\n\n**API Token:** You can create scoped API tokens for your user and use the token to make authenticated requests in the context of that user. The token must be provided via an `Authorization: Bearer <token>` header, similar to jwt auth. See the documentation for the `api` group to manage token creation and revocation.\n\n**BasicAuth:** Only used when requesting tasks via CalDAV.\n<!-- ReDoc-Inject: <security-definitions> -->",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -22,5 +22,6 @@ func GetTables() []interface{} {
 		&User{},
 		&TOTP{},
 		&Token{},
+		&NotificationPreference{},
 	}
 }
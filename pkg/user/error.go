@@ -506,3 +506,31 @@ func (err *ErrUsernameMustNotContainSpaces) HTTPError() web.HTTPError {
 		Message:  "The username must not contain spaces.",
 	}
 }
+
+// ErrInvalidNotificationPreference represents a "InvalidNotificationPreference" kind of error.
+type ErrInvalidNotificationPreference struct {
+	NotificationName string
+	Channel          string
+}
+
+// IsErrInvalidNotificationPreference checks if an error is a ErrInvalidNotificationPreference.
+func IsErrInvalidNotificationPreference(err error) bool {
+	_, ok := err.(*ErrInvalidNotificationPreference)
+	return ok
+}
+
+func (err *ErrInvalidNotificationPreference) Error() string {
+	return fmt.Sprintf("Invalid notification preference [NotificationName: %s, Channel: %s]", err.NotificationName, err.Channel)
+}
+
+// ErrCodeInvalidNotificationPreference holds the unique world-error code of this error
+const ErrCodeInvalidNotificationPreference = 1023
+
+// HTTPError holds the http error description
+func (err *ErrInvalidNotificationPreference) HTTPError() web.HTTPError {
+	return web.HTTPError{
+		HTTPCode: http.StatusPreconditionFailed,
+		Code:     ErrCodeInvalidNotificationPreference,
+		Message:  fmt.Sprintf("The notification %s cannot be configured for the channel %s.", err.NotificationName, err.Channel),
+	}
+}
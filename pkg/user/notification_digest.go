@@ -0,0 +1,106 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"time"
+
+	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/cron"
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/i18n"
+	"code.vikunja.io/api/pkg/log"
+	"code.vikunja.io/api/pkg/notifications"
+
+	"xorm.io/builder"
+)
+
+// DigestInterval returns how often the user wants to receive a digest of their mail notifications.
+func (u *User) DigestInterval() (string, error) {
+	s := db.NewSession()
+	defer s.Close()
+	user, err := getUser(s, &User{ID: u.ID}, true)
+	if err != nil {
+		return "", err
+	}
+
+	return user.NotificationDigestInterval, nil
+}
+
+// RegisterNotificationDigestCron registers a cron function which sends all queued notification digests every hour.
+func RegisterNotificationDigestCron() {
+	if !config.MailerEnabled.GetBool() {
+		log.Info("Mailer is disabled, not sending notification digests per mail")
+		return
+	}
+
+	err := cron.Schedule("0 * * * *", sendNotificationDigests)
+	if err != nil {
+		log.Fatalf("Could not register notification digest cron: %s", err)
+	}
+}
+
+// Because the cron does not always run at exactly the same second, we allow some slack
+// when checking if it is time to send the next digest.
+const digestIntervalTolerance = 5 * time.Minute
+
+func sendNotificationDigests() {
+	s := db.NewSession()
+	defer s.Close()
+
+	// Users who disabled their digest since a notification was queued are included as well
+	// so that their remaining queued notifications are sent out right away.
+	users := []*User{}
+	err := s.
+		Where(builder.Or(
+			builder.And(
+				builder.NotNull{"notification_digest_interval"},
+				builder.Neq{"notification_digest_interval": notifications.DigestIntervalNone},
+			),
+			builder.In("id", builder.Select("notifiable_id").From("notification_digest_queue")),
+		)).
+		Find(&users)
+	if err != nil {
+		log.Errorf("[Notification Digest] Could not get users to send a digest to: %s", err)
+		return
+	}
+
+	now := time.Now()
+	for _, u := range users {
+		interval := notifications.DigestIntervalDuration(u.NotificationDigestInterval)
+		if now.Sub(u.NotificationDigestLastSent) < interval-digestIntervalTolerance {
+			continue
+		}
+
+		err = notifications.SendDigest(u, i18n.T(u.Language, "notifications.common.greeting", u.GetName()))
+		if err != nil {
+			log.Errorf("[Notification Digest] Could not send digest to user %d: %s", u.ID, err)
+			continue
+		}
+
+		u.NotificationDigestLastSent = now
+		_, err = s.
+			Where("id = ?", u.ID).
+			Cols("notification_digest_last_sent").
+			Update(u)
+		if err != nil {
+			log.Errorf("[Notification Digest] Could not update last digest sent date of user %d: %s", u.ID, err)
+		}
+
+		log.Debugf("[Notification Digest] Sent digest to user %d", u.ID)
+	}
+}
@@ -0,0 +1,132 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"time"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/notifications"
+
+	"xorm.io/xorm"
+)
+
+// NotificationPreference holds whether a user wants to receive a notification through a channel.
+type NotificationPreference struct {
+	ID     int64 `xorm:"bigint autoincr not null unique pk" json:"-"`
+	UserID int64 `xorm:"bigint not null index" json:"-"`
+
+	// The name of the notification this preference is for, for example `task.comment`.
+	NotificationName string `xorm:"varchar(250) not null index" json:"notification_name"`
+	// The channel this preference is for. Can be either `email` or `in_app`.
+	Channel string `xorm:"varchar(50) not null" json:"channel"`
+	// If set, this preference only applies to notifications about this project and overrides the general preference
+	// for that notification. 0 means the preference applies to all projects.
+	ProjectID int64 `xorm:"bigint not null default 0" json:"project_id"`
+	// Whether the notification should be sent through the channel.
+	Enabled bool `xorm:"bool not null default true" json:"enabled"`
+
+	// A timestamp when this preference was created. You cannot change this value.
+	Created time.Time `xorm:"created not null" json:"created"`
+	// A timestamp when this preference was last updated. You cannot change this value.
+	Updated time.Time `xorm:"updated not null" json:"updated"`
+}
+
+// TableName returns the table name for notification preferences
+func (*NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// GetNotificationPreferences returns all notification preferences of a user.
+func GetNotificationPreferences(s *xorm.Session, u *User) (preferences []*NotificationPreference, err error) {
+	preferences = []*NotificationPreference{}
+	err = s.
+		Where("user_id = ?", u.ID).
+		OrderBy("project_id ASC, notification_name ASC, channel ASC").
+		Find(&preferences)
+	return
+}
+
+// SetNotificationPreferences replaces all notification preferences of a user with the provided ones.
+func SetNotificationPreferences(s *xorm.Session, u *User, preferences []*NotificationPreference) (err error) {
+	channels := make(map[string]bool)
+	for _, c := range notifications.Channels() {
+		channels[c] = true
+	}
+
+	seen := make(map[NotificationPreference]bool, len(preferences))
+	toInsert := make([]*NotificationPreference, 0, len(preferences))
+	for _, p := range preferences {
+		if p.NotificationName == "" || !channels[p.Channel] {
+			return &ErrInvalidNotificationPreference{
+				NotificationName: p.NotificationName,
+				Channel:          p.Channel,
+			}
+		}
+
+		key := NotificationPreference{NotificationName: p.NotificationName, Channel: p.Channel, ProjectID: p.ProjectID}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		p.ID = 0
+		p.UserID = u.ID
+		toInsert = append(toInsert, p)
+	}
+
+	_, err = s.Where("user_id = ?", u.ID).Delete(&NotificationPreference{})
+	if err != nil {
+		return err
+	}
+
+	if len(toInsert) == 0 {
+		return nil
+	}
+
+	_, err = s.Insert(&toInsert)
+	return err
+}
+
+// ShouldNotifyVia checks the notification preferences of a user for a notification and channel.
+// Project-level preferences take precedence over general ones. If the user has not set any preference,
+// all notifications will be sent.
+func (u *User) ShouldNotifyVia(n notifications.Notification, channel string) (bool, error) {
+	var projectID int64
+	if p, is := n.(notifications.ProjectID); is {
+		projectID = p.ProjectID()
+	}
+
+	s := db.NewSession()
+	defer s.Close()
+
+	preference := &NotificationPreference{}
+	has, err := s.
+		Where("user_id = ? AND notification_name = ? AND channel = ?", u.ID, n.Name(), channel).
+		In("project_id", []int64{0, projectID}).
+		OrderBy("project_id DESC").
+		Get(preference)
+	if err != nil {
+		return false, err
+	}
+
+	if !has {
+		return true, nil
+	}
+
+	return preference.Enabled, nil
+}
@@ -0,0 +1,131 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"testing"
+
+	"code.vikunja.io/api/pkg/db"
+	"code.vikunja.io/api/pkg/notifications"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type preferenceTestNotification struct {
+	projectID int64
+}
+
+func (n *preferenceTestNotification) ToMail(_ string) *notifications.Mail {
+	return nil
+}
+
+func (n *preferenceTestNotification) ToDB() interface{} {
+	return nil
+}
+
+func (n *preferenceTestNotification) ToPush() *notifications.Push {
+	return nil
+}
+
+func (n *preferenceTestNotification) Name() string {
+	return "task.comment"
+}
+
+func (n *preferenceTestNotification) ProjectID() int64 {
+	return n.projectID
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	u := &User{ID: 1}
+
+	t.Run("no preferences", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+
+		should, err := u.ShouldNotifyVia(&preferenceTestNotification{projectID: 1}, notifications.ChannelMail)
+		assert.NoError(t, err)
+		assert.True(t, should)
+	})
+	t.Run("general preference", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		err := SetNotificationPreferences(s, u, []*NotificationPreference{
+			{NotificationName: "task.comment", Channel: notifications.ChannelMail, Enabled: false},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, s.Commit())
+
+		should, err := u.ShouldNotifyVia(&preferenceTestNotification{projectID: 1}, notifications.ChannelMail)
+		assert.NoError(t, err)
+		assert.False(t, should)
+
+		should, err = u.ShouldNotifyVia(&preferenceTestNotification{projectID: 1}, notifications.ChannelDB)
+		assert.NoError(t, err)
+		assert.True(t, should)
+	})
+	t.Run("project override", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		err := SetNotificationPreferences(s, u, []*NotificationPreference{
+			{NotificationName: "task.comment", Channel: notifications.ChannelMail, Enabled: false},
+			{NotificationName: "task.comment", Channel: notifications.ChannelMail, ProjectID: 3, Enabled: true},
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, s.Commit())
+
+		should, err := u.ShouldNotifyVia(&preferenceTestNotification{projectID: 3}, notifications.ChannelMail)
+		assert.NoError(t, err)
+		assert.True(t, should)
+
+		should, err = u.ShouldNotifyVia(&preferenceTestNotification{projectID: 1}, notifications.ChannelMail)
+		assert.NoError(t, err)
+		assert.False(t, should)
+	})
+	t.Run("replaces existing preferences", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		err := SetNotificationPreferences(s, u, []*NotificationPreference{
+			{NotificationName: "task.comment", Channel: notifications.ChannelMail, Enabled: false},
+		})
+		assert.NoError(t, err)
+		err = SetNotificationPreferences(s, u, []*NotificationPreference{
+			{NotificationName: "task.assigned", Channel: notifications.ChannelDB, Enabled: false},
+		})
+		assert.NoError(t, err)
+
+		preferences, err := GetNotificationPreferences(s, u)
+		assert.NoError(t, err)
+		assert.Len(t, preferences, 1)
+		assert.Equal(t, "task.assigned", preferences[0].NotificationName)
+	})
+	t.Run("invalid channel", func(t *testing.T) {
+		db.LoadAndAssertFixtures(t)
+		s := db.NewSession()
+		defer s.Close()
+
+		err := SetNotificationPreferences(s, u, []*NotificationPreference{
+			{NotificationName: "task.comment", Channel: "carrier-pigeon"},
+		})
+		assert.Error(t, err)
+		assert.True(t, IsErrInvalidNotificationPreference(err))
+	})
+}
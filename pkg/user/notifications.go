@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	"code.vikunja.io/api/pkg/config"
+	"code.vikunja.io/api/pkg/i18n"
 	"code.vikunja.io/api/pkg/notifications"
 )
 
@@ -31,25 +32,25 @@ type EmailConfirmNotification struct {
 }
 
 // ToMail returns the mail notification for EmailConfirmNotification
-func (n *EmailConfirmNotification) ToMail() *notifications.Mail {
-
-	subject := n.User.GetName() + ", please confirm your email address at Vikunja"
+func (n *EmailConfirmNotification) ToMail(lang string) *notifications.Mail {
+	subject := i18n.T(lang, "notifications.email_confirm.subject", n.User.GetName())
 	if n.IsNew {
-		subject = n.User.GetName() + " + Vikunja = <3"
+		subject = i18n.T(lang, "notifications.email_confirm.subject_new", n.User.GetName())
 	}
 
 	nn := notifications.NewMail().
+		Template("email.confirm").
 		Subject(subject).
-		Greeting("Hi " + n.User.GetName() + ",")
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName()))
 
 	if n.IsNew {
-		nn.Line("Welcome to Vikunja!")
+		nn.Line(i18n.T(lang, "notifications.email_confirm.welcome"))
 	}
 
 	return nn.
-		Line("To confirm your email address, click the link below:").
-		Action("Confirm your email address", config.ServiceFrontendurl.GetString()+"?userEmailConfirm="+n.ConfirmToken).
-		Line("Have a nice day!")
+		Line(i18n.T(lang, "notifications.email_confirm.message")).
+		Action(i18n.T(lang, "notifications.email_confirm.action"), config.ServiceFrontendurl.GetString()+"?userEmailConfirm="+n.ConfirmToken).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the EmailConfirmNotification notification in a format which can be saved in the db
@@ -57,6 +58,11 @@ func (n *EmailConfirmNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the EmailConfirmNotification notification as web push message
+func (n *EmailConfirmNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *EmailConfirmNotification) Name() string {
 	return ""
@@ -68,12 +74,13 @@ type PasswordChangedNotification struct {
 }
 
 // ToMail returns the mail notification for PasswordChangedNotification
-func (n *PasswordChangedNotification) ToMail() *notifications.Mail {
+func (n *PasswordChangedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Your Password on Vikunja was changed").
-		Greeting("Hi " + n.User.GetName() + ",").
-		Line("Your account password was successfully changed.").
-		Line("If this wasn't you, it could mean someone compromised your account. In this case contact your server's administrator.")
+		Template("password.changed").
+		Subject(i18n.T(lang, "notifications.password.changed.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.password.changed.success")).
+		Line(i18n.T(lang, "notifications.password.changed.warning"))
 }
 
 // ToDB returns the PasswordChangedNotification notification in a format which can be saved in the db
@@ -81,6 +88,11 @@ func (n *PasswordChangedNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the PasswordChangedNotification notification as web push message
+func (n *PasswordChangedNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *PasswordChangedNotification) Name() string {
 	return ""
@@ -93,14 +105,15 @@ type ResetPasswordNotification struct {
 }
 
 // ToMail returns the mail notification for ResetPasswordNotification
-func (n *ResetPasswordNotification) ToMail() *notifications.Mail {
+func (n *ResetPasswordNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Reset your password on Vikunja").
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("To reset your password, click the link below:").
-		Action("Reset your password", config.ServiceFrontendurl.GetString()+"?userPasswordReset="+n.Token.Token).
-		Line("This link will be valid for 24 hours.").
-		Line("Have a nice day!")
+		Template("password.reset").
+		Subject(i18n.T(lang, "notifications.password.reset.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.password.reset.message")).
+		Action(i18n.T(lang, "notifications.common.actions.reset_password"), config.ServiceFrontendurl.GetString()+"?userPasswordReset="+n.Token.Token).
+		Line(i18n.T(lang, "notifications.password.reset.valid_duration")).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the ResetPasswordNotification notification in a format which can be saved in the db
@@ -108,6 +121,11 @@ func (n *ResetPasswordNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the ResetPasswordNotification notification as web push message
+func (n *ResetPasswordNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *ResetPasswordNotification) Name() string {
 	return ""
@@ -119,13 +137,13 @@ type InvalidTOTPNotification struct {
 }
 
 // ToMail returns the mail notification for InvalidTOTPNotification
-func (n *InvalidTOTPNotification) ToMail() *notifications.Mail {
+func (n *InvalidTOTPNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Someone just tried to login to your Vikunja account, but failed").
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("Someone just tried to log in into your account with correct username and password but a wrong TOTP passcode.").
-		Line("**If this was not you, someone else knows your password. You should set a new one immediately!**").
-		Action("Reset your password", config.ServiceFrontendurl.GetString()+"get-password-reset")
+		Subject(i18n.T(lang, "notifications.totp.invalid.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.totp.invalid.message")).
+		Line(i18n.T(lang, "notifications.totp.invalid.warning")).
+		Action(i18n.T(lang, "notifications.common.actions.reset_password"), config.ServiceFrontendurl.GetString()+"get-password-reset")
 }
 
 // ToDB returns the InvalidTOTPNotification notification in a format which can be saved in the db
@@ -133,6 +151,11 @@ func (n *InvalidTOTPNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the InvalidTOTPNotification notification as web push message
+func (n *InvalidTOTPNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *InvalidTOTPNotification) Name() string {
 	return "totp.invalid"
@@ -144,13 +167,13 @@ type PasswordAccountLockedAfterInvalidTOTOPNotification struct {
 }
 
 // ToMail returns the mail notification for PasswordAccountLockedAfterInvalidTOTOPNotification
-func (n *PasswordAccountLockedAfterInvalidTOTOPNotification) ToMail() *notifications.Mail {
+func (n *PasswordAccountLockedAfterInvalidTOTOPNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("We've disabled your account on Vikunja").
-		Greeting("Hi " + n.User.GetName() + ",").
-		Line("Someone tried to log in with your credentials but failed to provide a valid TOTP passcode.").
-		Line("After 10 failed attempts, we've disabled your account and reset your password. To set a new one, follow the instructions in the reset email we just sent you.").
-		Line("If you did not receive an email with reset instructions, you can always request a new one at [" + config.ServiceFrontendurl.GetString() + "get-password-reset](" + config.ServiceFrontendurl.GetString() + "get-password-reset).")
+		Subject(i18n.T(lang, "notifications.totp.account_locked.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.totp.account_locked.message")).
+		Line(i18n.T(lang, "notifications.totp.account_locked.disabled")).
+		Line(i18n.T(lang, "notifications.totp.account_locked.reset_instructions", config.ServiceFrontendurl.GetString()+"get-password-reset"))
 }
 
 // ToDB returns the PasswordAccountLockedAfterInvalidTOTOPNotification notification in a format which can be saved in the db
@@ -158,6 +181,11 @@ func (n *PasswordAccountLockedAfterInvalidTOTOPNotification) ToDB() interface{}
 	return nil
 }
 
+// ToPush returns the PasswordAccountLockedAfterInvalidTOTOPNotification notification as web push message
+func (n *PasswordAccountLockedAfterInvalidTOTOPNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *PasswordAccountLockedAfterInvalidTOTOPNotification) Name() string {
 	return "password.account.locked.after.invalid.totop"
@@ -169,14 +197,14 @@ type FailedLoginAttemptNotification struct {
 }
 
 // ToMail returns the mail notification for FailedLoginAttemptNotification
-func (n *FailedLoginAttemptNotification) ToMail() *notifications.Mail {
+func (n *FailedLoginAttemptNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Someone just tried to login to your Vikunja account, but failed to provide a correct password").
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("Someone just tried to log in into your account with a wrong password three times in a row.").
-		Line("If this was not you, this could be someone else trying to break into your account.").
-		Line("To enhance the security of you account you may want to set a stronger password or enable TOTP authentication in the settings:").
-		Action("Go to settings", config.ServiceFrontendurl.GetString()+"user/settings")
+		Subject(i18n.T(lang, "notifications.login.failed.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.login.failed.message")).
+		Line(i18n.T(lang, "notifications.login.failed.warning")).
+		Line(i18n.T(lang, "notifications.login.failed.enhance_security")).
+		Action(i18n.T(lang, "notifications.common.actions.go_to_settings"), config.ServiceFrontendurl.GetString()+"user/settings")
 }
 
 // ToDB returns the FailedLoginAttemptNotification notification in a format which can be saved in the db
@@ -184,6 +212,11 @@ func (n *FailedLoginAttemptNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the FailedLoginAttemptNotification notification as web push message
+func (n *FailedLoginAttemptNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *FailedLoginAttemptNotification) Name() string {
 	return "failed.login.attempt"
@@ -196,17 +229,17 @@ type AccountDeletionConfirmNotification struct {
 }
 
 // ToMail returns the mail notification for AccountDeletionConfirmNotification
-func (n *AccountDeletionConfirmNotification) ToMail() *notifications.Mail {
+func (n *AccountDeletionConfirmNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Please confirm the deletion of your Vikunja account").
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("You have requested the deletion of your account. To confirm this, please click the link below:").
-		Action("Confirm the deletion of my account", config.ServiceFrontendurl.GetString()+"?accountDeletionConfirm="+n.ConfirmToken).
-		Line("This link will be valid for 24 hours.").
-		Line("Once you confirm the deletion we will schedule the deletion of your account in three days and send you another email until then.").
-		Line("If you proceed with the deletion of your account, we will remove all of your projects and tasks you created. Everything you shared with another user or team will transfer ownership to them.").
-		Line("If you did not requested the deletion or changed your mind, you can simply ignore this email.").
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.account.deletion.confirm.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.account.deletion.confirm.request")).
+		Action(i18n.T(lang, "notifications.account.deletion.confirm.action"), config.ServiceFrontendurl.GetString()+"?accountDeletionConfirm="+n.ConfirmToken).
+		Line(i18n.T(lang, "notifications.account.deletion.confirm.valid_duration")).
+		Line(i18n.T(lang, "notifications.account.deletion.confirm.schedule_info")).
+		Line(i18n.T(lang, "notifications.account.deletion.confirm.consequences")).
+		Line(i18n.T(lang, "notifications.account.deletion.confirm.ignore")).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the AccountDeletionConfirmNotification notification in a format which can be saved in the db
@@ -214,6 +247,11 @@ func (n *AccountDeletionConfirmNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the AccountDeletionConfirmNotification notification as web push message
+func (n *AccountDeletionConfirmNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *AccountDeletionConfirmNotification) Name() string {
 	return "user.deletion.confirm"
@@ -226,21 +264,20 @@ type AccountDeletionNotification struct {
 }
 
 // ToMail returns the mail notification for AccountDeletionNotification
-func (n *AccountDeletionNotification) ToMail() *notifications.Mail {
-	durationString := "in " + strconv.Itoa(n.NotificationNumber) + " days"
-
+func (n *AccountDeletionNotification) ToMail(lang string) *notifications.Mail {
+	durationString := i18n.T(lang, "notifications.account.deletion.scheduled.in_days", strconv.Itoa(n.NotificationNumber))
 	if n.NotificationNumber == 1 {
-		durationString = "tomorrow"
+		durationString = i18n.T(lang, "notifications.account.deletion.scheduled.tomorrow")
 	}
 
 	return notifications.NewMail().
-		Subject("Your Vikunja account will be deleted "+durationString).
-		Greeting("Hi "+n.User.GetName()+",").
-		Line("You recently requested the deletion of your Vikunja account.").
-		Line("We will delete your account "+durationString+".").
-		Line("If you changed your mind, simply click the link below to cancel the deletion and follow the instructions there:").
-		Action("Abort the deletion", config.ServiceFrontendurl.GetString()).
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.account.deletion.scheduled.subject", durationString)).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.account.deletion.scheduled.request")).
+		Line(i18n.T(lang, "notifications.account.deletion.scheduled.deletion_time", durationString)).
+		Line(i18n.T(lang, "notifications.account.deletion.scheduled.abort")).
+		Action(i18n.T(lang, "notifications.account.deletion.scheduled.action"), config.ServiceFrontendurl.GetString()).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the AccountDeletionNotification notification in a format which can be saved in the db
@@ -248,6 +285,11 @@ func (n *AccountDeletionNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the AccountDeletionNotification notification as web push message
+func (n *AccountDeletionNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *AccountDeletionNotification) Name() string {
 	return "user.deletion"
@@ -259,13 +301,13 @@ type AccountDeletedNotification struct {
 }
 
 // ToMail returns the mail notification for AccountDeletedNotification
-func (n *AccountDeletedNotification) ToMail() *notifications.Mail {
+func (n *AccountDeletedNotification) ToMail(lang string) *notifications.Mail {
 	return notifications.NewMail().
-		Subject("Your Vikunja Account has been deleted").
-		Greeting("Hi " + n.User.GetName() + ",").
-		Line("As requested, we've deleted your Vikunja account.").
-		Line("This deletion is permanent. If did not create a backup and need your data back now, talk to your administrator.").
-		Line("Have a nice day!")
+		Subject(i18n.T(lang, "notifications.account.deletion.deleted.subject")).
+		Greeting(i18n.T(lang, "notifications.common.greeting", n.User.GetName())).
+		Line(i18n.T(lang, "notifications.account.deletion.deleted.message")).
+		Line(i18n.T(lang, "notifications.account.deletion.deleted.hint")).
+		Line(i18n.T(lang, "notifications.common.have_nice_day"))
 }
 
 // ToDB returns the AccountDeletedNotification notification in a format which can be saved in the db
@@ -273,6 +315,11 @@ func (n *AccountDeletedNotification) ToDB() interface{} {
 	return nil
 }
 
+// ToPush returns the AccountDeletedNotification notification as web push message
+func (n *AccountDeletedNotification) ToPush() *notifications.Push {
+	return nil
+}
+
 // Name returns the name of the notification
 func (n *AccountDeletedNotification) Name() string {
 	return "user.deleted"
@@ -101,6 +101,9 @@ type User struct {
 	Language                     string `xorm:"varchar(50) null" json:"-"`
 	Timezone                     string `xorm:"varchar(255) null" json:"-"`
 
+	NotificationDigestInterval string    `xorm:"varchar(10) null" json:"-"`
+	NotificationDigestLastSent time.Time `xorm:"datetime null" json:"-"`
+
 	DeletionScheduledAt      time.Time `xorm:"datetime null" json:"-"`
 	DeletionLastReminderSent time.Time `xorm:"datetime null" json:"-"`
 
@@ -137,6 +140,31 @@ func (u *User) RouteForDB() int64 {
 	return u.ID
 }
 
+// Lang returns the language the user wants to receive notifications in
+func (u *User) Lang() string {
+	if u.Language != "" || u.ID == 0 {
+		return u.Language
+	}
+
+	// Users passed through events don't carry their language, so we need to look it up.
+	s := db.NewSession()
+	defer s.Close()
+	user, err := getUser(s, &User{ID: u.ID}, true)
+	if err != nil {
+		log.Errorf("Could not get language of user %d: %s", u.ID, err)
+		return ""
+	}
+
+	return user.Language
+}
+
+// RouteForChat routes all notifications for a user to the chat destinations they configured
+func (u *User) RouteForChat() ([]*notifications.ChatDestination, error) {
+	s := db.NewSession()
+	defer s.Close()
+	return notifications.GetChatDestinationsForUser(s, u.ID)
+}
+
 func (u *User) ShouldNotify() (bool, error) {
 	s := db.NewSession()
 	defer s.Close()
@@ -528,6 +556,7 @@ func UpdateUser(s *xorm.Session, user *User, forceOverride bool) (updatedUser *U
 			"timezone",
 			"overdue_tasks_reminders_time",
 			"frontend_settings",
+			"notification_digest_interval",
 		).
 		Update(user)
 	if err != nil {
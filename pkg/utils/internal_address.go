@@ -0,0 +1,110 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Carrier-grade NAT addresses are not covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsInternalIP checks if an ip address is a loopback, private, link-local or other address which is not reachable
+// from the internet.
+func IsInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
+
+// IsHostAllowed checks if a host name or ip address is in a list of allowed host names and ip ranges in CIDR notation.
+func IsHostAllowed(host string, allowedHosts []string) bool {
+	ip := net.ParseIP(host)
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+		if _, ipNet, err := net.ParseCIDR(allowed); err == nil && ip != nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GuardedDialContext returns a dial function for http transports which only connects to internal addresses if they
+// are in the allowed hosts. Otherwise, it returns errNotAllowed. The check happens after resolving the host name so
+// that it also covers redirects and host names pointing to internal addresses. Transports using it must not use a
+// proxy, since only the address of the proxy would be checked then.
+func GuardedDialContext(allowedHosts func() []string, errNotAllowed error) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		allowed := allowedHosts()
+		dialer := &net.Dialer{Timeout: 30 * time.Second}
+		if !IsHostAllowed(host, allowed) {
+			dialer.Control = func(_, resolved string, _ syscall.RawConn) error {
+				ipString, _, err := net.SplitHostPort(resolved)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(ipString)
+				if ip == nil || (IsInternalIP(ip) && !IsHostAllowed(ip.String(), allowed)) {
+					return errNotAllowed
+				}
+				return nil
+			}
+		}
+
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+// CheckURLAddress returns errNotAllowed if a url obviously points to an internal address which is not in the
+// allowed hosts. Host names are only resolved when connecting through GuardedDialContext.
+func CheckURLAddress(rawURL string, allowedHosts []string, errNotAllowed error) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	if IsHostAllowed(host, allowedHosts) {
+		return nil
+	}
+
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errNotAllowed
+	}
+	if ip := net.ParseIP(host); ip != nil && IsInternalIP(ip) {
+		return errNotAllowed
+	}
+
+	return nil
+}
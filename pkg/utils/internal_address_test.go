@@ -0,0 +1,81 @@
+// Vikunja is a to-do list application to facilitate your life.
+// Copyright 2018-present Vikunja and contributors. All rights reserved.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public Licensee as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public Licensee for more details.
+//
+// You should have received a copy of the GNU Affero General Public Licensee
+// along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestNotAllowed = errors.New("not allowed")
+
+func TestIsInternalIP(t *testing.T) {
+	assert.True(t, IsInternalIP(net.ParseIP("127.0.0.1")))
+	assert.True(t, IsInternalIP(net.ParseIP("10.1.2.3")))
+	assert.True(t, IsInternalIP(net.ParseIP("169.254.169.254")))
+	assert.True(t, IsInternalIP(net.ParseIP("100.64.0.1")))
+	assert.True(t, IsInternalIP(net.ParseIP("::1")))
+	assert.True(t, IsInternalIP(net.ParseIP("::ffff:127.0.0.1")))
+	assert.False(t, IsInternalIP(net.ParseIP("1.1.1.1")))
+}
+
+func TestIsHostAllowed(t *testing.T) {
+	assert.True(t, IsHostAllowed("matrix.internal", []string{"Matrix.internal"}))
+	assert.True(t, IsHostAllowed("10.0.0.5", []string{"10.0.0.0/24"}))
+	assert.False(t, IsHostAllowed("10.0.1.5", []string{"10.0.0.0/24"}))
+	assert.False(t, IsHostAllowed("localhost", []string{}))
+}
+
+func TestCheckURLAddress(t *testing.T) {
+	assert.NoError(t, CheckURLAddress("https://vikunja.example.com", nil, errTestNotAllowed))
+	assert.ErrorIs(t, CheckURLAddress("http://localhost:3456", nil, errTestNotAllowed), errTestNotAllowed)
+	assert.ErrorIs(t, CheckURLAddress("http://192.168.1.1/api/v1", nil, errTestNotAllowed), errTestNotAllowed)
+	assert.NoError(t, CheckURLAddress("http://192.168.1.1/api/v1", []string{"192.168.1.0/24"}, errTestNotAllowed))
+}
+
+func TestGuardedDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	get := func(allowedHosts []string) error {
+		client := &http.Client{Transport: &http.Transport{
+			DialContext: GuardedDialContext(func() []string { return allowedHosts }, errTestNotAllowed),
+		}}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("internal address", func(t *testing.T) {
+		assert.ErrorIs(t, get(nil), errTestNotAllowed)
+	})
+	t.Run("allowed internal address", func(t *testing.T) {
+		assert.NoError(t, get([]string{"127.0.0.1"}))
+	})
+}